@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"istio.io/istio/galley/pkg/config/processing/snapshotter/strategy"
 	"istio.io/istio/galley/pkg/server"
 	"istio.io/istio/galley/pkg/server/settings"
 	istiocmd "istio.io/istio/pkg/cmd"
@@ -151,6 +152,22 @@ func serverCmd() *cobra.Command {
 		"Enable the Fsnotify for watching config source files on the disk and implicit signaling on a config change. Explicit signaling will still be enabled")
 	svr.PersistentFlags().BoolVar(&serverArgs.EnableConfigAnalysis, "enableAnalysis", serverArgs.EnableConfigAnalysis,
 		"Enable config analysis service")
+	svr.PersistentFlags().StringVar(&serverArgs.SnapshotStrategy, "snapshotStrategy", serverArgs.SnapshotStrategy,
+		fmt.Sprintf("Strategy deciding when to publish snapshots, one of %v. If empty, the strategy of each snapshot is used",
+			strategy.Names))
+	svr.PersistentFlags().DurationVar(&serverArgs.AdaptiveStrategy.MinQuiesce, "adaptiveStrategyMinQuiesce",
+		serverArgs.AdaptiveStrategy.MinQuiesce,
+		"Quiesce window of the adaptive snapshot strategy when changes are rare. The window grows with the rate of changes")
+	svr.PersistentFlags().DurationVar(&serverArgs.AdaptiveStrategy.MaxQuiesce, "adaptiveStrategyMaxQuiesce",
+		serverArgs.AdaptiveStrategy.MaxQuiesce, "Maximum quiesce window of the adaptive snapshot strategy")
+	svr.PersistentFlags().DurationVar(&serverArgs.AdaptiveStrategy.MaxStaleness, "adaptiveStrategyMaxStaleness",
+		serverArgs.AdaptiveStrategy.MaxStaleness,
+		"Maximum time a change waits before the adaptive snapshot strategy publishes a snapshot")
+	svr.PersistentFlags().StringVar(&serverArgs.SnapshotPersistenceDir, "snapshotPersistenceDir", serverArgs.SnapshotPersistenceDir,
+		"Directory to persist the published snapshots to, and serve them from as stale on startup until the sources sync. "+
+			"Empty disables persistence")
+	svr.PersistentFlags().DurationVar(&serverArgs.PersistedSnapshotMaxAge, "persistedSnapshotMaxAge", serverArgs.PersistedSnapshotMaxAge,
+		"Maximum age of a persisted snapshot for it to be served on startup. Zero means no limit")
 
 	// validation webhook server config
 	_ = svr.PersistentFlags().String("validation-webhook-config-file", "", "Setting this file has no effect")
@@ -22,6 +22,7 @@ import (
 	"istio.io/istio/galley/pkg/config/analysis/analyzers/deprecation"
 	"istio.io/istio/galley/pkg/config/analysis/analyzers/gateway"
 	"istio.io/istio/galley/pkg/config/analysis/analyzers/injection"
+	"istio.io/istio/galley/pkg/config/analysis/analyzers/multicluster"
 	"istio.io/istio/galley/pkg/config/analysis/analyzers/policy"
 	"istio.io/istio/galley/pkg/config/analysis/analyzers/schema"
 	"istio.io/istio/galley/pkg/config/analysis/analyzers/service"
@@ -63,3 +64,18 @@ func All() []analysis.Analyzer {
 func AllCombined() *analysis.CombinedAnalyzer {
 	return analysis.Combine("all", All()...)
 }
+
+// AllMultiCluster returns all analyzers of the merged snapshot of several clusters
+func AllMultiCluster() []analysis.Analyzer {
+	return []analysis.Analyzer{
+		// Please keep this list sorted alphabetically by pkg.name for convenience
+		&multicluster.ExportToAnalyzer{},
+		&multicluster.MeshNetworksAnalyzer{},
+		&multicluster.ServicePortAnalyzer{},
+	}
+}
+
+// AllMultiClusterCombined returns all analyzers of the merged snapshot of several clusters combined as one
+func AllMultiClusterCombined() *analysis.CombinedAnalyzer {
+	return analysis.Combine("all-multicluster", AllMultiCluster()...)
+}
@@ -75,6 +75,8 @@ type origin struct {
 }
 
 func (o origin) Namespace() resource.Namespace { return "" }
+func (o origin) Reference() resource.Reference { return nil }
+func (o origin) FieldMap() map[string]int      { return nil }
 func (o origin) FriendlyName() string          { return o.friendlyName }
 
 // This is a very basic benchmark on unit test data, so it doesn't tell us anything about how an analyzer performs at scale
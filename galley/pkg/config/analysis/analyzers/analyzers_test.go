@@ -606,3 +606,28 @@ func prettyPrintMessages(msgs diag.Messages) string {
 	}
 	return sb.String()
 }
+
+func TestAnalyzersReferenceSourceLines(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := testCase{
+		name:       "virtualServiceGatewaysLines",
+		inputFiles: []string{"testdata/virtualservice_gateways.yaml"},
+		analyzer:   &virtualservice.GatewayAnalyzer{},
+	}
+	sa, err := setupAnalyzerForCase(tc, nil)
+	if err != nil {
+		t.Fatalf("Error setting up analysis for testcase %s: %v", tc.name, err)
+	}
+	result, err := runAnalyzer(sa)
+	if err != nil {
+		t.Fatalf("Error running analysis on testcase %s: %v", tc.name, err)
+	}
+
+	g.Expect(result.Messages).To(HaveLen(1))
+	m := result.Messages[0]
+	// The message points to the bogus gateway, rather than to the start of the resource
+	g.Expect(m.Resource.Origin.Reference().String()).To(Equal("testdata/virtualservice_gateways.yaml:30"))
+	g.Expect(m.Line).To(Equal(38))
+	g.Expect(m.Reference()).To(Equal("testdata/virtualservice_gateways.yaml:38"))
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicluster
+
+import (
+	"reflect"
+	"sort"
+
+	"istio.io/api/networking/v1alpha3"
+
+	"istio.io/istio/galley/pkg/config/analysis"
+	"istio.io/istio/galley/pkg/config/analysis/analyzers/util"
+	"istio.io/istio/galley/pkg/config/analysis/msg"
+	"istio.io/istio/pkg/config/resource"
+	"istio.io/istio/pkg/config/schema/collection"
+	"istio.io/istio/pkg/config/schema/collections"
+)
+
+// ExportToAnalyzer checks that the service entries and destination rules with the same name in several
+// clusters are exported to the same namespaces. Otherwise, the workloads of a namespace see different
+// services or traffic policies depending on the cluster they run in.
+type ExportToAnalyzer struct{}
+
+var _ analysis.Analyzer = &ExportToAnalyzer{}
+
+// Metadata implements Analyzer
+func (e *ExportToAnalyzer) Metadata() analysis.Metadata {
+	return analysis.Metadata{
+		Name:        "multicluster.ExportToAnalyzer",
+		Description: "Checks that service entries and destination rules are exported the same way in every cluster",
+		Inputs: collection.Names{
+			collections.IstioNetworkingV1Alpha3Destinationrules.Name(),
+			collections.IstioNetworkingV1Alpha3Serviceentries.Name(),
+		},
+	}
+}
+
+// Analyze implements Analyzer
+func (e *ExportToAnalyzer) Analyze(ctx analysis.Context) {
+	analyzeExportTo(ctx, collections.IstioNetworkingV1Alpha3Destinationrules.Name(), func(r *resource.Instance) []string {
+		return r.Message.(*v1alpha3.DestinationRule).ExportTo
+	})
+	analyzeExportTo(ctx, collections.IstioNetworkingV1Alpha3Serviceentries.Name(), func(r *resource.Instance) []string {
+		return r.Message.(*v1alpha3.ServiceEntry).ExportTo
+	})
+}
+
+func analyzeExportTo(ctx analysis.Context, col collection.Name, exportToFn func(r *resource.Instance) []string) {
+	names, resources := resourcesByName(ctx, col)
+	for _, name := range names {
+		first := resources[name][0]
+		firstExportTo := normalizeExportTo(exportToFn(first))
+		for _, r := range resources[name][1:] {
+			exportTo := normalizeExportTo(exportToFn(r))
+			if !reflect.DeepEqual(exportTo, firstExportTo) {
+				ctx.Report(col, msg.NewMultiClusterExportToMismatch(r,
+					exportTo, analysis.ClusterName(r), firstExportTo, analysis.ClusterName(first)))
+			}
+		}
+	}
+}
+
+// normalizeExportTo returns the sorted namespaces a resource is exported to, an empty list meaning all namespaces.
+func normalizeExportTo(exportTo []string) []string {
+	if len(exportTo) == 0 {
+		return []string{util.ExportToAllNamespaces}
+	}
+	normalized := append([]string{}, exportTo...)
+	sort.Strings(normalized)
+	return normalized
+}
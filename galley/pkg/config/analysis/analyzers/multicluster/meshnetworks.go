@@ -0,0 +1,93 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+
+	"istio.io/api/mesh/v1alpha1"
+
+	"istio.io/istio/galley/pkg/config/analysis"
+	"istio.io/istio/galley/pkg/config/analysis/msg"
+	"istio.io/istio/pkg/config/resource"
+	"istio.io/istio/pkg/config/schema/collection"
+)
+
+// MeshNetworksAnalyzer checks that the clusters of the mesh have the same mesh networks. Each control plane
+// uses its own mesh networks to find the gateways of the other networks, so they must agree.
+type MeshNetworksAnalyzer struct{}
+
+var _ analysis.Analyzer = &MeshNetworksAnalyzer{}
+
+// Metadata implements Analyzer
+func (m *MeshNetworksAnalyzer) Metadata() analysis.Metadata {
+	return analysis.Metadata{
+		Name:        "multicluster.MeshNetworksAnalyzer",
+		Description: "Checks that the clusters of the mesh have the same mesh networks",
+		Inputs: collection.Names{
+			analysis.MeshNetworks,
+		},
+	}
+}
+
+// Analyze implements Analyzer
+func (m *MeshNetworksAnalyzer) Analyze(ctx analysis.Context) {
+	var first *resource.Instance
+	ctx.ForEach(analysis.MeshNetworks, func(r *resource.Instance) bool {
+		if first == nil {
+			first = r
+			return true
+		}
+		differences := meshNetworksDifferences(
+			r.Message.(*v1alpha1.MeshNetworks), first.Message.(*v1alpha1.MeshNetworks), analysis.ClusterName(first))
+		if len(differences) > 0 {
+			ctx.Report(analysis.MeshNetworks, msg.NewMultiClusterMeshNetworksMismatch(r,
+				analysis.ClusterName(r), analysis.ClusterName(first), strings.Join(differences, "; ")))
+		}
+		return true
+	})
+}
+
+// meshNetworksDifferences describes how the mesh networks of a cluster differ from those of another cluster.
+func meshNetworksDifferences(networks, other *v1alpha1.MeshNetworks, otherCluster string) []string {
+	var names []string
+	for name := range networks.Networks {
+		names = append(names, name)
+	}
+	for name := range other.Networks {
+		if _, ok := networks.Networks[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var differences []string
+	for _, name := range names {
+		network, otherNetwork := networks.Networks[name], other.Networks[name]
+		switch {
+		case otherNetwork == nil:
+			differences = append(differences, fmt.Sprintf("network %s is not defined in cluster %s", name, otherCluster))
+		case network == nil:
+			differences = append(differences, fmt.Sprintf("network %s is only defined in cluster %s", name, otherCluster))
+		case !proto.Equal(network, otherNetwork):
+			differences = append(differences, fmt.Sprintf("network %s has different endpoints or gateways", name))
+		}
+	}
+	return differences
+}
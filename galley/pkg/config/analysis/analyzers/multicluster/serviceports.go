@@ -0,0 +1,102 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicluster
+
+import (
+	"fmt"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/galley/pkg/config/analysis"
+	"istio.io/istio/galley/pkg/config/analysis/msg"
+	configKube "istio.io/istio/pkg/config/kube"
+	"istio.io/istio/pkg/config/resource"
+	"istio.io/istio/pkg/config/schema/collection"
+	"istio.io/istio/pkg/config/schema/collections"
+)
+
+// ServicePortAnalyzer checks that the services with the same name in several clusters have the same ports.
+// The services of all the clusters are merged into one service of the mesh, so the same port number must
+// have the same protocol, and the same port name the same number, in every cluster.
+type ServicePortAnalyzer struct{}
+
+var _ analysis.Analyzer = &ServicePortAnalyzer{}
+
+// Metadata implements Analyzer
+func (s *ServicePortAnalyzer) Metadata() analysis.Metadata {
+	return analysis.Metadata{
+		Name:        "multicluster.ServicePortAnalyzer",
+		Description: "Checks that services with the same name in several clusters have no conflicting ports",
+		Inputs: collection.Names{
+			collections.K8SCoreV1Services.Name(),
+		},
+	}
+}
+
+// Analyze implements Analyzer
+func (s *ServicePortAnalyzer) Analyze(ctx analysis.Context) {
+	names, services := resourcesByName(ctx, collections.K8SCoreV1Services.Name())
+	for _, name := range names {
+		analyzeServicePorts(ctx, services[name])
+	}
+}
+
+// servicePort is a port of the service of a cluster.
+type servicePort struct {
+	r    *resource.Instance
+	port v1.ServicePort
+}
+
+func analyzeServicePorts(ctx analysis.Context, services []*resource.Instance) {
+	byNumber := make(map[int32]servicePort)
+	byName := make(map[string]servicePort)
+	for _, r := range services {
+		svc := r.Message.(*v1.ServiceSpec)
+		for _, port := range svc.Ports {
+			if prev, ok := byNumber[port.Port]; ok {
+				definition, prevDefinition := portDefinition(port), portDefinition(prev.port)
+				if definition != prevDefinition {
+					ctx.Report(collections.K8SCoreV1Services.Name(), msg.NewMultiClusterServicePortConflict(r,
+						strconv.Itoa(int(port.Port)), definition, analysis.ClusterName(r), prevDefinition, analysis.ClusterName(prev.r)))
+				}
+			} else {
+				byNumber[port.Port] = servicePort{r: r, port: port}
+			}
+
+			if port.Name == "" {
+				continue
+			}
+			if prev, ok := byName[port.Name]; ok {
+				if port.Port != prev.port.Port {
+					ctx.Report(collections.K8SCoreV1Services.Name(), msg.NewMultiClusterServicePortConflict(r,
+						port.Name, strconv.Itoa(int(port.Port)), analysis.ClusterName(r),
+						strconv.Itoa(int(prev.port.Port)), analysis.ClusterName(prev.r)))
+				}
+			} else {
+				byName[port.Name] = servicePort{r: r, port: port}
+			}
+		}
+	}
+}
+
+// portDefinition returns the name and protocol of a port, e.g. "http-web (HTTP)".
+func portDefinition(port v1.ServicePort) string {
+	protocol := configKube.ConvertProtocol(port.Port, port.Name, port.Protocol)
+	if port.Name == "" {
+		return fmt.Sprintf("unnamed (%s)", protocol)
+	}
+	return fmt.Sprintf("%s (%s)", port.Name, protocol)
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicluster
+
+import (
+	"istio.io/istio/galley/pkg/config/analysis"
+	"istio.io/istio/pkg/config/resource"
+	"istio.io/istio/pkg/config/schema/collection"
+)
+
+// resourcesByName returns the resources of a collection with the same name in several clusters, in the order of
+// the clusters. Resources defined in only one cluster are left out.
+func resourcesByName(ctx analysis.Context, col collection.Name) ([]resource.FullName, map[resource.FullName][]*resource.Instance) {
+	var names []resource.FullName
+	byName := make(map[resource.FullName][]*resource.Instance)
+	ctx.ForEach(col, func(r *resource.Instance) bool {
+		name := r.Metadata.FullName
+		if _, ok := byName[name]; !ok {
+			names = append(names, name)
+		}
+		byName[name] = append(byName[name], r)
+		return true
+	})
+
+	var shared []resource.FullName
+	for _, name := range names {
+		if len(byName[name]) > 1 {
+			shared = append(shared, name)
+		} else {
+			delete(byName, name)
+		}
+	}
+	return shared, byName
+}
@@ -0,0 +1,137 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"istio.io/istio/galley/pkg/config/analysis"
+	"istio.io/istio/galley/pkg/config/analysis/analyzers/service"
+	"istio.io/istio/galley/pkg/config/analysis/diag"
+	"istio.io/istio/galley/pkg/config/analysis/local"
+	"istio.io/istio/galley/pkg/config/analysis/msg"
+	"istio.io/istio/pkg/config/schema"
+)
+
+type clusterInput struct {
+	name             string
+	inputFiles       []string
+	meshNetworksFile string // Optional
+}
+
+type clusterMessage struct {
+	messageType *diag.MessageType
+	origin      string
+	cluster     string
+}
+
+func TestMultiClusterAnalyzers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	clusters := []clusterInput{
+		{
+			name:             "cluster1",
+			inputFiles:       []string{"testdata/multicluster/cluster1.yaml"},
+			meshNetworksFile: "testdata/multicluster/meshnetworks-cluster1.yaml",
+		},
+		{
+			name:             "cluster2",
+			inputFiles:       []string{"testdata/multicluster/cluster2.yaml"},
+			meshNetworksFile: "testdata/multicluster/meshnetworks-cluster2.yaml",
+		},
+	}
+	newAnalyzer := func() *analysis.CombinedAnalyzer {
+		return analysis.Combine("testCase", &service.PortNameAnalyzer{})
+	}
+	ma := local.NewMultiClusterAnalyzer(schema.MustGet(), newAnalyzer, AllMultiClusterCombined(),
+		"", "istio-system", true, 10*time.Second)
+	for _, c := range clusters {
+		sa, err := ma.AddCluster(c.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.meshNetworksFile != "" {
+			if err := sa.AddFileKubeMeshNetworks(c.meshNetworksFile); err != nil {
+				t.Fatalf("Error applying mesh networks file %s: %v", c.meshNetworksFile, err)
+			}
+		}
+		var files []local.ReaderSource
+		for _, f := range c.inputFiles {
+			of, err := os.Open(f)
+			if err != nil {
+				t.Fatalf("Error opening test file: %q", f)
+			}
+			files = append(files, local.ReaderSource{Name: f, Reader: of})
+		}
+		if err := sa.AddReaderKubeSource(files); err != nil {
+			t.Fatalf("Error setting up file kube source of cluster %s: %v", c.name, err)
+		}
+	}
+	if _, err := ma.AddCluster("cluster1"); err == nil {
+		t.Errorf("AddCluster() succeeded for a cluster that was already added")
+	}
+
+	result, err := ma.Analyze(make(chan struct{}))
+	if err != nil {
+		t.Fatalf("Error running multi-cluster analysis: %v", err)
+	}
+
+	var got []clusterMessage
+	for _, m := range result.Messages {
+		got = append(got, clusterMessage{messageType: m.Type, origin: m.Resource.Origin.FriendlyName(), cluster: m.Cluster})
+	}
+	g.Expect(got).To(ConsistOf([]clusterMessage{
+		{msg.PortNameIsNotUnderNamingConvention, "Service details.default", "cluster1"},
+		{msg.MultiClusterServicePortConflict, "Service ratings.default", "cluster2"},
+		{msg.MultiClusterServicePortConflict, "Service ratings.default", "cluster2"},
+		{msg.MultiClusterExportToMismatch, "DestinationRule reviews.default", "cluster2"},
+		{msg.MultiClusterMeshNetworksMismatch, "MeshNetworks istio.istio-system", "cluster2"},
+	}), "%v", prettyPrintMessages(result.Messages))
+
+	var text []string
+	for _, m := range result.Messages {
+		text = append(text, m.String())
+	}
+	g.Expect(strings.Join(text, "\n")).To(And(
+		ContainSubstring("port 9080 across clusters: tcp (TCP) in cluster cluster2, http (HTTP) in cluster cluster1"),
+		ContainSubstring("port grpc across clusters: 9091 in cluster cluster2, 9090 in cluster cluster1"),
+		ContainSubstring("exported to [*] in cluster cluster2 but to [.] in cluster cluster1"),
+		ContainSubstring("network network2 has different endpoints or gateways; "+
+			"network network3 is not defined in cluster cluster1"),
+	))
+	g.Expect(result.ExecutedAnalyzers).To(ContainElement("multicluster.MeshNetworksAnalyzer"))
+}
+
+func TestMultiClusterAnalyzersHaveUniqueNamesAndDescription(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	existingNames := make(map[string]struct{})
+	for _, a := range All() {
+		existingNames[a.Metadata().Name] = struct{}{}
+	}
+	for _, a := range AllMultiCluster() {
+		n := a.Metadata().Name
+		_, ok := existingNames[n]
+		g.Expect(ok).To(BeFalse(), fmt.Sprintf("Analyzer name %q is used more than once.", n))
+		g.Expect(a.Metadata().Description).ToNot(Equal(""))
+		existingNames[n] = struct{}{}
+	}
+}
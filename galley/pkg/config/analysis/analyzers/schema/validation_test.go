@@ -152,3 +152,5 @@ type fakeOrigin struct{}
 
 func (fakeOrigin) FriendlyName() string          { return "myFriendlyName" }
 func (fakeOrigin) Namespace() resource.Namespace { return "myNamespace" }
+func (fakeOrigin) Reference() resource.Reference { return nil }
+func (fakeOrigin) FieldMap() map[string]int      { return nil }
@@ -0,0 +1,54 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	"istio.io/istio/galley/pkg/config/analysis/diag"
+	"istio.io/istio/pkg/config/resource"
+)
+
+// Paths of the resource fields reported by the analyzers, for the lookup of their line in the source
+const (
+	VSGateway                  = "{.spec.gateways[%d]}"
+	VSHTTPRouteDestinationHost = "{.spec.http[%d].route[%d].destination.host}"
+	VSHTTPMirrorHost           = "{.spec.http[%d].mirror.host}"
+	VSTCPRouteDestinationHost  = "{.spec.tcp[%d].route[%d].destination.host}"
+	VSTLSRouteDestinationHost  = "{.spec.tls[%d].route[%d].destination.host}"
+)
+
+// ErrorLine returns the line of a field of a resource in its source, if it is known. The path is
+// formatted with the arguments, e.g. ErrorLine(r, VSGateway, 0) for the first gateway of a virtual service.
+func ErrorLine(r *resource.Instance, path string, args ...interface{}) (int, bool) {
+	if r == nil || r.Origin == nil {
+		return 0, false
+	}
+	fieldMap := r.Origin.FieldMap()
+	if fieldMap == nil {
+		return 0, false
+	}
+	line, ok := fieldMap[fmt.Sprintf(path, args...)]
+	return line, ok
+}
+
+// AddLineToMessage sets the line of the message to the line of a field of its resource, if it is known,
+// and returns the message.
+func AddLineToMessage(m diag.Message, path string, args ...interface{}) diag.Message {
+	if line, ok := ErrorLine(m.Resource, path, args...); ok {
+		m.Line = line
+	}
+	return m
+}
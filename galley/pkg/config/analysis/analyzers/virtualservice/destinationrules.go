@@ -64,9 +64,9 @@ func (d *DestinationRuleAnalyzer) analyzeVirtualService(r *resource.Instance, ct
 	destinations := getRouteDestinations(vs)
 
 	for _, destination := range destinations {
-		if !d.checkDestinationSubset(ns, destination, destHostsAndSubsets) {
-			ctx.Report(collections.IstioNetworkingV1Alpha3Virtualservices.Name(),
-				msg.NewReferencedResourceNotFound(r, "host+subset in destinationrule", fmt.Sprintf("%s+%s", destination.GetHost(), destination.GetSubset())))
+		if !d.checkDestinationSubset(ns, destination.Destination, destHostsAndSubsets) {
+			m := msg.NewReferencedResourceNotFound(r, "host+subset in destinationrule", fmt.Sprintf("%s+%s", destination.GetHost(), destination.GetSubset()))
+			ctx.Report(collections.IstioNetworkingV1Alpha3Virtualservices.Name(), util.AddLineToMessage(m, destination.hostPath))
 		}
 	}
 }
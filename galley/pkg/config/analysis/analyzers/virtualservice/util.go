@@ -14,30 +14,52 @@
 package virtualservice
 
 import (
+	"fmt"
+
 	"istio.io/api/networking/v1alpha3"
+
+	"istio.io/istio/galley/pkg/config/analysis/analyzers/util"
 )
 
-func getRouteDestinations(vs *v1alpha3.VirtualService) []*v1alpha3.Destination {
-	destinations := make([]*v1alpha3.Destination, 0)
+// annotatedDestination is a route destination of a virtual service, with the path of its host field.
+type annotatedDestination struct {
+	*v1alpha3.Destination
+	hostPath string
+}
+
+func getRouteDestinations(vs *v1alpha3.VirtualService) []annotatedDestination {
+	destinations := make([]annotatedDestination, 0)
 
-	for _, r := range vs.GetTcp() {
-		for _, rd := range r.GetRoute() {
-			destinations = append(destinations, rd.GetDestination())
+	for i, r := range vs.GetTcp() {
+		for j, rd := range r.GetRoute() {
+			destinations = append(destinations, annotatedDestination{
+				Destination: rd.GetDestination(),
+				hostPath:    fmt.Sprintf(util.VSTCPRouteDestinationHost, i, j),
+			})
 		}
 	}
-	for _, r := range vs.GetTls() {
-		for _, rd := range r.GetRoute() {
-			destinations = append(destinations, rd.GetDestination())
+	for i, r := range vs.GetTls() {
+		for j, rd := range r.GetRoute() {
+			destinations = append(destinations, annotatedDestination{
+				Destination: rd.GetDestination(),
+				hostPath:    fmt.Sprintf(util.VSTLSRouteDestinationHost, i, j),
+			})
 		}
 	}
-	for _, r := range vs.GetHttp() {
-		for _, rd := range r.GetRoute() {
-			destinations = append(destinations, rd.GetDestination())
+	for i, r := range vs.GetHttp() {
+		for j, rd := range r.GetRoute() {
+			destinations = append(destinations, annotatedDestination{
+				Destination: rd.GetDestination(),
+				hostPath:    fmt.Sprintf(util.VSHTTPRouteDestinationHost, i, j),
+			})
 		}
 		// If there is a mirror destination, check it too
 		m := r.GetMirror()
 		if m != nil {
-			destinations = append(destinations, m)
+			destinations = append(destinations, annotatedDestination{
+				Destination: m,
+				hostPath:    fmt.Sprintf(util.VSHTTPMirrorHost, i),
+			})
 		}
 	}
 
@@ -29,3 +29,23 @@ func (o testOrigin) FriendlyName() string {
 func (o testOrigin) Namespace() resource.Namespace {
 	return ""
 }
+
+func (o testOrigin) Reference() resource.Reference {
+	return nil
+}
+
+func (o testOrigin) FieldMap() map[string]int {
+	return nil
+}
+
+var _ resource.Origin = &testRefOrigin{}
+
+// testRefOrigin is a testOrigin with a reference to its source.
+type testRefOrigin struct {
+	testOrigin
+	ref resource.Reference
+}
+
+func (o *testRefOrigin) Reference() resource.Reference {
+	return o.ref
+}
@@ -58,6 +58,28 @@ type Message struct {
 
 	// DocRef is an optional reference tracker for the documentation URL
 	DocRef string
+
+	// Line is the line of the resource field the message is about, or 0 if it is not known.
+	Line int
+
+	// Cluster is the name of the cluster of the resource, set by multi-cluster analysis.
+	Cluster string
+}
+
+// Reference returns where the message is in the source of its resource, e.g. "file.yaml:12",
+// or "" if it is not known.
+func (m *Message) Reference() string {
+	if m.Resource == nil || m.Resource.Origin == nil {
+		return ""
+	}
+	ref := m.Resource.Origin.Reference()
+	if ref == nil {
+		return ""
+	}
+	if p, ok := ref.(*resource.Position); ok && m.Line > 0 {
+		return (&resource.Position{Filename: p.Filename, Line: m.Line}).String()
+	}
+	return ref.String()
 }
 
 // Unstructured returns this message as a JSON-style unstructured map
@@ -68,6 +90,12 @@ func (m *Message) Unstructured(includeOrigin bool) map[string]interface{} {
 	result["level"] = m.Type.Level().String()
 	if includeOrigin && m.Resource != nil {
 		result["origin"] = m.Resource.Origin.FriendlyName()
+		if ref := m.Reference(); ref != "" {
+			result["reference"] = ref
+		}
+	}
+	if m.Cluster != "" {
+		result["cluster"] = m.Cluster
 	}
 	result["message"] = fmt.Sprintf(m.Type.Template(), m.Parameters...)
 
@@ -80,11 +108,33 @@ func (m *Message) Unstructured(includeOrigin bool) map[string]interface{} {
 	return result
 }
 
-// String implements io.Stringer
-func (m *Message) String() string {
+// Origin returns where the message comes from for display, e.g. "cluster1: VirtualService foo.bar file.yaml:12",
+// or "" if it is not known.
+func (m *Message) Origin() string {
 	origin := ""
 	if m.Resource != nil {
-		origin = "(" + m.Resource.Origin.FriendlyName() + ")"
+		origin = m.Resource.Origin.FriendlyName()
+		if ref := m.Reference(); ref != "" {
+			origin += " " + ref
+		}
+	}
+	if m.Cluster != "" {
+		if origin == "" {
+			origin = m.Cluster
+		} else {
+			origin = m.Cluster + ": " + origin
+		}
+	}
+	return origin
+}
+
+// String implements io.Stringer. The origin of the message is shown in parentheses, including the file and
+// line of the resource when it was read from a file, e.g. "Error [IST0101](VirtualService foo.bar file.yaml:12) ...",
+// so that the messages logged or printed by istioctl point at the offending field.
+func (m *Message) String() string {
+	origin := ""
+	if o := m.Origin(); o != "" {
+		origin = "(" + o + ")"
 	}
 	return fmt.Sprintf(
 		"%v [%v]%s %s", m.Type.Level(), m.Type.Code(), origin, fmt.Sprintf(m.Type.Template(), m.Parameters...))
@@ -39,6 +39,21 @@ func TestMessageWithResource_String(t *testing.T) {
 	g.Expect(m.String()).To(Equal(`Error [IST-0042](toppings/cheese) Cheese type not found: "Feta"`))
 }
 
+func TestMessageWithCluster_String(t *testing.T) {
+	g := NewGomegaWithT(t)
+	mt := NewMessageType(Error, "IST-0042", "Cheese type not found: %q")
+	m := NewMessage(mt, &resource.Instance{Origin: testOrigin("toppings/cheese")}, "Feta")
+	m.Cluster = "pizzeria"
+
+	g.Expect(m.Origin()).To(Equal("pizzeria: toppings/cheese"))
+	g.Expect(m.String()).To(Equal(`Error [IST-0042](pizzeria: toppings/cheese) Cheese type not found: "Feta"`))
+	g.Expect(m.Unstructured(false)).To(HaveKeyWithValue("cluster", "pizzeria"))
+
+	m = NewMessage(mt, nil, "Feta")
+	m.Cluster = "pizzeria"
+	g.Expect(m.String()).To(Equal(`Error [IST-0042](pizzeria) Cheese type not found: "Feta"`))
+}
+
 func TestMessage_Unstructured(t *testing.T) {
 	g := NewGomegaWithT(t)
 	mt := NewMessageType(Error, "IST-0042", "Cheese type not found: %q")
@@ -70,3 +85,27 @@ func TestMessage_JSON(t *testing.T) {
 	g.Expect(string(j)).To(Equal(`{"code":"IST-0042","documentation_url":"https://istio.io/docs/reference/config/analysis/IST-0042"` +
 		`,"level":"Error","message":"Cheese type not found: \"Feta\"","origin":"toppings/cheese"}`))
 }
+
+func TestMessageWithReference(t *testing.T) {
+	g := NewGomegaWithT(t)
+	mt := NewMessageType(Error, "IST-0042", "Cheese type not found: %q")
+	origin := &testRefOrigin{
+		testOrigin: "toppings/cheese",
+		ref:        &resource.Position{Filename: "pizza.yaml", Line: 3},
+	}
+	m := NewMessage(mt, &resource.Instance{Origin: origin}, "Feta")
+
+	g.Expect(m.Reference()).To(Equal("pizza.yaml:3"))
+	g.Expect(m.String()).To(Equal(`Error [IST-0042](toppings/cheese pizza.yaml:3) Cheese type not found: "Feta"`))
+	g.Expect(m.Unstructured(true)).To(HaveKeyWithValue("reference", "pizza.yaml:3"))
+	g.Expect(m.Unstructured(false)).To(Not(HaveKey("reference")))
+
+	// The line of the field the message is about takes precedence over the line of the resource.
+	m.Line = 12
+	g.Expect(m.Reference()).To(Equal("pizza.yaml:12"))
+	g.Expect(m.String()).To(Equal(`Error [IST-0042](toppings/cheese pizza.yaml:12) Cheese type not found: "Feta"`))
+
+	m = NewMessage(mt, &resource.Instance{Origin: testOrigin("toppings/cheese")}, "Feta")
+	m.Line = 12
+	g.Expect(m.Reference()).To(Equal(""))
+}
@@ -40,6 +40,7 @@ import (
 	"istio.io/istio/galley/pkg/config/source/kube"
 	"istio.io/istio/galley/pkg/config/source/kube/apiserver"
 	"istio.io/istio/galley/pkg/config/source/kube/inmemory"
+	"istio.io/istio/galley/pkg/config/source/kube/rt"
 	"istio.io/istio/galley/pkg/config/util/kuberesource"
 	"istio.io/istio/pkg/config/mesh"
 	"istio.io/istio/pkg/config/resource"
@@ -50,9 +51,10 @@ import (
 )
 
 const (
-	domainSuffix      = "cluster.local"
-	meshConfigMapKey  = "mesh"
-	meshConfigMapName = "istio"
+	domainSuffix             = "cluster.local"
+	meshConfigMapKey         = "mesh"
+	meshNetworksConfigMapKey = "meshNetworks"
+	meshConfigMapName        = "istio"
 )
 
 // Pseudo-constants, since golang doesn't support a true const slice/array
@@ -80,6 +82,13 @@ type SourceAnalyzer struct {
 	// Mesh config for this analyzer. This can come from multiple sources, and the last added version will take precedence.
 	meshCfg *v1alpha1.MeshConfig
 
+	// Mesh networks for this analyzer, if known. Only used by multi-cluster analysis.
+	meshNetworks       *v1alpha1.MeshNetworks
+	meshNetworksOrigin resource.Origin
+
+	// Collects the snapshot of the analysis for multi-cluster analysis, if set
+	collector *snapshotCollector
+
 	// Which kube resources are used by this analyzer
 	// Derived from metadata and the specified analyzer and transformer providers
 	kubeResources collection.Schemas
@@ -110,6 +119,13 @@ type ReaderSource struct {
 // then execute Analyze to perform the analysis
 func NewSourceAnalyzer(m *schema.Metadata, analyzer *analysis.CombinedAnalyzer, namespace, istioNamespace resource.Namespace,
 	cr snapshotter.CollectionReporterFn, serviceDiscovery bool, timeout time.Duration) *SourceAnalyzer {
+	return newSourceAnalyzer(m, analyzer, nil, namespace, istioNamespace, cr, serviceDiscovery, timeout)
+}
+
+// newSourceAnalyzer creates a new SourceAnalyzer, whose sources also provide the given additional input collections.
+func newSourceAnalyzer(m *schema.Metadata, analyzer *analysis.CombinedAnalyzer, inputs collection.Names,
+	namespace, istioNamespace resource.Namespace, cr snapshotter.CollectionReporterFn, serviceDiscovery bool,
+	timeout time.Duration) *SourceAnalyzer {
 
 	// collectionReporter hook function defaults to no-op
 	if cr == nil {
@@ -122,7 +138,7 @@ func NewSourceAnalyzer(m *schema.Metadata, analyzer *analysis.CombinedAnalyzer,
 	kubeResources := kuberesource.DisableExcludedCollections(
 		m.KubeCollections(),
 		transformerProviders,
-		analyzer.Metadata().Inputs,
+		append(analyzer.Metadata().Inputs, inputs...),
 		kuberesource.DefaultExcludedResourceKinds(),
 		serviceDiscovery)
 
@@ -179,9 +195,14 @@ func (sa *SourceAnalyzer) Analyze(cancel chan struct{}) (AnalysisResult, error)
 		WaitTimeout: sa.timeout,
 	}
 
+	analyzer := sa.analyzer
+	if sa.collector != nil {
+		analyzer = analysis.Combine(sa.analyzer.Metadata().Name, sa.analyzer, sa.collector)
+	}
+
 	distributorSettings := snapshotter.AnalyzingDistributorSettings{
 		StatusUpdater:      updater,
-		Analyzer:           sa.analyzer,
+		Analyzer:           analyzer,
 		Distributor:        snapshotter.NewInMemoryDistributor(),
 		AnalysisSnapshots:  analysisSnapshots,
 		TriggerSnapshot:    snapshots.LocalAnalysis,
@@ -293,6 +314,28 @@ func (sa *SourceAnalyzer) AddFileKubeMeshConfig(file string) error {
 	return nil
 }
 
+// AddFileKubeMeshNetworks gets mesh networks from the specified yaml file
+func (sa *SourceAnalyzer) AddFileKubeMeshNetworks(file string) error {
+	by, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	networks, err := mesh.ParseMeshNetworks(string(by))
+	if err != nil {
+		return err
+	}
+
+	sa.meshNetworks = networks
+	sa.meshNetworksOrigin = &rt.Origin{
+		Collection: collections.K8SCoreV1Configmaps.Name(),
+		Kind:       "MeshNetworks",
+		FullName:   resource.NewFullName(sa.istioNamespace, meshConfigMapName),
+		Ref:        &resource.Position{Filename: file},
+	}
+	return nil
+}
+
 // AddDefaultResources adds some basic dummy Istio resources, based on mesh configuration.
 // This is useful for files-only analysis cases where we don't expect the user to be including istio system resources
 // and don't want to generate false positives because they aren't there.
@@ -332,5 +375,18 @@ func (sa *SourceAnalyzer) addRunningKubeMeshConfigSource(client kubernetes.Inter
 	}
 
 	sa.meshCfg = cfg
+
+	if networksYaml, ok := meshConfigMap.Data[meshNetworksConfigMapKey]; ok {
+		networks, err := mesh.ParseMeshNetworks(networksYaml)
+		if err != nil {
+			return fmt.Errorf("error parsing mesh networks: %v", err)
+		}
+		sa.meshNetworks = networks
+		sa.meshNetworksOrigin = &rt.Origin{
+			Collection: collections.K8SCoreV1Configmaps.Name(),
+			Kind:       "MeshNetworks",
+			FullName:   resource.NewFullName(sa.istioNamespace, meshConfigMapName),
+		}
+	}
 	return nil
 }
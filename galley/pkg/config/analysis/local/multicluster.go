@@ -0,0 +1,273 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"istio.io/istio/galley/pkg/config/analysis"
+	"istio.io/istio/galley/pkg/config/analysis/diag"
+	"istio.io/istio/galley/pkg/config/processing/snapshotter"
+	"istio.io/istio/pkg/config/resource"
+	"istio.io/istio/pkg/config/schema"
+	"istio.io/istio/pkg/config/schema/collection"
+)
+
+// MultiClusterAnalyzer handles local analysis of the clusters of a multi-cluster mesh. Each cluster is analyzed
+// on its own, then the resources of all the clusters are merged into one snapshot, where each resource is tagged
+// with its cluster, and analyzed together by the cross-cluster analyzers.
+type MultiClusterAnalyzer struct {
+	m                    *schema.Metadata
+	newAnalyzer          func() *analysis.CombinedAnalyzer
+	crossClusterAnalyzer *analysis.CombinedAnalyzer
+	namespace            resource.Namespace
+	istioNamespace       resource.Namespace
+	serviceDiscovery     bool
+	timeout              time.Duration
+
+	clusters     []*clusterAnalyzer
+	suppressions []snapshotter.AnalysisSuppression
+}
+
+type clusterAnalyzer struct {
+	name string
+	sa   *SourceAnalyzer
+}
+
+// NewMultiClusterAnalyzer creates a new MultiClusterAnalyzer with no clusters. Each cluster is analyzed with a new
+// analyzer returned by newAnalyzer, and the merged snapshot of all the clusters with crossClusterAnalyzer. Use
+// AddCluster to add the clusters, then execute Analyze to perform the analysis.
+func NewMultiClusterAnalyzer(m *schema.Metadata, newAnalyzer func() *analysis.CombinedAnalyzer,
+	crossClusterAnalyzer *analysis.CombinedAnalyzer, namespace, istioNamespace resource.Namespace, serviceDiscovery bool,
+	timeout time.Duration) *MultiClusterAnalyzer {
+	return &MultiClusterAnalyzer{
+		m:                    m,
+		newAnalyzer:          newAnalyzer,
+		crossClusterAnalyzer: crossClusterAnalyzer,
+		namespace:            namespace,
+		istioNamespace:       istioNamespace,
+		serviceDiscovery:     serviceDiscovery,
+		timeout:              timeout,
+	}
+}
+
+// AddCluster adds a cluster to the analysis, and returns the SourceAnalyzer of the cluster. Use its Add*Source
+// methods to add the sources of the cluster.
+func (ma *MultiClusterAnalyzer) AddCluster(name string) (*SourceAnalyzer, error) {
+	for _, c := range ma.clusters {
+		if c.name == name {
+			return nil, fmt.Errorf("cluster %q is added more than once", name)
+		}
+	}
+
+	sa := newSourceAnalyzer(ma.m, ma.newAnalyzer(), ma.crossClusterAnalyzer.Metadata().Inputs,
+		ma.namespace, ma.istioNamespace, nil, ma.serviceDiscovery, ma.timeout)
+	sa.SetSuppressions(ma.suppressions)
+	ma.clusters = append(ma.clusters, &clusterAnalyzer{name: name, sa: sa})
+	return sa, nil
+}
+
+// SetSuppressions sets the list of suppressions for the analysis of every cluster, and of the merged snapshot.
+func (ma *MultiClusterAnalyzer) SetSuppressions(suppressions []snapshotter.AnalysisSuppression) {
+	ma.suppressions = suppressions
+	for _, c := range ma.clusters {
+		c.sa.SetSuppressions(suppressions)
+	}
+}
+
+// Analyze analyzes each cluster, then the merged snapshot of all the clusters. The messages are tagged with the
+// cluster of their resource.
+func (ma *MultiClusterAnalyzer) Analyze(cancel chan struct{}) (AnalysisResult, error) {
+	var result AnalysisResult
+
+	if len(ma.clusters) == 0 {
+		return result, fmt.Errorf("at least one cluster must be provided")
+	}
+
+	// The clusters have the same input collections, so any of them tells which cross-cluster analyzers can run.
+	first := ma.clusters[0].sa
+	colsInSnapshots := collection.Names{analysis.MeshNetworks}
+	for _, c := range ma.m.AllCollectionsInSnapshots(analysisSnapshots) {
+		colsInSnapshots = append(colsInSnapshots, collection.NewName(c))
+	}
+	skipped := ma.crossClusterAnalyzer.RemoveSkipped(colsInSnapshots, first.kubeResources.DisabledCollectionNames(),
+		first.transformerProviders)
+
+	var inputs collection.Names
+	for _, col := range ma.crossClusterAnalyzer.Metadata().Inputs {
+		if col != analysis.MeshNetworks {
+			inputs = append(inputs, col)
+		}
+	}
+
+	merged := &multiClusterContext{
+		resources: make(map[collection.Name][]*resource.Instance),
+		cancelCh:  cancel,
+	}
+
+	var messages diag.Messages
+	for i, c := range ma.clusters {
+		c.sa.collector = &snapshotCollector{inputs: inputs}
+
+		r, err := c.sa.Analyze(cancel)
+		if err != nil {
+			return result, fmt.Errorf("failed to analyze cluster %s: %v", c.name, err)
+		}
+		if i == 0 {
+			result.SkippedAnalyzers = append(r.SkippedAnalyzers, skipped...)
+			result.ExecutedAnalyzers = append(r.ExecutedAnalyzers, ma.crossClusterAnalyzer.AnalyzerNames()...)
+		}
+		for _, m := range r.Messages {
+			m.Cluster = c.name
+			messages = append(messages, m)
+		}
+
+		merged.add(c.name, c.sa.collector.snapshot())
+		if c.sa.meshNetworks != nil {
+			merged.add(c.name, map[collection.Name][]*resource.Instance{
+				analysis.MeshNetworks: {{
+					Metadata: resource.Metadata{FullName: resource.NewFullName(ma.istioNamespace, meshConfigMapName)},
+					Message:  c.sa.meshNetworks,
+					Origin:   c.sa.meshNetworksOrigin,
+				}},
+			})
+		}
+	}
+
+	ma.crossClusterAnalyzer.Analyze(merged)
+	if merged.Canceled() {
+		return result, fmt.Errorf("analysis of the merged snapshot of the clusters was canceled")
+	}
+
+	namespaces := make(map[resource.Namespace]struct{})
+	if ma.namespace != "" {
+		namespaces[ma.namespace] = struct{}{}
+	}
+	messages = append(messages, snapshotter.FilterMessages(merged.messages, namespaces, ma.suppressions)...)
+	result.Messages = messages.SortedDedupedCopy()
+
+	return result, nil
+}
+
+// snapshotCollector is an analyzer collecting the resources of its input collections, for their analysis in the
+// merged snapshot of all the clusters.
+type snapshotCollector struct {
+	inputs collection.Names
+
+	mu        sync.Mutex
+	resources map[collection.Name][]*resource.Instance
+}
+
+var _ analysis.Analyzer = &snapshotCollector{}
+
+// Metadata implements Analyzer
+func (c *snapshotCollector) Metadata() analysis.Metadata {
+	return analysis.Metadata{
+		Name:        "local.SnapshotCollector",
+		Description: "Collects the resources of a cluster for multi-cluster analysis",
+		Inputs:      c.inputs,
+	}
+}
+
+// Analyze implements Analyzer
+func (c *snapshotCollector) Analyze(ctx analysis.Context) {
+	resources := make(map[collection.Name][]*resource.Instance)
+	for _, col := range c.inputs {
+		ctx.ForEach(col, func(r *resource.Instance) bool {
+			resources[col] = append(resources[col], r)
+			return true
+		})
+	}
+	if ctx.Canceled() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resources = resources
+}
+
+func (c *snapshotCollector) snapshot() map[collection.Name][]*resource.Instance {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.resources
+}
+
+// multiClusterContext is the analysis context of the merged snapshot of several clusters.
+type multiClusterContext struct {
+	resources map[collection.Name][]*resource.Instance
+	cancelCh  chan struct{}
+	messages  diag.Messages
+}
+
+var _ analysis.Context = &multiClusterContext{}
+
+// add adds the resources of a cluster, tagging their origin with the cluster.
+func (c *multiClusterContext) add(cluster string, resources map[collection.Name][]*resource.Instance) {
+	for col, instances := range resources {
+		for _, r := range instances {
+			c.resources[col] = append(c.resources[col], &resource.Instance{
+				Metadata: r.Metadata,
+				Message:  r.Message,
+				Origin:   &analysis.ClusterOrigin{Origin: r.Origin, Cluster: cluster},
+			})
+		}
+	}
+}
+
+// Report implements analysis.Context
+func (c *multiClusterContext) Report(_ collection.Name, m diag.Message) {
+	if m.Cluster == "" && m.Resource != nil {
+		m.Cluster = analysis.ClusterName(m.Resource)
+	}
+	c.messages.Add(m)
+}
+
+// Find implements analysis.Context. It returns the resource of the first cluster that has it.
+func (c *multiClusterContext) Find(col collection.Name, name resource.FullName) *resource.Instance {
+	for _, r := range c.resources[col] {
+		if r.Metadata.FullName == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// Exists implements analysis.Context
+func (c *multiClusterContext) Exists(col collection.Name, name resource.FullName) bool {
+	return c.Find(col, name) != nil
+}
+
+// ForEach implements analysis.Context. It iterates over the resources of all the clusters, in the order the
+// clusters were added.
+func (c *multiClusterContext) ForEach(col collection.Name, fn analysis.IteratorFn) {
+	for _, r := range c.resources[col] {
+		if !fn(r) {
+			return
+		}
+	}
+}
+
+// Canceled implements analysis.Context
+func (c *multiClusterContext) Canceled() bool {
+	select {
+	case <-c.cancelCh:
+		return true
+	default:
+		return false
+	}
+}
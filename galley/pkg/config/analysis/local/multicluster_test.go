@@ -0,0 +1,70 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"istio.io/istio/galley/pkg/config/analysis"
+	"istio.io/istio/galley/pkg/config/analysis/msg"
+	"istio.io/istio/galley/pkg/config/testing/basicmeta"
+	"istio.io/istio/galley/pkg/config/testing/k8smeta"
+	"istio.io/istio/pkg/config/resource"
+	"istio.io/istio/pkg/config/schema/collection"
+)
+
+func TestMultiClusterAbortWithNoClusters(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newAnalyzer := func() *analysis.CombinedAnalyzer { return analysis.Combine("testCombined", blankTestAnalyzer) }
+	ma := NewMultiClusterAnalyzer(k8smeta.MustGet(), newAnalyzer, analysis.Combine("testCrossCluster"), "", "", false, timeout)
+	_, err := ma.Analyze(make(chan struct{}))
+	g.Expect(err).To(Not(BeNil()))
+}
+
+func TestMultiClusterContext(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	col := basicmeta.K8SCollection1.Name()
+	ctx := &multiClusterContext{
+		resources: make(map[collection.Name][]*resource.Instance),
+		cancelCh:  make(chan struct{}),
+	}
+	ctx.add("west", map[collection.Name][]*resource.Instance{col: {createTestResource(t, "ns", "r1", "v1")}})
+	ctx.add("east", map[collection.Name][]*resource.Instance{col: {
+		createTestResource(t, "ns", "r1", "v2"),
+		createTestResource(t, "ns", "r2", "v1"),
+	}})
+
+	var clusters []string
+	ctx.ForEach(col, func(r *resource.Instance) bool {
+		clusters = append(clusters, analysis.ClusterName(r)+"/"+r.Metadata.FullName.String())
+		return true
+	})
+	g.Expect(clusters).To(Equal([]string{"west/ns/r1", "east/ns/r1", "east/ns/r2"}))
+
+	// Find returns the resource of the first cluster that has it
+	r := ctx.Find(col, resource.NewFullName("ns", "r1"))
+	g.Expect(analysis.ClusterName(r)).To(Equal("west"))
+	g.Expect(r.Origin.FriendlyName()).To(Equal(createTestResource(t, "ns", "r1", "v1").Origin.FriendlyName()))
+	g.Expect(ctx.Exists(col, resource.NewFullName("ns", "r3"))).To(BeFalse())
+
+	// Reported messages are tagged with the cluster of their resource
+	ctx.Report(col, msg.NewInternalError(ctx.Find(col, resource.NewFullName("ns", "r2")), "msg"))
+	g.Expect(ctx.messages).To(HaveLen(1))
+	g.Expect(ctx.messages[0].Cluster).To(Equal("east"))
+}
@@ -100,6 +100,18 @@ var (
 	// MeshPolicyResourceIsDeprecated defines a diag.MessageType for message "MeshPolicyResourceIsDeprecated".
 	// Description: The MeshPolicy resource is deprecated and will be removed in a future Istio release. Migrate to the PeerAuthentication resource.
 	MeshPolicyResourceIsDeprecated = diag.NewMessageType(diag.Info, "IST0121", "The MeshPolicy resource is deprecated and will be removed in a future Istio release. Migrate to the PeerAuthentication resource.")
+
+	// MultiClusterServicePortConflict defines a diag.MessageType for message "MultiClusterServicePortConflict".
+	// Description: A service with the same name is defined in several clusters with conflicting ports.
+	MultiClusterServicePortConflict = diag.NewMessageType(diag.Error, "IST0122", "The service has conflicting definitions of port %s across clusters: %s in cluster %s, %s in cluster %s.")
+
+	// MultiClusterExportToMismatch defines a diag.MessageType for message "MultiClusterExportToMismatch".
+	// Description: A resource with the same name is exported to different namespaces in several clusters.
+	MultiClusterExportToMismatch = diag.NewMessageType(diag.Warning, "IST0123", "The resource is exported to %v in cluster %s but to %v in cluster %s.")
+
+	// MultiClusterMeshNetworksMismatch defines a diag.MessageType for message "MultiClusterMeshNetworksMismatch".
+	// Description: The mesh networks of the clusters of a mesh differ.
+	MultiClusterMeshNetworksMismatch = diag.NewMessageType(diag.Error, "IST0124", "The mesh networks of cluster %s differ from those of cluster %s: %s.")
 )
 
 // All returns a list of all known message types.
@@ -128,6 +140,9 @@ func All() []*diag.MessageType {
 		JwtFailureDueToInvalidServicePortPrefix,
 		PolicyResourceIsDeprecated,
 		MeshPolicyResourceIsDeprecated,
+		MultiClusterServicePortConflict,
+		MultiClusterExportToMismatch,
+		MultiClusterMeshNetworksMismatch,
 	}
 }
 
@@ -356,3 +371,39 @@ func NewMeshPolicyResourceIsDeprecated(r *resource.Instance) diag.Message {
 		r,
 	)
 }
+
+// NewMultiClusterServicePortConflict returns a new diag.Message based on MultiClusterServicePortConflict.
+func NewMultiClusterServicePortConflict(r *resource.Instance, port string, definition string, cluster string, otherDefinition string, otherCluster string) diag.Message {
+	return diag.NewMessage(
+		MultiClusterServicePortConflict,
+		r,
+		port,
+		definition,
+		cluster,
+		otherDefinition,
+		otherCluster,
+	)
+}
+
+// NewMultiClusterExportToMismatch returns a new diag.Message based on MultiClusterExportToMismatch.
+func NewMultiClusterExportToMismatch(r *resource.Instance, exportTo []string, cluster string, otherExportTo []string, otherCluster string) diag.Message {
+	return diag.NewMessage(
+		MultiClusterExportToMismatch,
+		r,
+		exportTo,
+		cluster,
+		otherExportTo,
+		otherCluster,
+	)
+}
+
+// NewMultiClusterMeshNetworksMismatch returns a new diag.Message based on MultiClusterMeshNetworksMismatch.
+func NewMultiClusterMeshNetworksMismatch(r *resource.Instance, cluster string, otherCluster string, difference string) diag.Message {
+	return diag.NewMessage(
+		MultiClusterMeshNetworksMismatch,
+		r,
+		cluster,
+		otherCluster,
+		difference,
+	)
+}
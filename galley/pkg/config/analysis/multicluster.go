@@ -0,0 +1,44 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"istio.io/istio/pkg/config/resource"
+	"istio.io/istio/pkg/config/schema/collection"
+)
+
+// MeshNetworks is the collection of the mesh networks configuration of each cluster in a
+// multi-cluster analysis. It is not read from a source, the multi-cluster analysis adds one
+// meshconfig.MeshNetworks resource per cluster to it.
+var MeshNetworks = collection.NewName("istio/mesh/v1alpha1/MeshNetworks")
+
+// ClusterOrigin is the origin of a resource in a multi-cluster analysis. It tags the origin of the
+// resource in its cluster with the name of the cluster.
+type ClusterOrigin struct {
+	resource.Origin
+
+	Cluster string
+}
+
+var _ resource.Origin = &ClusterOrigin{}
+
+// ClusterName returns the name of the cluster of a resource in a multi-cluster analysis, or "" if
+// the resource is not part of a multi-cluster analysis.
+func ClusterName(r *resource.Instance) string {
+	if o, ok := r.Origin.(*ClusterOrigin); ok {
+		return o.Cluster
+	}
+	return ""
+}
@@ -65,6 +65,14 @@ var (
 		"galley/runtime/strategy/timer_resets_total",
 		"The number of times the timer has been reset",
 		stats.UnitDimensionless)
+	strategySnapshotsSuppressedTotal = stats.Int64(
+		"galley/runtime/strategy/snapshots_suppressed_total",
+		"The number of snapshots suppressed by coalescing changes",
+		stats.UnitDimensionless)
+	strategyQuiesceWindowMs = stats.Int64(
+		"galley/runtime/strategy/quiesce_window_duration_milliseconds",
+		"The quiesce window used by the adaptive strategy before publishing a snapshot",
+		stats.UnitMilliseconds)
 	processorEventSpansMs = stats.Int64(
 		"galley/runtime/processor/event_span_duration_milliseconds",
 		"The duration between each incoming event",
@@ -115,6 +123,16 @@ func RecordOnTimer(maxTimeReached, quiesceTimeReached, timerReset bool) {
 	}
 }
 
+// RecordStrategySnapshotSuppressed event
+func RecordStrategySnapshotSuppressed() {
+	stats.Record(context.Background(), strategySnapshotsSuppressedTotal.M(1))
+}
+
+// RecordStrategyQuiesceWindow event
+func RecordStrategyQuiesceWindow(window time.Duration) {
+	stats.Record(context.Background(), strategyQuiesceWindowMs.M(window.Nanoseconds()/1e6))
+}
+
 // RecordProcessorEventProcessed event
 func RecordProcessorEventProcessed(eventSpan time.Duration) {
 	stats.Record(context.Background(), processorEventsProcessed.M(1),
@@ -233,6 +251,8 @@ func init() {
 		newView(strategyOnChangeTotal, noKeys, view.Count()),
 		newView(strategyOnTimerMaxTimeReachedTotal, noKeys, view.Count()),
 		newView(strategyOnTimerQuiesceReachedTotal, noKeys, view.Count()),
+		newView(strategySnapshotsSuppressedTotal, noKeys, view.Count()),
+		newView(strategyQuiesceWindowMs, noKeys, durationDistributionMs),
 		newView(processorEventSpansMs, noKeys, durationDistributionMs),
 		newView(processorEventsProcessed, noKeys, view.Count()),
 		newView(processorSnapshotsPublished, noKeys, view.Count()),
@@ -162,7 +162,7 @@ func (d *AnalyzingDistributor) analyzeAndDistribute(cancelCh chan struct{}, name
 	d.s.Analyzer.Analyze(ctx)
 	scope.Analysis.Debugf("Finished analyzing the current snapshot, found messages: %v", ctx.messages)
 
-	msgs := filterMessages(ctx.messages, namespaces, d.s.Suppressions)
+	msgs := FilterMessages(ctx.messages, namespaces, d.s.Suppressions)
 	if !ctx.Canceled() {
 		d.s.StatusUpdater.Update(msgs.SortedDedupedCopy())
 	}
@@ -190,7 +190,9 @@ func (d *AnalyzingDistributor) getCombinedSnapshot() *Snapshot {
 	return &Snapshot{set: coll.NewSetFromCollections(collections)}
 }
 
-func filterMessages(messages diag.Messages, namespaces map[resource.Namespace]struct{}, suppressions []AnalysisSuppression) diag.Messages {
+// FilterMessages returns the messages about resources of the given namespaces, or of any namespace if none is
+// given, that are not suppressed by the suppressions list or an annotation of their resource.
+func FilterMessages(messages diag.Messages, namespaces map[resource.Namespace]struct{}, suppressions []AnalysisSuppression) diag.Messages {
 	nsNames := make(map[string]struct{})
 	for k := range namespaces {
 		nsNames[k.String()] = struct{}{}
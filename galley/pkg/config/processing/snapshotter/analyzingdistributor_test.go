@@ -502,4 +502,6 @@ type fakeOrigin struct {
 }
 
 func (f fakeOrigin) Namespace() resource.Namespace { return f.namespace }
+func (f fakeOrigin) Reference() resource.Reference { return nil }
+func (f fakeOrigin) FieldMap() map[string]int      { return nil }
 func (f fakeOrigin) FriendlyName() string          { return f.friendlyName }
@@ -0,0 +1,91 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshotter
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"istio.io/istio/galley/pkg/config/scope"
+	"istio.io/istio/pkg/mcp/snapshot"
+)
+
+const persistedSnapshotExtension = ".snapshot"
+
+// PersistingDistributor is a Distributor that persists the last snapshot of each group to a file in a
+// directory, before passing it to a downstream Distributor. The persisted snapshots can be loaded with
+// LoadPersistedSnapshots, to serve them while Galley starts up.
+type PersistingDistributor struct {
+	dir         string
+	distributor Distributor
+
+	// Overridable for testing.
+	now func() time.Time
+}
+
+var _ Distributor = &PersistingDistributor{}
+
+// NewPersistingDistributor returns a new instance of PersistingDistributor.
+func NewPersistingDistributor(dir string, distributor Distributor) *PersistingDistributor {
+	return &PersistingDistributor{
+		dir:         dir,
+		distributor: distributor,
+		now:         time.Now,
+	}
+}
+
+// Distribute implements Distributor
+func (d *PersistingDistributor) Distribute(name string, s *Snapshot) {
+	d.distributor.Distribute(name, s)
+
+	if err := snapshot.Save(persistedSnapshotPath(d.dir, name), s, d.now()); err != nil {
+		scope.Processing.Errorf("Unable to persist snapshot %s to %s: %v", name, d.dir, err)
+	}
+}
+
+// LoadPersistedSnapshots loads the snapshots of the given groups persisted in dir by a PersistingDistributor.
+// Snapshots that are missing, cannot be read, or are older than maxAge are skipped. A zero maxAge disables
+// the age check.
+func LoadPersistedSnapshots(dir string, groups []string, maxAge time.Duration, now time.Time) map[string]snapshot.Snapshot {
+	result := make(map[string]snapshot.Snapshot)
+	for _, group := range groups {
+		path := persistedSnapshotPath(dir, group)
+		s, savedAt, err := snapshot.Load(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				scope.Processing.Infof("No persisted snapshot for %s at %s", group, path)
+			} else {
+				scope.Processing.Warnf("Unable to load persisted snapshot for %s: %v", group, err)
+			}
+			continue
+		}
+
+		age := now.Sub(savedAt)
+		if maxAge > 0 && age > maxAge {
+			scope.Processing.Infof("Ignoring persisted snapshot for %s, its age %v exceeds %v", group, age, maxAge)
+			continue
+		}
+
+		scope.Processing.Infof("Loaded persisted snapshot for %s, saved %v ago", group, age)
+		result[group] = s
+	}
+
+	return result
+}
+
+func persistedSnapshotPath(dir, group string) string {
+	return filepath.Join(dir, group+persistedSnapshotExtension)
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshotter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	coll "istio.io/istio/galley/pkg/config/collection"
+	"istio.io/istio/galley/pkg/config/testing/basicmeta"
+	"istio.io/istio/galley/pkg/config/testing/data"
+	"istio.io/istio/pkg/config/schema/collection"
+)
+
+func TestPersistingDistributor(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir, err := ioutil.TempDir("", t.Name())
+	g.Expect(err).To(BeNil())
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	set := coll.NewSet(collection.NewSchemasBuilder().MustAdd(basicmeta.K8SCollection1).Build())
+	set.Collection(basicmeta.K8SCollection1.Name()).Set(data.EntryN1I1V1)
+	sn := &Snapshot{set: set}
+
+	savedAt := time.Now()
+	downstream := NewInMemoryDistributor()
+	d := NewPersistingDistributor(dir, downstream)
+	d.now = func() time.Time { return savedAt }
+	d.Distribute("default", sn)
+	g.Expect(downstream.GetSnapshot("default")).To(Equal(sn))
+
+	col := basicmeta.K8SCollection1.Name().String()
+	loaded := LoadPersistedSnapshots(dir, []string{"default", "other"}, time.Minute, savedAt.Add(time.Second))
+	g.Expect(loaded).To(HaveLen(1))
+	g.Expect(loaded["default"].Version(col)).To(Equal(sn.Version(col)))
+	g.Expect(loaded["default"].Resources(col)).To(HaveLen(1))
+	g.Expect(loaded["default"].Resources(col)[0].Metadata.Name).To(Equal("n1/i1"))
+
+	// Snapshots older than the maximum age are ignored, unless the age is not checked.
+	g.Expect(LoadPersistedSnapshots(dir, []string{"default"}, time.Minute, savedAt.Add(time.Hour))).To(BeEmpty())
+	g.Expect(LoadPersistedSnapshots(dir, []string{"default"}, 0, savedAt.Add(time.Hour))).To(HaveLen(1))
+
+	// Unreadable snapshots are ignored.
+	g.Expect(ioutil.WriteFile(filepath.Join(dir, "default.snapshot"), []byte("{"), 0644)).To(BeNil())
+	g.Expect(LoadPersistedSnapshots(dir, []string{"default"}, 0, savedAt)).To(BeEmpty())
+}
+
+func TestPersistingDistributor_SaveError(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	set := coll.NewSet(collection.NewSchemasBuilder().MustAdd(basicmeta.K8SCollection1).Build())
+	sn := &Snapshot{set: set}
+
+	// The snapshot is still distributed if it cannot be persisted.
+	downstream := NewInMemoryDistributor()
+	d := NewPersistingDistributor(filepath.Join(os.TempDir(), "does", "not", "exist"), downstream)
+	d.Distribute("default", sn)
+	g.Expect(downstream.GetSnapshot("default")).To(Equal(sn))
+}
@@ -0,0 +1,246 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strategy
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"istio.io/istio/galley/pkg/config/monitoring"
+)
+
+const (
+	// Quiesce window used when changes are rare.
+	defaultMinQuiesceDuration = 100 * time.Millisecond
+
+	// Upper bound of the quiesce window, reached when changes arrive at a high rate.
+	defaultMaxQuiesceDuration = 2 * time.Second
+
+	// Maximum time a change can wait before a snapshot including it is published.
+	defaultMaxStalenessDuration = 5 * time.Second
+
+	// Time constant of the exponentially decaying average of the change rate.
+	defaultRateWindow = 10 * time.Second
+)
+
+// AdaptiveOptions are the options of the adaptive strategy. Zero values are replaced by the defaults.
+type AdaptiveOptions struct {
+	// MinQuiesce is the quiesce window when there are no recent changes. The window grows by MinQuiesce
+	// for each change per second of the observed change rate.
+	MinQuiesce time.Duration
+
+	// MaxQuiesce caps the quiesce window.
+	MaxQuiesce time.Duration
+
+	// MaxStaleness caps the time between the first unpublished change and the publishing of a snapshot.
+	MaxStaleness time.Duration
+
+	// RateWindow is the time constant of the exponentially decaying average of the change rate.
+	RateWindow time.Duration
+}
+
+// DefaultAdaptiveOptions returns the default options of the adaptive strategy.
+func DefaultAdaptiveOptions() AdaptiveOptions {
+	return AdaptiveOptions{
+		MinQuiesce:   defaultMinQuiesceDuration,
+		MaxQuiesce:   defaultMaxQuiesceDuration,
+		MaxStaleness: defaultMaxStalenessDuration,
+		RateWindow:   defaultRateWindow,
+	}
+}
+
+// Adaptive is a debouncing strategy whose quiesce window grows with the rate of the changes. Bursts of
+// changes, such as the ones of a bulk apply or a GitOps sync, are coalesced into fewer snapshots, while
+// isolated changes are published quickly. The total wait is bounded by the maximum staleness.
+type Adaptive struct {
+	mu sync.Mutex
+
+	options AdaptiveOptions
+
+	changeCh chan struct{}
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+var _ Instance = &Adaptive{}
+
+// NewAdaptiveWithDefaults creates a new adaptive strategy with default values.
+func NewAdaptiveWithDefaults() *Adaptive {
+	return NewAdaptive(DefaultAdaptiveOptions())
+}
+
+// NewAdaptive creates a new adaptive strategy with the given options.
+func NewAdaptive(o AdaptiveOptions) *Adaptive {
+	d := DefaultAdaptiveOptions()
+	if o.MinQuiesce <= 0 {
+		o.MinQuiesce = d.MinQuiesce
+	}
+	if o.MaxQuiesce <= 0 {
+		o.MaxQuiesce = d.MaxQuiesce
+	}
+	if o.MaxQuiesce < o.MinQuiesce {
+		o.MaxQuiesce = o.MinQuiesce
+	}
+	if o.MaxStaleness <= 0 {
+		o.MaxStaleness = d.MaxStaleness
+	}
+	if o.RateWindow <= 0 {
+		o.RateWindow = d.RateWindow
+	}
+
+	return &Adaptive{
+		options:  o,
+		changeCh: make(chan struct{}, 1),
+	}
+}
+
+// Start implements Instance
+func (a *Adaptive) Start(fn OnSnapshotFn) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.stopCh != nil {
+		scope.Debug("Adaptive.Start: already started")
+		return
+	}
+	a.stopCh = make(chan struct{})
+	a.doneCh = make(chan struct{})
+
+	// Drain the changeCh, to avoid events from a previous incarnation.
+	drainCh(a.changeCh)
+
+	go a.run(a.stopCh, a.doneCh, fn)
+}
+
+// Stop implements Instance
+func (a *Adaptive) Stop() {
+	a.mu.Lock()
+
+	if a.stopCh != nil {
+		scope.Debug("Adaptive.Stop: stopping")
+		close(a.stopCh)
+		a.stopCh = nil
+	} else {
+		scope.Debug("Adaptive.Stop: already stopped")
+	}
+	doneCh := a.doneCh
+	a.mu.Unlock()
+
+	// doneCh is nil if the strategy was never started.
+	if doneCh != nil {
+		<-doneCh
+	}
+}
+
+func (a *Adaptive) run(stopCh, doneCh chan struct{}, fn OnSnapshotFn) {
+	var rate float64
+	var lastChange time.Time
+
+	// observe updates the change rate with a change happening now, and returns the new quiesce window.
+	observe := func() time.Duration {
+		now := time.Now()
+		rate = decayRate(rate, lastChange, now, a.options.RateWindow)
+		lastChange = now
+		monitoring.RecordStrategyOnChange()
+		return quiesceWindow(rate, a.options.MinQuiesce, a.options.MaxQuiesce)
+	}
+
+mainloop:
+	for {
+		select {
+		case <-stopCh:
+			scope.Debug("Adaptive.run: stopping")
+			break mainloop
+
+		case <-a.changeCh:
+			scope.Debug("Adaptive.run: change")
+			// fallthrough to start the timers.
+		}
+
+		window := observe()
+		stalenessTimer := time.NewTimer(a.options.MaxStaleness)
+		quiesceTimer := time.NewTimer(window)
+
+	loop:
+		for {
+			select {
+			case <-stopCh:
+				scope.Debug("Adaptive.run: stopping")
+				break mainloop
+
+			case <-a.changeCh:
+				// The change is coalesced with the pending ones, instead of causing a snapshot of its own.
+				window = observe()
+				scope.Debugf("Adaptive.run: change, quiesce window: %v", window)
+				monitoring.RecordStrategySnapshotSuppressed()
+
+				quiesceTimer.Stop()
+				drainTimeCh(quiesceTimer.C)
+				quiesceTimer.Reset(window)
+				monitoring.RecordOnTimer(false, false, true)
+
+			case <-quiesceTimer.C:
+				scope.Debug("Adaptive.run: quiesce timer")
+				monitoring.RecordOnTimer(false, true, false)
+				break loop
+
+			case <-stalenessTimer.C:
+				scope.Debug("Adaptive.run: max staleness timer")
+				monitoring.RecordOnTimer(true, false, false)
+				break loop
+			}
+		}
+
+		quiesceTimer.Stop()
+		drainTimeCh(quiesceTimer.C)
+		stalenessTimer.Stop()
+		drainTimeCh(stalenessTimer.C)
+		monitoring.RecordStrategyQuiesceWindow(window)
+		scope.Debug("Adaptive.run: calling callback...")
+		fn()
+	}
+
+	close(doneCh)
+}
+
+// OnChange implements Instance
+func (a *Adaptive) OnChange() {
+	select {
+	case a.changeCh <- struct{}{}:
+	default:
+	}
+}
+
+// decayRate returns the change rate, in changes per second, after a change at time now. The rate is an
+// exponentially decaying average with the given time constant, and last is the time of the previous change.
+func decayRate(rate float64, last, now time.Time, window time.Duration) float64 {
+	if !last.IsZero() {
+		rate *= math.Exp(-now.Sub(last).Seconds() / window.Seconds())
+	} else {
+		rate = 0
+	}
+	return rate + 1/window.Seconds()
+}
+
+// quiesceWindow returns the quiesce window for the given change rate: the minimum window, grown by the
+// minimum window for each change per second, and capped by the maximum window.
+func quiesceWindow(rate float64, minQuiesce, maxQuiesce time.Duration) time.Duration {
+	w := float64(minQuiesce) * (1 + rate)
+	if w >= float64(maxQuiesce) {
+		return maxQuiesce
+	}
+	return time.Duration(w)
+}
@@ -0,0 +1,171 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strategy
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAdaptive_StartStop(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s := NewAdaptive(AdaptiveOptions{MinQuiesce: time.Millisecond * 500})
+
+	var called int32
+	s.Start(func() {
+		atomic.StoreInt32(&called, 1)
+	})
+	s.Start(func() {
+		atomic.StoreInt32(&called, 1)
+	})
+	s.Stop()
+	s.Stop()
+
+	g.Expect(atomic.LoadInt32(&called)).To(Equal(int32(0)))
+}
+
+func TestAdaptive_StopWithoutStart(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s := NewAdaptiveWithDefaults()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop()
+		s.Stop()
+		close(stopped)
+	}()
+	g.Eventually(stopped).Should(BeClosed())
+
+	// The strategy can still be started afterwards.
+	s.Start(func() {})
+	s.Stop()
+}
+
+func TestAdaptive_FireEvent(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s := NewAdaptive(AdaptiveOptions{MinQuiesce: time.Millisecond * 50, MaxStaleness: time.Second})
+
+	var called int32
+	s.Start(func() {
+		atomic.AddInt32(&called, 1)
+	})
+	defer s.Stop()
+
+	s.OnChange()
+
+	g.Eventually(func() int32 { return atomic.LoadInt32(&called) }, time.Second).Should(Equal(int32(1)))
+}
+
+func TestAdaptive_CoalescesBurst(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s := NewAdaptive(AdaptiveOptions{
+		MinQuiesce:   time.Millisecond * 20,
+		MaxQuiesce:   time.Second,
+		MaxStaleness: time.Second * 5,
+		RateWindow:   time.Second,
+	})
+
+	var called int32
+	s.Start(func() {
+		atomic.AddInt32(&called, 1)
+	})
+	defer s.Stop()
+
+	// The changes are further apart than the minimum quiesce window, but the window grows with the
+	// rate of the changes, so they are all coalesced in one snapshot.
+	for i := 0; i < 20; i++ {
+		s.OnChange()
+		time.Sleep(time.Millisecond * 25)
+	}
+	g.Expect(atomic.LoadInt32(&called)).To(Equal(int32(0)))
+
+	g.Eventually(func() int32 { return atomic.LoadInt32(&called) }, time.Second*2).Should(Equal(int32(1)))
+}
+
+func TestAdaptive_MaxStaleness(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s := NewAdaptive(AdaptiveOptions{
+		MinQuiesce:   time.Millisecond * 500,
+		MaxStaleness: time.Millisecond * 300,
+	})
+
+	var called int32
+	s.Start(func() {
+		atomic.AddInt32(&called, 1)
+	})
+
+	for i := 0; i < 50; i++ {
+		s.OnChange()
+		time.Sleep(time.Millisecond * 10)
+	}
+	s.Stop()
+
+	g.Expect(atomic.LoadInt32(&called)).To(Equal(int32(1)))
+}
+
+func TestAdaptive_NewWithDefaults(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s := NewAdaptiveWithDefaults()
+	g.Expect(s.options).To(Equal(DefaultAdaptiveOptions()))
+
+	s = NewAdaptive(AdaptiveOptions{MinQuiesce: time.Minute})
+	g.Expect(s.options.MinQuiesce).To(Equal(time.Minute))
+	g.Expect(s.options.MaxQuiesce).To(Equal(time.Minute))
+	g.Expect(s.options.MaxStaleness).To(Equal(defaultMaxStalenessDuration))
+	g.Expect(s.options.RateWindow).To(Equal(defaultRateWindow))
+}
+
+func TestDecayRate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Now()
+	r := decayRate(0, time.Time{}, now, time.Second)
+	g.Expect(r).To(BeNumerically("~", 1, 1e-9))
+
+	// Simultaneous changes add up.
+	r = decayRate(r, now, now, time.Second)
+	g.Expect(r).To(BeNumerically("~", 2, 1e-9))
+
+	// The rate decays with the time constant.
+	r = decayRate(r, now, now.Add(time.Second), time.Second)
+	g.Expect(r).To(BeNumerically("~", 2/2.718281828+1, 1e-6))
+
+	// A steady rate of changes converges to that rate.
+	r = 0
+	last := time.Time{}
+	for i := 0; i < 1000; i++ {
+		next := now.Add(time.Duration(i) * time.Second / 10)
+		r = decayRate(r, last, next, time.Second*10)
+		last = next
+	}
+	g.Expect(r).To(BeNumerically("~", 10, 0.5))
+}
+
+func TestQuiesceWindow(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(quiesceWindow(0, time.Millisecond*100, time.Second)).To(Equal(time.Millisecond * 100))
+	g.Expect(quiesceWindow(4, time.Millisecond*100, time.Second)).To(Equal(time.Millisecond * 500))
+	g.Expect(quiesceWindow(100, time.Millisecond*100, time.Second)).To(Equal(time.Second))
+}
@@ -17,13 +17,25 @@ package strategy
 import "fmt"
 
 const (
+	adaptive  = "adaptive"
 	debounce  = "debounce"
 	immediate = "immediate"
 )
 
+// Names of the known strategies.
+var Names = []string{adaptive, debounce, immediate}
+
 // Create a strategy with the given name.
 func Create(name string) (Instance, error) {
+	return CreateWithOptions(name, DefaultAdaptiveOptions())
+}
+
+// CreateWithOptions creates a strategy with the given name. The adaptive options are used if the
+// strategy is adaptive.
+func CreateWithOptions(name string, adaptiveOptions AdaptiveOptions) (Instance, error) {
 	switch name {
+	case adaptive:
+		return NewAdaptive(adaptiveOptions), nil
 	case debounce:
 		return NewDebounceWithDefaults(), nil
 	case immediate:
@@ -17,6 +17,7 @@ package strategy
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 )
@@ -39,6 +40,26 @@ func TestCreate_Debounce(t *testing.T) {
 	g.Expect(reflect.TypeOf(s)).To(Equal(reflect.TypeOf(&Debounce{})))
 }
 
+func TestCreate_Adaptive(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s, err := Create(adaptive)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(reflect.TypeOf(s)).To(Equal(reflect.TypeOf(&Adaptive{})))
+	g.Expect(s.(*Adaptive).options).To(Equal(DefaultAdaptiveOptions()))
+}
+
+func TestCreateWithOptions_Adaptive(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	o := AdaptiveOptions{MinQuiesce: time.Millisecond, MaxQuiesce: time.Second, MaxStaleness: time.Minute, RateWindow: time.Hour}
+	s, err := CreateWithOptions(adaptive, o)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(s.(*Adaptive).options).To(Equal(o))
+}
+
 func TestCreate_Unknown(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -32,6 +32,12 @@ type Settings struct {
 	TransformProviders transformer.Providers
 	Distributor        snapshotter.Distributor
 	EnabledSnapshots   []string
+
+	// SnapshotStrategy overrides the strategy of the snapshots defined in the metadata, if not empty.
+	SnapshotStrategy string
+
+	// AdaptiveStrategy holds the options of the adaptive strategy.
+	AdaptiveStrategy strategy.AdaptiveOptions
 }
 
 // Initialize a processing runtime for Galley.
@@ -42,7 +48,11 @@ func Initialize(settings Settings) (*processing.Runtime, error) {
 			continue
 		}
 
-		str, err := strategy.Create(s.Strategy)
+		name := s.Strategy
+		if settings.SnapshotStrategy != "" {
+			name = settings.SnapshotStrategy
+		}
+		str, err := strategy.CreateWithOptions(name, settings.AdaptiveStrategy)
 		if err != nil {
 			return nil, err
 		}
@@ -81,3 +81,23 @@ func TestProcessor(t *testing.T) {
 	time.Sleep(time.Second)
 	_ = distributor.GetSnapshot("default")
 }
+
+func TestProcessor_SnapshotStrategy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	processorSettings := Settings{
+		Metadata:           schema.MustGet(),
+		DomainSuffix:       "svc.local",
+		Source:             inmemory.NewKubeSource(schema.MustGet().KubeCollections()),
+		TransformProviders: transforms.Providers(schema.MustGet()),
+		Distributor:        snapshotter.NewInMemoryDistributor(),
+		EnabledSnapshots:   []string{snapshots.Default},
+		SnapshotStrategy:   "adaptive",
+	}
+	_, err := Initialize(processorSettings)
+	g.Expect(err).To(BeNil())
+
+	processorSettings.SnapshotStrategy = "foo"
+	_, err = Initialize(processorSettings)
+	g.Expect(err).NotTo(BeNil())
+}
@@ -15,13 +15,10 @@
 package inmemory
 
 import (
-	"bufio"
 	"bytes"
 	"crypto/sha1"
 	"errors"
 	"fmt"
-	"io"
-	"strings"
 	"sync"
 
 	"k8s.io/apimachinery/pkg/util/yaml"
@@ -201,25 +198,11 @@ func (s *KubeSource) parseContent(r *collection.Schemas, name, yamlText string)
 	var resources []kubeResource
 	var errs error
 
-	reader := bufio.NewReader(strings.NewReader(yamlText))
-	decoder := yaml.NewYAMLReader(reader)
-	chunkCount := -1
-
-	for {
-		chunkCount++
-		doc, err := decoder.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			e := fmt.Errorf("error reading documents in %s[%d]: %v", name, chunkCount, err)
-			scope.Source.Warnf("%v - skipping", e)
-			scope.Source.Debugf("Failed to parse yamlText chunk: %v", yamlText)
-			errs = multierror.Append(errs, e)
-			break
+	for chunkCount, doc := range splitDocuments(yamlText) {
+		chunk := bytes.TrimSpace(doc.content())
+		if len(chunk) == 0 {
+			continue
 		}
-
-		chunk := bytes.TrimSpace(doc)
 		r, err := s.parseChunk(r, chunk)
 		if err != nil {
 			var uerr *unknownSchemaError
@@ -234,6 +217,15 @@ func (s *KubeSource) parseContent(r *collection.Schemas, name, yamlText string)
 			}
 			continue
 		}
+
+		// Record where the resource is in the content, so that analysis messages can point to it.
+		position := &resource.Position{Filename: name, Line: doc.line()}
+		if o, ok := r.resource.Origin.(*rt.Origin); ok {
+			o.Ref = position
+			o.FieldsMap = doc.fieldMap()
+		}
+		// A resource moved within the content is updated, so that its position is up to date.
+		r.sha = sha1.Sum(append([]byte(position.String()+"\n"), chunk...))
 		resources = append(resources, r)
 	}
 
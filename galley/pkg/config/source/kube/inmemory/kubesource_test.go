@@ -347,6 +347,35 @@ func TestKubeSource_CanHandleDocumentSeparatorInComments(t *testing.T) {
 	g.Expect(s.ContentNames()).To(Equal(map[string]struct{}{"foo": {}}))
 }
 
+func TestKubeSource_Position(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s, acc := setupKubeSource()
+	s.Start()
+	defer s.Stop()
+
+	err := s.ApplyContent("foo", kubeyaml.JoinString(data.YamlN1I1V1, data.YamlN2I2V1))
+	g.Expect(err).To(BeNil())
+
+	actual := s.Get(basicmeta.K8SCollection1.Name()).AllSorted()
+	g.Expect(actual).To(HaveLen(2))
+	g.Expect(actual[0].Origin.Reference()).To(Equal(&resource.Position{Filename: "foo", Line: 2}))
+	g.Expect(actual[0].Origin.FieldMap()).To(HaveKeyWithValue("{.spec.n1_i1}", 8))
+	g.Expect(actual[1].Origin.Reference()).To(Equal(&resource.Position{Filename: "foo", Line: 11}))
+	g.Expect(actual[1].Origin.FieldMap()).To(HaveKeyWithValue("{.metadata.name}", 15))
+
+	// Moving a resource within the content updates its position.
+	acc.Clear()
+	err = s.ApplyContent("foo", kubeyaml.JoinString(data.YamlN2I2V1, data.YamlN1I1V1))
+	g.Expect(err).To(BeNil())
+
+	actual = s.Get(basicmeta.K8SCollection1.Name()).AllSorted()
+	g.Expect(actual).To(HaveLen(2))
+	g.Expect(actual[0].Origin.Reference()).To(Equal(&resource.Position{Filename: "foo", Line: 11}))
+	g.Expect(actual[1].Origin.Reference()).To(Equal(&resource.Position{Filename: "foo", Line: 2}))
+	g.Expect(acc.Events()).To(HaveLen(2))
+}
+
 func setupKubeSource() (*KubeSource, *fixtures.Accumulator) {
 	s := NewKubeSource(basicmeta.MustGet().KubeCollections())
 
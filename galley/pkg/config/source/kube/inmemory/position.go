@@ -0,0 +1,165 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemory
+
+import (
+	"fmt"
+	"strings"
+)
+
+// yamlDocument is a document of a multi-document YAML text.
+type yamlDocument struct {
+	// lines of the document.
+	lines []string
+	// firstLine is the 1-based line of the first line of the document in the text.
+	firstLine int
+}
+
+// content returns the text of the document.
+func (d *yamlDocument) content() []byte {
+	return []byte(strings.Join(d.lines, "\n"))
+}
+
+// line returns the 1-based line of the document in the text, i.e. its first line which is neither
+// blank nor a comment, or 0 if there is none.
+func (d *yamlDocument) line() int {
+	for i, l := range d.lines {
+		if trimmed := strings.TrimSpace(l); trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return d.firstLine + i
+		}
+	}
+	return 0
+}
+
+// splitDocuments splits a multi-document YAML text on the "---" separator lines, as the K8s YAML reader
+// does, keeping the position of the documents in the text. Empty documents are skipped.
+func splitDocuments(yamlText string) []yamlDocument {
+	var docs []yamlDocument
+	current := yamlDocument{firstLine: 1}
+	for i, l := range strings.Split(yamlText, "\n") {
+		if strings.HasPrefix(l, "---") && strings.TrimSpace(l[3:]) == "" {
+			if len(current.lines) > 0 {
+				docs = append(docs, current)
+			}
+			current = yamlDocument{firstLine: i + 2}
+			continue
+		}
+		current.lines = append(current.lines, l)
+	}
+	if len(current.lines) > 0 && (len(current.lines) > 1 || current.lines[0] != "") {
+		docs = append(docs, current)
+	}
+	return docs
+}
+
+// fieldLine is an entry of the stack of fields enclosing a line.
+type fieldLine struct {
+	indent int
+	path   string
+	// item is true for a list item.
+	item bool
+}
+
+// fieldMap returns the lines of the fields of a YAML document, by field path such as "{.spec.hosts[0]}".
+// It reads the block style documents written by hand, and ignores the flow style collections.
+func (d *yamlDocument) fieldMap() map[string]int {
+	fields := make(map[string]int)
+	stack := []fieldLine{{indent: -1}}
+	// next index of the items of a list, by path of the list
+	itemCounts := make(map[string]int)
+	// indent of the key of a block scalar, whose content lines are skipped, or -1
+	blockIndent := -1
+
+	for i, l := range d.lines {
+		content := strings.TrimLeft(l, " ")
+		indent := len(l) - len(content)
+		content = strings.TrimRight(content, " \t\r")
+		if content == "" || strings.HasPrefix(content, "#") {
+			continue
+		}
+		if blockIndent >= 0 {
+			if indent > blockIndent {
+				continue
+			}
+			blockIndent = -1
+		}
+		line := d.firstLine + i
+
+		// List items, possibly nested on the same line as in "- - a".
+		for content == "-" || strings.HasPrefix(content, "- ") {
+			for top := stack[len(stack)-1]; top.indent > indent || (top.indent == indent && top.item); top = stack[len(stack)-1] {
+				stack = stack[:len(stack)-1]
+			}
+			parent := stack[len(stack)-1].path
+			path := fmt.Sprintf("%s[%d]", parent, itemCounts[parent])
+			itemCounts[parent]++
+			fields[wrapPath(path)] = line
+			stack = append(stack, fieldLine{indent: indent, path: path, item: true})
+
+			rest := strings.TrimLeft(content[1:], " ")
+			indent += len(content) - len(rest)
+			content = rest
+		}
+
+		key, value, ok := splitKey(content)
+		if !ok {
+			continue
+		}
+		for stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		path := stack[len(stack)-1].path + "." + key
+		fields[wrapPath(path)] = line
+		delete(itemCounts, path)
+		stack = append(stack, fieldLine{indent: indent, path: path})
+		if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+			blockIndent = indent
+		}
+	}
+	return fields
+}
+
+// splitKey splits a "key: value" line, unquoting the key.
+func splitKey(content string) (key, value string, ok bool) {
+	if strings.HasPrefix(content, "{") || strings.HasPrefix(content, "[") {
+		return "", "", false
+	}
+	var i int
+	if strings.HasPrefix(content, "\"") || strings.HasPrefix(content, "'") {
+		end := strings.IndexByte(content[1:], content[0])
+		if end < 0 {
+			return "", "", false
+		}
+		key = content[1 : end+1]
+		i = end + 2
+		if i >= len(content) || content[i] != ':' {
+			return "", "", false
+		}
+	} else {
+		i = strings.Index(content, ": ")
+		if i < 0 {
+			if !strings.HasSuffix(content, ":") {
+				return "", "", false
+			}
+			i = len(content) - 1
+		}
+		key = content[:i]
+	}
+	return key, strings.TrimSpace(content[i+1:]), key != ""
+}
+
+func wrapPath(path string) string {
+	return "{" + path + "}"
+}
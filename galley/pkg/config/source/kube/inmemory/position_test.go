@@ -0,0 +1,110 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmemory
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSplitDocuments(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	docs := splitDocuments(`---
+# first
+kind: A
+---
+
+kind: B
+# --- not a separator
+---
+---
+kind: C
+`)
+	g.Expect(docs).To(HaveLen(3))
+	g.Expect(docs[0].line()).To(Equal(3))
+	g.Expect(string(docs[0].content())).To(Equal("# first\nkind: A"))
+	g.Expect(docs[1].line()).To(Equal(6))
+	g.Expect(string(docs[1].content())).To(Equal("\nkind: B\n# --- not a separator"))
+	g.Expect(docs[2].line()).To(Equal(10))
+	g.Expect(string(docs[2].content())).To(Equal("kind: C\n"))
+}
+
+func TestFieldMap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	docs := splitDocuments(`kind: A
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: reviews
+  annotations:
+    "example.com/note": |
+      a: not a field
+spec:
+  hosts:
+  - reviews
+  - "ratings"
+  gateways: [mesh]
+  http:
+  - match:
+    - uri:
+        prefix: /v1
+    route:
+    - destination:
+        host: reviews
+      weight: 80
+    -   destination:
+          host: ratings
+  - route:
+      - destination:
+          host: details
+`)
+	g.Expect(docs).To(HaveLen(2))
+	g.Expect(docs[1].fieldMap()).To(Equal(map[string]int{
+		"{.apiVersion}":           3,
+		"{.kind}":                 4,
+		"{.metadata}":             5,
+		"{.metadata.name}":        6,
+		"{.metadata.annotations}": 7,
+		"{.metadata.annotations.example.com/note}": 8,
+		"{.spec}":                                   10,
+		"{.spec.hosts}":                             11,
+		"{.spec.hosts[0]}":                          12,
+		"{.spec.hosts[1]}":                          13,
+		"{.spec.gateways}":                          14,
+		"{.spec.http}":                              15,
+		"{.spec.http[0]}":                           16,
+		"{.spec.http[0].match}":                     16,
+		"{.spec.http[0].match[0]}":                  17,
+		"{.spec.http[0].match[0].uri}":              17,
+		"{.spec.http[0].match[0].uri.prefix}":       18,
+		"{.spec.http[0].route}":                     19,
+		"{.spec.http[0].route[0]}":                  20,
+		"{.spec.http[0].route[0].destination}":      20,
+		"{.spec.http[0].route[0].destination.host}": 21,
+		"{.spec.http[0].route[0].weight}":           22,
+		"{.spec.http[0].route[1]}":                  23,
+		"{.spec.http[0].route[1].destination}":      23,
+		"{.spec.http[0].route[1].destination.host}": 24,
+		"{.spec.http[1]}":                           25,
+		"{.spec.http[1].route}":                     25,
+		"{.spec.http[1].route[0]}":                  26,
+		"{.spec.http[1].route[0].destination}":      26,
+		"{.spec.http[1].route[0].destination.host}": 27,
+	}))
+}
@@ -29,6 +29,11 @@ type Origin struct {
 	Kind       string
 	FullName   resource.FullName
 	Version    resource.Version
+
+	// Ref is the reference to the source of the resource, e.g. its position in a file, if known.
+	Ref resource.Reference
+	// FieldsMap is the line of the fields of the resource in its file, by field path, if known.
+	FieldsMap map[string]int
 }
 
 var _ resource.Origin = &Origin{}
@@ -53,3 +58,13 @@ func (o *Origin) Namespace() resource.Namespace {
 
 	return o.FullName.Namespace
 }
+
+// Reference implements resource.Origin
+func (o *Origin) Reference() resource.Reference {
+	return o.Ref
+}
+
+// FieldMap implements resource.Origin
+func (o *Origin) FieldMap() map[string]int {
+	return o.FieldsMap
+}
@@ -33,3 +33,11 @@ func (o origin) FriendlyName() string {
 func (o origin) Namespace() resource.Namespace {
 	return ""
 }
+
+func (o origin) Reference() resource.Reference {
+	return nil
+}
+
+func (o origin) FieldMap() map[string]int {
+	return nil
+}
@@ -17,6 +17,7 @@ package components
 import (
 	"fmt"
 	"net"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -121,6 +122,20 @@ func (p *Processing) Start() (err error) {
 
 	var distributor snapshotter.Distributor = snapshotter.NewMCPDistributor(p.mcpCache)
 
+	if p.args.SnapshotPersistenceDir != "" {
+		if err = os.MkdirAll(p.args.SnapshotPersistenceDir, 0750); err != nil {
+			return
+		}
+
+		// Serve the snapshots persisted by a previous run until the live ones are published.
+		persisted := snapshotter.LoadPersistedSnapshots(p.args.SnapshotPersistenceDir, p.args.Snapshots,
+			p.args.PersistedSnapshotMaxAge, time.Now())
+		for group, s := range persisted {
+			p.mcpCache.SetStaleSnapshot(group, s)
+		}
+		distributor = snapshotter.NewPersistingDistributor(p.args.SnapshotPersistenceDir, distributor)
+	}
+
 	if p.args.EnableConfigAnalysis {
 		combinedAnalyzer := analyzers.AllCombined()
 		combinedAnalyzer.RemoveSkipped(colsInSnapshots, kubeResources.DisabledCollectionNames(), transformProviders)
@@ -141,6 +156,8 @@ func (p *Processing) Start() (err error) {
 		TransformProviders: transformProviders,
 		Distributor:        distributor,
 		EnabledSnapshots:   p.args.Snapshots,
+		SnapshotStrategy:   p.args.SnapshotStrategy,
+		AdaptiveStrategy:   p.args.AdaptiveStrategy,
 	}
 	if p.runtime, err = processorInitialize(processorSettings); err != nil {
 		return
@@ -21,6 +21,7 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	k8sRuntime "k8s.io/apimachinery/pkg/runtime"
@@ -34,7 +35,9 @@ import (
 	"istio.io/istio/galley/pkg/testing/mock"
 	"istio.io/istio/pkg/config/event"
 	"istio.io/istio/pkg/config/schema/collection"
+	"istio.io/istio/pkg/config/schema/snapshots"
 	"istio.io/istio/pkg/mcp/monitoring"
+	"istio.io/istio/pkg/mcp/snapshot"
 	mcptestmon "istio.io/istio/pkg/mcp/testing/monitoring"
 	"istio.io/istio/pkg/mcp/testing/testcerts"
 )
@@ -151,3 +154,42 @@ func TestProcessing_Basic(t *testing.T) {
 
 	g.Expect(p.Address()).To(BeNil())
 }
+
+func TestProcessing_WarmStart(t *testing.T) {
+	g := NewGomegaWithT(t)
+	resetPatchTable()
+	defer resetPatchTable()
+
+	mk := mock.NewKube()
+	cl := fake.NewSimpleDynamicClient(k8sRuntime.NewScheme())
+
+	mk.AddResponse(cl, nil)
+	newInterfaces = func(string) (kube.Interfaces, error) { return mk, nil }
+	mcpMetricReporter = func(s string) monitoring.Reporter {
+		return mcptestmon.NewInMemoryStatsContext()
+	}
+	meshcfgNewFS = func(path string) (event.Source, error) { return meshcfg.NewInmemory(), nil }
+
+	tmpDir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	g.Expect(err).To(BeNil())
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	b := snapshot.NewInMemoryBuilder()
+	b.SetVersion("foo", "v1")
+	err = snapshot.Save(path.Join(tmpDir, snapshots.Default+".snapshot"), b.Build(), time.Now())
+	g.Expect(err).To(BeNil())
+
+	args := settings.DefaultArgs()
+	args.APIAddress = "tcp://0.0.0.0:0"
+	args.Insecure = true
+	args.SnapshotPersistenceDir = tmpDir
+
+	p := NewProcessing(args)
+	err = p.Start()
+	g.Expect(err).To(BeNil())
+	defer p.Stop()
+
+	// The persisted snapshot is served until the live one is published.
+	g.Expect(p.mcpCache.IsStale(snapshots.Default)).To(BeTrue())
+	g.Expect(p.mcpCache.GetGroups()).To(Equal([]string{snapshots.Default}))
+}
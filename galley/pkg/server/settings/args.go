@@ -27,6 +27,7 @@ import (
 	"istio.io/pkg/ctrlz"
 	"istio.io/pkg/probe"
 
+	"istio.io/istio/galley/pkg/config/processing/snapshotter/strategy"
 	"istio.io/istio/galley/pkg/config/util/kuberesource"
 	"istio.io/istio/pkg/config/schema/snapshots"
 	"istio.io/istio/pkg/keepalive"
@@ -45,6 +46,8 @@ const (
 	defaultAccessListFile   = defaultConfigMapFolder + "accesslist.yaml"
 	defaultMeshConfigFile   = defaultMeshConfigFolder + "mesh"
 	defaultDomainSuffix     = "cluster.local"
+
+	defaultPersistedSnapshotMaxAge = time.Hour
 )
 
 // Args contains the startup arguments to instantiate Galley.
@@ -162,6 +165,22 @@ type Args struct { // nolint:maligned
 
 	Snapshots       []string
 	TriggerSnapshot string
+
+	// SnapshotStrategy overrides the strategy deciding when to publish snapshots. If empty, each
+	// snapshot uses the strategy of its metadata.
+	SnapshotStrategy string
+
+	// AdaptiveStrategy holds the options of the adaptive snapshot strategy.
+	AdaptiveStrategy strategy.AdaptiveOptions
+
+	// SnapshotPersistenceDir is the directory where the last published snapshot of each group is persisted.
+	// On startup, the persisted snapshots are served as stale until the live sources sync. Leaving empty
+	// disables persistence.
+	SnapshotPersistenceDir string
+
+	// PersistedSnapshotMaxAge is the maximum age of a persisted snapshot for it to be served on startup. Zero
+	// means no limit.
+	PersistedSnapshotMaxAge time.Duration
 }
 
 // DefaultArgs allocates an Args struct initialized with Galley's default configuration.
@@ -203,8 +222,10 @@ func DefaultArgs() *Args {
 			Path:           defaultReadinessProbeFilePath,
 			UpdateInterval: defaultProbeCheckInterval,
 		},
-		Snapshots:       []string{snapshots.Default},
-		TriggerSnapshot: snapshots.Default,
+		Snapshots:               []string{snapshots.Default},
+		TriggerSnapshot:         snapshots.Default,
+		AdaptiveStrategy:        strategy.DefaultAdaptiveOptions(),
+		PersistedSnapshotMaxAge: defaultPersistedSnapshotMaxAge,
 	}
 }
 
@@ -239,6 +260,10 @@ func (a *Args) String() string {
 	_, _ = fmt.Fprintf(buf, "KeepAlive.MaxServerConnectionAgeGrace: %v\n", a.KeepAlive.MaxServerConnectionAgeGrace)
 	_, _ = fmt.Fprintf(buf, "KeepAlive.Time: %v\n", a.KeepAlive.Time)
 	_, _ = fmt.Fprintf(buf, "KeepAlive.Timeout: %v\n", a.KeepAlive.Timeout)
+	_, _ = fmt.Fprintf(buf, "SnapshotStrategy: %s\n", a.SnapshotStrategy)
+	_, _ = fmt.Fprintf(buf, "AdaptiveStrategy: %+v\n", a.AdaptiveStrategy)
+	_, _ = fmt.Fprintf(buf, "SnapshotPersistenceDir: %s\n", a.SnapshotPersistenceDir)
+	_, _ = fmt.Fprintf(buf, "PersistedSnapshotMaxAge: %v\n", a.PersistedSnapshotMaxAge)
 
 	return buf.String()
 }
@@ -14,7 +14,11 @@
 
 package settings
 
-import "testing"
+import (
+	"testing"
+
+	"istio.io/istio/galley/pkg/config/processing/snapshotter/strategy"
+)
 
 func TestDefaultArgs(t *testing.T) {
 	a := DefaultArgs()
@@ -51,6 +55,14 @@ func TestDefaultArgs(t *testing.T) {
 	if a.InitialConnectionWindowSize != 1024*1024*16 {
 		t.Fatal("Default of InitialConnectionWindowSize should be 1024 * 1024 * 16")
 	}
+
+	if a.SnapshotStrategy != "" {
+		t.Fatalf("unexpected SnapshotStrategy: %s", a.SnapshotStrategy)
+	}
+
+	if a.AdaptiveStrategy != strategy.DefaultAdaptiveOptions() {
+		t.Fatalf("unexpected AdaptiveStrategy: %+v", a.AdaptiveStrategy)
+	}
 }
 
 func TestArgs_String(t *testing.T) {
@@ -15,8 +15,10 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -32,12 +34,14 @@ import (
 	"github.com/spf13/cobra"
 
 	"istio.io/pkg/env"
+	"istio.io/pkg/version"
 
 	"istio.io/istio/galley/pkg/config/analysis"
 	"istio.io/istio/galley/pkg/config/analysis/analyzers"
 	"istio.io/istio/galley/pkg/config/analysis/diag"
 	"istio.io/istio/galley/pkg/config/analysis/local"
 	cfgKube "istio.io/istio/galley/pkg/config/source/kube"
+	"istio.io/istio/istioctl/pkg/util/formatting"
 	"istio.io/istio/istioctl/pkg/util/handlers"
 	"istio.io/istio/pkg/config/resource"
 	"istio.io/istio/pkg/config/schema"
@@ -55,6 +59,8 @@ const (
 	LogOutput       = "log"
 	JSONOutput      = "json"
 	YamlOutput      = "yaml"
+	SarifOutput     = "sarif"
+	JUnitOutput     = "junit"
 )
 
 func (f AnalyzerFoundIssuesError) Error() string {
@@ -81,6 +87,11 @@ var (
 	suppress          []string
 	analysisTimeout   time.Duration
 	recursive         bool
+	clusterContexts   []string
+	clusterConfigs    []string
+
+	// Names of the clusters of a multi-cluster analysis
+	analyzedClusters []string
 
 	termEnvVar = env.RegisterStringVar("TERM", "", "Specifies terminal type.  Use 'dumb' to suppress color output")
 
@@ -94,7 +105,9 @@ var (
 // Analyze command
 func Analyze() *cobra.Command {
 	// Validate the output format before doing potentially expensive work to fail earlier
-	msgOutputFormats := map[string]bool{LogOutput: true, JSONOutput: true, YamlOutput: true}
+	msgOutputFormats := map[string]bool{
+		LogOutput: true, JSONOutput: true, YamlOutput: true, SarifOutput: true, JUnitOutput: true,
+	}
 	var msgOutputFormatKeys []string
 
 	for k := range msgOutputFormats {
@@ -124,6 +137,13 @@ istioctl analyze -S "IST0103=Pod mypod.testing"
 # and suppress MisplacedAnnotation on deployment foobar in namespace default.
 istioctl analyze -S "IST0103=Pod *.testing" -S "IST0107=Deployment foobar.default"
 
+# Analyze the clusters of a multi-cluster mesh, defined by the contexts my-west and my-east of the kubeconfig,
+# both on their own and together for cross-cluster conflicts
+istioctl analyze --contexts my-west,my-east
+
+# Analyze the clusters of a multi-cluster mesh, defined by the current context of each kubeconfig file
+istioctl analyze --kubeconfigs west.kubeconfig,east.kubeconfig
+
 # List available analyzers
 istioctl analyze -L
 `,
@@ -137,10 +157,18 @@ istioctl analyze -L
 			}
 
 			if listAnalyzers {
-				fmt.Print(AnalyzersAsString(analyzers.All()))
+				fmt.Fprint(cmd.OutOrStdout(), AnalyzersAsString(append(analyzers.All(), analyzers.AllMultiCluster()...)))
 				return nil
 			}
 
+			clusters, err := analysisClusters()
+			if err != nil {
+				return err
+			}
+			if len(clusters) > 0 && !useKube {
+				return CommandParseError{fmt.Errorf("--contexts and --kubeconfigs can't be used with --use-kube=false")}
+			}
+
 			readers, err := gatherFiles(args)
 			if err != nil {
 				return err
@@ -156,9 +184,6 @@ istioctl analyze -L
 				selectedNamespace = ""
 			}
 
-			sa := local.NewSourceAnalyzer(schema.MustGet(), analyzers.AllCombined(),
-				resource.Namespace(selectedNamespace), resource.Namespace(istioNamespace), nil, true, analysisTimeout)
-
 			// Check for suppressions and add them to our SourceAnalyzer
 			var suppressions []snapshotter.AnalysisSuppression
 			for _, s := range suppress {
@@ -184,46 +209,23 @@ istioctl analyze -L
 					ResourceName: parts[1],
 				})
 			}
-			sa.SetSuppressions(suppressions)
 
-			// If we're using kube, use that as a base source.
-			if useKube {
-				// Set up the kube client
-				config := kube.BuildClientCmd(kubeconfig, configContext)
-				restConfig, err := config.ClientConfig()
-				if err != nil {
+			var result local.AnalysisResult
+			var parseErrors int
+			if len(clusters) > 0 {
+				result, parseErrors, err = analyzeClusters(cmd, clusters, readers, suppressions, cancel)
+			} else {
+				sa := local.NewSourceAnalyzer(schema.MustGet(), analyzers.AllCombined(),
+					resource.Namespace(selectedNamespace), resource.Namespace(istioNamespace), nil, true, analysisTimeout)
+				sa.SetSuppressions(suppressions)
+				if parseErrors, err = addAnalysisSources(cmd, sa, kubeconfig, configContext, readers); err != nil {
 					return err
 				}
-				k := cfgKube.NewInterfaces(restConfig)
-				sa.AddRunningKubeSource(k)
-			}
-
-			// If we explicitly specify mesh config, use it.
-			// This takes precedence over default mesh config or mesh config from a running Kube instance.
-			if meshCfgFile != "" {
-				_ = sa.AddFileKubeMeshConfig(meshCfgFile)
-			}
 
-			// If we're not using kube (files only), add defaults for some resources we expect to be provided by Istio
-			if !useKube {
-				err := sa.AddDefaultResources()
-				if err != nil {
-					return err
-				}
+				// Do the analysis
+				result, err = sa.Analyze(cancel)
 			}
 
-			// If files are provided, treat them (collectively) as a source.
-			parseErrors := 0
-			if len(readers) > 0 {
-				if err = sa.AddReaderKubeSource(readers); err != nil {
-					fmt.Fprintf(cmd.ErrOrStderr(), "Error(s) adding files: %v", err)
-					parseErrors++
-				}
-			}
-
-			// Do the analysis
-			result, err := sa.Analyze(cancel)
-
 			if err != nil {
 				return err
 			}
@@ -291,6 +293,18 @@ istioctl analyze -L
 					return err
 				}
 				fmt.Fprintln(cmd.OutOrStdout(), string(yamlOutput))
+			case SarifOutput:
+				sarifOutput, err := formatting.SARIF(outputMessages, version.Info.Version)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), sarifOutput)
+			case JUnitOutput:
+				junitOutput, err := formatting.JUnit(outputMessages, failureLevel.Level)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), junitOutput)
 			default: // This should never happen since we validate this already
 				panic(fmt.Sprintf("%q not found in output format switch statement post validate?", msgOutputFormat))
 			}
@@ -331,9 +345,130 @@ istioctl analyze -L
 		"the duration to wait before failing")
 	analysisCmd.PersistentFlags().BoolVarP(&recursive, "recursive", "R", false,
 		"Process directory arguments recursively. Useful when you want to analyze related manifests organized within the same directory.")
+	analysisCmd.PersistentFlags().StringSliceVar(&clusterContexts, "contexts", []string{},
+		"Analyze the clusters of these contexts of the kubeconfig together, reporting cross-cluster conflicts. "+
+			"Each cluster is named after its context.")
+	analysisCmd.PersistentFlags().StringSliceVar(&clusterConfigs, "kubeconfigs", []string{},
+		"Analyze the clusters of the current context of these kubeconfig files together, reporting cross-cluster conflicts. "+
+			"Each cluster is named after its kubeconfig file, without extension.")
 	return analysisCmd
 }
 
+// analysisCluster is a cluster of a multi-cluster analysis.
+type analysisCluster struct {
+	name       string
+	kubeconfig string
+	context    string
+}
+
+// analysisClusters returns the clusters to analyze, given by the --contexts and --kubeconfigs flags, or nothing if
+// the analysis is of a single cluster.
+func analysisClusters() ([]analysisCluster, error) {
+	var clusters []analysisCluster
+	for _, c := range clusterContexts {
+		clusters = append(clusters, analysisCluster{name: c, kubeconfig: kubeconfig, context: c})
+	}
+	for _, f := range clusterConfigs {
+		name := strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+		clusters = append(clusters, analysisCluster{name: name, kubeconfig: f})
+	}
+
+	analyzedClusters = nil
+	names := make(map[string]bool)
+	for _, c := range clusters {
+		if names[c.name] {
+			return nil, CommandParseError{fmt.Errorf("cluster %q is given more than once", c.name)}
+		}
+		names[c.name] = true
+		analyzedClusters = append(analyzedClusters, c.name)
+	}
+	return clusters, nil
+}
+
+// addAnalysisSources adds the sources of a cluster to a SourceAnalyzer: the live cluster of the kubeconfig and
+// context if kube is used, the mesh config file and the files to analyze. It returns the number of files that
+// couldn't be parsed.
+func addAnalysisSources(cmd *cobra.Command, sa *local.SourceAnalyzer, kubeconfig, context string,
+	readers []local.ReaderSource) (int, error) {
+	// If we're using kube, use that as a base source.
+	if useKube {
+		// Set up the kube client
+		config := kube.BuildClientCmd(kubeconfig, context)
+		restConfig, err := config.ClientConfig()
+		if err != nil {
+			return 0, err
+		}
+		k := cfgKube.NewInterfaces(restConfig)
+		sa.AddRunningKubeSource(k)
+	}
+
+	// If we explicitly specify mesh config, use it.
+	// This takes precedence over default mesh config or mesh config from a running Kube instance.
+	if meshCfgFile != "" {
+		_ = sa.AddFileKubeMeshConfig(meshCfgFile)
+	}
+
+	// If we're not using kube (files only), add defaults for some resources we expect to be provided by Istio
+	if !useKube {
+		err := sa.AddDefaultResources()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// If files are provided, treat them (collectively) as a source.
+	parseErrors := 0
+	if len(readers) > 0 {
+		if err := sa.AddReaderKubeSource(readers); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error(s) adding files: %v", err)
+			parseErrors++
+		}
+	}
+	return parseErrors, nil
+}
+
+// analyzeClusters analyzes each cluster on its own, then all the clusters together for cross-cluster conflicts.
+// The files are added to every cluster.
+func analyzeClusters(cmd *cobra.Command, clusters []analysisCluster, readers []local.ReaderSource,
+	suppressions []snapshotter.AnalysisSuppression, cancel chan struct{}) (local.AnalysisResult, int, error) {
+	// The files are read once, and added to each cluster.
+	contents := make([][]byte, len(readers))
+	for i, r := range readers {
+		by, err := ioutil.ReadAll(r.Reader)
+		if err != nil {
+			return local.AnalysisResult{}, 0, err
+		}
+		contents[i] = by
+	}
+
+	ma := local.NewMultiClusterAnalyzer(schema.MustGet(), analyzers.AllCombined, analyzers.AllMultiClusterCombined(),
+		resource.Namespace(selectedNamespace), resource.Namespace(istioNamespace), true, analysisTimeout)
+	ma.SetSuppressions(suppressions)
+
+	parseErrors := 0
+	for i, c := range clusters {
+		sa, err := ma.AddCluster(c.name)
+		if err != nil {
+			return local.AnalysisResult{}, 0, err
+		}
+		clusterReaders := make([]local.ReaderSource, len(readers))
+		for j, r := range readers {
+			clusterReaders[j] = local.ReaderSource{Name: r.Name, Reader: bytes.NewReader(contents[j])}
+		}
+		errs, err := addAnalysisSources(cmd, sa, c.kubeconfig, c.context, clusterReaders)
+		if err != nil {
+			return local.AnalysisResult{}, 0, fmt.Errorf("failed to add the sources of cluster %s: %v", c.name, err)
+		}
+		// The same files are added to each cluster, count their errors once.
+		if i == 0 {
+			parseErrors = errs
+		}
+	}
+
+	result, err := ma.Analyze(cancel)
+	return result, parseErrors, err
+}
+
 func gatherFiles(args []string) ([]local.ReaderSource, error) {
 	var readers []local.ReaderSource
 	for _, f := range args {
@@ -430,8 +565,8 @@ func colorSuffix() string {
 
 func renderMessage(m diag.Message) string {
 	origin := ""
-	if m.Resource != nil {
-		origin = " (" + m.Resource.Origin.FriendlyName() + ")"
+	if o := m.Origin(); o != "" {
+		origin = " (" + o + ")"
 	}
 	return fmt.Sprintf(
 		"%s%v%s [%v]%s %s", colorPrefix(m), m.Type.Level(), colorSuffix(), m.Type.Code(), origin, fmt.Sprintf(m.Type.Template(), m.Parameters...))
@@ -521,8 +656,12 @@ func AnalyzersAsString(analyzers []analysis.Analyzer) string {
 }
 
 func analyzeTargetAsString() string {
+	target := fmt.Sprintf("namespace: %s", selectedNamespace)
 	if allNamespaces {
-		return "all namespaces"
+		target = "all namespaces"
+	}
+	if len(analyzedClusters) > 0 {
+		target += fmt.Sprintf(" of clusters: %s", strings.Join(analyzedClusters, ", "))
 	}
-	return fmt.Sprintf("namespace: %s", selectedNamespace)
+	return target
 }
@@ -15,9 +15,13 @@
 package cmd
 
 import (
+	"bytes"
 	"testing"
 
+	"istio.io/istio/galley/pkg/config/analysis/analyzers"
 	"istio.io/istio/galley/pkg/config/analysis/diag"
+	"istio.io/istio/galley/pkg/config/source/kube/rt"
+	"istio.io/istio/pkg/config/resource"
 
 	. "github.com/onsi/gomega"
 )
@@ -63,3 +67,51 @@ func TestNoErrorIfMessageLevelsBelowThreshold(t *testing.T) {
 
 	g.Expect(err).To(BeNil())
 }
+
+func TestAnalysisClusters(t *testing.T) {
+	g := NewGomegaWithT(t)
+	prevKubeconfig := kubeconfig
+	defer func() {
+		kubeconfig = prevKubeconfig
+		clusterContexts, clusterConfigs, analyzedClusters = nil, nil, nil
+	}()
+
+	kubeconfig = "config"
+	clusterContexts = []string{"west"}
+	clusterConfigs = []string{"/tmp/east.kubeconfig"}
+	clusters, err := analysisClusters()
+	g.Expect(err).To(BeNil())
+	g.Expect(clusters).To(Equal([]analysisCluster{
+		{name: "west", kubeconfig: "config", context: "west"},
+		{name: "east", kubeconfig: "/tmp/east.kubeconfig"},
+	}))
+	g.Expect(analyzedClusters).To(Equal([]string{"west", "east"}))
+
+	clusterConfigs = []string{"/tmp/west.yaml"}
+	_, err = analysisClusters()
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestRenderMessageWithCluster(t *testing.T) {
+	g := NewGomegaWithT(t)
+	colorize = false
+
+	m := diag.NewMessage(
+		diag.NewMessageType(diag.Error, "B1", "Template: %q"),
+		&resource.Instance{Origin: &rt.Origin{Kind: "Service", FullName: resource.NewFullName("default", "reviews")}},
+		"value",
+	)
+	m.Cluster = "west"
+	g.Expect(renderMessage(m)).To(Equal(`Error [B1] (west: Service reviews.default) Template: "value"`))
+}
+
+func TestListAnalyzers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var out bytes.Buffer
+	cmd := Analyze()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--list-analyzers"})
+	g.Expect(cmd.Execute()).To(Succeed())
+	g.Expect(out.String()).To(Equal(AnalyzersAsString(append(analyzers.All(), analyzers.AllMultiCluster()...))))
+}
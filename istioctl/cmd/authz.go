@@ -21,6 +21,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -50,6 +51,10 @@ var (
 	serviceFiles             []string
 	rootNamespace            string
 	allowNoClusterRbacConfig bool
+
+	simulatedRequest authz.Request
+	simulatedClaims  []string
+	simulatedHeaders []string
 )
 
 var (
@@ -63,13 +68,27 @@ with authorization and the rules used in the authorization.
 The Envoy config dump could be provided either by pod name or from a config dump file
 (the whole output of http://localhost:15000/config_dump of an Envoy instance).
 
+When --port is given, check simulates a request to that inbound port instead: the RBAC
+filters of the matching inbound listener are evaluated against the source principal,
+namespace and IP, the request method, path and headers, and the JWT principal and claims,
+and the command reports whether the request is allowed or denied together with the exact
+policy and rule that matched.
+
 THIS COMMAND IS STILL UNDER ACTIVE DEVELOPMENT AND NOT READY FOR PRODUCTION USE.
 `,
 		Example: `  # Check Envoy authorization configuration for pod httpbin-88ddbcfdd-nt5jb:
   istioctl x authz check httpbin-88ddbcfdd-nt5jb
 
   # Check Envoy authorization configuration from a config dump file:
-  istioctl x authz check -f httpbin_config_dump.json`,
+  istioctl x authz check -f httpbin_config_dump.json
+
+  # Check if a POST /data request from the sleep service account in namespace bar is allowed:
+  istioctl x authz check httpbin-88ddbcfdd-nt5jb --port 80 --method POST --path /data \
+    --source-principal cluster.local/ns/bar/sa/sleep
+
+  # Check if a request with a JWT in the admin group is allowed:
+  istioctl x authz check httpbin-88ddbcfdd-nt5jb --port 80 \
+    --request-principal https://accounts.example.com/alice --claim groups=admin`,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 1 {
 				cmd.Println(cmd.UsageString())
@@ -99,6 +118,20 @@ THIS COMMAND IS STILL UNDER ACTIVE DEVELOPMENT AND NOT READY FOR PRODUCTION USE.
 			if err != nil {
 				return err
 			}
+			if simulatedRequest.Port != 0 {
+				req := simulatedRequest
+				if req.Claims, err = parseClaims(simulatedClaims); err != nil {
+					return err
+				}
+				if req.Headers, err = parseKeyValues(simulatedHeaders, "header"); err != nil {
+					return err
+				}
+				decision, err := analyzer.Simulate(req)
+				if err != nil {
+					return err
+				}
+				return decision.Print(cmd.OutOrStdout())
+			}
 			analyzer.Print(cmd.OutOrStdout(), printAll)
 			return nil
 		},
@@ -206,6 +239,33 @@ func getConfigDumpFromFile(filename string) (*configdump.Wrapper, error) {
 	return envoyConfig, nil
 }
 
+// parseKeyValues parses a list of name=value flags into a map.
+func parseKeyValues(flags []string, kind string) (map[string]string, error) {
+	values := make(map[string]string, len(flags))
+	for _, f := range flags {
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid %s %q, expected name=value", kind, f)
+		}
+		values[kv[0]] = kv[1]
+	}
+	return values, nil
+}
+
+// parseClaims parses a list of name=value flags into JWT claims, a claim given multiple
+// times is a list claim.
+func parseClaims(flags []string) (map[string][]string, error) {
+	claims := make(map[string][]string, len(flags))
+	for _, f := range flags {
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid claim %q, expected name=value", f)
+		}
+		claims[kv[0]] = append(claims[kv[0]], kv[1])
+	}
+	return claims, nil
+}
+
 func getConfigDumpFromPod(podName, podNamespace string) (*configdump.Wrapper, error) {
 	kubeClient, err := kubernetes.NewClient(kubeconfig, configContext)
 	if err != nil {
@@ -376,6 +436,28 @@ func init() {
 		"Show additional information (e.g. SNI and ALPN)")
 	checkCmd.PersistentFlags().StringVarP(&configDumpFile, "file", "f", "",
 		"The json file with Envoy config dump to be checked")
+	checkCmd.PersistentFlags().IntVar(&simulatedRequest.Port, "port", 0,
+		"Inbound port of a simulated request, enables the evaluation of the request against the authorization configuration")
+	checkCmd.PersistentFlags().StringVar(&simulatedRequest.SourcePrincipal, "source-principal", "",
+		"Peer identity of the simulated request, e.g. cluster.local/ns/bar/sa/sleep")
+	checkCmd.PersistentFlags().StringVar(&simulatedRequest.SourceNamespace, "source-namespace", "",
+		"Namespace of the simulated request source, used with the default service account if --source-principal is not set")
+	checkCmd.PersistentFlags().StringVar(&simulatedRequest.SourceIP, "source-ip", "",
+		"Source IP address of the simulated request")
+	checkCmd.PersistentFlags().StringVar(&simulatedRequest.RequestPrincipal, "request-principal", "",
+		"JWT principal (<issuer>/<subject>) of the simulated request")
+	checkCmd.PersistentFlags().StringSliceVar(&simulatedClaims, "claim", []string{},
+		"JWT claim of the simulated request as name=value, may be repeated")
+	checkCmd.PersistentFlags().StringVar(&simulatedRequest.Method, "method", "GET",
+		"HTTP method of the simulated request")
+	checkCmd.PersistentFlags().StringVar(&simulatedRequest.Path, "path", "/",
+		"HTTP path of the simulated request")
+	checkCmd.PersistentFlags().StringVar(&simulatedRequest.Host, "host", "",
+		"Host (authority) of the simulated request")
+	checkCmd.PersistentFlags().StringSliceVarP(&simulatedHeaders, "header", "H", []string{},
+		"Header of the simulated request as name=value, may be repeated")
+	checkCmd.PersistentFlags().StringVar(&simulatedRequest.SNI, "sni", "",
+		"Requested server name of the simulated request")
 	convertCmd.PersistentFlags().StringSliceVarP(&v1Files, "file", "f", []string{},
 		"The yaml file with v1alpha1 RBAC policies to be converted")
 	convertCmd.PersistentFlags().StringSliceVarP(&serviceFiles, "service", "s", []string{},
@@ -22,6 +22,7 @@ import (
 	"strings"
 	"testing"
 
+	"istio.io/istio/istioctl/pkg/authz"
 	"istio.io/istio/pilot/pkg/config/kube/crd"
 	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pilot/pkg/security/authz/policy"
@@ -107,6 +108,57 @@ func TestAuthZCheck(t *testing.T) {
 	}
 }
 
+// resetSimulateFlags restores the defaults of the request simulation flags of authz check.
+func resetSimulateFlags() {
+	simulatedRequest = authz.Request{Method: "GET", Path: "/"}
+	simulatedClaims = []string{}
+	simulatedHeaders = []string{}
+}
+
+func TestAuthZCheckSimulate(t *testing.T) {
+	defer resetSimulateFlags()
+	testCases := []struct {
+		name      string
+		flags     string
+		want      string
+		wantError string
+	}{
+		{
+			name:  "allowed",
+			flags: "--port 9080 --source-namespace default --path /productpage",
+			want:  "Decision:         ALLOW\nReason:           matched ALLOW policy service-viewer\n",
+		},
+		{
+			name:  "denied",
+			flags: "--port 9080 --source-principal cluster.local/ns/foo/sa/sleep",
+			want:  "Decision:         DENY\nReason:           no ALLOW policy matched the request\n",
+		},
+		{
+			name:      "invalid claim",
+			flags:     "--port 9080 --claim groups",
+			wantError: `invalid claim "groups", expected name=value`,
+		},
+	}
+
+	for _, c := range testCases {
+		t.Run(c.name, func(t *testing.T) {
+			resetSimulateFlags()
+			command := fmt.Sprintf("experimental authz check -f testdata/authz/productpage_config_dump.json %s", c.flags)
+			if c.wantError != "" {
+				runCommandWantError(command, c.wantError, t)
+				return
+			}
+			out, err := runCommand(command, t)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !strings.Contains(out.String(), c.want) {
+				t.Errorf("want output containing:\n%s\n got:\n%s", c.want, out.String())
+			}
+		})
+	}
+}
+
 func TestAuthZConvert(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -0,0 +1,265 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+
+	"istio.io/istio/istioctl/pkg/kubernetes"
+	"istio.io/istio/istioctl/pkg/util/handlers"
+	"istio.io/istio/pilot/pkg/config/kube/crd"
+	"istio.io/istio/pilot/pkg/model"
+	v2 "istio.io/istio/pilot/pkg/proxy/envoy/v2"
+	"istio.io/istio/pkg/config/schema/collection"
+	"istio.io/istio/pkg/config/schema/collections"
+)
+
+func configHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config-history",
+		Short: "Inspect and restore previous versions of Istio configuration",
+		Long: `Inspect and restore the previous versions of Istio configuration recorded by Pilot.
+
+Versions are only recorded when Pilot runs with PILOT_ENABLE_CONFIG_DISTRIBUTION_TRACKING, and
+the number of versions kept is controlled by PILOT_CONFIG_HISTORY_SIZE.`,
+	}
+	cmd.AddCommand(configHistoryListCmd(), configHistoryDiffCmd(), configHistoryRollbackCmd())
+	return cmd
+}
+
+func configHistoryListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the config versions recorded by Pilot",
+		Example: `  # List the config versions and the configs changed by each of them
+  istioctl experimental config-history list`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeClient, err := clientExecFactory(kubeconfig, configContext)
+			if err != nil {
+				return err
+			}
+			versions, err := getConfigHistory(kubeClient)
+			if err != nil {
+				return err
+			}
+			printConfigHistory(cmd.OutOrStdout(), versions)
+			return nil
+		},
+	}
+}
+
+func configHistoryDiffCmd() *cobra.Command {
+	var fromVersion, toVersion string
+	cmd := &cobra.Command{
+		Use:   "diff <type> <name>[.<namespace>]",
+		Short: "Show the changes to a config between two versions",
+		Example: `  # Show how the bookinfo virtual service changed since an older version
+  istioctl experimental config-history diff virtualservice bookinfo.default --from 3NLlGgyJWU4=
+
+  # Show the changes to the reviews destination rule between two versions
+  istioctl experimental config-history diff destinationrule reviews --from 3NLlGgyJWU4= --to k8Cw3bcHgfk=`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema, name, ns, err := configHistoryTarget(args)
+			if err != nil {
+				return err
+			}
+			if fromVersion == "" {
+				return errors.New("--from must be set to the version to compare with")
+			}
+			kubeClient, err := clientExecFactory(kubeconfig, configContext)
+			if err != nil {
+				return err
+			}
+			to := toVersion
+			if to == "" {
+				if to, err = latestConfigVersion(kubeClient); err != nil {
+					return err
+				}
+			}
+			key := model.Key(schema.Resource().Kind(), name, ns)
+			from, err := configAtVersionYAML(kubeClient, fromVersion, key)
+			if err != nil {
+				return err
+			}
+			current, err := configAtVersionYAML(kubeClient, to, key)
+			if err != nil {
+				return err
+			}
+			text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				FromFile: fmt.Sprintf("%s@%s", key, fromVersion),
+				A:        difflib.SplitLines(from),
+				ToFile:   fmt.Sprintf("%s@%s", key, to),
+				B:        difflib.SplitLines(current),
+				Context:  3,
+			})
+			if err != nil {
+				return err
+			}
+			if text == "" {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s is identical at versions %s and %s\n", key, fromVersion, to)
+				return nil
+			}
+			_, _ = fmt.Fprint(cmd.OutOrStdout(), text)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&fromVersion, "from", "", "the version to compare from")
+	cmd.Flags().StringVar(&toVersion, "to", "",
+		"the version to compare to, defaults to the latest version recorded by Pilot")
+	return cmd
+}
+
+func configHistoryRollbackCmd() *cobra.Command {
+	var toVersion string
+	cmd := &cobra.Command{
+		Use:   "rollback <type> <name>[.<namespace>]",
+		Short: "Re-apply a previous version of a config",
+		Example: `  # Restore the bookinfo virtual service to the content it had at an older version
+  istioctl experimental config-history rollback virtualservice bookinfo.default --to 3NLlGgyJWU4=`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema, name, ns, err := configHistoryTarget(args)
+			if err != nil {
+				return err
+			}
+			if toVersion == "" {
+				return errors.New("--to must be set to the version to roll back to")
+			}
+			kubeClient, err := clientExecFactory(kubeconfig, configContext)
+			if err != nil {
+				return err
+			}
+			key := model.Key(schema.Resource().Kind(), name, ns)
+			obj, err := getConfigAtVersion(kubeClient, toVersion, key)
+			if err != nil {
+				return err
+			}
+			cfg, err := crd.ConvertObject(schema, obj, "")
+			if err != nil {
+				return err
+			}
+
+			configClient, err := clientFactory()
+			if err != nil {
+				return err
+			}
+			if current := configClient.Get(schema.Resource().GroupVersionKind(), name, ns); current != nil {
+				cfg.ResourceVersion = current.ResourceVersion
+				_, err = configClient.Update(*cfg)
+			} else {
+				cfg.ResourceVersion = ""
+				_, err = configClient.Create(*cfg)
+			}
+			if err != nil {
+				return fmt.Errorf("unable to roll back %s: %v", key, err)
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s rolled back to version %s\n", key, toVersion)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&toVersion, "to", "", "the version to roll back to")
+	return cmd
+}
+
+func configHistoryTarget(args []string) (collection.Schema, string, string, error) {
+	kind := strings.ReplaceAll(args[0], "-", "")
+	for _, s := range collections.Pilot.All() {
+		if strings.EqualFold(kind, s.Resource().Kind()) {
+			name, ns := handlers.InferPodInfo(args[1], handlers.HandleNamespace(namespace, defaultNamespace))
+			return s, name, ns, nil
+		}
+	}
+	return nil, "", "", fmt.Errorf("type %s is not recognized", args[0])
+}
+
+func getConfigHistory(kubeClient kubernetes.ExecClient) ([]v2.ConfigHistoryEntry, error) {
+	res, err := kubeClient.PilotDiscoveryDo(istioNamespace, "GET", "/debug/config_history", nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query pilot for the config history "+
+			"(are you running pilot with config distribution tracking on): %v", err)
+	}
+	var versions []v2.ConfigHistoryEntry
+	if err := json.Unmarshal(res, &versions); err != nil {
+		return nil, fmt.Errorf("unable to retrieve the config history: %s", strings.TrimSpace(string(res)))
+	}
+	return versions, nil
+}
+
+func latestConfigVersion(kubeClient kubernetes.ExecClient) (string, error) {
+	versions, err := getConfigHistory(kubeClient)
+	if err != nil {
+		return "", err
+	}
+	if len(versions) == 0 {
+		return "", errors.New("pilot has not recorded any config version")
+	}
+	return versions[len(versions)-1].Version, nil
+}
+
+func getConfigAtVersion(kubeClient kubernetes.ExecClient, version, key string) (*crd.IstioKind, error) {
+	path := fmt.Sprintf("/debug/config_history?version=%s&resource=%s", url.QueryEscape(version), url.QueryEscape(key))
+	res, err := kubeClient.PilotDiscoveryDo(istioNamespace, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	obj := &crd.IstioKind{}
+	if err := json.Unmarshal(res, obj); err != nil || obj.Kind == "" {
+		return nil, fmt.Errorf("unable to retrieve %s at version %s: %s", key, version, strings.TrimSpace(string(res)))
+	}
+	return obj, nil
+}
+
+func configAtVersionYAML(kubeClient kubernetes.ExecClient, version, key string) (string, error) {
+	obj, err := getConfigAtVersion(kubeClient, version, key)
+	if err != nil {
+		return "", err
+	}
+	// The resource version changes with every update and would only add noise to the diff.
+	obj.ResourceVersion = ""
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func printConfigHistory(writer io.Writer, versions []v2.ConfigHistoryEntry) {
+	w := new(tabwriter.Writer).Init(writer, 0, 8, 5, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tTIME\tEVENT\tCONFIG")
+	for _, v := range versions {
+		version, timestamp := v.Version, v.Time.Format(time.RFC3339)
+		if len(v.Changes) == 0 {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t-\t-\n", version, timestamp)
+		}
+		for _, c := range v.Changes {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", version, timestamp, c.Event, c.Key)
+			version, timestamp = "", ""
+		}
+	}
+	_ = w.Flush()
+}
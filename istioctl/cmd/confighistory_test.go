@@ -0,0 +1,160 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	networking "istio.io/api/networking/v1alpha3"
+
+	"istio.io/istio/pilot/pkg/config/kube/crd"
+	"istio.io/istio/pilot/pkg/model"
+	v2 "istio.io/istio/pilot/pkg/proxy/envoy/v2"
+	"istio.io/istio/pkg/config/schema/collections"
+)
+
+func TestConfigHistory(t *testing.T) {
+	history, _ := json.Marshal([]v2.ConfigHistoryEntry{
+		{
+			Version: "v1",
+			Time:    time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC),
+			Changes: []v2.ConfigChange{
+				{Key: "DestinationRule/default/reviews", Event: "add", ResourceVersion: "1"},
+				{Key: "VirtualService/default/reviews", Event: "add", ResourceVersion: "2"},
+			},
+		},
+		{
+			Version: "v2",
+			Time:    time.Date(2020, 3, 1, 10, 5, 0, 0, time.UTC),
+			Changes: []v2.ConfigChange{
+				{Key: "VirtualService/default/reviews", Event: "update", ResourceVersion: "3"},
+			},
+		},
+	})
+
+	schema := collections.IstioNetworkingV1Alpha3Virtualservices
+	obj, err := crd.ConvertConfig(schema, model.Config{
+		ConfigMeta: model.ConfigMeta{
+			Type:            schema.Resource().Kind(),
+			Group:           schema.Resource().Group(),
+			Version:         schema.Resource().Version(),
+			Name:            "reviews",
+			Namespace:       "default",
+			ResourceVersion: "2",
+		},
+		Spec: &networking.VirtualService{
+			Hosts: []string{"reviews"},
+			Http: []*networking.HTTPRoute{{
+				Route: []*networking.HTTPRouteDestination{{
+					Destination: &networking.Destination{Host: "reviews", Subset: "v1"},
+				}},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, _ := json.Marshal(obj)
+
+	cases := []execTestCase{
+		{
+			execClientConfig: map[string][]byte{"pilot": history},
+			args:             strings.Split("x config-history list", " "),
+			expectedOutput: `VERSION     TIME                     EVENT      CONFIG
+v1          2020-03-01T10:00:00Z     add        DestinationRule/default/reviews
+                                     add        VirtualService/default/reviews
+v2          2020-03-01T10:05:00Z     update     VirtualService/default/reviews
+`,
+		},
+		{
+			execClientConfig: map[string][]byte{"pilot": []byte("Pilot Version tracking is disabled.")},
+			args:             strings.Split("x config-history list", " "),
+			expectedString:   "unable to retrieve the config history: Pilot Version tracking is disabled.",
+			wantException:    true,
+		},
+		{
+			execClientConfig: map[string][]byte{"pilot": config},
+			args:             strings.Split("x config-history diff virtual-service reviews.default --from v1 --to v2", " "),
+			expectedOutput:   "VirtualService/default/reviews is identical at versions v1 and v2\n",
+		},
+		{
+			// The --to flag of the previous diff must not leak into rollback.
+			execClientConfig: map[string][]byte{"pilot": config},
+			args:             strings.Split("x config-history rollback virtualservice reviews.default", " "),
+			expectedString:   "--to must be set",
+			wantException:    true,
+		},
+		{
+			execClientConfig: map[string][]byte{"pilot": config},
+			args:             strings.Split("x config-history diff virtual-service reviews.default", " "),
+			expectedString:   "--from must be set",
+			wantException:    true,
+		},
+		{
+			execClientConfig: map[string][]byte{"pilot": []byte("resource VirtualService/default/reviews does not exist at version v1")},
+			args:             strings.Split("x config-history diff virtual-service reviews.default --from v1 --to v2", " "),
+			expectedString:   "unable to retrieve VirtualService/default/reviews at version v1",
+			wantException:    true,
+		},
+		{
+			execClientConfig: map[string][]byte{"pilot": config},
+			args:             strings.Split("x config-history diff not-a-type reviews.default --from v1", " "),
+			expectedString:   "type not-a-type is not recognized",
+			wantException:    true,
+		},
+		{
+			execClientConfig: map[string][]byte{"pilot": config},
+			args:             strings.Split("x config-history rollback virtualservice reviews.default", " "),
+			expectedString:   "--to must be set",
+			wantException:    true,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("case %d %s", i, strings.Join(c.args, " ")), func(t *testing.T) {
+			verifyExecTestOutput(t, c)
+		})
+	}
+}
+
+func TestConfigHistoryRollback(t *testing.T) {
+	schema := collections.IstioNetworkingV1Alpha3Destinationrules
+	obj, err := crd.ConvertConfig(schema, model.Config{
+		ConfigMeta: model.ConfigMeta{
+			Type:      schema.Resource().Kind(),
+			Group:     schema.Resource().Group(),
+			Version:   schema.Resource().Version(),
+			Name:      "reviews",
+			Namespace: "default",
+		},
+		Spec: &networking.DestinationRule{Host: "reviews"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, _ := json.Marshal(obj)
+
+	// The mock config store starts empty, so the old version is created again.
+	clientFactory = mockClientFactoryGenerator(nil)
+	verifyExecTestOutput(t, execTestCase{
+		execClientConfig: map[string][]byte{"pilot": config},
+		args:             strings.Split("x config-history rollback destinationrule reviews.default --to v1", " "),
+		expectedOutput:   "DestinationRule/default/reviews rolled back to version v1\n",
+	})
+}
@@ -175,6 +175,7 @@ func describe() *cobra.Command {
 
 	describeCmd.AddCommand(podDescribeCmd())
 	describeCmd.AddCommand(svcDescribeCmd())
+	describeCmd.AddCommand(routeDescribeCmd())
 	return describeCmd
 }
 
@@ -0,0 +1,135 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	adminapi "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
+	"github.com/spf13/cobra"
+
+	"istio.io/istio/istioctl/pkg/routing"
+	"istio.io/istio/istioctl/pkg/util/clusters"
+	"istio.io/istio/istioctl/pkg/util/configdump"
+	"istio.io/istio/istioctl/pkg/util/handlers"
+)
+
+func routeDescribeCmd() *cobra.Command {
+	var (
+		req                         routing.Request
+		headers                     []string
+		dumpFile, endpointsDumpFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "route [<pod-name[.namespace]>]",
+		Short: "Explain how the Envoy of a pod routes an HTTP request",
+		Long: `Evaluates the HTTP route configuration of the Envoy in the source pod for a request, and
+reports the matched virtual host and route, the weighted destination clusters and their subsets,
+the timeout and retry policy, and the endpoints the request may be sent to.
+
+THIS COMMAND IS STILL UNDER ACTIVE DEVELOPMENT AND NOT READY FOR PRODUCTION USE.
+`,
+		Example: `  # Explain where productpage sends GET /api/v2 requests with the x-user: beta header to reviews.
+  istioctl experimental describe route productpage-v1-c7765c886-7zzd4 --host reviews --port 9080 \
+    --path /api/v2 -H x-user=beta
+
+  # Explain the routing using saved config_dump and clusters?format=json outputs of an Envoy.
+  istioctl experimental describe route --file config_dump.json --endpoints-file clusters.json \
+    --host reviews.default.svc.cluster.local --port 9080`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if (len(args) == 1) != (dumpFile == "") {
+				cmd.Println(cmd.UsageString())
+				return fmt.Errorf("route requires pod name or --file parameter")
+			}
+			if req.Host == "" || req.Port == 0 {
+				cmd.Println(cmd.UsageString())
+				return fmt.Errorf("route requires --host and --port parameters")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req.Headers = make(map[string]string, len(headers))
+			for _, h := range headers {
+				kv := strings.SplitN(h, "=", 2)
+				if len(kv) != 2 {
+					return fmt.Errorf("invalid header %q, expected name=value", h)
+				}
+				req.Headers[kv[0]] = kv[1]
+			}
+
+			var dump, endpoints []byte
+			var err error
+			if len(args) == 1 {
+				podName, ns := handlers.InferPodInfo(args[0], handlers.HandleNamespace(namespace, defaultNamespace))
+				kubeClient, err := clientExecFactory(kubeconfig, configContext)
+				if err != nil {
+					return fmt.Errorf("failed to create k8s client: %v", err)
+				}
+				if dump, err = kubeClient.EnvoyDo(podName, ns, "GET", "config_dump", nil); err != nil {
+					return fmt.Errorf("failed to execute command on sidecar: %v", err)
+				}
+				if endpoints, err = kubeClient.EnvoyDo(podName, ns, "GET", "clusters?format=json", nil); err != nil {
+					return fmt.Errorf("failed to execute command on sidecar: %v", err)
+				}
+			} else {
+				if dump, err = ioutil.ReadFile(dumpFile); err != nil {
+					return err
+				}
+				if endpointsDumpFile != "" {
+					if endpoints, err = ioutil.ReadFile(endpointsDumpFile); err != nil {
+						return err
+					}
+				}
+			}
+
+			cd := &configdump.Wrapper{}
+			if err := json.Unmarshal(dump, cd); err != nil {
+				return fmt.Errorf("error unmarshalling config dump: %v", err)
+			}
+			routeConfigs, err := routing.RouteConfigs(cd)
+			if err != nil {
+				return err
+			}
+			var clusterStatuses *adminapi.Clusters
+			if endpoints != nil {
+				cw := &clusters.Wrapper{}
+				if err := json.Unmarshal(endpoints, cw); err != nil {
+					return fmt.Errorf("error unmarshalling clusters: %v", err)
+				}
+				clusterStatuses = cw.Clusters
+			}
+			result, err := routing.Explain(routeConfigs, clusterStatuses, req)
+			if err != nil {
+				return err
+			}
+			return result.Print(cmd.OutOrStdout())
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&req.Host, "host", "", "Host (authority) of the request")
+	cmd.PersistentFlags().IntVar(&req.Port, "port", 0, "Destination port of the request")
+	cmd.PersistentFlags().StringVar(&req.Method, "method", "GET", "HTTP method of the request")
+	cmd.PersistentFlags().StringVar(&req.Path, "path", "/", "Path of the request, including the query string")
+	cmd.PersistentFlags().StringSliceVarP(&headers, "header", "H", nil, "Request header as name=value, may be repeated")
+	cmd.PersistentFlags().StringVarP(&dumpFile, "file", "f", "", "Envoy config dump JSON file")
+	cmd.PersistentFlags().StringVar(&endpointsDumpFile, "endpoints-file", "",
+		"Envoy clusters JSON file, as returned by /clusters?format=json")
+
+	return cmd
+}
@@ -711,3 +711,45 @@ func mockInterfaceFactoryGenerator(k8sConfigs []runtime.Object) func(kubeconfig
 
 	return outFactory
 }
+
+func TestDescribeRoute(t *testing.T) {
+	cases := []execTestCase{
+		{ // no pod or file
+			args:           strings.Split("experimental describe route --host istio-policy.istio-system --port 15004", " "),
+			expectedString: "route requires pod name or --file parameter",
+			wantException:  true,
+		},
+		{ // no destination port
+			args:           strings.Split("experimental describe route --file ../pkg/writer/compare/testdata/envoyconfigdump.json --host istio-policy", " "),
+			expectedString: "route requires --host and --port parameters",
+			wantException:  true,
+		},
+		{ // route using --file
+			args: strings.Split("experimental describe route --file ../pkg/writer/compare/testdata/envoyconfigdump.json "+
+				"--host istio-policy.istio-system --port 15004 --path /check -H x-user=beta", " "),
+			expectedOutput: `Route configuration: 15004
+Virtual host:        istio-policy.istio-system.svc.cluster.local:15004
+Route:               #0 <unnamed>
+Match:               prefix /
+Timeout:             disabled
+Retries:             none
+Operation:           istio-policy.istio-system.svc.cluster.local:15004/*
+
+WEIGHT     CLUSTER                                                         SUBSET
+100%       outbound|15004||istio-policy.istio-system.svc.cluster.local     -
+`,
+		},
+		{ // unknown port
+			args: strings.Split("experimental describe route --file ../pkg/writer/compare/testdata/envoyconfigdump.json "+
+				"--host istio-policy.istio-system --port 15014", " "),
+			expectedString: "no HTTP route configuration for port 15014",
+			wantException:  true,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("case %d %s", i, strings.Join(c.args, " ")), func(t *testing.T) {
+			verifyExecTestOutput(t, c)
+		})
+	}
+}
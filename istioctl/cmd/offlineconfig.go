@@ -0,0 +1,310 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/spf13/cobra"
+
+	meshconfig "istio.io/api/mesh/v1alpha1"
+
+	"istio.io/istio/istioctl/pkg/offline"
+	"istio.io/istio/istioctl/pkg/util/handlers"
+	"istio.io/istio/istioctl/pkg/writer/envoy/clusters"
+	"istio.io/istio/istioctl/pkg/writer/envoy/configdump"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/networking/plugin"
+	"istio.io/istio/pkg/config/host"
+	"istio.io/istio/pkg/config/mesh"
+)
+
+var (
+	offlineFiles          []string
+	offlineMeshConfigFile string
+	offlineDomainSuffix   string
+	offlinePlugins        []string
+	offlineProxy          offline.ProxyOptions
+	offlineProxyType      string
+
+	// The plugins enabled by default in Pilot.
+	defaultOfflinePlugins = []string{plugin.Authn, plugin.Authz, plugin.Health, plugin.Mixer}
+)
+
+// generateOfflineConfig loads the files and generates the configuration of the proxy of the named
+// pod, or of the synthetic proxy described by the flags when no pod is named.
+func generateOfflineConfig(args []string) (*offline.Config, error) {
+	if len(offlineFiles) == 0 {
+		return nil, errors.New("at least one file must be set with --filename")
+	}
+	registry := offline.NewRegistry(offlineDomainSuffix)
+	if err := registry.AddFiles(offlineFiles); err != nil {
+		return nil, err
+	}
+
+	var meshConfig *meshconfig.MeshConfig
+	if offlineMeshConfigFile != "" {
+		var err error
+		if meshConfig, err = mesh.ReadMeshConfig(offlineMeshConfigFile); err != nil {
+			return nil, err
+		}
+	}
+	env, err := registry.Environment(meshConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := offlineProxy
+	opts.Type = model.NodeType(offlineProxyType)
+	if len(args) == 1 {
+		podName, ns := handlers.InferPodInfo(args[0], handlers.HandleNamespace(namespace, defaultNamespace))
+		pod, err := registry.Pod(podName, ns)
+		if err != nil {
+			return nil, err
+		}
+		podOpts := offline.PodProxyOptions(pod)
+		if opts.IP != "" {
+			podOpts.IP = opts.IP
+		}
+		if offlineProxyType != "" {
+			podOpts.Type = opts.Type
+		}
+		podOpts.IstioVersion = opts.IstioVersion
+		opts = podOpts
+	} else if opts.Namespace == "" {
+		opts.Namespace = handlers.HandleNamespace(namespace, defaultNamespace)
+	}
+
+	proxy, err := offline.NewProxy(env, registry.DomainSuffix, opts)
+	if err != nil {
+		return nil, err
+	}
+	return offline.Generate(env, proxy, offlinePlugins)
+}
+
+func setupOfflineConfigdumpWriter(args []string, out io.Writer) (*configdump.ConfigWriter, error) {
+	config, err := generateOfflineConfig(args)
+	if err != nil {
+		return nil, err
+	}
+	dump, err := config.ConfigDump()
+	if err != nil {
+		return nil, err
+	}
+	buffer := &bytes.Buffer{}
+	if err := (&jsonpb.Marshaler{}).Marshal(buffer, dump); err != nil {
+		return nil, err
+	}
+	return setupConfigdumpEnvoyConfigWriter(buffer.Bytes(), out)
+}
+
+func setupOfflineClustersWriter(args []string, out io.Writer) (*clusters.ConfigWriter, error) {
+	config, err := generateOfflineConfig(args)
+	if err != nil {
+		return nil, err
+	}
+	buffer := &bytes.Buffer{}
+	if err := (&jsonpb.Marshaler{}).Marshal(buffer, config.Endpoints); err != nil {
+		return nil, err
+	}
+	return setupClustersEnvoyConfigWriter(buffer.Bytes(), out)
+}
+
+func offlineProxyConfig() *cobra.Command {
+	// output format (json or short)
+	var outputFormat string
+
+	configCmd := &cobra.Command{
+		Use:   "offline-proxy-config",
+		Short: "Generate proxy configuration from local files, without a running Pilot",
+		Long: `A group of commands used to compute the configuration Pilot would send to a proxy, from local files
+holding Istio configuration and Kubernetes Services, Endpoints and Pods.
+
+The configuration is generated for the named pod, which must be in the files, or for a synthetic proxy
+described by --ip, --labels and --proxy-type. Services without Endpoints in the files get an endpoint
+for each pod in the files their selector matches. The output is the same as the proxy-config commands.`,
+		Example: `  # Generate the listeners of a pod from a directory of manifests.
+  istioctl experimental offline-proxy-config listeners productpage-v1-123456-abcde.default -f ./manifests
+
+  # Generate the routes of a synthetic sidecar using the output of kubectl.
+  kubectl get svc,endpoints,pods -A -o yaml > cluster.yaml
+  istioctl experimental offline-proxy-config routes -f cluster.yaml -f istio-config.yaml \
+    --ip 10.40.0.12 --labels app=reviews,version=v2 -n default`,
+		Aliases: []string{"opc"},
+	}
+
+	configCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", summaryOutput, "Output format: one of json|short")
+	configCmd.PersistentFlags().StringSliceVarP(&offlineFiles, "filename", "f", nil,
+		"Files or directories holding the Istio configuration and Kubernetes Services, Endpoints and Pods")
+	configCmd.PersistentFlags().StringVar(&offlineMeshConfigFile, "meshConfigFile", "",
+		"Mesh configuration filename. Defaults to the default mesh configuration")
+	configCmd.PersistentFlags().StringVar(&offlineDomainSuffix, "domain", offline.DefaultDomainSuffix,
+		"Kubernetes DNS domain suffix")
+	configCmd.PersistentFlags().StringSliceVar(&offlinePlugins, "plugins", defaultOfflinePlugins,
+		"Comma separated list of Pilot networking plugins to enable")
+	configCmd.PersistentFlags().StringVar(&offlineProxy.IP, "ip", "",
+		"IP address of the proxy, required when no pod is named")
+	configCmd.PersistentFlags().StringToStringVar(&offlineProxy.Labels, "labels", nil,
+		"Workload labels of a proxy for which no pod is named")
+	configCmd.PersistentFlags().StringVar(&offlineProxyType, "proxy-type", "",
+		"Type of the proxy: sidecar or router. Defaults to router for gateway pods and sidecar otherwise")
+	configCmd.PersistentFlags().StringVar(&offlineProxy.IstioVersion, "proxy-version", "",
+		"Istio version of the proxy. Defaults to the latest version")
+
+	args := func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 || (len(args) == 0 && offlineProxy.IP == "") {
+			cmd.Println(cmd.UsageString())
+			return fmt.Errorf("%s requires a pod name or the --ip parameter", cmd.Name())
+		}
+		return nil
+	}
+
+	clusterConfigCmd := &cobra.Command{
+		Use:   "cluster [<pod-name[.namespace]>]",
+		Short: "Generates the cluster configuration of a proxy",
+		Example: `  # Generate the clusters with port 9080 of a pod.
+  istioctl experimental offline-proxy-config clusters <pod-name[.namespace]> -f ./manifests --port 9080`,
+		Aliases: []string{"clusters", "c"},
+		Args:    args,
+		RunE: func(c *cobra.Command, args []string) error {
+			configWriter, err := setupOfflineConfigdumpWriter(args, c.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			filter := configdump.ClusterFilter{
+				FQDN:      host.Name(fqdn),
+				Port:      port,
+				Subset:    subset,
+				Direction: model.TrafficDirection(direction),
+			}
+			switch outputFormat {
+			case summaryOutput:
+				return configWriter.PrintClusterSummary(filter)
+			case jsonOutput:
+				return configWriter.PrintClusterDump(filter)
+			default:
+				return fmt.Errorf("output format %q not supported", outputFormat)
+			}
+		},
+	}
+
+	clusterConfigCmd.PersistentFlags().StringVar(&fqdn, "fqdn", "", "Filter clusters by substring of Service FQDN field")
+	clusterConfigCmd.PersistentFlags().StringVar(&direction, "direction", "", "Filter clusters by Direction field")
+	clusterConfigCmd.PersistentFlags().StringVar(&subset, "subset", "", "Filter clusters by substring of Subset field")
+	clusterConfigCmd.PersistentFlags().IntVar(&port, "port", 0, "Filter clusters by Port field")
+
+	listenerConfigCmd := &cobra.Command{
+		Use:   "listener [<pod-name[.namespace]>]",
+		Short: "Generates the listener configuration of a proxy",
+		Example: `  # Generate the HTTP listeners with a wildcard address (0.0.0.0) of a pod.
+  istioctl experimental offline-proxy-config listeners <pod-name[.namespace]> -f ./manifests --type HTTP --address 0.0.0.0 -o json`,
+		Aliases: []string{"listeners", "l"},
+		Args:    args,
+		RunE: func(c *cobra.Command, args []string) error {
+			configWriter, err := setupOfflineConfigdumpWriter(args, c.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			filter := configdump.ListenerFilter{
+				Address: address,
+				Port:    uint32(port),
+				Type:    listenerType,
+			}
+			switch outputFormat {
+			case summaryOutput:
+				return configWriter.PrintListenerSummary(filter)
+			case jsonOutput:
+				return configWriter.PrintListenerDump(filter)
+			default:
+				return fmt.Errorf("output format %q not supported", outputFormat)
+			}
+		},
+	}
+
+	listenerConfigCmd.PersistentFlags().StringVar(&address, "address", "", "Filter listeners by address field")
+	listenerConfigCmd.PersistentFlags().StringVar(&listenerType, "type", "", "Filter listeners by type field")
+	listenerConfigCmd.PersistentFlags().IntVar(&port, "port", 0, "Filter listeners by Port field")
+
+	routeConfigCmd := &cobra.Command{
+		Use:   "route [<pod-name[.namespace]>]",
+		Short: "Generates the route configuration of a proxy",
+		Example: `  # Generate route 9080 of a pod.
+  istioctl experimental offline-proxy-config route <pod-name[.namespace]> -f ./manifests --name 9080 -o json`,
+		Aliases: []string{"routes", "r"},
+		Args:    args,
+		RunE: func(c *cobra.Command, args []string) error {
+			configWriter, err := setupOfflineConfigdumpWriter(args, c.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			filter := configdump.RouteFilter{
+				Name: routeName,
+			}
+			switch outputFormat {
+			case summaryOutput:
+				return configWriter.PrintRouteSummary(filter)
+			case jsonOutput:
+				return configWriter.PrintRouteDump(filter)
+			default:
+				return fmt.Errorf("output format %q not supported", outputFormat)
+			}
+		},
+	}
+
+	routeConfigCmd.PersistentFlags().StringVar(&routeName, "name", "", "Filter listeners by route name field")
+
+	endpointConfigCmd := &cobra.Command{
+		Use:   "endpoint [<pod-name[.namespace]>]",
+		Short: "Generates the endpoint configuration of a proxy",
+		Example: `  # Generate the endpoints of a cluster of a pod.
+  istioctl experimental offline-proxy-config endpoints <pod-name[.namespace]> -f ./manifests \
+    --cluster "outbound|9080||reviews.default.svc.cluster.local"`,
+		Aliases: []string{"endpoints", "ep"},
+		Args:    args,
+		RunE: func(c *cobra.Command, args []string) error {
+			configWriter, err := setupOfflineClustersWriter(args, c.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			filter := clusters.EndpointFilter{
+				Address: address,
+				Port:    uint32(port),
+				Cluster: clusterName,
+				Status:  status,
+			}
+			switch outputFormat {
+			case summaryOutput:
+				return configWriter.PrintEndpointsSummary(filter)
+			case jsonOutput:
+				return configWriter.PrintEndpoints(filter)
+			default:
+				return fmt.Errorf("output format %q not supported", outputFormat)
+			}
+		},
+	}
+
+	endpointConfigCmd.PersistentFlags().StringVar(&address, "address", "", "Filter endpoints by address field")
+	endpointConfigCmd.PersistentFlags().IntVar(&port, "port", 0, "Filter endpoints by Port field")
+	endpointConfigCmd.PersistentFlags().StringVar(&clusterName, "cluster", "", "Filter endpoints by cluster name field")
+	endpointConfigCmd.PersistentFlags().StringVar(&status, "status", "", "Filter endpoints by status field")
+
+	configCmd.AddCommand(clusterConfigCmd, listenerConfigCmd, routeConfigCmd, endpointConfigCmd)
+
+	return configCmd
+}
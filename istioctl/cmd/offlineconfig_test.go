@@ -0,0 +1,67 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOfflineProxyConfig(t *testing.T) {
+	files := "-f ../pkg/offline/testdata/bookinfo.yaml"
+	cases := []execTestCase{
+		{
+			args:           strings.Split("x offline-proxy-config", " "),
+			expectedString: "A group of commands used to compute the configuration Pilot would send to a proxy",
+		},
+		{ // neither a pod nor an IP
+			args:           strings.Split("x opc clusters "+files, " "),
+			expectedString: "cluster requires a pod name or the --ip parameter",
+			wantException:  true,
+		},
+		{ // no files
+			args:           strings.Split("x opc clusters productpage-v1", " "),
+			expectedString: "at least one file must be set with --filename",
+			wantException:  true,
+		},
+		{ // pod not in the files
+			args:           strings.Split("x opc clusters invalid "+files, " "),
+			expectedString: "pod invalid.default not found in the files",
+			wantException:  true,
+		},
+		{
+			args:           strings.Split("x opc clusters productpage-v1 --fqdn reviews --subset v2 "+files, " "),
+			expectedString: "reviews.default.svc.cluster.local     9080     v2         outbound      EDS",
+		},
+		{
+			args:           strings.Split("x opc listeners productpage-v1.default --port 9080 "+files, " "),
+			expectedString: "10.40.0.1     9080     HTTP",
+		},
+		{
+			args:           strings.Split("x opc routes --ip 10.50.0.1 --name 9080 -o json "+files, " "),
+			expectedString: "outbound|9080|v2|reviews.default.svc.cluster.local",
+		},
+		{
+			args:           strings.Split("x opc endpoints productpage-v1 --cluster outbound|9080|v2|reviews.default.svc.cluster.local "+files, " "),
+			expectedString: "10.40.0.3:9080     HEALTHY     OK                outbound|9080|v2|reviews.default.svc.cluster.local",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			verifyExecTestOutput(t, c)
+		})
+	}
+}
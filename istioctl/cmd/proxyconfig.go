@@ -28,6 +28,7 @@ import (
 	"istio.io/pkg/log"
 
 	"istio.io/istio/istioctl/pkg/util/handlers"
+	"istio.io/istio/istioctl/pkg/writer/compare"
 	"istio.io/istio/istioctl/pkg/writer/envoy/clusters"
 	"istio.io/istio/istioctl/pkg/writer/envoy/configdump"
 	"istio.io/istio/pilot/pkg/model"
@@ -261,6 +262,36 @@ func setupClustersEnvoyConfigWriter(debug []byte, out io.Writer) (*clusters.Conf
 	return cw, nil
 }
 
+func podConfigDump(podName, podNamespace string) (*compare.ConfigDump, error) {
+	kubeClient, err := clientExecFactory(kubeconfig, configContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create k8s client: %v", err)
+	}
+	dump, err := kubeClient.EnvoyDo(podName, podNamespace, "GET", "config_dump", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute command on sidecar: %v", err)
+	}
+	endpoints, err := kubeClient.EnvoyDo(podName, podNamespace, "GET", "clusters?format=json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute command on Envoy: %v", err)
+	}
+	return compare.NewConfigDump(podName+"."+podNamespace, dump, endpoints)
+}
+
+func fileConfigDump(filename, endpointsFilename string) (*compare.ConfigDump, error) {
+	dump, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var endpoints []byte
+	if endpointsFilename != "" {
+		if endpoints, err = ioutil.ReadFile(endpointsFilename); err != nil {
+			return nil, err
+		}
+	}
+	return compare.NewConfigDump(filename, dump, endpoints)
+}
+
 func proxyConfig() *cobra.Command {
 	// output format (yaml or short)
 	var outputFormat string
@@ -270,7 +301,10 @@ func proxyConfig() *cobra.Command {
 		Short: "Retrieve information about proxy configuration from Envoy [kube only]",
 		Long:  `A group of commands used to retrieve information about proxy configuration from the Envoy config dump`,
 		Example: `  # Retrieve information about proxy configuration from an Envoy instance.
-  istioctl proxy-config <clusters|listeners|routes|endpoints|bootstrap> <pod-name[.namespace]>`,
+  istioctl proxy-config <clusters|listeners|routes|endpoints|bootstrap> <pod-name[.namespace]>
+
+  # Compare the proxy configuration of two Envoy instances.
+  istioctl proxy-config diff <pod-name[.namespace]> <pod-name[.namespace]>`,
 		Aliases: []string{"pc"},
 	}
 
@@ -714,8 +748,83 @@ THIS COMMAND IS STILL UNDER ACTIVE DEVELOPMENT AND NOT READY FOR PRODUCTION USE.
 	secretConfigCmd.PersistentFlags().StringVarP(&configDumpFile, "file", "f", "",
 		"Envoy config dump JSON file")
 
+	var diffFiles, diffEndpointsFiles []string
+	diffConfigCmd := &cobra.Command{
+		Use:   "diff [<pod-name[.namespace]>] [<pod-name[.namespace]>]",
+		Short: "Compares the configuration of two Envoys or config dumps",
+		Long: `Compare the listeners, clusters, routes and endpoints of two Envoy instances, or of config dumps
+saved to files, such as snapshots taken before and after a configuration change. Config dump
+files come first in the comparison, followed by pods, whatever their order on the command line.
+Resources are matched by name and changed fields are reported by path. Fields changing on every
+push, such as version_info and last_updated, are ignored.`,
+		Example: `  # Compare the configuration of two pods.
+  istioctl proxy-config diff <pod-name[.namespace]> <pod-name[.namespace]>
+
+  # Compare a config dump taken before a change with the current configuration of the pod.
+  kubectl exec <pod-name> -c istio-proxy -- curl localhost:15000/config_dump > before.json
+  istioctl proxy-config diff --file before.json <pod-name[.namespace]>
+
+  # Compare two config dumps, including their endpoints.
+  istioctl proxy-config diff --file before.json --endpoints-file before-clusters.json \
+    --file after.json --endpoints-file after-clusters.json
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args)+len(diffFiles) != 2 {
+				cmd.Println(cmd.UsageString())
+				return fmt.Errorf("diff requires two pod names or --file parameters")
+			}
+			if len(diffEndpointsFiles) != 0 && len(diffEndpointsFiles) != len(diffFiles) {
+				return fmt.Errorf("diff requires one --endpoints-file parameter per --file parameter")
+			}
+			return nil
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			// Config dump files are compared first, so that a snapshot taken before a change
+			// is compared with the current configuration of a pod.
+			var dumps []*compare.ConfigDump
+			for i, filename := range diffFiles {
+				endpointsFile := ""
+				if len(diffEndpointsFiles) > 0 {
+					endpointsFile = diffEndpointsFiles[i]
+				}
+				dump, err := fileConfigDump(filename, endpointsFile)
+				if err != nil {
+					return err
+				}
+				dumps = append(dumps, dump)
+			}
+			for _, arg := range args {
+				podName, ns := handlers.InferPodInfo(arg, handlers.HandleNamespace(namespace, defaultNamespace))
+				dump, err := podConfigDump(podName, ns)
+				if err != nil {
+					return err
+				}
+				dumps = append(dumps, dump)
+			}
+			diff, err := compare.DiffConfigDumps(dumps[0], dumps[1])
+			if err != nil {
+				return err
+			}
+			switch outputFormat {
+			case summaryOutput:
+				diff.Print(c.OutOrStdout())
+				return nil
+			case jsonOutput:
+				return diff.PrintJSON(c.OutOrStdout())
+			default:
+				return fmt.Errorf("output format %q not supported", outputFormat)
+			}
+		},
+	}
+
+	diffConfigCmd.PersistentFlags().StringSliceVarP(&diffFiles, "file", "f", nil,
+		"Envoy config dump JSON file, may be repeated")
+	diffConfigCmd.PersistentFlags().StringSliceVar(&diffEndpointsFiles, "endpoints-file", nil,
+		"Envoy clusters JSON file, as returned by /clusters?format=json, for each config dump file")
+
 	configCmd.AddCommand(
-		clusterConfigCmd, listenerConfigCmd, logCmd, routeConfigCmd, bootstrapConfigCmd, endpointConfigCmd, secretConfigCmd)
+		clusterConfigCmd, listenerConfigCmd, logCmd, routeConfigCmd, bootstrapConfigCmd, endpointConfigCmd, secretConfigCmd,
+		diffConfigCmd)
 
 	return configCmd
 }
@@ -42,7 +42,8 @@ type execTestCase struct {
 
 // mockExecConfig lets us mock calls to remote Envoy and Istio instances
 type mockExecConfig struct {
-	// results is a map of pod to the results of the expected test on the pod
+	// results is a map of pod to the results of the expected test on the pod. Results of a
+	// single Envoy path are keyed by "<pod>/<path>", and take precedence over those of the pod.
 	results map[string][]byte
 }
 
@@ -53,6 +54,11 @@ func TestProxyConfig(t *testing.T) {
 	endpointConfig := map[string][]byte{
 		"details-v1-5b7f94f9bc-wp5tb": util.ReadFile("../pkg/writer/envoy/clusters/testdata/clusters.json", t),
 	}
+	diffConfig := map[string][]byte{
+		"details-v1-5b7f94f9bc-wp5tb": util.ReadFile("../pkg/writer/compare/testdata/envoyconfigdump.json", t),
+		"details-v1-5b7f94f9bc-wp5tb/clusters?format=json": util.ReadFile(
+			"../pkg/writer/envoy/clusters/testdata/clusters.json", t),
+	}
 	loggingConfig := map[string][]byte{
 		"details-v1-5b7f94f9bc-wp5tb": util.ReadFile("../pkg/writer/envoy/logging/testdata/logging.txt", t),
 	}
@@ -213,6 +219,37 @@ default           Cert Chain     ACTIVE      true           17232678821166591831
 			expectedString:   `Error: secret requires pod name or --file parameter`,
 			wantException:    true,
 		},
+		{ // diff without two config dumps
+			args:           strings.Split("proxy-config diff --file ../pkg/writer/compare/testdata/envoyconfigdump.json", " "),
+			expectedString: `Error: diff requires two pod names or --file parameters`,
+			wantException:  true,
+		},
+		{ // diff with the same config dump
+			args: strings.Split("proxy-config diff --file ../pkg/writer/compare/testdata/envoyconfigdump.json "+
+				"--file ../pkg/writer/compare/testdata/envoyconfigdump.json", " "),
+			expectedOutput: `--- ../pkg/writer/compare/testdata/envoyconfigdump.json
++++ ../pkg/writer/compare/testdata/envoyconfigdump.json
+Listeners Match
+Clusters Match
+Routes Match
+`,
+		},
+		{ // diff using --file
+			args: strings.Split("proxy-config diff --file ../pkg/writer/compare/testdata/envoyconfigdump.json "+
+				"--file ../pkg/writer/compare/testdata/diffenvoyconfigdump.json", " "),
+			expectedString: `Clusters:
+~ outbound|15004||istio-policy.istio-system.svc.cluster.local
+    - circuit_breakers.thresholds[0].max_requests: 10000
+`,
+		},
+		{ // diff mixing a pod and --file, the file comes first whatever the order of the arguments
+			execClientConfig: diffConfig,
+			args: strings.Split("proxy-config diff details-v1-5b7f94f9bc-wp5tb "+
+				"--file ../pkg/writer/compare/testdata/diffenvoyconfigdump.json", " "),
+			expectedString: `--- ../pkg/writer/compare/testdata/diffenvoyconfigdump.json
++++ details-v1-5b7f94f9bc-wp5tb.default
+`,
+		},
 		{ // clusters using --file
 			args: strings.Split("proxy-config clusters --file ../pkg/writer/compare/testdata/envoyconfigdump.json", " "),
 			expectedOutput: `SERVICE FQDN                                    PORT      SUBSET     DIRECTION     TYPE
@@ -313,6 +350,9 @@ func (client mockExecConfig) AllPilotsDiscoveryDo(pilotNamespace, method, path s
 
 // nolint: unparam
 func (client mockExecConfig) EnvoyDo(podName, podNamespace, method, path string, body []byte) ([]byte, error) {
+	if results, ok := client.results[podName+"/"+path]; ok {
+		return results, nil
+	}
 	results, ok := client.results[podName]
 	if !ok {
 		return nil, fmt.Errorf("unable to retrieve Pod: pods %q not found", podName)
@@ -0,0 +1,539 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	listener "github.com/envoyproxy/go-control-plane/envoy/api/v2/listener"
+	rbac_http_filter "github.com/envoyproxy/go-control-plane/envoy/config/filter/http/rbac/v2"
+	rbac_tcp_filter "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/rbac/v2"
+	rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v2"
+	envoy_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher"
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+
+	"istio.io/istio/istioctl/pkg/routing"
+	"istio.io/pkg/log"
+)
+
+const (
+	authnFilterName      = "istio_authn"
+	rbacHTTPFilterName   = "envoy.filters.http.rbac"
+	rbacTCPFilterName    = "envoy.filters.network.rbac"
+	virtualInboundName   = "virtualInbound"
+	defaultTrustDomain   = "cluster.local"
+	spiffeURIPrefix      = "spiffe://"
+	defaultSimulatedPath = "/"
+)
+
+// Request describes a request to a workload, to evaluate against the authorization policies
+// configured in its Envoy.
+type Request struct {
+	// Port is the inbound port of the workload receiving the request.
+	Port int
+	// SourcePrincipal is the identity of the mTLS client, e.g. cluster.local/ns/foo/sa/sleep.
+	SourcePrincipal string
+	// SourceNamespace is used to build the source principal, with the default service account,
+	// when SourcePrincipal is not set.
+	SourceNamespace string
+	SourceIP        string
+	// RequestPrincipal is the <issuer>/<subject> of the request JWT.
+	RequestPrincipal string
+	// Claims are the claims of the request JWT.
+	Claims  map[string][]string
+	Method  string
+	Path    string
+	Host    string
+	Headers map[string]string
+	// SNI is the TLS server name requested by the client.
+	SNI string
+}
+
+// Decision is the result of the evaluation of the authorization policies for a request.
+type Decision struct {
+	Listener    string
+	FilterChain int
+	Allowed     bool
+	// Action is the action of the RBAC filter that decided, ALLOW or DENY. Empty if the workload
+	// has no authorization policy.
+	Action string
+	// Policy is the name of the matched RBAC policy of the filter that decided, empty when no
+	// policy matched.
+	Policy string
+	// Permission and Principal are the indexes of the matched permission and principal in the policy.
+	Permission int
+	Principal  int
+	// ShadowPolicies are the shadow (dry run) policies matching the request.
+	ShadowPolicies []string
+
+	request Request
+}
+
+// rbacFilter is an RBAC filter of a filter chain, in the order Envoy applies them.
+type rbacFilter struct {
+	rules       *rbac.RBAC
+	shadowRules *rbac.RBAC
+	network     bool
+}
+
+// Simulate evaluates the RBAC filters of the inbound listener of the Envoy for the request,
+// the way Envoy enforces them: a request matching a DENY policy is denied, then a request must
+// match an ALLOW policy of each ALLOW filter.
+func (a *Analyzer) Simulate(req Request) (*Decision, error) {
+	if req.SourcePrincipal == "" && req.SourceNamespace != "" {
+		req.SourcePrincipal = fmt.Sprintf("%s/ns/%s/sa/default", defaultTrustDomain, req.SourceNamespace)
+	}
+	if req.Method == "" {
+		req.Method = "GET"
+	}
+	if req.Path == "" {
+		req.Path = defaultSimulatedPath
+	}
+
+	l, fcIndex, fc, err := a.inboundFilterChain(req)
+	if err != nil {
+		return nil, err
+	}
+	decision := &Decision{Listener: l.Name, FilterChain: fcIndex, Allowed: true, request: req}
+	ctx := newEvalContext(req, a.nodeIP)
+	for _, filter := range rbacFilters(fc) {
+		ctx.network = filter.network
+		if filter.shadowRules != nil {
+			if name, _, _ := evaluate(filter.shadowRules, ctx); name != "" {
+				decision.ShadowPolicies = append(decision.ShadowPolicies, name)
+			}
+		}
+		if filter.rules == nil {
+			continue
+		}
+		name, permission, principal := evaluate(filter.rules, ctx)
+		if matched := name != ""; matched == (filter.rules.Action == rbac.RBAC_DENY) {
+			// Denied by a matching DENY policy, or by no matching ALLOW policy.
+			decision.Allowed = false
+			decision.decidedBy(filter.rules.Action, name, permission, principal)
+			return decision, nil
+		}
+		// The request passed the filter. A matched ALLOW policy explains an allowed request
+		// better than an unmatched DENY filter.
+		if decision.Action == "" || filter.rules.Action == rbac.RBAC_ALLOW {
+			decision.decidedBy(filter.rules.Action, name, permission, principal)
+		}
+	}
+	return decision, nil
+}
+
+// decidedBy records the action and the matched policy of the RBAC filter deciding the request.
+func (d *Decision) decidedBy(action rbac.RBAC_Action, policy string, permission, principal int) {
+	d.Action = action.String()
+	d.Policy, d.Permission, d.Principal = policy, permission, principal
+}
+
+// inboundFilterChain returns the listener and filter chain that would handle the request: the
+// inbound listener bound to the node IP and port, or the virtual inbound listener.
+func (a *Analyzer) inboundFilterChain(req Request) (*v2.Listener, int, *listener.FilterChain, error) {
+	var virtualInbound *v2.Listener
+	for _, dl := range a.listenerDump.DynamicListeners {
+		if dl.ActiveState == nil {
+			continue
+		}
+		l := &v2.Listener{}
+		if err := ptypes.UnmarshalAny(dl.ActiveState.Listener, l); err != nil {
+			return nil, 0, nil, err
+		}
+		address := l.Address.GetSocketAddress()
+		if address.GetAddress() == a.nodeIP && int(address.GetPortValue()) == req.Port {
+			if i, fc := selectFilterChain(l, req); fc != nil {
+				return l, i, fc, nil
+			}
+		}
+		if l.Name == virtualInboundName {
+			virtualInbound = l
+		}
+	}
+	if virtualInbound != nil {
+		if i, fc := selectFilterChain(virtualInbound, req); fc != nil {
+			return virtualInbound, i, fc, nil
+		}
+	}
+	return nil, 0, nil, fmt.Errorf("no inbound listener for %s:%d", a.nodeIP, req.Port)
+}
+
+// selectFilterChain returns the filter chain for the port, preferring TLS filter chains for mTLS
+// requests (with a source principal), plain text ones otherwise, and HTTP filter chains.
+func selectFilterChain(l *v2.Listener, req Request) (int, *listener.FilterChain) {
+	bestIndex, bestScore := -1, -1
+	for i, fc := range l.FilterChains {
+		match := fc.FilterChainMatch
+		if port := match.GetDestinationPort(); port != nil && int(port.Value) != req.Port {
+			continue
+		}
+		if l.Name == virtualInboundName && match.GetDestinationPort() == nil {
+			// The catch all filter chains of the virtual inbound listener forward to the original destination.
+			continue
+		}
+		score := 0
+		isTLS := fc.TlsContext != nil || fc.TransportSocket != nil || match.GetTransportProtocol() == "tls"
+		if isTLS == (req.SourcePrincipal != "") {
+			score += 2
+		}
+		if hasHTTPConnectionManager(fc) {
+			score++
+		}
+		if score > bestScore {
+			bestIndex, bestScore = i, score
+		}
+	}
+	if bestIndex < 0 {
+		return 0, nil
+	}
+	return bestIndex, l.FilterChains[bestIndex]
+}
+
+func hasHTTPConnectionManager(fc *listener.FilterChain) bool {
+	for _, filter := range fc.Filters {
+		if filter.Name == "envoy.http_connection_manager" {
+			return true
+		}
+	}
+	return false
+}
+
+// rbacFilters returns the network RBAC filters, then the HTTP RBAC filters of the filter chain.
+func rbacFilters(fc *listener.FilterChain) []rbacFilter {
+	var out []rbacFilter
+	for _, filter := range fc.Filters {
+		switch filter.Name {
+		case rbacTCPFilterName:
+			config := &rbac_tcp_filter.RBAC{}
+			if err := getFilterConfig(filter, config); err != nil {
+				log.Errorf("found RBAC network filter but failed to parse: %s", err)
+				continue
+			}
+			out = append(out, rbacFilter{rules: config.Rules, shadowRules: config.ShadowRules, network: true})
+		case "envoy.http_connection_manager":
+			cm := getHTTPConnectionManager(filter)
+			for _, httpFilter := range cm.GetHttpFilters() {
+				if httpFilter.GetName() != rbacHTTPFilterName {
+					continue
+				}
+				config := &rbac_http_filter.RBAC{}
+				if err := getHTTPFilterConfig(httpFilter, config); err != nil {
+					log.Errorf("found RBAC HTTP filter but failed to parse: %s", err)
+					continue
+				}
+				out = append(out, rbacFilter{rules: config.Rules, shadowRules: config.ShadowRules})
+			}
+		}
+	}
+	return out
+}
+
+// evalContext holds the request attributes available to RBAC filters.
+type evalContext struct {
+	req     Request
+	nodeIP  string
+	headers map[string]string
+	// metadata is the dynamic metadata set by the Istio authentication filter.
+	metadata map[string]*structpb.Struct
+	// network is true when evaluating a network filter, which has no HTTP attributes.
+	network bool
+}
+
+func newEvalContext(req Request, nodeIP string) *evalContext {
+	headers := make(map[string]string, len(req.Headers)+3)
+	for k, v := range req.Headers {
+		headers[strings.ToLower(k)] = v
+	}
+	headers[":method"] = req.Method
+	headers[":path"] = req.Path
+	headers[":authority"] = req.Host
+
+	authn := map[string]*structpb.Value{}
+	if req.SourcePrincipal != "" {
+		authn["source.principal"] = stringValue(req.SourcePrincipal)
+		authn["source.user"] = stringValue(req.SourcePrincipal)
+	}
+	if req.RequestPrincipal != "" {
+		authn["request.auth.principal"] = stringValue(req.RequestPrincipal)
+	}
+	if aud := req.Claims["aud"]; len(aud) > 0 {
+		authn["request.auth.audiences"] = stringValue(aud[0])
+	}
+	if azp := req.Claims["azp"]; len(azp) > 0 {
+		authn["request.auth.presenter"] = stringValue(azp[0])
+	}
+	if len(req.Claims) > 0 {
+		claims := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		for name, values := range req.Claims {
+			list := &structpb.ListValue{}
+			for _, v := range values {
+				list.Values = append(list.Values, stringValue(v))
+			}
+			claims.Fields[name] = &structpb.Value{Kind: &structpb.Value_ListValue{ListValue: list}}
+		}
+		authn["request.auth.claims"] = &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: claims}}
+	}
+	return &evalContext{
+		req:      req,
+		nodeIP:   nodeIP,
+		headers:  headers,
+		metadata: map[string]*structpb.Struct{authnFilterName: {Fields: authn}},
+	}
+}
+
+func stringValue(s string) *structpb.Value {
+	return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: s}}
+}
+
+// evaluate returns the name of the first policy, in name order like Envoy, matching the request,
+// and the indexes of the matching permission and principal.
+func evaluate(rules *rbac.RBAC, ctx *evalContext) (string, int, int) {
+	names := make([]string, 0, len(rules.Policies))
+	for name := range rules.Policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		policy := rules.Policies[name]
+		permission := firstMatch(len(policy.Permissions), func(i int) bool {
+			return matchPermission(policy.Permissions[i], ctx)
+		})
+		if permission < 0 {
+			continue
+		}
+		principal := firstMatch(len(policy.Principals), func(i int) bool {
+			return matchPrincipal(policy.Principals[i], ctx)
+		})
+		if principal < 0 {
+			continue
+		}
+		return name, permission, principal
+	}
+	return "", -1, -1
+}
+
+func firstMatch(n int, match func(int) bool) int {
+	for i := 0; i < n; i++ {
+		if match(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+func matchPermission(p *rbac.Permission, ctx *evalContext) bool {
+	switch rule := p.Rule.(type) {
+	case *rbac.Permission_AndRules:
+		for _, r := range rule.AndRules.Rules {
+			if !matchPermission(r, ctx) {
+				return false
+			}
+		}
+		return true
+	case *rbac.Permission_OrRules:
+		for _, r := range rule.OrRules.Rules {
+			if matchPermission(r, ctx) {
+				return true
+			}
+		}
+		return false
+	case *rbac.Permission_Any:
+		return rule.Any
+	case *rbac.Permission_Header:
+		return !ctx.network && routing.MatchHeader(rule.Header, ctx.headers)
+	case *rbac.Permission_DestinationIp:
+		return matchCidr(rule.DestinationIp, ctx.nodeIP)
+	case *rbac.Permission_DestinationPort:
+		return int(rule.DestinationPort) == ctx.req.Port
+	case *rbac.Permission_Metadata:
+		return matchMetadata(rule.Metadata, ctx)
+	case *rbac.Permission_NotRule:
+		return !matchPermission(rule.NotRule, ctx)
+	case *rbac.Permission_RequestedServerName:
+		return routing.MatchString(rule.RequestedServerName, ctx.req.SNI)
+	default:
+		return false
+	}
+}
+
+func matchPrincipal(p *rbac.Principal, ctx *evalContext) bool {
+	switch id := p.Identifier.(type) {
+	case *rbac.Principal_AndIds:
+		for _, i := range id.AndIds.Ids {
+			if !matchPrincipal(i, ctx) {
+				return false
+			}
+		}
+		return true
+	case *rbac.Principal_OrIds:
+		for _, i := range id.OrIds.Ids {
+			if matchPrincipal(i, ctx) {
+				return true
+			}
+		}
+		return false
+	case *rbac.Principal_Any:
+		return id.Any
+	case *rbac.Principal_Authenticated_:
+		if ctx.req.SourcePrincipal == "" {
+			return false
+		}
+		if id.Authenticated.GetPrincipalName() == nil {
+			return true
+		}
+		return routing.MatchString(id.Authenticated.PrincipalName, spiffeURIPrefix+ctx.req.SourcePrincipal)
+	case *rbac.Principal_SourceIp:
+		return matchCidr(id.SourceIp, ctx.req.SourceIP)
+	case *rbac.Principal_Header:
+		return !ctx.network && routing.MatchHeader(id.Header, ctx.headers)
+	case *rbac.Principal_Metadata:
+		return matchMetadata(id.Metadata, ctx)
+	case *rbac.Principal_NotId:
+		return !matchPrincipal(id.NotId, ctx)
+	default:
+		return false
+	}
+}
+
+func matchCidr(cidr *core.CidrRange, ip string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil || cidr == nil {
+		return false
+	}
+	prefix := net.ParseIP(cidr.AddressPrefix)
+	if prefix == nil {
+		return false
+	}
+	bits := 32
+	if prefix.To4() == nil {
+		bits = 128
+	}
+	prefixLen := bits
+	if cidr.PrefixLen != nil {
+		prefixLen = int(cidr.PrefixLen.Value)
+	}
+	_, network, err := net.ParseCIDR(cidr.AddressPrefix + "/" + strconv.Itoa(prefixLen))
+	return err == nil && network.Contains(addr)
+}
+
+func matchMetadata(m *envoy_matcher.MetadataMatcher, ctx *evalContext) bool {
+	if ctx.network {
+		// The Istio authentication metadata is only available to HTTP filters.
+		return false
+	}
+	s, f := ctx.metadata[m.Filter]
+	if !f {
+		return false
+	}
+	var value *structpb.Value
+	for i, segment := range m.Path {
+		value, f = s.GetFields()[segment.GetKey()]
+		if !f {
+			return false
+		}
+		if i < len(m.Path)-1 {
+			if s = value.GetStructValue(); s == nil {
+				return false
+			}
+		}
+	}
+	return matchValue(m.Value, value)
+}
+
+func matchValue(m *envoy_matcher.ValueMatcher, value *structpb.Value) bool {
+	if value == nil {
+		return false
+	}
+	switch vm := m.GetMatchPattern().(type) {
+	case *envoy_matcher.ValueMatcher_NullMatch_:
+		_, isNull := value.Kind.(*structpb.Value_NullValue)
+		return isNull
+	case *envoy_matcher.ValueMatcher_DoubleMatch:
+		n, isNumber := value.Kind.(*structpb.Value_NumberValue)
+		if !isNumber {
+			return false
+		}
+		if r := vm.DoubleMatch.GetRange(); r != nil {
+			return n.NumberValue >= r.Start && n.NumberValue < r.End
+		}
+		return n.NumberValue == vm.DoubleMatch.GetExact()
+	case *envoy_matcher.ValueMatcher_StringMatch:
+		s, isString := value.Kind.(*structpb.Value_StringValue)
+		return isString && routing.MatchString(vm.StringMatch, s.StringValue)
+	case *envoy_matcher.ValueMatcher_BoolMatch:
+		b, isBool := value.Kind.(*structpb.Value_BoolValue)
+		return isBool && b.BoolValue == vm.BoolMatch
+	case *envoy_matcher.ValueMatcher_PresentMatch:
+		return vm.PresentMatch
+	case *envoy_matcher.ValueMatcher_ListMatch:
+		for _, v := range value.GetListValue().GetValues() {
+			if matchValue(vm.ListMatch.GetOneOf(), v) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// policyNameRegexp parses the RBAC policy names generated for AuthorizationPolicy rules.
+var policyNameRegexp = regexp.MustCompile(`^ns\[(.+)\]-policy\[(.+)\]-rule\[(\d+)\]$`)
+
+// describePolicy returns the Istio policy and rule the RBAC policy was generated for.
+func describePolicy(name string) string {
+	if m := policyNameRegexp.FindStringSubmatch(name); m != nil {
+		return fmt.Sprintf("%s (AuthorizationPolicy %s.%s, rule %s)", name, m[2], m[1], m[3])
+	}
+	return name
+}
+
+// Print writes the decision to writer.
+func (d *Decision) Print(writer io.Writer) error {
+	w := new(tabwriter.Writer).Init(writer, 0, 8, 1, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Listener:\t%s[%d]\n", d.Listener, d.FilterChain)
+	if d.request.SourcePrincipal != "" {
+		_, _ = fmt.Fprintf(w, "Source principal:\t%s\n", d.request.SourcePrincipal)
+	}
+	_, _ = fmt.Fprintf(w, "Request:\t%s %s\n", d.request.Method, d.request.Path)
+	result := "ALLOW"
+	if !d.Allowed {
+		result = "DENY"
+	}
+	_, _ = fmt.Fprintf(w, "Decision:\t%s\n", result)
+	switch {
+	case d.Action == "":
+		_, _ = fmt.Fprintf(w, "Reason:\tno authorization policy applies to the workload\n")
+	case d.Policy == "":
+		_, _ = fmt.Fprintf(w, "Reason:\tno %s policy matched the request\n", d.Action)
+	default:
+		_, _ = fmt.Fprintf(w, "Reason:\tmatched %s policy %s\n", d.Action, describePolicy(d.Policy))
+		_, _ = fmt.Fprintf(w, "Matched:\tpermission[%d], principal[%d]\n", d.Permission, d.Principal)
+	}
+	for _, shadow := range d.ShadowPolicies {
+		_, _ = fmt.Fprintf(w, "Shadow policy:\t%s\n", describePolicy(shadow))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"istio.io/istio/istioctl/pkg/offline"
+	"istio.io/istio/istioctl/pkg/util/configdump"
+	"istio.io/istio/pilot/pkg/networking/plugin"
+)
+
+// simulationAnalyzer generates the configuration of the httpbin pod, protected by the policies
+// in testdata/simulate, with the Pilot authorization plugin.
+func simulationAnalyzer(t *testing.T) *Analyzer {
+	t.Helper()
+	registry := offline.NewRegistry("")
+	if err := registry.AddFiles([]string{"testdata/simulate"}); err != nil {
+		t.Fatal(err)
+	}
+	env, err := registry.Environment(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod, err := registry.Pod("httpbin-1", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy, err := offline.NewProxy(env, registry.DomainSuffix, offline.PodProxyOptions(pod))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := offline.Generate(env, proxy, []string{plugin.Authn, plugin.Authz})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dump, err := config.ConfigDump()
+	if err != nil {
+		t.Fatal(err)
+	}
+	listeners, err := (&configdump.Wrapper{ConfigDump: dump}).GetDynamicListenerDump(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Analyzer{nodeIP: pod.Status.PodIP, nodeType: "sidecar", listenerDump: listeners}
+}
+
+func TestSimulate(t *testing.T) {
+	analyzer := simulationAnalyzer(t)
+	sleep := "cluster.local/ns/bar/sa/sleep"
+
+	cases := []struct {
+		name        string
+		req         Request
+		wantAllowed bool
+		wantAction  string
+		wantPolicy  string
+	}{
+		{
+			name:        "allowed principal and method",
+			req:         Request{Port: 80, SourcePrincipal: sleep, Method: "GET", Path: "/status/200"},
+			wantAllowed: true,
+			wantAction:  "ALLOW",
+			wantPolicy:  "ns[foo]-policy[allow-sleep]-rule[0]",
+		},
+		{
+			name:       "method not allowed",
+			req:        Request{Port: 80, SourcePrincipal: sleep, Method: "POST", Path: "/status/200"},
+			wantAction: "ALLOW",
+		},
+		{
+			name:       "denied path",
+			req:        Request{Port: 80, SourcePrincipal: sleep, Method: "GET", Path: "/admin/users"},
+			wantAction: "DENY",
+			wantPolicy: "ns[foo]-policy[deny-admin]-rule[0]",
+		},
+		{
+			name:       "other namespace",
+			req:        Request{Port: 80, SourceNamespace: "baz", Method: "GET", Path: "/status/200"},
+			wantAction: "ALLOW",
+		},
+		{
+			name: "JWT claims",
+			req: Request{
+				Port:             80,
+				Method:           "POST",
+				Path:             "/post",
+				RequestPrincipal: "https://accounts.example.com/alice",
+				Claims:           map[string][]string{"groups": {"dev", "admin"}},
+			},
+			wantAllowed: true,
+			wantAction:  "ALLOW",
+			wantPolicy:  "ns[foo]-policy[allow-sleep]-rule[1]",
+		},
+		{
+			name: "JWT claims mismatch",
+			req: Request{
+				Port:             80,
+				RequestPrincipal: "https://accounts.example.com/alice",
+				Claims:           map[string][]string{"groups": {"dev"}},
+			},
+			wantAction: "ALLOW",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := analyzer.Simulate(c.req)
+			if err != nil {
+				t.Fatalf("Simulate() failed: %v", err)
+			}
+			if got.Allowed != c.wantAllowed || got.Action != c.wantAction || got.Policy != c.wantPolicy {
+				t.Errorf("Simulate() = allowed %v by %s policy %q, want allowed %v by %s policy %q",
+					got.Allowed, got.Action, got.Policy, c.wantAllowed, c.wantAction, c.wantPolicy)
+			}
+		})
+	}
+
+	if _, err := analyzer.Simulate(Request{Port: 9999}); err == nil {
+		t.Errorf("Simulate() succeeded for a port without inbound listener")
+	}
+}
+
+func TestDecisionPrint(t *testing.T) {
+	analyzer := simulationAnalyzer(t)
+	decision, err := analyzer.Simulate(Request{Port: 80, SourceNamespace: "bar", Path: "/admin"})
+	if err != nil {
+		t.Fatalf("Simulate() failed: %v", err)
+	}
+	out := &bytes.Buffer{}
+	if err := decision.Print(out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Source principal: cluster.local/ns/bar/sa/default\n",
+		"Request:          GET /admin\n",
+		"Decision:         DENY\n",
+		"Reason:           matched DENY policy ns[foo]-policy[deny-admin]-rule[0] (AuthorizationPolicy deny-admin.foo, rule 0)\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Print() output\n%s\ndoes not contain %q", out.String(), want)
+		}
+	}
+}
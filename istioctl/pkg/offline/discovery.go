@@ -0,0 +1,155 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline
+
+import (
+	"fmt"
+	"sort"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/serviceregistry/kube"
+	"istio.io/istio/pkg/config/host"
+	"istio.io/istio/pkg/config/labels"
+)
+
+// serviceDiscovery serves the services, instances and workloads converted from the loaded
+// Kubernetes resources, the way the Kubernetes registry serves them from a cluster.
+type serviceDiscovery struct {
+	registry *Registry
+
+	services map[host.Name]*model.Service
+	// Key is the hostname of the service, ':', port.
+	instancesByPort map[string][]*model.ServiceInstance
+	// Used by GetProxyServiceInstances, the instances of each endpoint IP.
+	ip2instance map[string][]*model.ServiceInstance
+	// Used by GetProxyWorkloadLabels, the labels of each pod IP.
+	ip2workloadLabels map[string]labels.Instance
+}
+
+var _ model.ServiceDiscovery = &serviceDiscovery{}
+
+func newServiceDiscovery(r *Registry) *serviceDiscovery {
+	return &serviceDiscovery{
+		registry:          r,
+		services:          make(map[host.Name]*model.Service),
+		instancesByPort:   make(map[string][]*model.ServiceInstance),
+		ip2instance:       make(map[string][]*model.ServiceInstance),
+		ip2workloadLabels: make(map[string]labels.Instance),
+	}
+}
+
+func instancesKey(hostname host.Name, port int) string {
+	return fmt.Sprintf("%s:%d", hostname, port)
+}
+
+func (sd *serviceDiscovery) addService(svc *model.Service) {
+	sd.services[svc.Hostname] = svc
+}
+
+func (sd *serviceDiscovery) addInstance(instance *model.ServiceInstance) {
+	key := instancesKey(instance.Service.Hostname, instance.ServicePort.Port)
+	sd.instancesByPort[key] = append(sd.instancesByPort[key], instance)
+	sd.ip2instance[instance.Endpoint.Address] = append(sd.ip2instance[instance.Endpoint.Address], instance)
+}
+
+func (sd *serviceDiscovery) addWorkload(ip string, workloadLabels labels.Instance) {
+	sd.ip2workloadLabels[ip] = workloadLabels
+}
+
+// Services implements discovery interface
+// Services are sorted by hostname, so the generated configuration does not depend on map order.
+func (sd *serviceDiscovery) Services() ([]*model.Service, error) {
+	out := make([]*model.Service, 0, len(sd.services))
+	for _, svc := range sd.services {
+		out = append(out, svc)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Hostname < out[j].Hostname })
+	return out, nil
+}
+
+// GetService implements discovery interface
+func (sd *serviceDiscovery) GetService(hostname host.Name) (*model.Service, error) {
+	return sd.services[hostname], nil
+}
+
+// InstancesByPort implements discovery interface
+func (sd *serviceDiscovery) InstancesByPort(svc *model.Service, num int,
+	labels labels.Collection) ([]*model.ServiceInstance, error) {
+	out := make([]*model.ServiceInstance, 0)
+	for _, instance := range sd.instancesByPort[instancesKey(svc.Hostname, num)] {
+		if labels.HasSubsetOf(instance.Endpoint.Labels) {
+			out = append(out, instance)
+		}
+	}
+	return out, nil
+}
+
+// GetProxyServiceInstances implements discovery interface
+func (sd *serviceDiscovery) GetProxyServiceInstances(node *model.Proxy) ([]*model.ServiceInstance, error) {
+	out := make([]*model.ServiceInstance, 0)
+	for _, ip := range node.IPAddresses {
+		out = append(out, sd.ip2instance[ip]...)
+	}
+	return out, nil
+}
+
+// GetProxyWorkloadLabels implements discovery interface
+func (sd *serviceDiscovery) GetProxyWorkloadLabels(proxy *model.Proxy) (labels.Collection, error) {
+	var out labels.Collection
+	for _, ip := range proxy.IPAddresses {
+		if l, found := sd.ip2workloadLabels[ip]; found {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+// ManagementPorts implements discovery interface
+func (sd *serviceDiscovery) ManagementPorts(addr string) model.PortList {
+	pod := sd.registry.podByIP(addr)
+	if pod == nil {
+		return nil
+	}
+	// A partial list of ports is returned on errors, as in the Kubernetes registry.
+	managementPorts, _ := kube.ConvertProbesToPorts(&pod.Spec)
+	return managementPorts
+}
+
+// WorkloadHealthCheckInfo implements discovery interface
+func (sd *serviceDiscovery) WorkloadHealthCheckInfo(addr string) model.ProbeList {
+	return nil
+}
+
+// GetIstioServiceAccounts implements discovery interface
+func (sd *serviceDiscovery) GetIstioServiceAccounts(svc *model.Service, ports []int) []string {
+	out := make([]string, 0)
+	found := make(map[string]bool)
+	for _, port := range ports {
+		for _, instance := range sd.instancesByPort[instancesKey(svc.Hostname, port)] {
+			sa := instance.Endpoint.ServiceAccount
+			if sa != "" && !found[sa] {
+				found[sa] = true
+				out = append(out, sa)
+			}
+		}
+	}
+	for _, sa := range svc.ServiceAccounts {
+		if !found[sa] {
+			found[sa] = true
+			out = append(out, sa)
+		}
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline
+
+import (
+	"testing"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/serviceregistry/memory"
+	"istio.io/istio/pkg/config/labels"
+)
+
+func TestServiceDiscovery(t *testing.T) {
+	svc := memory.MakeService("reviews.default.svc.cluster.local", "10.1.0.1")
+	sd := newServiceDiscovery(NewRegistry(""))
+	sd.addService(svc)
+	port, _ := svc.Ports.Get(memory.PortHTTPName)
+	sd.addInstance(&model.ServiceInstance{
+		Service:     svc,
+		ServicePort: port,
+		Endpoint: &model.IstioEndpoint{
+			Address:         "10.2.0.1",
+			EndpointPort:    9080,
+			ServicePortName: port.Name,
+			Labels:          labels.Instance{"version": "v3"},
+			ServiceAccount:  "spiffe://cluster.local/ns/default/sa/reviews",
+		},
+	})
+	sd.addWorkload("10.2.0.1", labels.Instance{"version": "v3"})
+
+	instances, _ := sd.InstancesByPort(svc, 80, nil)
+	if len(instances) != 1 || instances[0].Endpoint.Address != "10.2.0.1" {
+		t.Errorf("InstancesByPort() => %v, want the added instance", instances)
+	}
+	if instances, _ = sd.InstancesByPort(svc, 80, labels.Collection{{"version": "v1"}}); len(instances) != 0 {
+		t.Errorf("InstancesByPort() => %v, want no instances for another version", instances)
+	}
+
+	proxy := &model.Proxy{IPAddresses: []string{"10.2.0.1"}}
+	if instances, _ = sd.GetProxyServiceInstances(proxy); len(instances) != 1 {
+		t.Errorf("GetProxyServiceInstances() => %v, want the added instance", instances)
+	}
+	if workloadLabels, _ := sd.GetProxyWorkloadLabels(proxy); len(workloadLabels) != 1 || workloadLabels[0]["version"] != "v3" {
+		t.Errorf("GetProxyWorkloadLabels() => %v, want the workload labels", workloadLabels)
+	}
+	if sa := sd.GetIstioServiceAccounts(svc, []int{80}); len(sa) != 1 || sa[0] != "spiffe://cluster.local/ns/default/sa/reviews" {
+		t.Errorf("GetIstioServiceAccounts() => %v, want the instance service account", sa)
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline
+
+import (
+	"fmt"
+	"sort"
+
+	adminapi "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	http_conn "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/http_connection_manager/v2"
+	xdsutil "github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	coreV1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/pilot/pkg/model"
+	networkingcore "istio.io/istio/pilot/pkg/networking/core"
+	"istio.io/istio/pilot/pkg/networking/util"
+)
+
+// ProxyOptions describes the proxy to generate the configuration for.
+type ProxyOptions struct {
+	// Type is the type of the proxy, sidecar by default.
+	Type model.NodeType
+	// ID is the proxy ID, usually <pod name>.<namespace>.
+	ID string
+	// IP is the address of the proxy, used to select its inbound service instances.
+	IP string
+	// Namespace is the namespace of the workload.
+	Namespace string
+	// Labels are the labels of the workload.
+	Labels map[string]string
+	// IstioVersion is the version of the proxy, the latest version if empty.
+	IstioVersion string
+}
+
+// PodProxyOptions returns the options of the proxy running in a pod.
+func PodProxyOptions(pod *coreV1.Pod) ProxyOptions {
+	return ProxyOptions{
+		Type:      podProxyType(pod),
+		ID:        pod.Name + "." + pod.Namespace,
+		IP:        pod.Status.PodIP,
+		Namespace: pod.Namespace,
+		Labels:    pod.Labels,
+	}
+}
+
+// podProxyType returns router for pods whose istio-proxy container runs as a gateway.
+func podProxyType(pod *coreV1.Pod) model.NodeType {
+	for _, c := range pod.Spec.Containers {
+		if c.Name != "istio-proxy" {
+			continue
+		}
+		for _, arg := range c.Args {
+			if arg == string(model.Router) {
+				return model.Router
+			}
+		}
+	}
+	return model.SidecarProxy
+}
+
+// NewProxy initializes a proxy the way Pilot does when the proxy connects.
+func NewProxy(env *model.Environment, domainSuffix string, opts ProxyOptions) (*model.Proxy, error) {
+	if opts.IP == "" {
+		return nil, fmt.Errorf("the proxy has no IP address")
+	}
+	if opts.Type == "" {
+		opts.Type = model.SidecarProxy
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = "default"
+	}
+	if opts.ID == "" {
+		opts.ID = "offline." + opts.Namespace
+	}
+
+	meta := &model.NodeMetadata{
+		Namespace:    opts.Namespace,
+		Labels:       opts.Labels,
+		InstanceIPs:  []string{opts.IP},
+		IstioVersion: opts.IstioVersion,
+	}
+	node := fmt.Sprintf("%s~%s~%s~%s.svc.%s", opts.Type, opts.IP, opts.ID, opts.Namespace, domainSuffix)
+	proxy, err := model.ParseServiceNodeWithMetadata(node, meta)
+	if err != nil {
+		return nil, err
+	}
+	proxy.ConfigNamespace = model.GetProxyConfigNamespace(proxy)
+
+	push := env.PushContext
+	if err := proxy.SetWorkloadLabels(env); err != nil {
+		return nil, err
+	}
+	if err := proxy.SetServiceInstances(push.ServiceDiscovery); err != nil {
+		return nil, err
+	}
+	proxy.SetSidecarScope(push)
+	proxy.SetGatewaysForProxy(push)
+	if len(proxy.ServiceInstances) > 0 {
+		proxy.Locality = util.ConvertLocality(proxy.ServiceInstances[0].GetLocality())
+	}
+	return proxy, nil
+}
+
+// Config is the xDS configuration generated for a proxy.
+type Config struct {
+	Listeners []*xdsapi.Listener
+	Clusters  []*xdsapi.Cluster
+	Routes    []*xdsapi.RouteConfiguration
+	// Endpoints are the endpoints of each cluster, as reported by the Envoy /clusters admin endpoint.
+	Endpoints *adminapi.Clusters
+}
+
+// Generate runs the Pilot config generator with the provided plugins for the proxy.
+func Generate(env *model.Environment, proxy *model.Proxy, plugins []string) (*Config, error) {
+	generator := networkingcore.NewConfigGenerator(plugins)
+	push := env.PushContext
+
+	listeners := generator.BuildListeners(proxy, push)
+	clusters := generator.BuildClusters(proxy, push)
+	routes := generator.BuildHTTPRoutes(proxy, push, routeNames(listeners))
+	endpoints, err := clusterEndpoints(env, proxy, clusters)
+	if err != nil {
+		return nil, err
+	}
+	return &Config{
+		Listeners: listeners,
+		Clusters:  clusters,
+		Routes:    routes,
+		Endpoints: endpoints,
+	}, nil
+}
+
+// routeNames returns the RDS route names referenced by the listeners, as Envoy would request them.
+func routeNames(listeners []*xdsapi.Listener) []string {
+	found := make(map[string]bool)
+	var out []string
+	for _, l := range listeners {
+		for _, fc := range l.FilterChains {
+			for _, filter := range fc.Filters {
+				if filter.Name != xdsutil.HTTPConnectionManager || filter.GetTypedConfig() == nil {
+					continue
+				}
+				hcm := &http_conn.HttpConnectionManager{}
+				if err := ptypes.UnmarshalAny(filter.GetTypedConfig(), hcm); err != nil {
+					continue
+				}
+				if name := hcm.GetRds().GetRouteConfigName(); name != "" && !found[name] {
+					found[name] = true
+					out = append(out, name)
+				}
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// clusterEndpoints computes the endpoints Envoy would have for each cluster: the EDS endpoints
+// from the service registry and the static endpoints of the other clusters.
+func clusterEndpoints(env *model.Environment, proxy *model.Proxy, clusters []*xdsapi.Cluster) (*adminapi.Clusters, error) {
+	out := &adminapi.Clusters{}
+	for _, c := range clusters {
+		status := &adminapi.ClusterStatus{Name: c.Name}
+		if c.GetType() == xdsapi.Cluster_EDS {
+			_, subset, hostname, port := model.ParseSubsetKey(c.Name)
+			svc, err := env.GetService(hostname)
+			if err != nil {
+				return nil, err
+			}
+			if svc != nil {
+				instances, err := env.InstancesByPort(svc, port, env.PushContext.SubsetToLabels(proxy, subset, hostname))
+				if err != nil {
+					return nil, err
+				}
+				for _, instance := range instances {
+					status.HostStatuses = append(status.HostStatuses,
+						hostStatus(instance.Endpoint.Address, instance.Endpoint.EndpointPort))
+				}
+			}
+		} else {
+			for _, localityEndpoints := range c.GetLoadAssignment().GetEndpoints() {
+				for _, lbEndpoint := range localityEndpoints.LbEndpoints {
+					address := lbEndpoint.GetEndpoint().GetAddress().GetSocketAddress()
+					if address != nil {
+						status.HostStatuses = append(status.HostStatuses, hostStatus(address.Address, address.GetPortValue()))
+					}
+				}
+			}
+		}
+		out.ClusterStatuses = append(out.ClusterStatuses, status)
+	}
+	return out, nil
+}
+
+func hostStatus(address string, port uint32) *adminapi.HostStatus {
+	return &adminapi.HostStatus{
+		Address: &core.Address{
+			Address: &core.Address_SocketAddress{
+				SocketAddress: &core.SocketAddress{
+					Address:       address,
+					PortSpecifier: &core.SocketAddress_PortValue{PortValue: port},
+				},
+			},
+		},
+		HealthStatus: &adminapi.HostHealthStatus{EdsHealthStatus: core.HealthStatus_HEALTHY},
+	}
+}
+
+// ConfigDump returns the configuration in the format of the Envoy /config_dump admin endpoint.
+func (c *Config) ConfigDump() (*adminapi.ConfigDump, error) {
+	dynamicActiveClusters := make([]*adminapi.ClustersConfigDump_DynamicCluster, 0, len(c.Clusters))
+	for _, cs := range c.Clusters {
+		cluster, err := ptypes.MarshalAny(cs)
+		if err != nil {
+			return nil, err
+		}
+		dynamicActiveClusters = append(dynamicActiveClusters, &adminapi.ClustersConfigDump_DynamicCluster{Cluster: cluster})
+	}
+	clustersAny, err := util.MessageToAnyWithError(&adminapi.ClustersConfigDump{
+		DynamicActiveClusters: dynamicActiveClusters,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicActiveListeners := make([]*adminapi.ListenersConfigDump_DynamicListener, 0, len(c.Listeners))
+	for _, ls := range c.Listeners {
+		listener, err := ptypes.MarshalAny(ls)
+		if err != nil {
+			return nil, err
+		}
+		dynamicActiveListeners = append(dynamicActiveListeners, &adminapi.ListenersConfigDump_DynamicListener{
+			Name:        ls.Name,
+			ActiveState: &adminapi.ListenersConfigDump_DynamicListenerState{Listener: listener}})
+	}
+	listenersAny, err := util.MessageToAnyWithError(&adminapi.ListenersConfigDump{
+		DynamicListeners: dynamicActiveListeners,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicRouteConfig := make([]*adminapi.RoutesConfigDump_DynamicRouteConfig, 0, len(c.Routes))
+	for _, rs := range c.Routes {
+		route, err := ptypes.MarshalAny(rs)
+		if err != nil {
+			return nil, err
+		}
+		dynamicRouteConfig = append(dynamicRouteConfig, &adminapi.RoutesConfigDump_DynamicRouteConfig{RouteConfig: route})
+	}
+	routeConfigAny, err := util.MessageToAnyWithError(&adminapi.RoutesConfigDump{DynamicRouteConfigs: dynamicRouteConfig})
+	if err != nil {
+		return nil, err
+	}
+
+	bootstrapAny := util.MessageToAny(&adminapi.BootstrapConfigDump{})
+	return &adminapi.ConfigDump{Configs: []*any.Any{bootstrapAny, clustersAny, listenersAny, routeConfigAny}}, nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline
+
+import (
+	"testing"
+
+	adminapi "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
+
+	"istio.io/istio/pilot/pkg/model"
+)
+
+const reviewsV2Cluster = "outbound|9080|v2|reviews.default.svc.cluster.local"
+
+func generateForPod(t *testing.T, name string) (*model.Proxy, *Config) {
+	t.Helper()
+	registry := NewRegistry("")
+	if err := registry.AddFiles([]string{"testdata"}); err != nil {
+		t.Fatalf("AddFiles() failed: %v", err)
+	}
+	env, err := registry.Environment(nil)
+	if err != nil {
+		t.Fatalf("Environment() failed: %v", err)
+	}
+	pod, err := registry.Pod(name, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy, err := NewProxy(env, registry.DomainSuffix, PodProxyOptions(pod))
+	if err != nil {
+		t.Fatalf("NewProxy() failed: %v", err)
+	}
+	config, err := Generate(env, proxy, nil)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	return proxy, config
+}
+
+func clusterStatus(clusters *adminapi.Clusters, name string) *adminapi.ClusterStatus {
+	for _, status := range clusters.ClusterStatuses {
+		if status.Name == name {
+			return status
+		}
+	}
+	return nil
+}
+
+func TestGenerateForPod(t *testing.T) {
+	proxy, config := generateForPod(t, "productpage-v1")
+
+	if len(proxy.ServiceInstances) != 1 || proxy.ServiceInstances[0].Service.Hostname != "productpage.default.svc.cluster.local" {
+		t.Errorf("unexpected service instances %v", proxy.ServiceInstances)
+	}
+	if proxy.Metadata.Labels["app"] != "productpage" || proxy.Type != model.SidecarProxy {
+		t.Errorf("unexpected proxy %+v", proxy)
+	}
+
+	found := false
+	for _, l := range config.Listeners {
+		if l.Name == "10.40.0.1_9080" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("no inbound listener for the productpage instance in %d listeners", len(config.Listeners))
+	}
+
+	// The virtual service routes all reviews traffic to the v2 subset.
+	found = false
+	for _, r := range config.Routes {
+		for _, vh := range r.VirtualHosts {
+			if vh.Name != "reviews.default.svc.cluster.local:9080" {
+				continue
+			}
+			found = true
+			if cluster := vh.Routes[0].GetRoute().GetCluster(); cluster != reviewsV2Cluster {
+				t.Errorf("reviews routed to %s, want %s", cluster, reviewsV2Cluster)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("no reviews virtual host in %d routes", len(config.Routes))
+	}
+
+	// The reviews endpoints come from the pods selected by the service, filtered by subset.
+	status := clusterStatus(config.Endpoints, reviewsV2Cluster)
+	if status == nil || len(status.HostStatuses) != 1 {
+		t.Fatalf("unexpected endpoints for %s: %v", reviewsV2Cluster, status)
+	}
+	if addr := status.HostStatuses[0].Address.GetSocketAddress(); addr.Address != "10.40.0.3" || addr.GetPortValue() != 9080 {
+		t.Errorf("unexpected endpoint %v", addr)
+	}
+	if status = clusterStatus(config.Endpoints, "outbound|9080||reviews.default.svc.cluster.local"); len(status.HostStatuses) != 2 {
+		t.Errorf("unexpected endpoints for all reviews versions: %v", status)
+	}
+}
+
+func TestGenerateForSyntheticProxy(t *testing.T) {
+	registry := NewRegistry("")
+	if err := registry.AddFiles([]string{"testdata/bookinfo.yaml"}); err != nil {
+		t.Fatalf("AddFiles() failed: %v", err)
+	}
+	env, err := registry.Environment(nil)
+	if err != nil {
+		t.Fatalf("Environment() failed: %v", err)
+	}
+	if _, err := NewProxy(env, registry.DomainSuffix, ProxyOptions{}); err == nil {
+		t.Errorf("NewProxy() succeeded without an IP address")
+	}
+
+	proxy, err := NewProxy(env, registry.DomainSuffix, ProxyOptions{IP: "10.50.0.1", Labels: map[string]string{"app": "ratings"}})
+	if err != nil {
+		t.Fatalf("NewProxy() failed: %v", err)
+	}
+	if len(proxy.ServiceInstances) != 0 || proxy.ConfigNamespace != "default" {
+		t.Errorf("unexpected proxy %+v", proxy)
+	}
+	config, err := Generate(env, proxy, nil)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if clusterStatus(config.Endpoints, reviewsV2Cluster) == nil {
+		t.Errorf("no %s cluster for the synthetic proxy", reviewsV2Cluster)
+	}
+
+	dump, err := config.ConfigDump()
+	if err != nil {
+		t.Fatalf("ConfigDump() failed: %v", err)
+	}
+	if len(dump.Configs) != 4 {
+		t.Errorf("config dump has %d configs, want 4", len(dump.Configs))
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package offline computes the xDS configuration of a proxy from local files,
+// without a running Pilot or Kubernetes cluster.
+package offline
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kubeyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	meshconfig "istio.io/api/mesh/v1alpha1"
+
+	"istio.io/istio/pilot/pkg/config/kube/crd"
+	"istio.io/istio/pilot/pkg/config/memory"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/serviceregistry"
+	"istio.io/istio/pilot/pkg/serviceregistry/kube"
+	"istio.io/istio/pilot/pkg/serviceregistry/kube/controller"
+	"istio.io/istio/pkg/config/labels"
+	"istio.io/istio/pkg/config/mesh"
+	"istio.io/istio/pkg/config/schema/collections"
+)
+
+// DefaultDomainSuffix is the DNS domain suffix of Kubernetes clusters.
+const DefaultDomainSuffix = "cluster.local"
+
+// Registry holds the Istio configuration and the Kubernetes Services, Endpoints and Pods
+// loaded from local files.
+type Registry struct {
+	// DomainSuffix is the Kubernetes DNS domain suffix used for the service hostnames.
+	DomainSuffix string
+
+	configs   []model.Config
+	services  []*coreV1.Service
+	endpoints map[string]*coreV1.Endpoints
+	pods      []*coreV1.Pod
+}
+
+// NewRegistry creates an empty registry for the provided DNS domain suffix.
+func NewRegistry(domainSuffix string) *Registry {
+	if domainSuffix == "" {
+		domainSuffix = DefaultDomainSuffix
+	}
+	return &Registry{
+		DomainSuffix: domainSuffix,
+		endpoints:    make(map[string]*coreV1.Endpoints),
+	}
+}
+
+// AddFiles loads every file, or every YAML and JSON file of a directory, into the registry.
+func (r *Registry) AddFiles(paths []string) error {
+	for _, path := range paths {
+		err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			// Files in directories are filtered by extension, named files are always read.
+			if file != path && !isConfigFile(file) {
+				return nil
+			}
+			content, err := ioutil.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			if err := r.Add(bytes.NewReader(content)); err != nil {
+				return fmt.Errorf("failed to load %s: %v", file, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isConfigFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// Add loads a YAML or JSON stream of Istio configs and Kubernetes Services, Endpoints and Pods.
+// Kubernetes lists, such as the output of kubectl get -o yaml, are expanded. Other kinds are ignored.
+func (r *Registry) Add(reader io.Reader) error {
+	yamlReader := kubeyaml.NewYAMLReader(bufio.NewReader(reader))
+	for {
+		doc, err := yamlReader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		content, err := yaml.YAMLToJSON(doc)
+		if err != nil {
+			return err
+		}
+		if err := r.addObject(content); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *Registry) addObject(content []byte) error {
+	var typeMeta metaV1.TypeMeta
+	if err := json.Unmarshal(content, &typeMeta); err != nil {
+		return err
+	}
+	if typeMeta.APIVersion != "v1" {
+		return r.addConfigs(content)
+	}
+
+	switch typeMeta.Kind {
+	case "List":
+		var list struct {
+			Items []json.RawMessage `json:"items"`
+		}
+		if err := json.Unmarshal(content, &list); err != nil {
+			return err
+		}
+		for _, item := range list.Items {
+			if err := r.addObject(item); err != nil {
+				return err
+			}
+		}
+	case "Service":
+		svc := &coreV1.Service{}
+		if err := json.Unmarshal(content, svc); err != nil {
+			return err
+		}
+		defaultNamespace(&svc.ObjectMeta)
+		r.services = append(r.services, svc)
+	case "Endpoints":
+		ep := &coreV1.Endpoints{}
+		if err := json.Unmarshal(content, ep); err != nil {
+			return err
+		}
+		defaultNamespace(&ep.ObjectMeta)
+		r.endpoints[kube.KeyFunc(ep.Name, ep.Namespace)] = ep
+	case "Pod":
+		pod := &coreV1.Pod{}
+		if err := json.Unmarshal(content, pod); err != nil {
+			return err
+		}
+		defaultNamespace(&pod.ObjectMeta)
+		r.pods = append(r.pods, pod)
+	}
+	return nil
+}
+
+func (r *Registry) addConfigs(content []byte) error {
+	configs, _, err := crd.ParseInputs(string(content))
+	if err != nil {
+		return err
+	}
+	for _, cfg := range configs {
+		if cfg.Namespace == "" {
+			cfg.Namespace = metaV1.NamespaceDefault
+		}
+		cfg.Domain = r.DomainSuffix
+		r.configs = append(r.configs, cfg)
+	}
+	return nil
+}
+
+func defaultNamespace(meta *metaV1.ObjectMeta) {
+	if meta.Namespace == "" {
+		meta.Namespace = metaV1.NamespaceDefault
+	}
+}
+
+// Pod returns the loaded pod with the provided name and namespace.
+func (r *Registry) Pod(name, namespace string) (*coreV1.Pod, error) {
+	for _, pod := range r.pods {
+		if pod.Name == name && pod.Namespace == namespace {
+			return pod, nil
+		}
+	}
+	return nil, fmt.Errorf("pod %s.%s not found in the files", name, namespace)
+}
+
+func (r *Registry) podByIP(ip string) *coreV1.Pod {
+	for _, pod := range r.pods {
+		if pod.Status.PodIP == ip {
+			return pod
+		}
+	}
+	return nil
+}
+
+// Environment builds the Pilot environment over in-memory registries holding the loaded
+// resources. Services without Endpoints in the files get endpoints for the loaded pods
+// their selector matches.
+func (r *Registry) Environment(meshConfig *meshconfig.MeshConfig) (*model.Environment, error) {
+	if meshConfig == nil {
+		m := mesh.DefaultMeshConfig()
+		meshConfig = &m
+	}
+
+	store := memory.Make(collections.Pilot)
+	for _, cfg := range r.configs {
+		if _, err := store.Create(cfg); err != nil {
+			return nil, fmt.Errorf("failed to add %s %s.%s: %v", cfg.Type, cfg.Name, cfg.Namespace, err)
+		}
+	}
+
+	discovery := newServiceDiscovery(r)
+	for _, k8sSvc := range r.services {
+		svc := kube.ConvertService(*k8sSvc, r.DomainSuffix, string(serviceregistry.Kubernetes))
+		discovery.addService(svc)
+		instances := kube.ExternalNameServiceInstances(*k8sSvc, svc)
+		if instances == nil {
+			instances = r.serviceInstances(k8sSvc, svc)
+		}
+		for _, instance := range instances {
+			discovery.addInstance(instance)
+		}
+	}
+	for _, pod := range r.pods {
+		if pod.Status.PodIP != "" {
+			discovery.addWorkload(pod.Status.PodIP, pod.Labels)
+		}
+	}
+
+	env := &model.Environment{
+		ServiceDiscovery: discovery,
+		IstioConfigStore: model.MakeIstioStore(store),
+		Watcher:          mesh.NewFixedWatcher(meshConfig),
+		PushContext:      model.NewPushContext(),
+	}
+	if err := env.PushContext.InitContext(env, nil, nil); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// serviceInstances converts the Endpoints of a service, or the pods it selects, to service instances.
+func (r *Registry) serviceInstances(k8sSvc *coreV1.Service, svc *model.Service) []*model.ServiceInstance {
+	var out []*model.ServiceInstance
+	if ep, f := r.endpoints[kube.KeyFunc(k8sSvc.Name, k8sSvc.Namespace)]; f {
+		for _, ss := range ep.Subsets {
+			for _, ea := range ss.Addresses {
+				for _, port := range ss.Ports {
+					for _, svcPort := range svc.Ports {
+						// K8S EndpointPort uses the service port name, which is optional if a single port is defined.
+						if port.Name == "" || port.Name == svcPort.Name {
+							out = append(out, r.serviceInstance(svc, svcPort, ea.IP, int(port.Port), r.podByIP(ea.IP)))
+						}
+					}
+				}
+			}
+		}
+		return out
+	}
+
+	if len(k8sSvc.Spec.Selector) == 0 {
+		return nil
+	}
+	selector := klabels.SelectorFromSet(k8sSvc.Spec.Selector)
+	for _, pod := range r.pods {
+		if pod.Namespace != k8sSvc.Namespace || pod.Status.PodIP == "" || !selector.Matches(klabels.Set(pod.Labels)) {
+			continue
+		}
+		for _, port := range k8sSvc.Spec.Ports {
+			svcPort, exists := svc.Ports.Get(port.Name)
+			if !exists {
+				continue
+			}
+			// The API server defaults the target port to the service port.
+			if port.TargetPort.Type == intstr.Int && port.TargetPort.IntVal == 0 {
+				port.TargetPort = intstr.FromInt(int(port.Port))
+			}
+			portNum, err := controller.FindPort(pod, &port)
+			if err != nil {
+				continue
+			}
+			out = append(out, r.serviceInstance(svc, svcPort, pod.Status.PodIP, portNum, pod))
+		}
+	}
+	return out
+}
+
+func (r *Registry) serviceInstance(svc *model.Service, svcPort *model.Port, ip string, port int,
+	pod *coreV1.Pod) *model.ServiceInstance {
+	var podLabels labels.Instance
+	locality, sa, uid := "", "", ""
+	if pod != nil {
+		podLabels = pod.Labels
+		locality = model.GetLocalityOrDefault(pod.Labels[model.LocalityLabel], "")
+		sa = kube.SecureNamingSAN(pod)
+		uid = "kubernetes://" + pod.Name + "." + pod.Namespace
+	}
+	return &model.ServiceInstance{
+		Endpoint: &model.IstioEndpoint{
+			Address:         ip,
+			EndpointPort:    uint32(port),
+			ServicePortName: svcPort.Name,
+			UID:             uid,
+			Locality:        locality,
+			Labels:          podLabels,
+			ServiceAccount:  sa,
+			TLSMode:         kube.PodTLSMode(pod),
+		},
+		ServicePort: svcPort,
+		Service:     svc,
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package routing evaluates the HTTP route configuration of an Envoy for a request,
+// the way Envoy selects the virtual host, route and upstream clusters.
+package routing
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	adminapi "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	route "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher"
+	"github.com/golang/protobuf/ptypes"
+
+	"istio.io/istio/istioctl/pkg/util/configdump"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config/host"
+)
+
+// Request describes the HTTP request to route.
+type Request struct {
+	// Host is the value of the Host (:authority) header.
+	Host string
+	// Port is the destination port, which selects the route configuration.
+	Port int
+	// Method is the HTTP method, GET by default.
+	Method string
+	// Path is the request path, including the query string. / by default.
+	Path string
+	// Headers are the request headers. Header names are case insensitive.
+	Headers map[string]string
+}
+
+// Endpoint is an upstream host of a cluster, as reported by Envoy.
+type Endpoint struct {
+	Address string
+	Health  string
+	// Healthy is true if Envoy would send requests to the endpoint.
+	Healthy bool
+}
+
+// Destination is an upstream cluster the request may be sent to.
+type Destination struct {
+	Cluster string
+	// Weight is the share of the requests sent to the cluster, in percent.
+	Weight    float64
+	Direction model.TrafficDirection
+	Subset    string
+	Hostname  host.Name
+	Port      int
+	Endpoints []Endpoint
+}
+
+// Result is the outcome of routing a request.
+type Result struct {
+	RouteConfig string
+	VirtualHost string
+	// RouteIndex is the position of the matched route in the virtual host.
+	RouteIndex int
+	Route      *route.Route
+	// Destinations are empty for redirect and direct response routes.
+	Destinations []*Destination
+	// Mirrors are the clusters requests are mirrored to.
+	Mirrors []string
+
+	withEndpoints bool
+}
+
+// RouteConfigs returns the static and dynamic route configurations of a config dump.
+func RouteConfigs(dump *configdump.Wrapper) ([]*xdsapi.RouteConfiguration, error) {
+	routeDump, err := dump.GetRouteConfigDump()
+	if err != nil {
+		return nil, err
+	}
+	var out []*xdsapi.RouteConfiguration
+	for _, r := range routeDump.StaticRouteConfigs {
+		rc := &xdsapi.RouteConfiguration{}
+		if err := ptypes.UnmarshalAny(r.RouteConfig, rc); err != nil {
+			return nil, err
+		}
+		out = append(out, rc)
+	}
+	for _, r := range routeDump.DynamicRouteConfigs {
+		rc := &xdsapi.RouteConfiguration{}
+		if err := ptypes.UnmarshalAny(r.RouteConfig, rc); err != nil {
+			return nil, err
+		}
+		out = append(out, rc)
+	}
+	return out, nil
+}
+
+// Explain routes the request through the route configurations, and resolves the endpoints of the
+// selected clusters from the Envoy clusters. endpoints may be nil.
+func Explain(routeConfigs []*xdsapi.RouteConfiguration, endpoints *adminapi.Clusters, req Request) (*Result, error) {
+	if req.Method == "" {
+		req.Method = "GET"
+	}
+	if req.Path == "" {
+		req.Path = "/"
+	}
+	headers := make(map[string]string, len(req.Headers)+4)
+	for k, v := range req.Headers {
+		headers[strings.ToLower(k)] = v
+	}
+	headers[":authority"] = req.Host
+	headers[":method"] = req.Method
+	headers[":path"] = req.Path
+	if _, f := headers[":scheme"]; !f {
+		headers[":scheme"] = "http"
+	}
+
+	candidates := routeConfigCandidates(routeConfigs, req.Port)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no HTTP route configuration for port %d, the traffic is not routed as HTTP", req.Port)
+	}
+	var rc *xdsapi.RouteConfiguration
+	var vh *route.VirtualHost
+	for _, rc = range candidates {
+		if vh = selectVirtualHost(rc.VirtualHosts, req.Host); vh != nil {
+			break
+		}
+	}
+	if vh == nil {
+		return nil, fmt.Errorf("no virtual host in route configuration %s matches host %q, Envoy would respond with 404",
+			candidates[0].Name, req.Host)
+	}
+
+	for i, r := range vh.Routes {
+		if !matchRoute(r.Match, req.Path, headers) {
+			continue
+		}
+		result := &Result{
+			RouteConfig: rc.Name,
+			VirtualHost: vh.Name,
+			RouteIndex:  i,
+			Route:       r,
+		}
+		if action := r.GetRoute(); action != nil {
+			result.Destinations = destinations(action, headers)
+			for _, mirror := range action.GetRequestMirrorPolicies() {
+				result.Mirrors = append(result.Mirrors, mirror.Cluster)
+			}
+			if mirror := action.GetRequestMirrorPolicy(); mirror != nil {
+				result.Mirrors = append(result.Mirrors, mirror.Cluster)
+			}
+		}
+		if endpoints != nil {
+			result.withEndpoints = true
+			for _, d := range result.Destinations {
+				d.Endpoints = clusterEndpoints(endpoints, d.Cluster)
+			}
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("no route of virtual host %s matches the request, Envoy would respond with 404", vh.Name)
+}
+
+// routeConfigCandidates returns the route configurations that may serve the port: the route named
+// after the port for sidecars, http.<port> for gateways, then routes of listeners bound to an address.
+func routeConfigCandidates(routeConfigs []*xdsapi.RouteConfiguration, port int) []*xdsapi.RouteConfiguration {
+	sPort := strconv.Itoa(port)
+	var exact, gateway, bound []*xdsapi.RouteConfiguration
+	for _, rc := range routeConfigs {
+		switch {
+		case rc.Name == sPort:
+			exact = append(exact, rc)
+		case rc.Name == "http."+sPort:
+			gateway = append(gateway, rc)
+		case strings.HasSuffix(rc.Name, ":"+sPort):
+			bound = append(bound, rc)
+		}
+	}
+	return append(append(exact, gateway...), bound...)
+}
+
+// selectVirtualHost selects the virtual host for the host like Envoy: exact domains first, then
+// the longest suffix wildcard, the longest prefix wildcard and finally the * domain.
+func selectVirtualHost(vhosts []*route.VirtualHost, hostname string) *route.VirtualHost {
+	hostname = strings.ToLower(hostname)
+	var best *route.VirtualHost
+	bestRank, bestLen := -1, -1
+	for _, vh := range vhosts {
+		for _, domain := range vh.Domains {
+			domain = strings.ToLower(domain)
+			rank := -1
+			switch {
+			case domain == hostname:
+				return vh
+			case domain == "*":
+				rank = 0
+			case strings.HasPrefix(domain, "*") && len(hostname) > len(domain)-1 && strings.HasSuffix(hostname, domain[1:]):
+				rank = 2
+			case strings.HasSuffix(domain, "*") && len(hostname) > len(domain)-1 && strings.HasPrefix(hostname, domain[:len(domain)-1]):
+				rank = 1
+			}
+			if rank > bestRank || (rank == bestRank && rank > 0 && len(domain) > bestLen) {
+				best, bestRank, bestLen = vh, rank, len(domain)
+			}
+		}
+	}
+	return best
+}
+
+func matchRoute(m *route.RouteMatch, path string, headers map[string]string) bool {
+	if m == nil || !matchPath(m, path) {
+		return false
+	}
+	for _, h := range m.Headers {
+		if !MatchHeader(h, headers) {
+			return false
+		}
+	}
+	if len(m.QueryParameters) > 0 {
+		query := url.Values{}
+		if i := strings.Index(path, "?"); i >= 0 {
+			query, _ = url.ParseQuery(path[i+1:])
+		}
+		for _, q := range m.QueryParameters {
+			if !matchQueryParameter(q, query) {
+				return false
+			}
+		}
+	}
+	if m.Grpc != nil && !strings.HasPrefix(headers["content-type"], "application/grpc") {
+		return false
+	}
+	return true
+}
+
+// matchPath matches the path like Envoy: prefixes match the whole path, exact paths and
+// regular expressions the path without query string.
+func matchPath(m *route.RouteMatch, path string) bool {
+	pathOnly := path
+	if i := strings.IndexAny(pathOnly, "?#"); i >= 0 {
+		pathOnly = pathOnly[:i]
+	}
+	caseSensitive := m.GetCaseSensitive() == nil || m.GetCaseSensitive().Value
+	switch ps := m.PathSpecifier.(type) {
+	case *route.RouteMatch_Prefix:
+		if caseSensitive {
+			return strings.HasPrefix(path, ps.Prefix)
+		}
+		return strings.HasPrefix(strings.ToLower(path), strings.ToLower(ps.Prefix))
+	case *route.RouteMatch_Path:
+		if caseSensitive {
+			return pathOnly == ps.Path
+		}
+		return strings.EqualFold(pathOnly, ps.Path)
+	case *route.RouteMatch_Regex:
+		return fullMatch(ps.Regex, pathOnly)
+	case *route.RouteMatch_SafeRegex:
+		return fullMatch(ps.SafeRegex.GetRegex(), pathOnly)
+	default:
+		return false
+	}
+}
+
+// MatchHeader returns true if the headers, keyed by lower case name, match the header matcher like in Envoy.
+func MatchHeader(h *route.HeaderMatcher, headers map[string]string) bool {
+	value, present := headers[strings.ToLower(h.Name)]
+	if !present {
+		// Envoy only matches missing headers with inverted present matchers.
+		_, isPresentMatch := h.HeaderMatchSpecifier.(*route.HeaderMatcher_PresentMatch)
+		return h.InvertMatch && isPresentMatch
+	}
+	match := true
+	switch hm := h.HeaderMatchSpecifier.(type) {
+	case *route.HeaderMatcher_ExactMatch:
+		match = value == hm.ExactMatch
+	case *route.HeaderMatcher_RegexMatch:
+		match = fullMatch(hm.RegexMatch, value)
+	case *route.HeaderMatcher_SafeRegexMatch:
+		match = fullMatch(hm.SafeRegexMatch.GetRegex(), value)
+	case *route.HeaderMatcher_RangeMatch:
+		n, err := strconv.ParseInt(value, 10, 64)
+		match = err == nil && n >= hm.RangeMatch.GetStart() && n < hm.RangeMatch.GetEnd()
+	case *route.HeaderMatcher_PresentMatch:
+		match = true
+	case *route.HeaderMatcher_PrefixMatch:
+		match = strings.HasPrefix(value, hm.PrefixMatch)
+	case *route.HeaderMatcher_SuffixMatch:
+		match = strings.HasSuffix(value, hm.SuffixMatch)
+	}
+	return match != h.InvertMatch
+}
+
+func matchQueryParameter(q *route.QueryParameterMatcher, query url.Values) bool {
+	values, present := query[q.Name]
+	if !present {
+		return false
+	}
+	value := ""
+	if len(values) > 0 {
+		value = values[0]
+	}
+	switch qm := q.QueryParameterMatchSpecifier.(type) {
+	case *route.QueryParameterMatcher_StringMatch:
+		return MatchString(qm.StringMatch, value)
+	case *route.QueryParameterMatcher_PresentMatch:
+		return true
+	}
+	// Deprecated value and regex fields.
+	if q.Value == "" {
+		return true
+	}
+	if q.GetRegex().GetValue() {
+		return fullMatch(q.Value, value)
+	}
+	return value == q.Value
+}
+
+// MatchString returns true if the value matches the string matcher.
+func MatchString(m *matcher.StringMatcher, value string) bool {
+	switch sm := m.MatchPattern.(type) {
+	case *matcher.StringMatcher_Exact:
+		return value == sm.Exact
+	case *matcher.StringMatcher_Prefix:
+		return strings.HasPrefix(value, sm.Prefix)
+	case *matcher.StringMatcher_Suffix:
+		return strings.HasSuffix(value, sm.Suffix)
+	case *matcher.StringMatcher_Regex:
+		return fullMatch(sm.Regex, value)
+	case *matcher.StringMatcher_SafeRegex:
+		return fullMatch(sm.SafeRegex.GetRegex(), value)
+	default:
+		return false
+	}
+}
+
+// fullMatch returns true if the regular expression matches the whole value. Envoy regular
+// expressions use the RE2 syntax, as Go does.
+func fullMatch(expr, value string) bool {
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+func destinations(action *route.RouteAction, headers map[string]string) []*Destination {
+	switch cs := action.ClusterSpecifier.(type) {
+	case *route.RouteAction_Cluster:
+		return []*Destination{newDestination(cs.Cluster, 100)}
+	case *route.RouteAction_ClusterHeader:
+		return []*Destination{newDestination(headers[strings.ToLower(cs.ClusterHeader)], 100)}
+	case *route.RouteAction_WeightedClusters:
+		total := float64(cs.WeightedClusters.GetTotalWeight().GetValue())
+		if total == 0 {
+			total = 100
+		}
+		out := make([]*Destination, 0, len(cs.WeightedClusters.Clusters))
+		for _, c := range cs.WeightedClusters.Clusters {
+			out = append(out, newDestination(c.Name, 100*float64(c.GetWeight().GetValue())/total))
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+func newDestination(cluster string, weight float64) *Destination {
+	direction, subset, hostname, port := model.ParseSubsetKey(cluster)
+	return &Destination{
+		Cluster:   cluster,
+		Weight:    weight,
+		Direction: direction,
+		Subset:    subset,
+		Hostname:  hostname,
+		Port:      port,
+	}
+}
+
+func clusterEndpoints(endpoints *adminapi.Clusters, cluster string) []Endpoint {
+	var out []Endpoint
+	for _, cs := range endpoints.ClusterStatuses {
+		if cs.Name != cluster {
+			continue
+		}
+		for _, hs := range cs.HostStatuses {
+			address := "unix://" + hs.GetAddress().GetPipe().GetPath()
+			if addr := hs.GetAddress().GetSocketAddress(); addr != nil {
+				address = addr.Address + ":" + strconv.Itoa(int(addr.GetPortValue()))
+			}
+			health := hs.GetHealthStatus().GetEdsHealthStatus().String()
+			healthy := health == "HEALTHY" || health == "UNKNOWN"
+			if hs.GetHealthStatus().GetFailedOutlierCheck() {
+				health += ", FAILED_OUTLIER_CHECK"
+				healthy = false
+			}
+			out = append(out, Endpoint{Address: address, Health: health, Healthy: healthy})
+		}
+	}
+	return out
+}
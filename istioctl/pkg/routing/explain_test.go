@@ -0,0 +1,225 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	adminapi "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	route "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+)
+
+const (
+	reviewsV1 = "outbound|9080|v1|reviews.default.svc.cluster.local"
+	reviewsV2 = "outbound|9080|v2|reviews.default.svc.cluster.local"
+	reviewsV3 = "outbound|9080|v3|reviews.default.svc.cluster.local"
+)
+
+func routeConfigs() []*xdsapi.RouteConfiguration {
+	return []*xdsapi.RouteConfiguration{
+		{
+			Name: "9080",
+			VirtualHosts: []*route.VirtualHost{
+				{
+					Name:    "reviews.default.svc.cluster.local:9080",
+					Domains: []string{"reviews.default.svc.cluster.local", "reviews", "reviews:9080"},
+					Routes: []*route.Route{
+						{
+							Name: "beta",
+							Match: &route.RouteMatch{
+								PathSpecifier: &route.RouteMatch_Prefix{Prefix: "/api/v2"},
+								Headers: []*route.HeaderMatcher{{
+									Name:                 "x-user",
+									HeaderMatchSpecifier: &route.HeaderMatcher_ExactMatch{ExactMatch: "beta"},
+								}},
+							},
+							Action: &route.Route_Route{Route: &route.RouteAction{
+								ClusterSpecifier: &route.RouteAction_WeightedClusters{WeightedClusters: &route.WeightedCluster{
+									Clusters: []*route.WeightedCluster_ClusterWeight{
+										{Name: reviewsV2, Weight: &wrappers.UInt32Value{Value: 75}},
+										{Name: reviewsV3, Weight: &wrappers.UInt32Value{Value: 25}},
+									},
+								}},
+								Timeout: ptypes.DurationProto(3 * time.Second),
+								RetryPolicy: &route.RetryPolicy{
+									RetryOn:       "connect-failure",
+									NumRetries:    &wrappers.UInt32Value{Value: 2},
+									PerTryTimeout: ptypes.DurationProto(time.Second),
+								},
+							}},
+						},
+						{
+							Name: "admin",
+							Match: &route.RouteMatch{
+								PathSpecifier: &route.RouteMatch_SafeRegex{SafeRegex: &matcher.RegexMatcher{Regex: "/admin/[0-9]+"}},
+							},
+							Action: &route.Route_DirectResponse{DirectResponse: &route.DirectResponseAction{Status: 403}},
+						},
+						{
+							Name:  "default",
+							Match: &route.RouteMatch{PathSpecifier: &route.RouteMatch_Prefix{Prefix: "/"}},
+							Action: &route.Route_Route{Route: &route.RouteAction{
+								ClusterSpecifier: &route.RouteAction_Cluster{Cluster: reviewsV1},
+							}},
+						},
+					},
+				},
+				{
+					Name:    "allow_any",
+					Domains: []string{"*"},
+					Routes: []*route.Route{{
+						Match: &route.RouteMatch{PathSpecifier: &route.RouteMatch_Prefix{Prefix: "/"}},
+						Action: &route.Route_Route{Route: &route.RouteAction{
+							ClusterSpecifier: &route.RouteAction_Cluster{Cluster: "PassthroughCluster"},
+						}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func hostStatus(address string, health core.HealthStatus) *adminapi.HostStatus {
+	return &adminapi.HostStatus{
+		Address: &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+			Address:       address,
+			PortSpecifier: &core.SocketAddress_PortValue{PortValue: 9080},
+		}}},
+		HealthStatus: &adminapi.HostHealthStatus{EdsHealthStatus: health},
+	}
+}
+
+func TestExplain(t *testing.T) {
+	endpoints := &adminapi.Clusters{ClusterStatuses: []*adminapi.ClusterStatus{
+		{Name: reviewsV2, HostStatuses: []*adminapi.HostStatus{
+			hostStatus("10.40.0.2", core.HealthStatus_HEALTHY),
+			hostStatus("10.40.0.3", core.HealthStatus_UNHEALTHY),
+		}},
+	}}
+
+	tests := []struct {
+		name            string
+		req             Request
+		wantRoute       string
+		wantVirtualHost string
+		wantClusters    []string
+		wantErr         string
+	}{
+		{
+			name:            "header match",
+			req:             Request{Host: "reviews", Port: 9080, Path: "/api/v2/reviews?id=1", Headers: map[string]string{"X-User": "beta"}},
+			wantRoute:       "beta",
+			wantVirtualHost: "reviews.default.svc.cluster.local:9080",
+			wantClusters:    []string{reviewsV2, reviewsV3},
+		},
+		{
+			name:            "header mismatch falls through",
+			req:             Request{Host: "reviews:9080", Port: 9080, Path: "/api/v2", Headers: map[string]string{"x-user": "alpha"}},
+			wantRoute:       "default",
+			wantVirtualHost: "reviews.default.svc.cluster.local:9080",
+			wantClusters:    []string{reviewsV1},
+		},
+		{
+			name:            "regex is matched against the whole path",
+			req:             Request{Host: "reviews", Port: 9080, Path: "/admin/12?x=y"},
+			wantRoute:       "admin",
+			wantVirtualHost: "reviews.default.svc.cluster.local:9080",
+		},
+		{
+			name:            "regex does not match a path prefix",
+			req:             Request{Host: "reviews", Port: 9080, Path: "/admin/12/x"},
+			wantRoute:       "default",
+			wantVirtualHost: "reviews.default.svc.cluster.local:9080",
+			wantClusters:    []string{reviewsV1},
+		},
+		{
+			name:            "unknown host uses the wildcard virtual host",
+			req:             Request{Host: "example.com", Port: 9080},
+			wantVirtualHost: "allow_any",
+			wantClusters:    []string{"PassthroughCluster"},
+		},
+		{
+			name:    "no route configuration",
+			req:     Request{Host: "reviews", Port: 9090},
+			wantErr: "no HTTP route configuration for port 9090",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Explain(routeConfigs(), endpoints, tt.req)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Explain() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Explain() failed: %v", err)
+			}
+			if got.Route.Name != tt.wantRoute || got.VirtualHost != tt.wantVirtualHost {
+				t.Errorf("Explain() matched route %q of %q, want %q of %q", got.Route.Name, got.VirtualHost, tt.wantRoute, tt.wantVirtualHost)
+			}
+			var clusters []string
+			for _, d := range got.Destinations {
+				clusters = append(clusters, d.Cluster)
+			}
+			if strings.Join(clusters, ",") != strings.Join(tt.wantClusters, ",") {
+				t.Errorf("Explain() destinations = %v, want %v", clusters, tt.wantClusters)
+			}
+		})
+	}
+}
+
+func TestResultPrint(t *testing.T) {
+	endpoints := &adminapi.Clusters{ClusterStatuses: []*adminapi.ClusterStatus{
+		{Name: reviewsV2, HostStatuses: []*adminapi.HostStatus{hostStatus("10.40.0.2", core.HealthStatus_HEALTHY)}},
+	}}
+	result, err := Explain(routeConfigs(), endpoints, Request{
+		Host: "reviews", Port: 9080, Path: "/api/v2", Headers: map[string]string{"x-user": "beta"},
+	})
+	if err != nil {
+		t.Fatalf("Explain() failed: %v", err)
+	}
+	if d := result.Destinations[0]; d.Subset != "v2" || d.Hostname != "reviews.default.svc.cluster.local" || d.Port != 9080 || d.Weight != 75 {
+		t.Errorf("unexpected destination %+v", d)
+	}
+
+	out := &bytes.Buffer{}
+	if err := result.Print(out); err != nil {
+		t.Fatal(err)
+	}
+	want := `Route configuration: 9080
+Virtual host:        reviews.default.svc.cluster.local:9080
+Route:               #0 beta
+Match:               prefix /api/v2, x-user=beta
+Timeout:             3s
+Retries:             2 attempts, 1s per try on connect-failure
+
+WEIGHT     CLUSTER                                                SUBSET     ENDPOINT           STATUS
+75%        outbound|9080|v2|reviews.default.svc.cluster.local     v2         10.40.0.2:9080     HEALTHY
+25%        outbound|9080|v3|reviews.default.svc.cluster.local     v3         -                  NO ENDPOINTS
+`
+	if out.String() != want {
+		t.Errorf("Print() =\n%s\nwant\n%s", out.String(), want)
+	}
+}
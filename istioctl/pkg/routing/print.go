@@ -0,0 +1,190 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	route "github.com/envoyproxy/go-control-plane/envoy/api/v2/route"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+)
+
+// Print writes a human readable explanation of the routing decision to w.
+func (r *Result) Print(w io.Writer) error {
+	tw := new(tabwriter.Writer).Init(w, 0, 8, 1, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Route configuration:\t%s\n", r.RouteConfig)
+	_, _ = fmt.Fprintf(tw, "Virtual host:\t%s\n", r.VirtualHost)
+	routeName := r.Route.Name
+	if routeName == "" {
+		routeName = "<unnamed>"
+	}
+	_, _ = fmt.Fprintf(tw, "Route:\t#%d %s\n", r.RouteIndex, routeName)
+	_, _ = fmt.Fprintf(tw, "Match:\t%s\n", renderMatch(r.Route.Match))
+	if config := r.Route.GetMetadata().GetFilterMetadata()["istio"].GetFields()["config"].GetStringValue(); config != "" {
+		_, _ = fmt.Fprintf(tw, "Istio config:\t%s\n", config)
+	}
+
+	switch {
+	case r.Route.GetRedirect() != nil:
+		redirect := r.Route.GetRedirect()
+		_, _ = fmt.Fprintf(tw, "Redirect:\t%s %s%s\n", redirect.GetResponseCode(), redirect.GetHostRedirect(), redirect.GetPathRedirect())
+	case r.Route.GetDirectResponse() != nil:
+		_, _ = fmt.Fprintf(tw, "Direct response:\t%d\n", r.Route.GetDirectResponse().GetStatus())
+	case r.Route.GetRoute() != nil:
+		action := r.Route.GetRoute()
+		_, _ = fmt.Fprintf(tw, "Timeout:\t%s\n", renderTimeout(action.GetTimeout()))
+		_, _ = fmt.Fprintf(tw, "Retries:\t%s\n", renderRetries(action.GetRetryPolicy()))
+		if action.GetPrefixRewrite() != "" {
+			_, _ = fmt.Fprintf(tw, "Prefix rewrite:\t%s\n", action.GetPrefixRewrite())
+		}
+		if action.GetHostRewrite() != "" {
+			_, _ = fmt.Fprintf(tw, "Host rewrite:\t%s\n", action.GetHostRewrite())
+		}
+		if len(r.Mirrors) > 0 {
+			_, _ = fmt.Fprintf(tw, "Mirrored to:\t%s\n", strings.Join(r.Mirrors, ", "))
+		}
+	}
+	if r.Route.GetDecorator().GetOperation() != "" {
+		_, _ = fmt.Fprintf(tw, "Operation:\t%s\n", r.Route.GetDecorator().GetOperation())
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(r.Destinations) == 0 {
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(w)
+	tw = new(tabwriter.Writer).Init(w, 0, 8, 5, ' ', 0)
+	if r.withEndpoints {
+		_, _ = fmt.Fprintln(tw, "WEIGHT\tCLUSTER\tSUBSET\tENDPOINT\tSTATUS")
+	} else {
+		_, _ = fmt.Fprintln(tw, "WEIGHT\tCLUSTER\tSUBSET")
+	}
+	for _, d := range r.Destinations {
+		subset := d.Subset
+		if subset == "" {
+			subset = "-"
+		}
+		weight := strconv.FormatFloat(d.Weight, 'f', -1, 64) + "%"
+		if !r.withEndpoints {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", weight, d.Cluster, subset)
+			continue
+		}
+		if len(d.Endpoints) == 0 {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", weight, d.Cluster, subset, "-", "NO ENDPOINTS")
+			continue
+		}
+		for _, e := range d.Endpoints {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", weight, d.Cluster, subset, e.Address, e.Health)
+		}
+	}
+	return tw.Flush()
+}
+
+func renderMatch(m *route.RouteMatch) string {
+	var parts []string
+	switch ps := m.GetPathSpecifier().(type) {
+	case *route.RouteMatch_Prefix:
+		parts = append(parts, "prefix "+ps.Prefix)
+	case *route.RouteMatch_Path:
+		parts = append(parts, "path "+ps.Path)
+	case *route.RouteMatch_Regex:
+		parts = append(parts, "regex "+ps.Regex)
+	case *route.RouteMatch_SafeRegex:
+		parts = append(parts, "regex "+ps.SafeRegex.GetRegex())
+	}
+	if m.GetCaseSensitive() != nil && !m.GetCaseSensitive().Value {
+		parts = append(parts, "(ignore case)")
+	}
+	for _, h := range m.GetHeaders() {
+		parts = append(parts, renderHeaderMatch(h))
+	}
+	for _, q := range m.GetQueryParameters() {
+		parts = append(parts, "query "+q.Name)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func renderHeaderMatch(h *route.HeaderMatcher) string {
+	op := "="
+	if h.InvertMatch {
+		op = "!="
+	}
+	switch hm := h.HeaderMatchSpecifier.(type) {
+	case *route.HeaderMatcher_ExactMatch:
+		return h.Name + op + hm.ExactMatch
+	case *route.HeaderMatcher_RegexMatch:
+		return h.Name + op + "~" + hm.RegexMatch
+	case *route.HeaderMatcher_SafeRegexMatch:
+		return h.Name + op + "~" + hm.SafeRegexMatch.GetRegex()
+	case *route.HeaderMatcher_PrefixMatch:
+		return h.Name + op + hm.PrefixMatch + "*"
+	case *route.HeaderMatcher_SuffixMatch:
+		return h.Name + op + "*" + hm.SuffixMatch
+	case *route.HeaderMatcher_RangeMatch:
+		return fmt.Sprintf("%s%s[%d,%d)", h.Name, op, hm.RangeMatch.GetStart(), hm.RangeMatch.GetEnd())
+	default:
+		if h.InvertMatch {
+			return "no " + h.Name
+		}
+		return h.Name + " present"
+	}
+}
+
+func renderTimeout(d *duration.Duration) string {
+	if d == nil {
+		// Envoy defaults the route timeout to 15s.
+		return "15s (default)"
+	}
+	td, err := ptypes.Duration(d)
+	if err != nil {
+		return d.String()
+	}
+	if td == 0 {
+		return "disabled"
+	}
+	return td.String()
+}
+
+func renderRetries(p *route.RetryPolicy) string {
+	if p == nil {
+		return "none"
+	}
+	// Envoy retries once when the number of retries is not set.
+	retries := uint32(1)
+	if p.GetNumRetries() != nil {
+		retries = p.GetNumRetries().GetValue()
+	}
+	if retries == 0 {
+		return "none"
+	}
+	out := fmt.Sprintf("%d attempts", retries)
+	if p.GetPerTryTimeout() != nil {
+		if td, err := ptypes.Duration(p.GetPerTryTimeout()); err == nil && td > 0 {
+			out += fmt.Sprintf(", %s per try", td.Round(time.Millisecond))
+		}
+	}
+	if p.GetRetryOn() != "" {
+		out += " on " + p.GetRetryOn()
+	}
+	return out
+}
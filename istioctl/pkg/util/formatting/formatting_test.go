@@ -0,0 +1,155 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formatting
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"istio.io/istio/galley/pkg/config/analysis/diag"
+	"istio.io/istio/galley/pkg/config/analysis/msg"
+	"istio.io/istio/galley/pkg/config/source/kube/rt"
+	"istio.io/istio/pkg/config/resource"
+	"istio.io/istio/pkg/config/schema/collections"
+)
+
+func newTestMessage(kind, namespace, name string, ref resource.Reference) diag.Message {
+	schema := collections.IstioNetworkingV1Alpha3Virtualservices
+	if kind == "Gateway" {
+		schema = collections.IstioNetworkingV1Alpha3Gateways
+	}
+	fullName := resource.NewFullName(resource.Namespace(namespace), resource.LocalName(name))
+	r := &resource.Instance{
+		Metadata: resource.Metadata{
+			Schema:   schema.Resource(),
+			FullName: fullName,
+		},
+		Origin: &rt.Origin{
+			Collection: schema.Name(),
+			Kind:       kind,
+			FullName:   fullName,
+			Ref:        ref,
+		},
+	}
+	return msg.NewReferencedResourceNotFound(r, "host", "ratings")
+}
+
+func TestLocate(t *testing.T) {
+	withLine := newTestMessage("Gateway", "istio-system", "bookinfo-gateway", &resource.Position{Filename: "bookinfo.yaml", Line: 13})
+	withLine.Line = 16
+
+	cases := []struct {
+		message  diag.Message
+		found    bool
+		location Location
+	}{
+		{newTestMessage("VirtualService", "default", "reviews", &resource.Position{Filename: "bookinfo.yaml", Line: 2}),
+			true, Location{"bookinfo.yaml", 2}},
+		{withLine, true, Location{"bookinfo.yaml", 16}},
+		{newTestMessage("VirtualService", "other", "reviews", nil), false, Location{}},
+		{msg.NewInternalError(nil, "no resource"), false, Location{}},
+	}
+	for _, c := range cases {
+		location, found := locate(&c.message)
+		if found != c.found || location != c.location {
+			t.Errorf("locate(%v) => %v, %v, want %v, %v", c.message.String(), location, found, c.location, c.found)
+		}
+	}
+}
+
+func TestSARIF(t *testing.T) {
+	messages := diag.Messages{
+		newTestMessage("VirtualService", "default", "reviews", &resource.Position{Filename: "bookinfo.yaml", Line: 2}),
+		newTestMessage("VirtualService", "default", "details", nil),
+		msg.NewInternalError(nil, "no resource"),
+	}
+
+	out, err := SARIF(messages, "1.5.0")
+	if err != nil {
+		t.Fatalf("SARIF() failed: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal([]byte(out), &log); err != nil {
+		t.Fatalf("SARIF() returned invalid JSON: %v", err)
+	}
+	if log.Version != sarifVersion || len(log.Runs) != 1 {
+		t.Fatalf("SARIF() returned an unexpected log: %s", out)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || len(run.Results) != 3 {
+		t.Fatalf("SARIF() returned %d rules and %d results, want 2 and 3", len(run.Tool.Driver.Rules), len(run.Results))
+	}
+
+	located := run.Results[0]
+	if located.RuleID != msg.ReferencedResourceNotFound.Code() || located.Level != "error" {
+		t.Errorf("unexpected result %+v", located)
+	}
+	physical := located.Locations[0].PhysicalLocation
+	if physical == nil || physical.ArtifactLocation.URI != "bookinfo.yaml" || physical.Region.StartLine != 2 {
+		t.Errorf("unexpected physical location %+v", physical)
+	}
+	if name := located.Locations[0].LogicalLocations[0].FullyQualifiedName; name != "VirtualService reviews.default" {
+		t.Errorf("unexpected logical location %s", name)
+	}
+
+	// The resource has no position, so only the logical location is known.
+	if run.Results[1].Locations[0].PhysicalLocation != nil {
+		t.Errorf("unexpected physical location for a resource not in the files")
+	}
+	// The message has no resource, so no location.
+	if run.Results[2].RuleIndex != 1 || len(run.Results[2].Locations) != 0 {
+		t.Errorf("unexpected result %+v", run.Results[2])
+	}
+}
+
+func TestJUnit(t *testing.T) {
+	messages := diag.Messages{
+		newTestMessage("VirtualService", "default", "reviews", &resource.Position{Filename: "bookinfo.yaml", Line: 2}),
+		msg.NewInternalError(nil, "no resource"),
+	}
+
+	out, err := JUnit(messages, diag.Error)
+	if err != nil {
+		t.Fatalf("JUnit() failed: %v", err)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal([]byte(out), &suites); err != nil {
+		t.Fatalf("JUnit() returned invalid XML: %v", err)
+	}
+	if suites.Tests != 2 || suites.Failures != 2 {
+		t.Fatalf("JUnit() returned %d tests and %d failures, want 2 and 2", suites.Tests, suites.Failures)
+	}
+	tc := suites.Suites[0].TestCases[0]
+	if tc.File != "bookinfo.yaml" || tc.Line != 2 || tc.Name != "IST0101 VirtualService reviews.default" {
+		t.Errorf("unexpected test case %+v", tc)
+	}
+	if tc.Failure == nil || !strings.HasPrefix(tc.Failure.Text, "bookinfo.yaml:2: ") {
+		t.Errorf("unexpected failure %+v", tc.Failure)
+	}
+
+	// Messages below the failure level are passing test cases.
+	out, _ = JUnit(diag.Messages{msg.NewDeprecated(nil, "deprecated")}, diag.Error)
+	if strings.Contains(out, "<failure") {
+		t.Errorf("JUnit() reported a failure below the failure level: %s", out)
+	}
+
+	// A passing test case is reported without messages.
+	out, _ = JUnit(nil, diag.Error)
+	if err := xml.Unmarshal([]byte(out), &suites); err != nil || suites.Tests != 1 || suites.Failures != 0 {
+		t.Errorf("JUnit() returned an unexpected report without messages: %s", out)
+	}
+}
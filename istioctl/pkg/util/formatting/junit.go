@@ -0,0 +1,104 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formatting
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"istio.io/istio/galley/pkg/config/analysis/diag"
+)
+
+// The JUnit XML elements used to report the analysis messages, as understood by the common CI
+// test dashboards.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnit returns the messages as a JUnit XML report. Each message is a test case, which fails if the
+// message level is at least the failure level. A passing test case is reported if there is no message.
+func JUnit(messages diag.Messages, failureLevel diag.Level) (string, error) {
+	suite := junitTestSuite{Name: toolName}
+	for i := range messages {
+		m := &messages[i]
+		text := fmt.Sprintf(m.Type.Template(), m.Parameters...)
+		tc := junitTestCase{
+			Name:      m.Type.Code(),
+			ClassName: toolName,
+		}
+		if m.Resource != nil {
+			tc.Name = fmt.Sprintf("%s %s", m.Type.Code(), m.Resource.Origin.FriendlyName())
+			if loc, ok := locate(m); ok {
+				tc.ClassName = loc.File
+				tc.File = loc.File
+				tc.Line = loc.Line
+			}
+		}
+		detail := fmt.Sprintf("%s\n%s/%s", m.String(), diag.DocPrefix, m.Type.Code())
+		if tc.File != "" {
+			detail = fmt.Sprintf("%s:%d: %s", tc.File, tc.Line, detail)
+		}
+		if m.Type.Level().IsWorseThanOrEqualTo(failureLevel) {
+			tc.Failure = &junitFailure{
+				Message: text,
+				Type:    m.Type.Level().String(),
+				Text:    detail,
+			}
+			suite.Failures++
+		} else {
+			tc.SystemOut = detail
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	if len(suite.TestCases) == 0 {
+		suite.TestCases = append(suite.TestCases, junitTestCase{Name: "analysis", ClassName: toolName})
+	}
+	suite.Tests = len(suite.TestCases)
+
+	out, err := xml.MarshalIndent(junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(out), nil
+}
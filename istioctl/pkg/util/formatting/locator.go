@@ -0,0 +1,45 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formatting
+
+import (
+	"istio.io/istio/galley/pkg/config/analysis/diag"
+	"istio.io/istio/pkg/config/resource"
+)
+
+// Location is the location of a resource in a file.
+type Location struct {
+	// File is the path of the file.
+	File string
+	// Line is the 1-based line of the resource in the file, or 0 if it is not known.
+	Line int
+}
+
+// locate returns the location of the resource of a message from the reference of its origin, for the
+// resources read from files. The line of the field the message is about is used if it is known.
+func locate(m *diag.Message) (Location, bool) {
+	if m.Resource == nil || m.Resource.Origin == nil {
+		return Location{}, false
+	}
+	p, ok := m.Resource.Origin.Reference().(*resource.Position)
+	if !ok || p == nil {
+		return Location{}, false
+	}
+	loc := Location{File: p.Filename, Line: p.Line}
+	if m.Line > 0 {
+		loc.Line = m.Line
+	}
+	return loc, true
+}
@@ -0,0 +1,434 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compare
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes/any"
+
+	"istio.io/istio/istioctl/pkg/util/clusters"
+	"istio.io/istio/istioctl/pkg/util/configdump"
+)
+
+// ConfigDump is one side of a config dump diff: a config dump from the Envoy admin config_dump
+// endpoint and, optionally, the endpoints reported by the Envoy admin clusters endpoint.
+type ConfigDump struct {
+	// Name identifies the config dump in the output, for example the pod or file name.
+	Name      string
+	dump      *configdump.Wrapper
+	endpoints *clusters.Wrapper
+}
+
+// NewConfigDump parses the config_dump and the optional clusters?format=json responses of an Envoy.
+func NewConfigDump(name string, dump, endpoints []byte) (*ConfigDump, error) {
+	cd := &ConfigDump{Name: name, dump: &configdump.Wrapper{}}
+	if err := json.Unmarshal(dump, cd.dump); err != nil {
+		return nil, fmt.Errorf("error unmarshalling config dump of %s: %v", name, err)
+	}
+	if endpoints != nil {
+		cd.endpoints = &clusters.Wrapper{}
+		if err := json.Unmarshal(endpoints, cd.endpoints); err != nil {
+			return nil, fmt.Errorf("error unmarshalling clusters of %s: %v", name, err)
+		}
+	}
+	return cd, nil
+}
+
+// Action is the kind of difference of a resource.
+type Action string
+
+const (
+	// Added resources are only in the second config dump.
+	Added Action = "added"
+	// Removed resources are only in the first config dump.
+	Removed Action = "removed"
+	// Changed resources are in both config dumps, with different fields.
+	Changed Action = "changed"
+)
+
+// FieldChange is a field that differs between two versions of a resource. From is nil for
+// added fields and To is nil for removed fields.
+type FieldChange struct {
+	Path string      `json:"path"`
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+// ResourceDiff is a resource that differs between two config dumps.
+type ResourceDiff struct {
+	Name    string        `json:"name"`
+	Action  Action        `json:"action"`
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// DumpDiff holds the differences between two config dumps, per resource type.
+type DumpDiff struct {
+	From      string         `json:"from"`
+	To        string         `json:"to"`
+	Listeners []ResourceDiff `json:"listeners"`
+	Clusters  []ResourceDiff `json:"clusters"`
+	Routes    []ResourceDiff `json:"routes"`
+	// Endpoints is nil unless both config dumps have endpoints. The changes of a cluster are
+	// keyed by endpoint address, with the health status of the endpoint as value.
+	Endpoints []ResourceDiff `json:"endpoints,omitempty"`
+}
+
+// DiffConfigDumps compares the listeners, clusters, routes and endpoints of two config dumps.
+// Resources are matched by name, and the version_info and last_updated fields are ignored.
+func DiffConfigDumps(from, to *ConfigDump) (*DumpDiff, error) {
+	d := &DumpDiff{From: from.Name, To: to.Name}
+	for _, section := range []struct {
+		resources func(*configdump.Wrapper) (map[string]interface{}, error)
+		out       *[]ResourceDiff
+	}{
+		{listenerResources, &d.Listeners},
+		{clusterResources, &d.Clusters},
+		{routeResources, &d.Routes},
+	} {
+		a, err := section.resources(from.dump)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", from.Name, err)
+		}
+		b, err := section.resources(to.dump)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", to.Name, err)
+		}
+		*section.out = diffResources(a, b)
+	}
+	if from.endpoints != nil && to.endpoints != nil {
+		d.Endpoints = diffResources(endpointResources(from.endpoints), endpointResources(to.endpoints))
+		if d.Endpoints == nil {
+			d.Endpoints = []ResourceDiff{}
+		}
+	}
+	return d, nil
+}
+
+// Empty returns true if the config dumps have no differences.
+func (d *DumpDiff) Empty() bool {
+	return len(d.Listeners) == 0 && len(d.Clusters) == 0 && len(d.Routes) == 0 && len(d.Endpoints) == 0
+}
+
+// Print writes the differences to w, one line per resource and field.
+func (d *DumpDiff) Print(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "--- %s\n+++ %s\n", d.From, d.To)
+	sections := []struct {
+		title string
+		diffs []ResourceDiff
+	}{
+		{"Listeners", d.Listeners},
+		{"Clusters", d.Clusters},
+		{"Routes", d.Routes},
+	}
+	if d.Endpoints != nil {
+		sections = append(sections, struct {
+			title string
+			diffs []ResourceDiff
+		}{"Endpoints", d.Endpoints})
+	}
+	for _, section := range sections {
+		if len(section.diffs) == 0 {
+			_, _ = fmt.Fprintf(w, "%s Match\n", section.title)
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "%s:\n", section.title)
+		for _, rd := range section.diffs {
+			switch rd.Action {
+			case Added:
+				_, _ = fmt.Fprintf(w, "+ %s\n", rd.Name)
+			case Removed:
+				_, _ = fmt.Fprintf(w, "- %s\n", rd.Name)
+			default:
+				_, _ = fmt.Fprintf(w, "~ %s\n", rd.Name)
+			}
+			for _, c := range rd.Changes {
+				switch {
+				case c.From == nil:
+					_, _ = fmt.Fprintf(w, "    + %s: %s\n", c.Path, renderValue(c.To))
+				case c.To == nil:
+					_, _ = fmt.Fprintf(w, "    - %s: %s\n", c.Path, renderValue(c.From))
+				default:
+					_, _ = fmt.Fprintf(w, "    ~ %s: %s -> %s\n", c.Path, renderValue(c.From), renderValue(c.To))
+				}
+			}
+		}
+	}
+}
+
+// PrintJSON writes the differences to w as JSON.
+func (d *DumpDiff) PrintJSON(w io.Writer) error {
+	out, err := json.MarshalIndent(d, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(w, string(out))
+	return nil
+}
+
+func renderValue(v interface{}) string {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(out)
+}
+
+func listenerResources(w *configdump.Wrapper) (map[string]interface{}, error) {
+	dump, err := w.GetListenerConfigDump()
+	if err != nil {
+		return nil, err
+	}
+	var resources []*any.Any
+	for _, l := range dump.StaticListeners {
+		resources = append(resources, l.Listener)
+	}
+	for _, l := range dump.DynamicListeners {
+		if l.ActiveState != nil {
+			resources = append(resources, l.ActiveState.Listener)
+		}
+	}
+	return namedResources(resources)
+}
+
+func clusterResources(w *configdump.Wrapper) (map[string]interface{}, error) {
+	dump, err := w.GetClusterConfigDump()
+	if err != nil {
+		return nil, err
+	}
+	var resources []*any.Any
+	for _, c := range dump.StaticClusters {
+		resources = append(resources, c.Cluster)
+	}
+	for _, c := range dump.DynamicActiveClusters {
+		resources = append(resources, c.Cluster)
+	}
+	return namedResources(resources)
+}
+
+func routeResources(w *configdump.Wrapper) (map[string]interface{}, error) {
+	dump, err := w.GetRouteConfigDump()
+	if err != nil {
+		return nil, err
+	}
+	var resources []*any.Any
+	for _, r := range dump.StaticRouteConfigs {
+		resources = append(resources, r.RouteConfig)
+	}
+	for _, r := range dump.DynamicRouteConfigs {
+		resources = append(resources, r.RouteConfig)
+	}
+	return namedResources(resources)
+}
+
+// namedResources converts the resources to generic JSON values, keyed by resource name.
+func namedResources(resources []*any.Any) (map[string]interface{}, error) {
+	jsonm := &jsonpb.Marshaler{OrigName: true}
+	out := make(map[string]interface{}, len(resources))
+	for _, r := range resources {
+		if r == nil {
+			continue
+		}
+		buf := &bytes.Buffer{}
+		if err := jsonm.Marshal(buf, r); err != nil {
+			return nil, err
+		}
+		var value map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &value); err != nil {
+			return nil, err
+		}
+		delete(value, "@type")
+		name, _ := value["name"].(string)
+		out[name] = value
+	}
+	return out, nil
+}
+
+// endpointResources returns the health status of the endpoints of each cluster, keyed by cluster name.
+func endpointResources(w *clusters.Wrapper) map[string]interface{} {
+	out := make(map[string]interface{}, len(w.GetClusterStatuses()))
+	for _, cs := range w.GetClusterStatuses() {
+		hosts := make(map[string]interface{}, len(cs.HostStatuses))
+		for _, hs := range cs.HostStatuses {
+			address := ""
+			if addr := hs.GetAddress().GetSocketAddress(); addr != nil {
+				address = addr.Address + ":" + strconv.Itoa(int(addr.GetPortValue()))
+			} else {
+				address = "unix://" + hs.GetAddress().GetPipe().GetPath()
+			}
+			status := hs.GetHealthStatus().GetEdsHealthStatus().String()
+			if hs.GetHealthStatus().GetFailedOutlierCheck() {
+				status += ",FAILED_OUTLIER_CHECK"
+			}
+			hosts[address] = status
+		}
+		out[cs.Name] = hosts
+	}
+	return out
+}
+
+// diffResources compares two sets of resources keyed by name.
+func diffResources(from, to map[string]interface{}) []ResourceDiff {
+	names := make([]string, 0, len(from)+len(to))
+	for name := range from {
+		names = append(names, name)
+	}
+	for name := range to {
+		if _, f := from[name]; !f {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var out []ResourceDiff
+	for _, name := range names {
+		a, inFrom := from[name]
+		b, inTo := to[name]
+		switch {
+		case !inFrom:
+			out = append(out, ResourceDiff{Name: name, Action: Added})
+		case !inTo:
+			out = append(out, ResourceDiff{Name: name, Action: Removed})
+		default:
+			if changes := diffFields("", a, b); len(changes) > 0 {
+				out = append(out, ResourceDiff{Name: name, Action: Changed, Changes: changes})
+			}
+		}
+	}
+	return out
+}
+
+// volatileFields change on every push, and are ignored when comparing resources.
+var volatileFields = map[string]bool{
+	"version_info": true,
+	"last_updated": true,
+}
+
+// diffFields compares two generic JSON values. Objects are compared by key, and lists of
+// named objects by name, so reordering does not show up as a difference.
+func diffFields(path string, from, to interface{}) []FieldChange {
+	switch a := from.(type) {
+	case map[string]interface{}:
+		b, ok := to.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(a)+len(b))
+		for k := range a {
+			keys = append(keys, k)
+		}
+		for k := range b {
+			if _, f := a[k]; !f {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		var out []FieldChange
+		for _, k := range keys {
+			if volatileFields[k] {
+				continue
+			}
+			out = append(out, diffChild(joinPath(path, k), a, b, k)...)
+		}
+		return out
+	case []interface{}:
+		b, ok := to.([]interface{})
+		if !ok {
+			break
+		}
+		if na, nb := byName(a), byName(b); na != nil && nb != nil {
+			keys := make([]string, 0, len(na)+len(nb))
+			for k := range na {
+				keys = append(keys, k)
+			}
+			for k := range nb {
+				if _, f := na[k]; !f {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			var out []FieldChange
+			for _, k := range keys {
+				out = append(out, diffChild(fmt.Sprintf("%s[%s]", path, k), na, nb, k)...)
+			}
+			return out
+		}
+		var out []FieldChange
+		for i := 0; i < len(a) || i < len(b); i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(a):
+				out = append(out, FieldChange{Path: p, To: b[i]})
+			case i >= len(b):
+				out = append(out, FieldChange{Path: p, From: a[i]})
+			default:
+				out = append(out, diffFields(p, a[i], b[i])...)
+			}
+		}
+		return out
+	}
+	if reflect.DeepEqual(from, to) {
+		return nil
+	}
+	return []FieldChange{{Path: path, From: from, To: to}}
+}
+
+func diffChild(path string, from, to map[string]interface{}, key string) []FieldChange {
+	a, inFrom := from[key]
+	b, inTo := to[key]
+	switch {
+	case !inFrom:
+		return []FieldChange{{Path: path, To: b}}
+	case !inTo:
+		return []FieldChange{{Path: path, From: a}}
+	default:
+		return diffFields(path, a, b)
+	}
+}
+
+// byName indexes a list of objects by their name field, or returns nil if some element has
+// no name or names are not unique.
+func byName(list []interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(list))
+	for _, e := range list {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		name, ok := m["name"].(string)
+		if !ok || name == "" {
+			return nil
+		}
+		if _, f := out[name]; f {
+			return nil
+		}
+		out[name] = e
+	}
+	return out
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
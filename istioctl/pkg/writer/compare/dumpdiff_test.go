@@ -0,0 +1,163 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compare
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffConfigDumps(t *testing.T) {
+	from, err := NewConfigDump("before", loadEnvoyDump(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := NewConfigDump("after", loadDiffEnvoyDump(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	same, err := DiffConfigDumps(from, from)
+	if err != nil {
+		t.Fatalf("DiffConfigDumps() failed: %v", err)
+	}
+	if !same.Empty() {
+		t.Errorf("DiffConfigDumps() of the same dump = %+v, want no differences", same)
+	}
+
+	d, err := DiffConfigDumps(from, to)
+	if err != nil {
+		t.Fatalf("DiffConfigDumps() failed: %v", err)
+	}
+	if d.Empty() || d.Endpoints != nil {
+		t.Fatalf("unexpected diff %+v", d)
+	}
+	wantClusters := []ResourceDiff{{
+		Name:    "outbound|15004||istio-policy.istio-system.svc.cluster.local",
+		Action:  Changed,
+		Changes: []FieldChange{{Path: "circuit_breakers.thresholds[0].max_requests", From: float64(10000)}},
+	}}
+	if !reflect.DeepEqual(d.Clusters, wantClusters) {
+		t.Errorf("Clusters = %+v, want %+v", d.Clusters, wantClusters)
+	}
+	if len(d.Listeners) != 1 || len(d.Listeners[0].Changes) != 1 ||
+		d.Listeners[0].Changes[0].Path != "filter_chains[0].filters[envoy.http_connection_manager].config.http_filters[envoy.router]" {
+		t.Errorf("unexpected listener diff %+v", d.Listeners)
+	}
+	if len(d.Routes) != 1 || d.Routes[0].Name != "15004" || len(d.Routes[0].Changes) != 4 {
+		t.Errorf("unexpected route diff %+v", d.Routes)
+	}
+
+	out := &bytes.Buffer{}
+	d.Print(out)
+	for _, want := range []string{
+		"--- before\n+++ after\n",
+		"~ outbound|15004||istio-policy.istio-system.svc.cluster.local\n    - circuit_breakers.thresholds[0].max_requests: 10000\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Print() output\n%s\ndoes not contain\n%s", out.String(), want)
+		}
+	}
+	out.Reset()
+	if err := d.PrintJSON(out); err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(out.Bytes()) {
+		t.Errorf("PrintJSON() printed invalid JSON %s", out.String())
+	}
+}
+
+func TestDiffConfigDumpsEndpoints(t *testing.T) {
+	from, err := NewConfigDump("before", loadEnvoyDump(), []byte(`{"cluster_statuses": [
+		{"name": "outbound|9080||reviews", "host_statuses": [
+			{"address": {"socket_address": {"address": "10.0.0.1", "port_value": 9080}}, "health_status": {"eds_health_status": "HEALTHY"}},
+			{"address": {"socket_address": {"address": "10.0.0.2", "port_value": 9080}}, "health_status": {"eds_health_status": "HEALTHY"}}]},
+		{"name": "outbound|9080||ratings"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := NewConfigDump("after", loadEnvoyDump(), []byte(`{"cluster_statuses": [
+		{"name": "outbound|9080||reviews", "host_statuses": [
+			{"address": {"socket_address": {"address": "10.0.0.1", "port_value": 9080}}, "health_status": {"eds_health_status": "UNHEALTHY"}},
+			{"address": {"socket_address": {"address": "10.0.0.3", "port_value": 9080}}, "health_status": {"eds_health_status": "HEALTHY"}}]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := DiffConfigDumps(from, to)
+	if err != nil {
+		t.Fatalf("DiffConfigDumps() failed: %v", err)
+	}
+	want := []ResourceDiff{
+		{Name: "outbound|9080||ratings", Action: Removed},
+		{Name: "outbound|9080||reviews", Action: Changed, Changes: []FieldChange{
+			{Path: "10.0.0.1:9080", From: "HEALTHY", To: "UNHEALTHY"},
+			{Path: "10.0.0.2:9080", From: "HEALTHY"},
+			{Path: "10.0.0.3:9080", To: "HEALTHY"},
+		}},
+	}
+	if !reflect.DeepEqual(d.Endpoints, want) {
+		t.Errorf("Endpoints = %+v, want %+v", d.Endpoints, want)
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		want     []FieldChange
+	}{
+		{
+			name: "ignores volatile fields",
+			from: `{"a": 1, "version_info": "1", "last_updated": "x"}`,
+			to:   `{"a": 1, "version_info": "2"}`,
+		},
+		{
+			name: "matches named lists by name",
+			from: `{"l": [{"name": "x", "v": 1}, {"name": "y", "v": 2}]}`,
+			to:   `{"l": [{"name": "y", "v": 3}, {"name": "x", "v": 1}]}`,
+			want: []FieldChange{{Path: "l[y].v", From: float64(2), To: float64(3)}},
+		},
+		{
+			name: "compares other lists by index",
+			from: `{"l": ["a", "b"]}`,
+			to:   `{"l": ["a", "c", "d"]}`,
+			want: []FieldChange{{Path: "l[1]", From: "b", To: "c"}, {Path: "l[2]", To: "d"}},
+		},
+		{
+			name: "reports type changes as a whole",
+			from: `{"a": {"b": 1}}`,
+			to:   `{"a": "b"}`,
+			want: []FieldChange{{Path: "a", From: map[string]interface{}{"b": float64(1)}, To: "b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var from, to interface{}
+			if err := json.Unmarshal([]byte(tt.from), &from); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.to), &to); err != nil {
+				t.Fatal(err)
+			}
+			if got := diffFields("", from, to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffFields() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	memquota "istio.io/istio/mixer/adapter/memquota"
 	noop "istio.io/istio/mixer/adapter/noop"
 	opa "istio.io/istio/mixer/adapter/opa"
+	otlp "istio.io/istio/mixer/adapter/otlp"
 	prometheus "istio.io/istio/mixer/adapter/prometheus"
 	redisquota "istio.io/istio/mixer/adapter/redisquota"
 	solarwinds "istio.io/istio/mixer/adapter/solarwinds"
@@ -52,6 +53,7 @@ func Inventory() []adptr.InfoFn {
 		memquota.GetInfo,
 		noop.GetInfo,
 		opa.GetInfo,
+		otlp.GetInfo,
 		prometheus.GetInfo,
 		redisquota.GetInfo,
 		solarwinds.GetInfo,
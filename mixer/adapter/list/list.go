@@ -46,6 +46,7 @@ import (
 type (
 	handler struct {
 		log           adapter.Logger
+		env           adapter.Env
 		closing       chan bool
 		refreshTicker *time.Ticker
 		purgeTimer    *time.Timer
@@ -231,12 +232,18 @@ func (h *handler) fetchList() {
 	h.log.Infof("Installing updated list with %d entries", l.numEntries())
 
 	h.lock.Lock()
+	replaced := h.list != nil
 	h.list = l
 	h.lastFetchError = nil
 	h.lock.Unlock()
 
 	h.latestSHA = sha
 	h.resetPurgeTimer()
+
+	if replaced {
+		// drop the check results of the previous list from the check caches
+		h.env.InvalidateCheckCache(invalidationKey(h.config))
+	}
 }
 
 func (h *handler) resetPurgeTimer() {
@@ -263,6 +270,8 @@ func (h *handler) purgeList() {
 	h.lock.Lock()
 	h.list = nil
 	h.lock.Unlock()
+
+	h.env.InvalidateCheckCache(invalidationKey(h.config))
 }
 
 func (h *handler) hasData() (bool, error) {
@@ -276,12 +285,18 @@ func (h *handler) hasData() (bool, error) {
 
 func getCheckResult(config config.Params, code rpc.Code, msg string) adapter.CheckResult {
 	return adapter.CheckResult{
-		Status:        status.WithMessage(code, msg),
-		ValidDuration: config.CachingInterval,
-		ValidUseCount: config.CachingUseCount,
+		Status:           status.WithMessage(code, msg),
+		ValidDuration:    config.CachingInterval,
+		ValidUseCount:    config.CachingUseCount,
+		InvalidationKeys: []string{invalidationKey(config)},
 	}
 }
 
+// invalidationKey returns the check cache invalidation key of the results of the list.
+func invalidationKey(config config.Params) string {
+	return "listchecker/" + config.ProviderUrl
+}
+
 ///////////////// Bootstrap ///////////////
 
 // GetInfo returns the Info associated with this adapter implementation.
@@ -356,6 +371,7 @@ func (b *builder) Build(context context.Context, env adapter.Env) (adapter.Handl
 
 	h := &handler{
 		log:     env.Logger(),
+		env:     env,
 		closing: make(chan bool),
 		config:  *ac,
 		readAll: ioutil.ReadAll,
@@ -20,6 +20,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strconv"
 	"sync/atomic"
 	"testing"
@@ -537,6 +538,64 @@ func TestRefreshAndPurge(t *testing.T) {
 	}
 }
 
+func TestCheckCacheInvalidation(t *testing.T) {
+	var listToServe atomic.Value
+	listToServe.Store("ABC")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write([]byte(listToServe.Load().(string))); err != nil {
+			t.Errorf("w.Write failed: %v", err)
+		}
+	}))
+	defer ts.Close()
+
+	cfg := config.Params{
+		ProviderUrl:     ts.URL,
+		RefreshInterval: time.Hour,
+		Ttl:             time.Hour,
+		EntryType:       config.STRINGS,
+	}
+
+	env := test.NewEnv(t)
+	b := GetInfo().NewBuilder().(*builder)
+	b.SetAdapterConfig(&cfg)
+	hh, err := b.Build(context.Background(), env)
+	if err != nil {
+		t.Fatalf("Got error %v, expecting success", err)
+	}
+	h := hh.(*handler)
+
+	key := "listchecker/" + ts.URL
+	result, err := h.HandleListEntry(context.Background(), &listentry.Instance{Value: "ABC"})
+	if err != nil {
+		t.Fatalf("Got error %v, expecting success", err)
+	}
+	if !reflect.DeepEqual(result.InvalidationKeys, []string{key}) {
+		t.Errorf("Got invalidation keys %v, expected [%s]", result.InvalidationKeys, key)
+	}
+
+	// the initial and unchanged lists don't invalidate anything
+	h.fetchList()
+	if got := env.GetInvalidations(); len(got) != 0 {
+		t.Errorf("Got invalidations %v, expected none", got)
+	}
+
+	listToServe.Store("DEF")
+	h.fetchList()
+	if got := env.GetInvalidations(); !reflect.DeepEqual(got, []string{key}) {
+		t.Errorf("Got invalidations %v, expected [%s]", got, key)
+	}
+
+	h.purgeList()
+	if got := env.GetInvalidations(); !reflect.DeepEqual(got, []string{key, key}) {
+		t.Errorf("Got invalidations %v, expected [%s %s]", got, key, key)
+	}
+
+	if err := h.Close(); err != nil {
+		t.Errorf("Unable to close adapter: %v", err)
+	}
+}
+
 func TestValidateConfig(t *testing.T) {
 	cases := []struct {
 		cfg   config.Params
@@ -24,6 +24,7 @@ import (
 	math "math"
 	math_bits "math/bits"
 	reflect "reflect"
+	strconv "strconv"
 	strings "strings"
 	time "time"
 )
@@ -40,6 +41,38 @@ var _ = time.Kitchen
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// Algorithms for rate-limiting:
+type Params_QuotaAlgorithm int32
+
+const (
+	// `ROLLING_WINDOW` The rolling window algorithm allows `max_amount` within `valid_duration`, with a
+	// precision of 100ms.
+	ROLLING_WINDOW Params_QuotaAlgorithm = 0
+	// `TOKEN_BUCKET` The token bucket algorithm refills `max_amount` tokens every `valid_duration`, and allows
+	// bursts of up to `burst_size` tokens.
+	TOKEN_BUCKET Params_QuotaAlgorithm = 1
+	// `SLIDING_LOG` The sliding log algorithm records every allocation, and allows exactly `max_amount` within
+	// any `valid_duration` interval. Its precision comes at the cost of memory per allocation, so it is best
+	// suited to low volume quotas.
+	SLIDING_LOG Params_QuotaAlgorithm = 2
+)
+
+var Params_QuotaAlgorithm_name = map[int32]string{
+	0: "ROLLING_WINDOW",
+	1: "TOKEN_BUCKET",
+	2: "SLIDING_LOG",
+}
+
+var Params_QuotaAlgorithm_value = map[string]int32{
+	"ROLLING_WINDOW": 0,
+	"TOKEN_BUCKET":   1,
+	"SLIDING_LOG":    2,
+}
+
+func (Params_QuotaAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_67b4efe0be29bdbf, []int{0, 0}
+}
+
 // Configuration format for the `memquota` adapter.
 type Params struct {
 	// The set of known quotas.
@@ -89,6 +122,16 @@ type Params_Quota struct {
 	// Overrides associated with this quota.
 	// The first matching override is applied.
 	Overrides []Params_Override `protobuf:"bytes,4,rep,name=overrides,proto3" json:"overrides"`
+	// Rate limiting algorithm, used if `valid_duration` is not zero. The default value is `ROLLING_WINDOW`.
+	RateLimitAlgorithm Params_QuotaAlgorithm `protobuf:"varint,5,opt,name=rate_limit_algorithm,json=rateLimitAlgorithm,proto3,enum=adapter.memquota.config.Params_QuotaAlgorithm" json:"rate_limit_algorithm,omitempty"`
+	// The maximum number of tokens the bucket holds, for the `TOKEN_BUCKET` algorithm.
+	// If 0, `max_amount` is used.
+	BurstSize int64 `protobuf:"varint,6,opt,name=burst_size,json=burstSize,proto3" json:"burst_size,omitempty"`
+	// Enclosing limits of a hierarchical quota, from the innermost to the outermost, e.g. a per-tenant
+	// and a global limit around a per-user quota. A request is only granted if the quota and every
+	// level have capacity, in which case it is charged to all of them.
+	// The levels use the `valid_duration` and `rate_limit_algorithm` of the quota.
+	Levels []Params_Level `protobuf:"bytes,7,rep,name=levels,proto3" json:"levels"`
 }
 
 func (m *Params_Quota) Reset()      { *m = Params_Quota{} }
@@ -147,6 +190,98 @@ func (m *Params_Quota) GetOverrides() []Params_Override {
 	return nil
 }
 
+func (m *Params_Quota) GetRateLimitAlgorithm() Params_QuotaAlgorithm {
+	if m != nil {
+		return m.RateLimitAlgorithm
+	}
+	return ROLLING_WINDOW
+}
+
+func (m *Params_Quota) GetBurstSize() int64 {
+	if m != nil {
+		return m.BurstSize
+	}
+	return 0
+}
+
+func (m *Params_Quota) GetLevels() []Params_Level {
+	if m != nil {
+		return m.Levels
+	}
+	return nil
+}
+
+// Defines an enclosing limit of a hierarchical quota. The instances of the quota are grouped
+// by the given dimensions, and each group shares the limit of the level.
+type Params_Level struct {
+	// The name of the level, which must be unique within the quota.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The instance dimensions that identify a group of the level. If empty, all the instances
+	// of the quota share the limit of the level.
+	Dimensions []string `protobuf:"bytes,2,rep,name=dimensions,proto3" json:"dimensions,omitempty"`
+	// The upper limit for each group of the level.
+	MaxAmount int64 `protobuf:"varint,3,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	// The burst size for each group of the level, used by the `TOKEN_BUCKET` algorithm.
+	// If 0, `max_amount` is used.
+	BurstSize int64 `protobuf:"varint,4,opt,name=burst_size,json=burstSize,proto3" json:"burst_size,omitempty"`
+}
+
+func (m *Params_Level) Reset()      { *m = Params_Level{} }
+func (*Params_Level) ProtoMessage() {}
+func (*Params_Level) Descriptor() ([]byte, []int) {
+	return fileDescriptor_67b4efe0be29bdbf, []int{0, 1}
+}
+func (m *Params_Level) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params_Level) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *Params_Level) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params_Level.Merge(m, src)
+}
+func (m *Params_Level) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params_Level) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params_Level.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params_Level proto.InternalMessageInfo
+
+func (m *Params_Level) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Params_Level) GetDimensions() []string {
+	if m != nil {
+		return m.Dimensions
+	}
+	return nil
+}
+
+func (m *Params_Level) GetMaxAmount() int64 {
+	if m != nil {
+		return m.MaxAmount
+	}
+	return 0
+}
+
+func (m *Params_Level) GetBurstSize() int64 {
+	if m != nil {
+		return m.BurstSize
+	}
+	return 0
+}
+
 // Defines an override value for a quota. If no override matches
 // a particular quota request, the default for the quota is used.
 type Params_Override struct {
@@ -159,12 +294,15 @@ type Params_Override struct {
 	// automatically released. This is only meaningful for rate limit
 	// quotas, otherwise the value must be zero.
 	ValidDuration time.Duration `protobuf:"bytes,3,opt,name=valid_duration,json=validDuration,proto3,stdduration" json:"valid_duration"`
+	// The burst size for this quota override, used by the `TOKEN_BUCKET` algorithm.
+	// If 0, `max_amount` of the override is used.
+	BurstSize int64 `protobuf:"varint,4,opt,name=burst_size,json=burstSize,proto3" json:"burst_size,omitempty"`
 }
 
 func (m *Params_Override) Reset()      { *m = Params_Override{} }
 func (*Params_Override) ProtoMessage() {}
 func (*Params_Override) Descriptor() ([]byte, []int) {
-	return fileDescriptor_67b4efe0be29bdbf, []int{0, 1}
+	return fileDescriptor_67b4efe0be29bdbf, []int{0, 2}
 }
 func (m *Params_Override) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -210,9 +348,18 @@ func (m *Params_Override) GetValidDuration() time.Duration {
 	return 0
 }
 
+func (m *Params_Override) GetBurstSize() int64 {
+	if m != nil {
+		return m.BurstSize
+	}
+	return 0
+}
+
 func init() {
+	proto.RegisterEnum("adapter.memquota.config.Params_QuotaAlgorithm", Params_QuotaAlgorithm_name, Params_QuotaAlgorithm_value)
 	proto.RegisterType((*Params)(nil), "adapter.memquota.config.Params")
 	proto.RegisterType((*Params_Quota)(nil), "adapter.memquota.config.Params.Quota")
+	proto.RegisterType((*Params_Level)(nil), "adapter.memquota.config.Params.Level")
 	proto.RegisterType((*Params_Override)(nil), "adapter.memquota.config.Params.Override")
 	proto.RegisterMapType((map[string]string)(nil), "adapter.memquota.config.Params.Override.DimensionsEntry")
 }
@@ -222,38 +369,56 @@ func init() {
 }
 
 var fileDescriptor_67b4efe0be29bdbf = []byte{
-	// 460 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x91, 0x3f, 0x8b, 0x13, 0x41,
-	0x18, 0xc6, 0x67, 0xf2, 0x8f, 0xcb, 0x7b, 0xf8, 0x87, 0xe1, 0xc0, 0x75, 0xc1, 0x37, 0x41, 0x10,
-	0x82, 0xc5, 0x2c, 0x9c, 0xcd, 0x71, 0x60, 0x61, 0x8c, 0x8d, 0x08, 0xea, 0x56, 0x62, 0x13, 0xe6,
-	0x6e, 0xe7, 0x96, 0xc1, 0xcc, 0x4c, 0xdc, 0xec, 0x86, 0x5c, 0x67, 0x69, 0x69, 0x61, 0x61, 0x69,
-	0x61, 0xe1, 0x47, 0x49, 0x99, 0x32, 0x58, 0xa8, 0xd9, 0x34, 0x96, 0xf7, 0x11, 0x64, 0x67, 0x77,
-	0xbd, 0x43, 0x10, 0xaf, 0xba, 0x6a, 0xdf, 0x9d, 0x79, 0x9e, 0xe7, 0xfd, 0xf1, 0x0c, 0xdc, 0xd7,
-	0x6a, 0x21, 0x93, 0x40, 0x44, 0x62, 0x9a, 0xca, 0x24, 0xd0, 0x52, 0xbf, 0xcd, 0x6c, 0x2a, 0x82,
-	0x63, 0x6b, 0x4e, 0x54, 0x5c, 0x7d, 0xf8, 0x34, 0xb1, 0xa9, 0x65, 0xb7, 0x2a, 0x15, 0xaf, 0x55,
-	0xbc, 0xbc, 0xf6, 0x31, 0xb6, 0x36, 0x9e, 0xc8, 0xc0, 0xc9, 0x8e, 0xb2, 0x93, 0x20, 0xca, 0x12,
-	0x91, 0x2a, 0x6b, 0x4a, 0xa3, 0xbf, 0x17, 0xdb, 0xd8, 0xba, 0x31, 0x28, 0xa6, 0xf2, 0xf4, 0xee,
-	0x97, 0x36, 0x74, 0x5e, 0x88, 0x44, 0xe8, 0x19, 0x7b, 0x0c, 0x1d, 0x17, 0x38, 0xf3, 0x68, 0xbf,
-	0x39, 0xd8, 0xdd, 0xbf, 0xc7, 0xff, 0xb1, 0x8a, 0x97, 0x06, 0xfe, 0xb2, 0x38, 0x1b, 0xb6, 0x96,
-	0xdf, 0x7b, 0x24, 0xac, 0xac, 0x4c, 0x80, 0xaf, 0x95, 0x19, 0x47, 0x32, 0xca, 0xa6, 0x13, 0x75,
-	0xec, 0x00, 0xc6, 0x35, 0x89, 0xd7, 0xe8, 0xd3, 0xc1, 0xee, 0xfe, 0x6d, 0x5e, 0xa2, 0xf2, 0x1a,
-	0x95, 0x8f, 0x2a, 0xc1, 0x70, 0xa7, 0x08, 0xfb, 0xf4, 0xa3, 0x47, 0x43, 0x4f, 0x2b, 0x33, 0xba,
-	0x98, 0x52, 0x6b, 0xfc, 0x6f, 0x14, 0xda, 0x6e, 0x35, 0x63, 0xd0, 0x32, 0x42, 0x4b, 0x8f, 0xf6,
-	0xe9, 0xa0, 0x1b, 0xba, 0x99, 0xdd, 0x01, 0xd0, 0x62, 0x31, 0x16, 0xda, 0x66, 0x26, 0x75, 0x0b,
-	0x9b, 0x61, 0x57, 0x8b, 0xc5, 0x23, 0x77, 0xc0, 0x9e, 0xc2, 0xf5, 0xb9, 0x98, 0xa8, 0xe8, 0x9c,
-	0xa9, 0x79, 0x79, 0xa6, 0x6b, 0xce, 0x5a, 0x5f, 0xb0, 0x67, 0xd0, 0xb5, 0x73, 0x99, 0x24, 0x2a,
-	0x92, 0x33, 0xaf, 0xe5, 0x3a, 0x1b, 0xfc, 0xaf, 0xb3, 0xe7, 0x95, 0xa1, 0xaa, 0xed, 0x3c, 0xe0,
-	0xb0, 0xf5, 0xfe, 0x73, 0x8f, 0xfa, 0x1f, 0x1b, 0xb0, 0x53, 0x6b, 0xd8, 0x2b, 0x80, 0x48, 0x69,
-	0x69, 0x66, 0xca, 0x9a, 0xfa, 0x55, 0x0e, 0x2e, 0xbb, 0x81, 0x8f, 0xfe, 0x58, 0x9f, 0x98, 0x34,
-	0x39, 0x0d, 0x2f, 0x64, 0x5d, 0x61, 0x4b, 0xfe, 0x43, 0xb8, 0xf1, 0x17, 0x09, 0xbb, 0x09, 0xcd,
-	0x37, 0xf2, 0xb4, 0x7a, 0xb6, 0x62, 0x64, 0x7b, 0xd0, 0x9e, 0x8b, 0x49, 0x26, 0x1d, 0x4a, 0x37,
-	0x2c, 0x7f, 0x0e, 0x1b, 0x07, 0xb4, 0xac, 0x65, 0x38, 0x5a, 0x6e, 0x90, 0xac, 0x36, 0x48, 0xd6,
-	0x1b, 0x24, 0x67, 0x1b, 0x24, 0xef, 0x72, 0xa4, 0x5f, 0x73, 0x24, 0xcb, 0x1c, 0xe9, 0x2a, 0x47,
-	0xba, 0xce, 0x91, 0xfe, 0xcc, 0x91, 0xfe, 0xca, 0x91, 0x9c, 0xe5, 0x48, 0x3f, 0x6c, 0x91, 0xac,
-	0xb6, 0x48, 0xd6, 0x5b, 0x24, 0xaf, 0x3b, 0x65, 0x43, 0x47, 0x1d, 0x87, 0xfd, 0xe0, 0x77, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x70, 0x63, 0xb6, 0xfd, 0x70, 0x03, 0x00, 0x00,
+	// 626 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x52, 0x4f, 0x4f, 0xd4, 0x4e,
+	0x18, 0xee, 0x6c, 0x77, 0xf7, 0xc7, 0x0e, 0x3f, 0x97, 0xcd, 0x84, 0xc4, 0xba, 0x89, 0xc3, 0x86,
+	0xc4, 0x64, 0xe3, 0xa1, 0x4d, 0xf0, 0x42, 0x48, 0x3c, 0xb0, 0x94, 0x10, 0xa4, 0x61, 0xb5, 0x60,
+	0x30, 0x5e, 0xea, 0x40, 0x87, 0x3a, 0xb1, 0xd3, 0xc1, 0xfe, 0xd9, 0x00, 0x17, 0x3d, 0x7a, 0xf4,
+	0xe8, 0x91, 0xa3, 0x77, 0xbf, 0x04, 0x47, 0x12, 0x2f, 0x9c, 0xd4, 0x2d, 0x17, 0x8f, 0x7c, 0x04,
+	0xd3, 0x69, 0x0b, 0xec, 0x46, 0x71, 0x2f, 0x9e, 0xfa, 0xf6, 0x9d, 0xe7, 0x79, 0xdf, 0x67, 0x9e,
+	0x67, 0xe0, 0x43, 0xce, 0x0e, 0x69, 0x68, 0x10, 0x97, 0x1c, 0xc4, 0x34, 0x34, 0x38, 0xe5, 0x6f,
+	0x13, 0x11, 0x13, 0x63, 0x4f, 0x04, 0xfb, 0xcc, 0x2b, 0x3e, 0xfa, 0x41, 0x28, 0x62, 0x81, 0xee,
+	0x16, 0x28, 0xbd, 0x44, 0xe9, 0xf9, 0x71, 0x1b, 0x7b, 0x42, 0x78, 0x3e, 0x35, 0x24, 0x6c, 0x37,
+	0xd9, 0x37, 0xdc, 0x24, 0x24, 0x31, 0x13, 0x41, 0x4e, 0x6c, 0xcf, 0x7a, 0xc2, 0x13, 0xb2, 0x34,
+	0xb2, 0x2a, 0xef, 0xce, 0x7f, 0x9d, 0x82, 0xf5, 0xa7, 0x24, 0x24, 0x3c, 0x42, 0x2b, 0xb0, 0x2e,
+	0x07, 0x46, 0x1a, 0xe8, 0xa8, 0xdd, 0xe9, 0x85, 0x07, 0xfa, 0x1f, 0x56, 0xe9, 0x39, 0x41, 0x7f,
+	0x96, 0xf5, 0x7a, 0xd5, 0xd3, 0x6f, 0x73, 0x8a, 0x5d, 0x50, 0x11, 0x81, 0x6d, 0xce, 0x02, 0xc7,
+	0xa5, 0x6e, 0x72, 0xe0, 0xb3, 0x3d, 0x29, 0xc0, 0x29, 0x95, 0x68, 0x95, 0x0e, 0xe8, 0x4e, 0x2f,
+	0xdc, 0xd3, 0x73, 0xa9, 0x7a, 0x29, 0x55, 0x37, 0x0b, 0x40, 0x6f, 0x2a, 0x1b, 0xf6, 0xe9, 0xfb,
+	0x1c, 0xb0, 0x35, 0xce, 0x02, 0xf3, 0xe6, 0x94, 0x12, 0xd3, 0x3e, 0x51, 0x61, 0x4d, 0xae, 0x46,
+	0x08, 0x56, 0x03, 0xc2, 0xa9, 0x06, 0x3a, 0xa0, 0xdb, 0xb0, 0x65, 0x8d, 0xee, 0x43, 0xc8, 0xc9,
+	0xa1, 0x43, 0xb8, 0x48, 0x82, 0x58, 0x2e, 0x54, 0xed, 0x06, 0x27, 0x87, 0xcb, 0xb2, 0x81, 0x9e,
+	0xc0, 0xe6, 0x80, 0xf8, 0xcc, 0xbd, 0xd6, 0xa4, 0x4e, 0xae, 0xe9, 0x8e, 0xa4, 0x96, 0x07, 0xc8,
+	0x82, 0x0d, 0x31, 0xa0, 0x61, 0xc8, 0x5c, 0x1a, 0x69, 0x55, 0xe9, 0x59, 0xf7, 0x6f, 0x9e, 0xf5,
+	0x0b, 0x42, 0x61, 0xdb, 0xf5, 0x00, 0xf4, 0x0a, 0xce, 0x86, 0x24, 0xa6, 0x8e, 0xcf, 0x38, 0x8b,
+	0x1d, 0xe2, 0x7b, 0x22, 0x64, 0xf1, 0x6b, 0xae, 0xd5, 0x3a, 0xa0, 0xdb, 0x5c, 0xd0, 0x27, 0x0a,
+	0x63, 0xb9, 0x64, 0xd9, 0x28, 0x9b, 0x65, 0x65, 0xa3, 0xae, 0x7a, 0x99, 0x35, 0xbb, 0x49, 0x18,
+	0xc5, 0x4e, 0xc4, 0x8e, 0xa9, 0x56, 0xcf, 0xad, 0x91, 0x9d, 0x2d, 0x76, 0x4c, 0xb3, 0xfc, 0x7d,
+	0x3a, 0xa0, 0x7e, 0xa4, 0xfd, 0x37, 0x59, 0xfe, 0x56, 0x86, 0x2e, 0xf3, 0xcf, 0xa9, 0x4b, 0xd5,
+	0x0f, 0x27, 0x73, 0xa0, 0xfd, 0x0e, 0xd6, 0xe4, 0xe1, 0x6f, 0x13, 0xc2, 0x10, 0xba, 0x8c, 0xd3,
+	0x20, 0x62, 0x22, 0x88, 0xb4, 0x4a, 0x47, 0xed, 0x36, 0xec, 0x1b, 0x9d, 0xb1, 0x04, 0xd5, 0xf1,
+	0x04, 0x47, 0x6f, 0x51, 0x1d, 0xbb, 0x45, 0x21, 0xe0, 0x4b, 0x05, 0x4e, 0x95, 0x56, 0xa3, 0x17,
+	0x23, 0x0b, 0xf3, 0xc7, 0xbd, 0x38, 0x69, 0x50, 0xba, 0x79, 0x45, 0x5d, 0x0d, 0xe2, 0xf0, 0xe8,
+	0x16, 0xa9, 0xff, 0xf4, 0xb1, 0xdd, 0x7e, 0xed, 0xf6, 0x63, 0x38, 0x33, 0x26, 0x14, 0xb5, 0xa0,
+	0xfa, 0x86, 0x1e, 0x15, 0xd6, 0x67, 0x25, 0x9a, 0x85, 0xb5, 0x01, 0xf1, 0x13, 0x2a, 0x95, 0x36,
+	0xec, 0xfc, 0x67, 0xa9, 0xb2, 0x08, 0x72, 0xd7, 0xe6, 0xd7, 0x60, 0x73, 0xf4, 0x19, 0x21, 0x04,
+	0x9b, 0x76, 0xdf, 0xb2, 0xd6, 0x37, 0xd7, 0x9c, 0x9d, 0xf5, 0x4d, 0xb3, 0xbf, 0xd3, 0x52, 0x50,
+	0x0b, 0xfe, 0xbf, 0xdd, 0xdf, 0x58, 0xdd, 0x74, 0x7a, 0xcf, 0x57, 0x36, 0x56, 0xb7, 0x5b, 0x00,
+	0xcd, 0xc0, 0xe9, 0x2d, 0x6b, 0xdd, 0xcc, 0x50, 0x56, 0x7f, 0xad, 0x55, 0xe9, 0x99, 0xa7, 0x43,
+	0xac, 0x9c, 0x0d, 0xb1, 0x72, 0x3e, 0xc4, 0xca, 0xe5, 0x10, 0x2b, 0xef, 0x53, 0x0c, 0x3e, 0xa7,
+	0x58, 0x39, 0x4d, 0x31, 0x38, 0x4b, 0x31, 0x38, 0x4f, 0x31, 0xf8, 0x91, 0x62, 0xf0, 0x33, 0xc5,
+	0xca, 0x65, 0x8a, 0xc1, 0xc7, 0x0b, 0xac, 0x9c, 0x5d, 0x60, 0xe5, 0xfc, 0x02, 0x2b, 0x2f, 0xeb,
+	0x79, 0x12, 0xbb, 0x75, 0x69, 0xcf, 0xa3, 0x5f, 0x03, 0x00, 0x87, 0x2f, 0x62, 0x0b, 0x1f, 0x05,
+	0x00, 0x00,
 }
 
+func (x Params_QuotaAlgorithm) String() string {
+	s, ok := Params_QuotaAlgorithm_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
 func (m *Params) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -319,6 +484,30 @@ func (m *Params_Quota) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Levels) > 0 {
+		for iNdEx := len(m.Levels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Levels[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintConfig(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.BurstSize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.BurstSize))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.RateLimitAlgorithm != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.RateLimitAlgorithm))
+		i--
+		dAtA[i] = 0x28
+	}
 	if len(m.Overrides) > 0 {
 		for iNdEx := len(m.Overrides) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -356,6 +545,55 @@ func (m *Params_Quota) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Params_Level) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params_Level) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params_Level) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BurstSize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.BurstSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MaxAmount != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxAmount))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Dimensions) > 0 {
+		for iNdEx := len(m.Dimensions) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Dimensions[iNdEx])
+			copy(dAtA[i:], m.Dimensions[iNdEx])
+			i = encodeVarintConfig(dAtA, i, uint64(len(m.Dimensions[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Params_Override) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -376,6 +614,11 @@ func (m *Params_Override) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BurstSize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.BurstSize))
+		i--
+		dAtA[i] = 0x20
+	}
 	n3, err3 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.ValidDuration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.ValidDuration):])
 	if err3 != nil {
 		return 0, err3
@@ -465,6 +708,43 @@ func (m *Params_Quota) Size() (n int) {
 			n += 1 + l + sovConfig(uint64(l))
 		}
 	}
+	if m.RateLimitAlgorithm != 0 {
+		n += 1 + sovConfig(uint64(m.RateLimitAlgorithm))
+	}
+	if m.BurstSize != 0 {
+		n += 1 + sovConfig(uint64(m.BurstSize))
+	}
+	if len(m.Levels) > 0 {
+		for _, e := range m.Levels {
+			l = e.Size()
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Params_Level) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if len(m.Dimensions) > 0 {
+		for _, s := range m.Dimensions {
+			l = len(s)
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
+	if m.MaxAmount != 0 {
+		n += 1 + sovConfig(uint64(m.MaxAmount))
+	}
+	if m.BurstSize != 0 {
+		n += 1 + sovConfig(uint64(m.BurstSize))
+	}
 	return n
 }
 
@@ -487,6 +767,9 @@ func (m *Params_Override) Size() (n int) {
 	}
 	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.ValidDuration)
 	n += 1 + l + sovConfig(uint64(l))
+	if m.BurstSize != 0 {
+		n += 1 + sovConfig(uint64(m.BurstSize))
+	}
 	return n
 }
 
@@ -521,11 +804,32 @@ func (this *Params_Quota) String() string {
 		repeatedStringForOverrides += fmt.Sprintf("%v", f) + ","
 	}
 	repeatedStringForOverrides += "}"
+	repeatedStringForLevels := "[]Params_Level{"
+	for _, f := range this.Levels {
+		repeatedStringForLevels += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForLevels += "}"
 	s := strings.Join([]string{`&Params_Quota{`,
 		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
 		`MaxAmount:` + fmt.Sprintf("%v", this.MaxAmount) + `,`,
 		`ValidDuration:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ValidDuration), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
 		`Overrides:` + repeatedStringForOverrides + `,`,
+		`RateLimitAlgorithm:` + fmt.Sprintf("%v", this.RateLimitAlgorithm) + `,`,
+		`BurstSize:` + fmt.Sprintf("%v", this.BurstSize) + `,`,
+		`Levels:` + repeatedStringForLevels + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Params_Level) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Params_Level{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Dimensions:` + fmt.Sprintf("%v", this.Dimensions) + `,`,
+		`MaxAmount:` + fmt.Sprintf("%v", this.MaxAmount) + `,`,
+		`BurstSize:` + fmt.Sprintf("%v", this.BurstSize) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -548,6 +852,7 @@ func (this *Params_Override) String() string {
 		`Dimensions:` + mapStringForDimensions + `,`,
 		`MaxAmount:` + fmt.Sprintf("%v", this.MaxAmount) + `,`,
 		`ValidDuration:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ValidDuration), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`BurstSize:` + fmt.Sprintf("%v", this.BurstSize) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -827,6 +1132,233 @@ func (m *Params_Quota) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RateLimitAlgorithm", wireType)
+			}
+			m.RateLimitAlgorithm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RateLimitAlgorithm |= Params_QuotaAlgorithm(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BurstSize", wireType)
+			}
+			m.BurstSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BurstSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Levels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Levels = append(m.Levels, Params_Level{})
+			if err := m.Levels[len(m.Levels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Params_Level) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Level: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Level: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dimensions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dimensions = append(m.Dimensions, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAmount", wireType)
+			}
+			m.MaxAmount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAmount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BurstSize", wireType)
+			}
+			m.BurstSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BurstSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -1059,6 +1591,25 @@ func (m *Params_Override) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BurstSize", wireType)
+			}
+			m.BurstSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BurstSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -0,0 +1,72 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memquota
+
+import (
+	"time"
+
+	"istio.io/istio/mixer/adapter/memquota/config"
+	"istio.io/istio/mixer/template/quota"
+)
+
+// bound is a limit enforced on a request, along with the key of the counter it is enforced on.
+type bound struct {
+	key   string
+	limit Limit
+}
+
+// levelLimit is the limit of a level of a hierarchical quota, which uses the valid duration of the quota.
+type levelLimit struct {
+	*config.Params_Level
+	validDuration time.Duration
+}
+
+var _ Limit = levelLimit{}
+
+func (l levelLimit) GetValidDuration() time.Duration {
+	return l.validDuration
+}
+
+// bounds returns the limits enforced on a request: the limit of the quota itself, tracked with the
+// given key, followed by the limits of the enclosing levels of a hierarchical quota.
+func (h *handler) bounds(key string, instance *quota.Instance, q Limit) []bound {
+	levels := h.limits[instance.Name].Levels
+
+	bounds := make([]bound, 0, 1+len(levels))
+	bounds = append(bounds, bound{key: key, limit: q})
+
+	for idx := range levels {
+		l := &levels[idx]
+		bounds = append(bounds, bound{
+			key:   levelKey(instance, l),
+			limit: levelLimit{Params_Level: l, validDuration: q.GetValidDuration()},
+		})
+	}
+
+	return bounds
+}
+
+// levelKey returns the key of the group of the level the instance belongs to.
+func levelKey(instance *quota.Instance, l *config.Params_Level) string {
+	dimensions := make(map[string]interface{}, len(l.Dimensions))
+	for _, d := range l.Dimensions {
+		if v, ok := instance.Dimensions[d]; ok {
+			dimensions[d] = v
+		}
+	}
+
+	// the level name can't be confused with a dimension, as dimensions are separated by ';'
+	return makeKey(instance.Name+"/"+l.Name, dimensions)
+}
@@ -51,6 +51,12 @@ type handler struct {
 	// the rolling windows we track for expiring quotas, protected by lock
 	windows map[string]*rollingWindow
 
+	// the token buckets we track for expiring quotas using the TOKEN_BUCKET algorithm, protected by lock
+	buckets map[string]*tokenBucket
+
+	// the sliding logs we track for expiring quotas using the SLIDING_LOG algorithm, protected by lock
+	logs map[string]*slidingLog
+
 	// the limits we know about
 	limits map[string]*config.Params_Quota
 
@@ -62,6 +68,7 @@ type handler struct {
 type Limit interface {
 	GetMaxAmount() int64
 	GetValidDuration() time.Duration
+	GetBurstSize() int64
 }
 
 // matchDimensions matches configured dimensions with dimensions of the instance.
@@ -110,38 +117,55 @@ func (h *handler) alloc(instance *quota.Instance, args adapter.QuotaArgs, q Limi
 	amount, exp, key := h.common.handleDedup(instance, args, func(key string, currentTime time.Time, currentTick int64) (int64, time.Time,
 		time.Duration) {
 		result := args.QuotaAmount
+		bounds := h.bounds(key, instance, q)
 
 		// we optimize storage for non-expiring quotas
 		if q.GetValidDuration() == 0 {
-			inUse := h.cells[key]
+			// every level must have room for the allocation
+			for _, b := range bounds {
+				inUse := h.cells[b.key]
 
-			if result > q.GetMaxAmount()-inUse {
-				if !args.BestEffort {
-					return 0, time.Time{}, 0
+				if result > b.limit.GetMaxAmount()-inUse {
+					if !args.BestEffort {
+						return 0, time.Time{}, 0
+					}
+
+					// grab as much as we can
+					result = b.limit.GetMaxAmount() - inUse
 				}
+			}
 
-				// grab as much as we can
-				result = q.GetMaxAmount() - inUse
+			for _, b := range bounds {
+				h.cells[b.key] += result
 			}
-			h.cells[key] = inUse + result
 			return result, time.Time{}, 0
 		}
 
-		window, ok := h.windows[key]
-		if !ok {
-			seconds := int32((q.GetValidDuration() + time.Second - 1) / time.Second)
-			window = newRollingWindow(q.GetMaxAmount(), int64(seconds)*ticksPerSecond)
-			h.windows[key] = window
-		}
+		algorithm := h.limits[instance.Name].RateLimitAlgorithm
 
-		if !window.alloc(result, currentTick) {
-			if !args.BestEffort {
-				return 0, time.Time{}, 0
+		// the allocation is charged to every level, and refunded if a level does not have room for it
+		allocated := make([]window, 0, len(bounds))
+		for _, b := range bounds {
+			w := h.getWindow(algorithm, b.key, b.limit, currentTick)
+
+			if !w.alloc(result, currentTick) {
+				if !args.BestEffort {
+					for _, a := range allocated {
+						_ = a.release(result, currentTick)
+					}
+					return 0, time.Time{}, 0
+				}
+
+				// grab as much as we can, giving back the excess to the previous levels
+				avail := w.available()
+				for _, a := range allocated {
+					_ = a.release(result-avail, currentTick)
+				}
+				result = avail
+				_ = w.alloc(result, currentTick)
 			}
 
-			// grab as much as we can
-			result = window.available()
-			_ = window.alloc(result, currentTick)
+			allocated = append(allocated, w)
 		}
 
 		return result, currentTime.Add(q.GetValidDuration()), q.GetValidDuration()
@@ -160,17 +184,24 @@ func (h *handler) free(instance *quota.Instance, args adapter.QuotaArgs, q Limit
 	amount, _, _ := h.common.handleDedup(instance, args, func(key string, currentTime time.Time, currentTick int64) (int64, time.Time,
 		time.Duration) {
 		result := args.QuotaAmount
+		bounds := h.bounds(key, instance, q)
 
 		if q.GetValidDuration() == 0 {
 			inUse := h.cells[key]
-
-			if result >= inUse {
-				// delete the cell since it contains no useful state
-				delete(h.cells, key)
-				return inUse, time.Time{}, 0
+			if result > inUse {
+				result = inUse
 			}
 
-			h.cells[key] = inUse - result
+			// the enclosing levels give back what the quota itself gives back
+			for _, b := range bounds {
+				if h.cells[b.key] <= result {
+					// delete the cell since it contains no useful state
+					delete(h.cells, b.key)
+					continue
+				}
+
+				h.cells[b.key] -= result
+			}
 			return result, time.Time{}, 0
 		}
 
@@ -178,25 +209,113 @@ func (h *handler) free(instance *quota.Instance, args adapter.QuotaArgs, q Limit
 		//          inherently racy. A release can easily end up
 		//          freeing quota in the wrong window.
 
+		algorithm := h.limits[instance.Name].RateLimitAlgorithm
+
+		result = h.releaseWindow(algorithm, key, q, result, currentTick)
+		for _, b := range bounds[1:] {
+			_ = h.releaseWindow(algorithm, b.key, b.limit, result, currentTick)
+		}
+
+		return result, time.Time{}, 0
+	})
+
+	return adapter.QuotaResult{
+		Status: status.OK,
+		Amount: amount,
+	}, nil
+}
+
+// window is implemented by the rate limiting algorithms of expiring quotas.
+type window interface {
+	alloc(amount int64, currentTick int64) bool
+	release(amount int64, currentTick int64) int64
+	available() int64
+}
+
+// getWindow returns the window of the given algorithm tracking the key, creating it if needed.
+func (h *handler) getWindow(algorithm config.Params_QuotaAlgorithm, key string, q Limit, currentTick int64) window {
+	switch algorithm {
+	case config.TOKEN_BUCKET:
+		bucket, ok := h.buckets[key]
+		if !ok {
+			burst := q.GetBurstSize()
+			if burst == 0 {
+				burst = q.GetMaxAmount()
+			}
+			bucket = newTokenBucket(q.GetMaxAmount(), burst, ticksInWindow(q.GetValidDuration()), currentTick)
+			h.buckets[key] = bucket
+		}
+		return bucket
+
+	case config.SLIDING_LOG:
+		log, ok := h.logs[key]
+		if !ok {
+			log = newSlidingLog(q.GetMaxAmount(), ticksInWindow(q.GetValidDuration()))
+			h.logs[key] = log
+		}
+		return log
+
+	default:
+		rolling, ok := h.windows[key]
+		if !ok {
+			seconds := int32((q.GetValidDuration() + time.Second - 1) / time.Second)
+			rolling = newRollingWindow(q.GetMaxAmount(), int64(seconds)*ticksPerSecond)
+			h.windows[key] = rolling
+		}
+		return rolling
+	}
+}
+
+// releaseWindow releases the amount from the window of the given algorithm tracking the key, and returns
+// the amount actually released.
+func (h *handler) releaseWindow(algorithm config.Params_QuotaAlgorithm, key string, q Limit, amount int64, currentTick int64) int64 {
+	switch algorithm {
+	case config.TOKEN_BUCKET:
+		bucket, ok := h.buckets[key]
+		if !ok {
+			return 0
+		}
+
+		amount = bucket.release(amount, currentTick)
+
+		if bucket.full() {
+			// delete the bucket since it contains no useful state
+			delete(h.buckets, key)
+		}
+
+	case config.SLIDING_LOG:
+		log, ok := h.logs[key]
+		if !ok {
+			return 0
+		}
+
+		amount = log.release(amount, currentTick)
+
+		if log.available() == q.GetMaxAmount() {
+			// delete the log since it contains no useful state
+			delete(h.logs, key)
+		}
+
+	default:
 		window, ok := h.windows[key]
 		if !ok {
-			return 0, time.Time{}, 0
+			return 0
 		}
 
-		result = window.release(result, currentTick)
+		amount = window.release(amount, currentTick)
 
 		if window.available() == q.GetMaxAmount() {
 			// delete the cell since it contains no useful state
 			delete(h.windows, key)
 		}
+	}
 
-		return result, time.Time{}, 0
-	})
+	return amount
+}
 
-	return adapter.QuotaResult{
-		Status: status.OK,
-		Amount: amount,
-	}, nil
+// ticksInWindow returns the number of ticks in the given window, rounded up.
+func ticksInWindow(window time.Duration) int64 {
+	return (window.Nanoseconds() + nanosPerTick - 1) / nanosPerTick
 }
 
 func (h *handler) Close() error {
@@ -227,6 +346,42 @@ func (b *builder) Validate() (ce *adapter.ConfigErrors) {
 	if ac.MinDeduplicationDuration <= 0 {
 		ce = ce.Appendf("minDeduplicationDuration", "deduplication window of %v is invalid, must be > 0", ac.MinDeduplicationDuration)
 	}
+
+	for idx := range ac.Quotas {
+		q := &ac.Quotas[idx]
+
+		if q.RateLimitAlgorithm != config.ROLLING_WINDOW && q.ValidDuration <= 0 {
+			ce = ce.Appendf("validDuration", "quota %s: validDuration must be > 0 for the %v algorithm", q.Name, q.RateLimitAlgorithm)
+		}
+
+		if q.BurstSize < 0 {
+			ce = ce.Appendf("burstSize", "quota %s: burstSize of %d is invalid, must be >= 0", q.Name, q.BurstSize)
+		}
+
+		for _, o := range q.Overrides {
+			if o.BurstSize < 0 {
+				ce = ce.Appendf("burstSize", "quota %s: override burstSize of %d is invalid, must be >= 0", q.Name, o.BurstSize)
+			}
+		}
+
+		levels := make(map[string]bool, len(q.Levels))
+		for _, l := range q.Levels {
+			if len(l.Name) == 0 {
+				ce = ce.Appendf("levels", "quota %s: level name should not be empty", q.Name)
+			} else if levels[l.Name] {
+				ce = ce.Appendf("levels", "quota %s: level name %s is not unique", q.Name, l.Name)
+			}
+			levels[l.Name] = true
+
+			if l.MaxAmount <= 0 {
+				ce = ce.Appendf("maxAmount", "quota %s: level %s maxAmount of %d is invalid, must be > 0", q.Name, l.Name, l.MaxAmount)
+			}
+
+			if l.BurstSize < 0 {
+				ce = ce.Appendf("burstSize", "quota %s: level %s burstSize of %d is invalid, must be >= 0", q.Name, l.Name, l.BurstSize)
+			}
+		}
+	}
 	return
 }
 
@@ -260,6 +415,8 @@ func (b *builder) buildWithDedup(_ context.Context, env adapter.Env, ticker *tim
 		},
 		cells:   make(map[string]int64),
 		windows: make(map[string]*rollingWindow),
+		buckets: make(map[string]*tokenBucket),
+		logs:    make(map[string]*slidingLog),
 		limits:  limits,
 		logger:  env.Logger(),
 	}
@@ -80,6 +80,21 @@ func TestAllocAndRelease(t *testing.T) {
 			MaxAmount:     10,
 			ValidDuration: time.Second * 60,
 		},
+
+		{
+			Name:               "Q4",
+			MaxAmount:          10,
+			ValidDuration:      time.Second * 10,
+			BurstSize:          20,
+			RateLimitAlgorithm: config.TOKEN_BUCKET,
+		},
+
+		{
+			Name:               "Q5",
+			MaxAmount:          10,
+			ValidDuration:      time.Second * 60,
+			RateLimitAlgorithm: config.SLIDING_LOG,
+		},
 	}
 
 	cfg := config.Params{
@@ -129,6 +144,18 @@ func TestAllocAndRelease(t *testing.T) {
 		{"Q3", "11", 100, 0, false, 0, 122, 10, 10},
 		{"Q3", "12", 0, 0, false, 0, 123, 1000, 0},
 		{"Q3", "12", 0, 0, false, 0, 124, 1000, 0},
+
+		{"Q4", "13", 15, 15, false, time.Second * 10, 200, 0, 0},
+		{"Q4", "14", 10, 0, false, 0, 201, 0, 0},
+		{"Q4", "15", 10, 7, true, time.Second * 10, 202, 0, 0},
+		{"Q4", "16", 0, 0, false, 0, 202, 5, 5},
+		{"Q4", "17", 100, 20, true, time.Second * 10, 300, 30, 20},
+
+		{"Q5", "18", 6, 6, false, time.Second * 60, 400, 0, 0},
+		{"Q5", "19", 6, 0, false, 0, 430, 0, 0},
+		{"Q5", "20", 6, 4, true, time.Second * 60, 430, 0, 0},
+		{"Q5", "21", 7, 0, false, 0, 460, 2, 2},
+		{"Q5", "22", 10, 8, true, time.Second * 60, 461, 10, 10},
 	}
 
 	dims := make(map[string]interface{})
@@ -224,6 +251,185 @@ func TestBadConfig(t *testing.T) {
 	if err == nil {
 		t.Error("Expecting failure, got success")
 	}
+
+	cfg.Quotas = []config.Params_Quota{
+		{
+			Name:               "Foo",
+			MaxAmount:          10,
+			RateLimitAlgorithm: config.TOKEN_BUCKET,
+		},
+	}
+	if err := b.Validate(); err == nil {
+		t.Error("Expecting failure, got success")
+	}
+
+	cfg.Quotas[0].ValidDuration = time.Second
+	cfg.Quotas[0].BurstSize = -1
+	if err := b.Validate(); err == nil {
+		t.Error("Expecting failure, got success")
+	}
+
+	cfg.Quotas[0].BurstSize = 0
+	cfg.Quotas[0].Overrides = []config.Params_Override{{MaxAmount: 5, BurstSize: -1}}
+	if err := b.Validate(); err == nil {
+		t.Error("Expecting failure, got success")
+	}
+
+	cfg.Quotas[0].Overrides[0].BurstSize = 20
+	if err := b.Validate(); err != nil {
+		t.Errorf("Expecting success, got %v", err)
+	}
+
+	cfg.Quotas[0].Levels = []config.Params_Level{{Name: "global", MaxAmount: 100}, {Name: "global", MaxAmount: 200}}
+	if err := b.Validate(); err == nil {
+		t.Error("Expecting failure, got success")
+	}
+
+	cfg.Quotas[0].Levels[1].Name = ""
+	if err := b.Validate(); err == nil {
+		t.Error("Expecting failure, got success")
+	}
+
+	cfg.Quotas[0].Levels[1].Name = "tenant"
+	cfg.Quotas[0].Levels[1].BurstSize = -1
+	if err := b.Validate(); err == nil {
+		t.Error("Expecting failure, got success")
+	}
+
+	cfg.Quotas[0].Levels[1].BurstSize = 0
+	cfg.Quotas[0].Levels[1].MaxAmount = 0
+	if err := b.Validate(); err == nil {
+		t.Error("Expecting failure, got success")
+	}
+
+	cfg.Quotas[0].Levels[1].MaxAmount = 200
+	if err := b.Validate(); err != nil {
+		t.Errorf("Expecting success, got %v", err)
+	}
+}
+
+func TestHierarchicalQuota(t *testing.T) {
+	levels := []config.Params_Level{
+		{
+			Name:       "tenant",
+			Dimensions: []string{"tenant"},
+			MaxAmount:  8,
+		},
+		{
+			Name:      "global",
+			MaxAmount: 10,
+		},
+	}
+
+	limits := []config.Params_Quota{
+		{
+			Name:      "H1",
+			MaxAmount: 5,
+			Levels:    levels,
+		},
+
+		{
+			Name:          "H2",
+			MaxAmount:     5,
+			ValidDuration: time.Second * 10,
+			Levels:        levels,
+		},
+
+		{
+			Name:               "H3",
+			MaxAmount:          5,
+			ValidDuration:      time.Second * 10,
+			RateLimitAlgorithm: config.TOKEN_BUCKET,
+			Levels:             levels,
+		},
+	}
+
+	cfg := config.Params{
+		MinDeduplicationDuration: 3600 * time.Second,
+		Quotas:                   limits,
+	}
+	info := GetInfo()
+	b := info.NewBuilder()
+	b.SetAdapterConfig(&cfg)
+
+	hndlr, err := b.Build(context.Background(), test.NewEnv(t))
+	if err != nil {
+		t.Fatalf("Got error %v, expecting success", err)
+	}
+
+	h := hndlr.(*handler)
+
+	cases := []struct {
+		name       string
+		user       string
+		tenant     string
+		amount     int64
+		bestEffort bool
+		seconds    int64
+		result     int64
+	}{
+		{"H1", "u1", "t1", 5, false, 0, 5},
+		{"H1", "u2", "t1", 5, false, 0, 0}, // tenant t1 has 3 left
+		{"H1", "u2", "t1", 5, true, 0, 3},
+		{"H1", "u3", "t2", 5, false, 0, 0}, // global has 2 left
+		{"H1", "u3", "t2", 2, false, 0, 2},
+		{"H1", "u1", "t1", -5, false, 0, 5},
+		{"H1", "u3", "t2", 3, false, 0, 3},
+		{"H1", "u2", "t1", 3, true, 0, 2}, // global has 2 left
+
+		{"H2", "u1", "t1", 5, false, 0, 5},
+		{"H2", "u2", "t1", 5, false, 0, 0}, // refunded to u2, as tenant t1 has 3 left
+		{"H2", "u2", "t1", 5, true, 1, 3},
+		{"H2", "u2", "t1", 2, false, 2, 0},
+		{"H2", "u2", "t1", 2, false, 9, 0}, // allocations of u1 are still in the window of tenant t1
+		{"H2", "u2", "t1", 2, false, 11, 2},
+		{"H2", "u2", "t1", -1, false, 11, 1},
+		{"H2", "u3", "t1", 5, true, 11, 5},
+		{"H2", "u4", "t1", 5, true, 11, 2},
+
+		{"H3", "u1", "t2", 5, false, 0, 5},
+		{"H3", "u2", "t2", 4, false, 0, 0}, // refunded to u2, as tenant t2 has 3 left
+		{"H3", "u2", "t2", 4, true, 0, 3},
+		{"H3", "u2", "t2", 1, false, 4, 1}, // each level is refilled at its own rate
+		{"H3", "u3", "t3", 5, true, 4, 5},
+		{"H3", "u4", "t3", 5, true, 4, 0}, // global is exhausted
+	}
+
+	now := time.Now()
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			qa := adapter.QuotaArgs{
+				DeduplicationID: "H" + strconv.Itoa(i),
+				QuotaAmount:     c.amount,
+				BestEffort:      c.bestEffort,
+			}
+
+			instance := quota.Instance{
+				Name: c.name,
+				Dimensions: map[string]interface{}{
+					"user":   c.user,
+					"tenant": c.tenant,
+				},
+			}
+
+			h.common.getTime = func() time.Time {
+				return now.Add(time.Duration(c.seconds) * time.Second)
+			}
+
+			qr, err := h.HandleQuota(context.Background(), &instance, qa)
+			if err != nil {
+				t.Errorf("Expecting success, got %v", err)
+			}
+
+			if qr.Amount != c.result {
+				t.Errorf("Expecting %d, got %d", c.result, qr.Amount)
+			}
+		})
+	}
+
+	if err := h.Close(); err != nil {
+		t.Errorf("Unable to close handler: %v", err)
+	}
 }
 
 func TestReaper(t *testing.T) {
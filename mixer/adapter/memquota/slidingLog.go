@@ -0,0 +1,103 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memquota
+
+// Implements a sliding log that allows N units to be allocated within any time interval, by
+// recording every allocation. Unlike the rolling window, allocations are not quantized into
+// slots, at the cost of memory proportional to the number of allocations within the interval.
+// Like the rolling window, time is abstracted in terms of ticks provided by the caller.
+type slidingLog struct {
+	// the allocations within the window, oldest first
+	entries []logEntry
+
+	// the # of ticks in the window
+	ticksInWindow int64
+
+	// the total # of units currently available in the window
+	avail int64
+}
+
+// logEntry records an allocation in a sliding log.
+type logEntry struct {
+	tick   int64
+	amount int64
+}
+
+// Creates a new sliding log used to implement rate limiting.
+//
+// The limit parameter determines the maximum amount that can be allocated within the
+// window, which spans ticksInWindow ticks.
+func newSlidingLog(limit int64, ticksInWindow int64) *slidingLog {
+	return &slidingLog{
+		avail:         limit,
+		ticksInWindow: ticksInWindow,
+	}
+}
+
+func (l *slidingLog) alloc(amount int64, currentTick int64) bool {
+	l.expire(currentTick)
+
+	if amount > l.avail {
+		// not enough room
+		return false
+	}
+
+	if amount > 0 {
+		l.entries = append(l.entries, logEntry{tick: currentTick, amount: amount})
+		l.avail -= amount
+	}
+
+	return true
+}
+
+func (l *slidingLog) release(amount int64, currentTick int64) int64 {
+	l.expire(currentTick)
+
+	// we release the most recent allocations first, until we've released enough
+	// or released everything, whichever is first...
+	var total int64
+	for len(l.entries) > 0 && amount > 0 {
+		last := &l.entries[len(l.entries)-1]
+
+		if last.amount > amount {
+			last.amount -= amount
+			total += amount
+			break
+		}
+
+		total += last.amount
+		amount -= last.amount
+		l.entries = l.entries[:len(l.entries)-1]
+	}
+
+	l.avail += total
+	return total
+}
+
+func (l *slidingLog) expire(currentTick int64) {
+	// reclaim the units of the allocations that are now outside of the window
+	i := 0
+	for ; i < len(l.entries) && l.entries[i].tick <= currentTick-l.ticksInWindow; i++ {
+		l.avail += l.entries[i].amount
+	}
+
+	if i > 0 {
+		l.entries = append(l.entries[:0], l.entries[i:]...)
+	}
+}
+
+func (l *slidingLog) available() int64 {
+	return l.avail
+}
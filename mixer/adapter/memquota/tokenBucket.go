@@ -0,0 +1,102 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memquota
+
+// Implements a token bucket that is refilled with N units per time interval, and holds up to
+// a burst of units. Like the rolling window, time is abstracted in terms of ticks provided
+// by the caller.
+//
+// Units are tracked scaled by the number of ticks in the interval, so that the refill of a
+// single tick is always integral.
+type tokenBucket struct {
+	// the maximum # of units the bucket holds, scaled
+	capacity int64
+
+	// the # of units added to the bucket per tick, scaled
+	refill int64
+
+	// the # of ticks in the refill interval, which is the scale of the units
+	ticksInWindow int64
+
+	// the # of units currently in the bucket, scaled
+	avail int64
+
+	// the tick count of the last refill
+	lastTick int64
+}
+
+// Creates a new token bucket used to implement rate limiting.
+//
+// The limit parameter determines the amount added to the bucket per refill interval, and the burst
+// parameter the maximum amount the bucket holds. The ticksInWindow parameter determines the number of
+// quantized time intervals within the refill interval. The bucket starts full at currentTick.
+func newTokenBucket(limit int64, burst int64, ticksInWindow int64, currentTick int64) *tokenBucket {
+	return &tokenBucket{
+		capacity:      burst * ticksInWindow,
+		refill:        limit,
+		ticksInWindow: ticksInWindow,
+		avail:         burst * ticksInWindow,
+		lastTick:      currentTick,
+	}
+}
+
+func (b *tokenBucket) alloc(amount int64, currentTick int64) bool {
+	b.fill(currentTick)
+
+	if amount > b.available() {
+		// not enough tokens
+		return false
+	}
+
+	b.avail -= amount * b.ticksInWindow
+	return true
+}
+
+func (b *tokenBucket) release(amount int64, currentTick int64) int64 {
+	b.fill(currentTick)
+
+	// tokens beyond the capacity of the bucket are dropped
+	room := (b.capacity - b.avail) / b.ticksInWindow
+	if amount > room {
+		amount = room
+	}
+
+	b.avail += amount * b.ticksInWindow
+	return amount
+}
+
+func (b *tokenBucket) fill(currentTick int64) {
+	elapsed := currentTick - b.lastTick
+	if elapsed <= 0 || b.refill <= 0 {
+		return
+	}
+	b.lastTick = currentTick
+
+	// avoid overflowing when the bucket has been idle for a long time
+	if elapsed >= (b.capacity-b.avail+b.refill-1)/b.refill {
+		b.avail = b.capacity
+		return
+	}
+
+	b.avail += elapsed * b.refill
+}
+
+func (b *tokenBucket) available() int64 {
+	return b.avail / b.ticksInWindow
+}
+
+func (b *tokenBucket) full() bool {
+	return b.avail == b.capacity
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memquota
+
+import (
+	"testing"
+)
+
+func TestTokenBucketAlloc(t *testing.T) {
+	cases := []struct {
+		amount int64
+		tick   int64
+		avail  int64
+		result bool
+	}{
+		{11, 0, 10, false},
+		{4, 0, 6, true},
+		{6, 0, 0, true},
+		{1, 0, 0, false},
+		{1, 1, 0, false},
+		{1, 2, 0, true},
+		{2, 6, 0, true},
+		{3, 7, 0, false},
+		{1, 8, 0, true},
+		{5, 10, 1, false},
+		{4, 30, 6, true},
+		{10, 1000, 0, true},
+	}
+
+	// refills 5 units every 10 ticks, holding up to 10 units
+	b := newTokenBucket(5, 10, 10, 0)
+
+	for i, c := range cases {
+		if ok := b.alloc(c.amount, c.tick); ok != c.result {
+			t.Errorf("Expecting %v got %v, case %d", c.result, ok, i)
+		}
+
+		if b.available() != c.avail {
+			t.Errorf("Expecting %d available, got %d, case %d", c.avail, b.available(), i)
+		}
+	}
+}
+
+func TestTokenBucketRelease(t *testing.T) {
+	cases := []struct {
+		allocAmount   int64
+		allocTick     int64
+		releaseAmount int64
+		releaseTick   int64
+		releaseResult int64
+		avail         int64
+		full          bool
+	}{
+		{4, 0, 4, 0, 4, 10, true},
+		{4, 0, 6, 0, 4, 10, true},
+		{8, 10, 3, 14, 3, 7, false},
+		{0, 16, 5, 16, 2, 10, true},
+	}
+
+	b := newTokenBucket(5, 10, 10, 0)
+
+	for i, c := range cases {
+		if ok := b.alloc(c.allocAmount, c.allocTick); !ok {
+			t.Errorf("Expecting alloc to succeed, case %d", i)
+		}
+
+		if result := b.release(c.releaseAmount, c.releaseTick); result != c.releaseResult {
+			t.Errorf("Expecting %d released, got %d, case %d", c.releaseResult, result, i)
+		}
+
+		if b.available() != c.avail {
+			t.Errorf("Expecting %d available, got %d, case %d", c.avail, b.available(), i)
+		}
+
+		if b.full() != c.full {
+			t.Errorf("Expecting full %v, got %v, case %d", c.full, b.full(), i)
+		}
+	}
+}
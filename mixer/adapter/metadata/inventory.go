@@ -33,6 +33,7 @@ import (
 	list "istio.io/istio/mixer/adapter/list/config"
 	memquota "istio.io/istio/mixer/adapter/memquota/config"
 	opa "istio.io/istio/mixer/adapter/opa/config"
+	otlp "istio.io/istio/mixer/adapter/otlp/config"
 	prometheus "istio.io/istio/mixer/adapter/prometheus/config"
 	redisquota "istio.io/istio/mixer/adapter/redisquota/config"
 	solarwinds "istio.io/istio/mixer/adapter/solarwinds/config"
@@ -200,6 +201,29 @@ var (
 			DefaultConfig: &opa.Params{},
 		},
 
+		{
+			Name:        "otlp",
+			Impl:        "istio.io/istio/mixer/adapter/otlp",
+			Description: "Exports metrics, logs and traces to an OpenTelemetry collector over OTLP/gRPC.",
+			SupportedTemplates: []string{
+				logentry.TemplateName,
+				metric.TemplateName,
+				tracespan.TemplateName,
+			},
+			DefaultConfig: &otlp.Params{
+				Endpoint:      "localhost:55680",
+				ExportTimeout: 10 * time.Second,
+				MaxBatchSize:  512,
+				FlushInterval: 5 * time.Second,
+				MaxQueueSize:  2048,
+				Retry: otlp.Params_RetryPolicy{
+					InitialBackoff: 1 * time.Second,
+					MaxBackoff:     30 * time.Second,
+					MaxElapsedTime: 1 * time.Minute,
+				},
+			},
+		},
+
 		{
 			Name:        "prometheus",
 			Impl:        "istio.io/istio/mixer/adapter/prometheus",
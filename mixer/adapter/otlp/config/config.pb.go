@@ -0,0 +1,1828 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: mixer/adapter/otlp/config/config.proto
+
+// The `otlp` adapter exports Istio telemetry to an
+// [OpenTelemetry](https://opentelemetry.io/) collector, or any other backend
+// accepting the OpenTelemetry protocol (OTLP) over gRPC.
+//
+// Instances are queued in memory and exported in batches. Failed exports are
+// retried with an exponential backoff, and instances are dropped once the
+// queue is full, so that a slow or unavailable collector never causes the
+// adapter to use unbounded memory.
+//
+// This adapter supports the [logentry template](https://istio.io/docs/reference/config/policy-and-telemetry/templates/logentry/),
+// the [metric template](https://istio.io/docs/reference/config/policy-and-telemetry/templates/metric/)
+// and the [tracespan template](https://istio.io/docs/reference/config/policy-and-telemetry/templates/tracespan/).
+
+package config
+
+import (
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	reflect "reflect"
+	strconv "strconv"
+	strings "strings"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type Params_MetricInfo_Kind int32
+
+const (
+	UNSPECIFIED  Params_MetricInfo_Kind = 0
+	GAUGE        Params_MetricInfo_Kind = 1
+	COUNTER      Params_MetricInfo_Kind = 2
+	DISTRIBUTION Params_MetricInfo_Kind = 3
+)
+
+var Params_MetricInfo_Kind_name = map[int32]string{
+	0: "UNSPECIFIED",
+	1: "GAUGE",
+	2: "COUNTER",
+	3: "DISTRIBUTION",
+}
+
+var Params_MetricInfo_Kind_value = map[string]int32{
+	"UNSPECIFIED":  0,
+	"GAUGE":        1,
+	"COUNTER":      2,
+	"DISTRIBUTION": 3,
+}
+
+func (Params_MetricInfo_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_db8256f5344d6f8d, []int{0, 3, 0}
+}
+
+// Configuration format for the `otlp` adapter.
+//
+// Example configuration:
+//
+// ```yaml
+// endpoint: otel-collector.istio-system:55680
+// insecure: true
+// maxBatchSize: 512
+// flushInterval: 5s
+// metrics:
+//   - instanceName: requestcount.instance.istio-system
+//     name: istio.requests
+//     kind: COUNTER
+//   - instanceName: requestduration.instance.istio-system
+//     name: istio.request.duration
+//     unit: s
+//     kind: DISTRIBUTION
+//     bounds: [0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10]
+//
+// ```
+type Params struct {
+	// Address of the OTLP/gRPC endpoint of the collector, e.g. `localhost:55680`.
+	//
+	// Required.
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// If true, the connection to the collector is not secured with TLS.
+	Insecure bool `protobuf:"varint,2,opt,name=insecure,proto3" json:"insecure,omitempty"`
+	// Headers sent as gRPC metadata with every export request, e.g. for
+	// authentication with the collector.
+	Headers map[string]string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Attributes added to the resource of every exported metric, log record
+	// and span, e.g. `deployment.environment`.
+	ResourceAttributes map[string]string `protobuf:"bytes,4,rep,name=resource_attributes,json=resourceAttributes,proto3" json:"resource_attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Timeout of a single export request to the collector.
+	// Defaults to 10s.
+	ExportTimeout time.Duration `protobuf:"bytes,5,opt,name=export_timeout,json=exportTimeout,proto3,stdduration" json:"export_timeout"`
+	// Maximum number of instances sent to the collector in a single export
+	// request. Metrics, log entries and trace spans are batched separately.
+	// Defaults to 512.
+	MaxBatchSize int64 `protobuf:"varint,6,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	// Maximum amount of time instances are held in memory before being exported,
+	// regardless of the batch size.
+	// Defaults to 5s.
+	FlushInterval time.Duration `protobuf:"bytes,7,opt,name=flush_interval,json=flushInterval,proto3,stdduration" json:"flush_interval"`
+	// Maximum number of instances of each kind queued for export. This bounds
+	// the memory used by the adapter while the collector is slow or
+	// unavailable: once the queue is full, new instances are dropped.
+	// Defaults to 2048.
+	MaxQueueSize int64 `protobuf:"varint,8,opt,name=max_queue_size,json=maxQueueSize,proto3" json:"max_queue_size,omitempty"`
+	// Retry policy of failed export requests. Only transient failures, such as
+	// an unavailable collector, are retried.
+	Retry Params_RetryPolicy `protobuf:"bytes,9,opt,name=retry,proto3" json:"retry"`
+	// Describes how metric instances are exported. Metric instances without a
+	// `MetricInfo` are exported as gauges named after the instance.
+	Metrics []*Params_MetricInfo `protobuf:"bytes,10,rep,name=metrics,proto3" json:"metrics,omitempty"`
+}
+
+func (m *Params) Reset()      { *m = Params{} }
+func (*Params) ProtoMessage() {}
+func (*Params) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db8256f5344d6f8d, []int{0}
+}
+func (m *Params) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params.Merge(m, src)
+}
+func (m *Params) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params proto.InternalMessageInfo
+
+type Params_RetryPolicy struct {
+	Disabled       bool          `protobuf:"varint,1,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	InitialBackoff time.Duration `protobuf:"bytes,2,opt,name=initial_backoff,json=initialBackoff,proto3,stdduration" json:"initial_backoff"`
+	MaxBackoff     time.Duration `protobuf:"bytes,3,opt,name=max_backoff,json=maxBackoff,proto3,stdduration" json:"max_backoff"`
+	MaxElapsedTime time.Duration `protobuf:"bytes,4,opt,name=max_elapsed_time,json=maxElapsedTime,proto3,stdduration" json:"max_elapsed_time"`
+}
+
+func (m *Params_RetryPolicy) Reset()      { *m = Params_RetryPolicy{} }
+func (*Params_RetryPolicy) ProtoMessage() {}
+func (*Params_RetryPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db8256f5344d6f8d, []int{0, 2}
+}
+func (m *Params_RetryPolicy) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params_RetryPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params_RetryPolicy.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params_RetryPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params_RetryPolicy.Merge(m, src)
+}
+func (m *Params_RetryPolicy) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params_RetryPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params_RetryPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params_RetryPolicy proto.InternalMessageInfo
+
+type Params_MetricInfo struct {
+	InstanceName string                 `protobuf:"bytes,1,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Unit         string                 `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	Kind         Params_MetricInfo_Kind `protobuf:"varint,5,opt,name=kind,proto3,enum=adapter.otlp.config.Params_MetricInfo_Kind" json:"kind,omitempty"`
+	Bounds       []float64              `protobuf:"fixed64,6,rep,packed,name=bounds,proto3" json:"bounds,omitempty"`
+}
+
+func (m *Params_MetricInfo) Reset()      { *m = Params_MetricInfo{} }
+func (*Params_MetricInfo) ProtoMessage() {}
+func (*Params_MetricInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db8256f5344d6f8d, []int{0, 3}
+}
+func (m *Params_MetricInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params_MetricInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params_MetricInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params_MetricInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params_MetricInfo.Merge(m, src)
+}
+func (m *Params_MetricInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params_MetricInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params_MetricInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params_MetricInfo proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("adapter.otlp.config.Params_MetricInfo_Kind", Params_MetricInfo_Kind_name, Params_MetricInfo_Kind_value)
+	proto.RegisterType((*Params)(nil), "adapter.otlp.config.Params")
+	proto.RegisterMapType((map[string]string)(nil), "adapter.otlp.config.Params.HeadersEntry")
+	proto.RegisterMapType((map[string]string)(nil), "adapter.otlp.config.Params.ResourceAttributesEntry")
+	proto.RegisterType((*Params_RetryPolicy)(nil), "adapter.otlp.config.Params.RetryPolicy")
+	proto.RegisterType((*Params_MetricInfo)(nil), "adapter.otlp.config.Params.MetricInfo")
+}
+
+func init() {
+	proto.RegisterFile("mixer/adapter/otlp/config/config.proto", fileDescriptor_db8256f5344d6f8d)
+}
+
+var fileDescriptor_db8256f5344d6f8d = []byte{
+	// 755 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xcf, 0x6f, 0x1b, 0x45,
+	0x14, 0xde, 0xf5, 0x6f, 0x3f, 0xbb, 0xae, 0x35, 0xad, 0x60, 0xf1, 0x61, 0x6b, 0x15, 0x54, 0x2c,
+	0x21, 0xad, 0xa5, 0xf4, 0x82, 0x7a, 0xa1, 0x71, 0x6c, 0x8a, 0x81, 0xba, 0x61, 0x62, 0x5f, 0xb8,
+	0x58, 0xe3, 0xdd, 0xb1, 0x33, 0xca, 0xee, 0x8c, 0x99, 0x9d, 0xad, 0xec, 0x9e, 0xe0, 0xce, 0x81,
+	0x1b, 0xfc, 0x09, 0xfc, 0x29, 0x39, 0xe6, 0x98, 0x13, 0x60, 0xe7, 0xc2, 0x31, 0x7f, 0x02, 0xda,
+	0x99, 0xb5, 0x89, 0x04, 0x8d, 0x92, 0x93, 0xe7, 0xfd, 0xf8, 0xbe, 0xef, 0xed, 0x7b, 0x9f, 0xe1,
+	0x59, 0xc4, 0x56, 0x54, 0x76, 0x49, 0x40, 0x96, 0x8a, 0xca, 0xae, 0x50, 0xe1, 0xb2, 0xeb, 0x0b,
+	0x3e, 0x67, 0x8b, 0xec, 0xc7, 0x5b, 0x4a, 0xa1, 0x04, 0x7a, 0x94, 0x75, 0x78, 0x69, 0x87, 0x67,
+	0x4a, 0xad, 0xc7, 0x0b, 0xb1, 0x10, 0xba, 0xde, 0x4d, 0x5f, 0xa6, 0xb5, 0xe5, 0x2e, 0x84, 0x58,
+	0x84, 0xb4, 0xab, 0xa3, 0x59, 0x32, 0xef, 0x06, 0x89, 0x24, 0x8a, 0x09, 0x6e, 0xea, 0x4f, 0x7f,
+	0x02, 0x28, 0x1d, 0x13, 0x49, 0xa2, 0x18, 0xb5, 0xa0, 0x42, 0x79, 0xb0, 0x14, 0x8c, 0x2b, 0xc7,
+	0x6e, 0xdb, 0x9d, 0x2a, 0xde, 0xc7, 0x69, 0x8d, 0xf1, 0x98, 0xfa, 0x89, 0xa4, 0x4e, 0xae, 0x6d,
+	0x77, 0x2a, 0x78, 0x1f, 0xa3, 0x1e, 0x94, 0x4f, 0x29, 0x09, 0xa8, 0x8c, 0x9d, 0x7c, 0x3b, 0xdf,
+	0xa9, 0x1d, 0x74, 0xbc, 0xff, 0x99, 0xcf, 0x33, 0x2a, 0xde, 0x57, 0xa6, 0x75, 0xc0, 0x95, 0x5c,
+	0xe3, 0x1d, 0x10, 0x05, 0xf0, 0x48, 0xd2, 0x58, 0x24, 0xd2, 0xa7, 0x53, 0xa2, 0x94, 0x64, 0xb3,
+	0x44, 0xd1, 0xd8, 0x29, 0x68, 0xbe, 0xe7, 0xb7, 0xf1, 0xe1, 0x0c, 0x76, 0xb8, 0x47, 0x19, 0x6a,
+	0x24, 0xff, 0x53, 0x40, 0x5f, 0x43, 0x83, 0xae, 0x96, 0x42, 0xaa, 0xa9, 0x62, 0x11, 0x15, 0x89,
+	0x72, 0x8a, 0x6d, 0xbb, 0x53, 0x3b, 0xf8, 0xc8, 0x33, 0x5b, 0xf2, 0x76, 0x5b, 0xf2, 0xfa, 0xd9,
+	0x96, 0x7a, 0x95, 0xf3, 0x3f, 0x9e, 0x58, 0xbf, 0xfd, 0xf9, 0xc4, 0xc6, 0x0f, 0x0c, 0x74, 0x6c,
+	0x90, 0xe8, 0x13, 0x68, 0x44, 0x64, 0x35, 0x9d, 0x11, 0xe5, 0x9f, 0x4e, 0x63, 0xf6, 0x8e, 0x3a,
+	0xa5, 0xb6, 0xdd, 0xc9, 0xe3, 0x7a, 0x44, 0x56, 0xbd, 0x34, 0x79, 0xc2, 0xde, 0xd1, 0x54, 0x71,
+	0x1e, 0x26, 0xf1, 0xe9, 0x94, 0x71, 0x45, 0xe5, 0x5b, 0x12, 0x3a, 0xe5, 0x7b, 0x28, 0x6a, 0xe8,
+	0x30, 0x43, 0xee, 0x14, 0x7f, 0x48, 0x68, 0x42, 0x8d, 0x62, 0x65, 0xaf, 0xf8, 0x5d, 0x9a, 0xd4,
+	0x8a, 0x47, 0x50, 0x94, 0x54, 0xc9, 0xb5, 0x53, 0xd5, 0x42, 0x9f, 0xde, 0xbe, 0x3b, 0x25, 0xd7,
+	0xc7, 0x22, 0x64, 0xfe, 0xba, 0x57, 0x48, 0x65, 0xb1, 0xc1, 0xa2, 0x97, 0x50, 0x8e, 0xa8, 0x92,
+	0xcc, 0x8f, 0x1d, 0xd0, 0x27, 0x78, 0x76, 0x1b, 0xcd, 0x6b, 0xdd, 0x3a, 0xe4, 0x73, 0x81, 0x77,
+	0xb0, 0xd6, 0x0b, 0xa8, 0xdf, 0xbc, 0x34, 0x6a, 0x42, 0xfe, 0x8c, 0xae, 0x33, 0x5f, 0xa5, 0x4f,
+	0xf4, 0x18, 0x8a, 0x6f, 0x49, 0x98, 0x18, 0x3f, 0x55, 0xb1, 0x09, 0x5e, 0xe4, 0x3e, 0xb7, 0x5b,
+	0x03, 0xf8, 0xf0, 0x3d, 0x57, 0xbd, 0x17, 0xcd, 0xcf, 0x39, 0xa8, 0xdd, 0xf8, 0xc2, 0xd4, 0xc3,
+	0x01, 0x8b, 0xc9, 0x2c, 0xa4, 0x81, 0x26, 0xa8, 0xe0, 0x7d, 0x8c, 0xbe, 0x85, 0x87, 0x8c, 0x33,
+	0xc5, 0x48, 0x38, 0x9d, 0x11, 0xff, 0x4c, 0xcc, 0xe7, 0x4e, 0xee, 0xee, 0x87, 0x6a, 0x64, 0xd8,
+	0x9e, 0x81, 0xa2, 0x3e, 0xd4, 0x8c, 0x37, 0x0c, 0x53, 0xfe, 0xee, 0x4c, 0xa0, 0xdd, 0x63, 0x58,
+	0x5e, 0x43, 0x33, 0x65, 0xa1, 0x21, 0x59, 0xc6, 0x34, 0xd0, 0x96, 0x75, 0x0a, 0xf7, 0x18, 0x2a,
+	0x22, 0xab, 0x81, 0xc1, 0xa6, 0x9e, 0x6d, 0xfd, 0x9a, 0x03, 0xf8, 0xf7, 0x52, 0xe8, 0x63, 0x78,
+	0xc0, 0x78, 0xac, 0x08, 0xf7, 0xe9, 0x94, 0x93, 0x88, 0x66, 0x3b, 0xad, 0xef, 0x92, 0x23, 0x12,
+	0x51, 0x84, 0xa0, 0xa0, 0x6b, 0x66, 0xb7, 0xfa, 0x8d, 0xda, 0x50, 0x0b, 0x68, 0xec, 0x4b, 0xb6,
+	0x4c, 0x05, 0xf5, 0xc7, 0x55, 0xf1, 0xcd, 0x54, 0x8a, 0x4a, 0x38, 0x53, 0x7a, 0xd8, 0x2a, 0xd6,
+	0x6f, 0xf4, 0x05, 0x14, 0xce, 0x18, 0x0f, 0xf4, 0x1f, 0xae, 0x71, 0xf0, 0xd9, 0xdd, 0xec, 0xe4,
+	0x7d, 0xc3, 0x78, 0x80, 0x35, 0x10, 0x7d, 0x00, 0xa5, 0x99, 0x48, 0x78, 0x10, 0x3b, 0xa5, 0x76,
+	0xbe, 0x63, 0xe3, 0x2c, 0x7a, 0x7a, 0x08, 0x85, 0xb4, 0x0b, 0x3d, 0x84, 0xda, 0x64, 0x74, 0x72,
+	0x3c, 0x38, 0x1a, 0x7e, 0x39, 0x1c, 0xf4, 0x9b, 0x16, 0xaa, 0x42, 0xf1, 0xd5, 0xe1, 0xe4, 0xd5,
+	0xa0, 0x69, 0xa3, 0x1a, 0x94, 0x8f, 0xde, 0x4c, 0x46, 0xe3, 0x01, 0x6e, 0xe6, 0x50, 0x13, 0xea,
+	0xfd, 0xe1, 0xc9, 0x18, 0x0f, 0x7b, 0x93, 0xf1, 0xf0, 0xcd, 0xa8, 0x99, 0xef, 0xbd, 0x3c, 0xdf,
+	0xb8, 0xd6, 0xc5, 0xc6, 0xb5, 0x2e, 0x37, 0xae, 0x75, 0xbd, 0x71, 0xad, 0x1f, 0xb7, 0xae, 0xfd,
+	0xfb, 0xd6, 0xb5, 0xce, 0xb7, 0xae, 0x7d, 0xb1, 0x75, 0xed, 0xbf, 0xb6, 0xae, 0xfd, 0xf7, 0xd6,
+	0xb5, 0xae, 0xb7, 0xae, 0xfd, 0xcb, 0x95, 0x6b, 0x5d, 0x5c, 0xb9, 0xd6, 0xe5, 0x95, 0x6b, 0x7d,
+	0x5f, 0x32, 0x93, 0xcf, 0x4a, 0xfa, 0x10, 0xcf, 0xff, 0x19, 0x00, 0x98, 0xd8, 0x78, 0x60, 0xc1,
+	0x05, 0x00, 0x00,
+}
+
+func (x Params_MetricInfo_Kind) String() string {
+	s, ok := Params_MetricInfo_Kind_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Metrics) > 0 {
+		for iNdEx := len(m.Metrics) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metrics[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintConfig(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	{
+		size, err := m.Retry.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintConfig(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x4a
+	if m.MaxQueueSize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxQueueSize))
+		i--
+		dAtA[i] = 0x40
+	}
+	n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.FlushInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.FlushInterval):])
+	if err2 != nil {
+		return 0, err2
+	}
+	i -= n2
+	i = encodeVarintConfig(dAtA, i, uint64(n2))
+	i--
+	dAtA[i] = 0x3a
+	if m.MaxBatchSize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxBatchSize))
+		i--
+		dAtA[i] = 0x30
+	}
+	n3, err3 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.ExportTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.ExportTimeout):])
+	if err3 != nil {
+		return 0, err3
+	}
+	i -= n3
+	i = encodeVarintConfig(dAtA, i, uint64(n3))
+	i--
+	dAtA[i] = 0x2a
+	if len(m.ResourceAttributes) > 0 {
+		for k := range m.ResourceAttributes {
+			v := m.ResourceAttributes[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintConfig(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintConfig(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintConfig(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Headers) > 0 {
+		for k := range m.Headers {
+			v := m.Headers[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintConfig(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintConfig(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintConfig(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Insecure {
+		i--
+		if m.Insecure {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Endpoint) > 0 {
+		i -= len(m.Endpoint)
+		copy(dAtA[i:], m.Endpoint)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Endpoint)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Params_RetryPolicy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params_RetryPolicy) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params_RetryPolicy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n4, err4 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MaxElapsedTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxElapsedTime):])
+	if err4 != nil {
+		return 0, err4
+	}
+	i -= n4
+	i = encodeVarintConfig(dAtA, i, uint64(n4))
+	i--
+	dAtA[i] = 0x22
+	n5, err5 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MaxBackoff, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxBackoff):])
+	if err5 != nil {
+		return 0, err5
+	}
+	i -= n5
+	i = encodeVarintConfig(dAtA, i, uint64(n5))
+	i--
+	dAtA[i] = 0x1a
+	n6, err6 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.InitialBackoff, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.InitialBackoff):])
+	if err6 != nil {
+		return 0, err6
+	}
+	i -= n6
+	i = encodeVarintConfig(dAtA, i, uint64(n6))
+	i--
+	dAtA[i] = 0x12
+	if m.Disabled {
+		i--
+		if m.Disabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Params_MetricInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params_MetricInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params_MetricInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Bounds) > 0 {
+		for iNdEx := len(m.Bounds) - 1; iNdEx >= 0; iNdEx-- {
+			f7 := math.Float64bits(float64(m.Bounds[iNdEx]))
+			i -= 8
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f7))
+		}
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Bounds)*8))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Kind != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.Kind))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Unit) > 0 {
+		i -= len(m.Unit)
+		copy(dAtA[i:], m.Unit)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Unit)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.InstanceName) > 0 {
+		i -= len(m.InstanceName)
+		copy(dAtA[i:], m.InstanceName)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.InstanceName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintConfig(dAtA []byte, offset int, v uint64) int {
+	offset -= sovConfig(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Endpoint)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.Insecure {
+		n += 2
+	}
+	if len(m.Headers) > 0 {
+		for k, v := range m.Headers {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovConfig(uint64(len(k))) + 1 + len(v) + sovConfig(uint64(len(v)))
+			n += mapEntrySize + 1 + sovConfig(uint64(mapEntrySize))
+		}
+	}
+	if len(m.ResourceAttributes) > 0 {
+		for k, v := range m.ResourceAttributes {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovConfig(uint64(len(k))) + 1 + len(v) + sovConfig(uint64(len(v)))
+			n += mapEntrySize + 1 + sovConfig(uint64(mapEntrySize))
+		}
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.ExportTimeout)
+	n += 1 + l + sovConfig(uint64(l))
+	if m.MaxBatchSize != 0 {
+		n += 1 + sovConfig(uint64(m.MaxBatchSize))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.FlushInterval)
+	n += 1 + l + sovConfig(uint64(l))
+	if m.MaxQueueSize != 0 {
+		n += 1 + sovConfig(uint64(m.MaxQueueSize))
+	}
+	l = m.Retry.Size()
+	n += 1 + l + sovConfig(uint64(l))
+	if len(m.Metrics) > 0 {
+		for _, e := range m.Metrics {
+			l = e.Size()
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Params_RetryPolicy) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Disabled {
+		n += 2
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.InitialBackoff)
+	n += 1 + l + sovConfig(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxBackoff)
+	n += 1 + l + sovConfig(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxElapsedTime)
+	n += 1 + l + sovConfig(uint64(l))
+	return n
+}
+
+func (m *Params_MetricInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.InstanceName)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = len(m.Unit)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.Kind != 0 {
+		n += 1 + sovConfig(uint64(m.Kind))
+	}
+	if len(m.Bounds) > 0 {
+		n += 1 + sovConfig(uint64(len(m.Bounds)*8)) + len(m.Bounds)*8
+	}
+	return n
+}
+
+func sovConfig(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozConfig(x uint64) (n int) {
+	return sovConfig(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *Params) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetrics := "[]*Params_MetricInfo{"
+	for _, f := range this.Metrics {
+		repeatedStringForMetrics += strings.Replace(fmt.Sprintf("%v", f), "Params_MetricInfo", "Params_MetricInfo", 1) + ","
+	}
+	repeatedStringForMetrics += "}"
+	keysForHeaders := make([]string, 0, len(this.Headers))
+	for k, _ := range this.Headers {
+		keysForHeaders = append(keysForHeaders, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForHeaders)
+	mapStringForHeaders := "map[string]string{"
+	for _, k := range keysForHeaders {
+		mapStringForHeaders += fmt.Sprintf("%v: %v,", k, this.Headers[k])
+	}
+	mapStringForHeaders += "}"
+	keysForResourceAttributes := make([]string, 0, len(this.ResourceAttributes))
+	for k, _ := range this.ResourceAttributes {
+		keysForResourceAttributes = append(keysForResourceAttributes, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForResourceAttributes)
+	mapStringForResourceAttributes := "map[string]string{"
+	for _, k := range keysForResourceAttributes {
+		mapStringForResourceAttributes += fmt.Sprintf("%v: %v,", k, this.ResourceAttributes[k])
+	}
+	mapStringForResourceAttributes += "}"
+	s := strings.Join([]string{`&Params{`,
+		`Endpoint:` + fmt.Sprintf("%v", this.Endpoint) + `,`,
+		`Insecure:` + fmt.Sprintf("%v", this.Insecure) + `,`,
+		`Headers:` + mapStringForHeaders + `,`,
+		`ResourceAttributes:` + mapStringForResourceAttributes + `,`,
+		`ExportTimeout:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ExportTimeout), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`MaxBatchSize:` + fmt.Sprintf("%v", this.MaxBatchSize) + `,`,
+		`FlushInterval:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.FlushInterval), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`MaxQueueSize:` + fmt.Sprintf("%v", this.MaxQueueSize) + `,`,
+		`Retry:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Retry), "Params_RetryPolicy", "Params_RetryPolicy", 1), `&`, ``, 1) + `,`,
+		`Metrics:` + repeatedStringForMetrics + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Params_RetryPolicy) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Params_RetryPolicy{`,
+		`Disabled:` + fmt.Sprintf("%v", this.Disabled) + `,`,
+		`InitialBackoff:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.InitialBackoff), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`MaxBackoff:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.MaxBackoff), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`MaxElapsedTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.MaxElapsedTime), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Params_MetricInfo) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Params_MetricInfo{`,
+		`InstanceName:` + fmt.Sprintf("%v", this.InstanceName) + `,`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Description:` + fmt.Sprintf("%v", this.Description) + `,`,
+		`Unit:` + fmt.Sprintf("%v", this.Unit) + `,`,
+		`Kind:` + fmt.Sprintf("%v", this.Kind) + `,`,
+		`Bounds:` + fmt.Sprintf("%v", this.Bounds) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringConfig(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Endpoint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Insecure", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Insecure = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Headers == nil {
+				m.Headers = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConfig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthConfig
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthConfig
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipConfig(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Headers[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceAttributes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ResourceAttributes == nil {
+				m.ResourceAttributes = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConfig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthConfig
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthConfig
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipConfig(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ResourceAttributes[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExportTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.ExportTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBatchSize", wireType)
+			}
+			m.MaxBatchSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBatchSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FlushInterval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.FlushInterval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxQueueSize", wireType)
+			}
+			m.MaxQueueSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxQueueSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Retry", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Retry.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metrics", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metrics = append(m.Metrics, &Params_MetricInfo{})
+			if err := m.Metrics[len(m.Metrics)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Params_RetryPolicy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RetryPolicy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RetryPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Disabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Disabled = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitialBackoff", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.InitialBackoff, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBackoff", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.MaxBackoff, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxElapsedTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.MaxElapsedTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Params_MetricInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MetricInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MetricInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InstanceName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unit", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Unit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			m.Kind = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Kind |= Params_MetricInfo_Kind(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.Bounds = append(m.Bounds, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConfig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthConfig
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthConfig
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				elementCount = packedLen / 8
+				if elementCount != 0 && len(m.Bounds) == 0 {
+					m.Bounds = make([]float64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.Bounds = append(m.Bounds, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bounds", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipConfig(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthConfig
+			}
+			iNdEx += length
+			if iNdEx < 0 {
+				return 0, ErrInvalidLengthConfig
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowConfig
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipConfig(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+				if iNdEx < 0 {
+					return 0, ErrInvalidLengthConfig
+				}
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthConfig = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowConfig   = fmt.Errorf("proto: integer overflow")
+)
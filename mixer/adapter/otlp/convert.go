@@ -0,0 +1,490 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opencensus.io/trace"
+
+	"istio.io/istio/mixer/adapter/otlp/config"
+	otel "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry"
+	"istio.io/istio/mixer/pkg/adapter/opencensus"
+	"istio.io/istio/mixer/template/logentry"
+	"istio.io/istio/mixer/template/tracespan"
+)
+
+const (
+	// Resource attribute holding the type of the monitored resource of metrics and log entries.
+	resourceTypeAttribute = "istio.monitored_resource.type"
+
+	// Log record attribute holding the name of the logentry instance.
+	logNameAttribute = "log.name"
+
+	// Resource and span attributes of spans, following the OpenTelemetry semantic conventions.
+	serviceNameAttribute    = "service.name"
+	peerServiceAttribute    = "peer.service"
+	peerIPAttribute         = "net.peer.ip"
+	httpStatusCodeAttribute = "http.status_code"
+)
+
+type (
+	// metricInfo describes how the instances of a metric are exported.
+	metricInfo struct {
+		name        string
+		description string
+		unit        string
+		kind        config.Params_MetricInfo_Kind
+		bounds      []float64
+	}
+
+	// point is a single value of a metric instance. Points of the same series are aggregated
+	// when exported.
+	point struct {
+		metric      *metricInfo
+		attributes  []*otel.KeyValue
+		seriesKey   string
+		time        time.Time
+		isInt       bool
+		intValue    int64
+		doubleValue float64
+	}
+
+	// series aggregates the points of a metric with the same attributes.
+	series struct {
+		attributes  []*otel.KeyValue
+		start       time.Time
+		end         time.Time
+		isInt       bool
+		intValue    int64
+		doubleValue float64
+		count       uint64
+		sum         float64
+		buckets     []uint64
+	}
+
+	// aggregation holds the series of a metric, in the order they were first seen.
+	aggregation struct {
+		metric *metricInfo
+		series []*series
+		byKey  map[string]*series
+	}
+)
+
+func (p *point) float() float64 {
+	if p.isInt {
+		return float64(p.intValue)
+	}
+	return p.doubleValue
+}
+
+func (s *series) add(kind config.Params_MetricInfo_Kind, bounds []float64, p *point) {
+	if s.start.IsZero() || p.time.Before(s.start) {
+		s.start = p.time
+	}
+	latest := !p.time.Before(s.end)
+	if latest {
+		s.end = p.time
+	}
+
+	switch kind {
+	case config.COUNTER:
+		switch {
+		case s.count == 0:
+			s.isInt, s.intValue, s.doubleValue = p.isInt, p.intValue, p.doubleValue
+		case s.isInt && p.isInt:
+			s.intValue += p.intValue
+		case s.isInt:
+			s.isInt = false
+			s.doubleValue = float64(s.intValue) + p.doubleValue
+		default:
+			s.doubleValue += p.float()
+		}
+	case config.DISTRIBUTION:
+		if s.buckets == nil {
+			s.buckets = make([]uint64, len(bounds)+1)
+		}
+		v := p.float()
+		s.sum += v
+		// Bucket i counts the values in (bounds[i-1], bounds[i]].
+		s.buckets[sort.SearchFloat64s(bounds, v)]++
+	default:
+		if s.count == 0 || latest {
+			s.isInt, s.intValue, s.doubleValue = p.isInt, p.intValue, p.doubleValue
+		}
+	}
+	s.count++
+}
+
+func (s *series) numberDataPoint() *otel.NumberDataPoint {
+	dp := &otel.NumberDataPoint{
+		Attributes:        s.attributes,
+		StartTimeUnixNano: unixNano(s.start),
+		TimeUnixNano:      unixNano(s.end),
+	}
+	if s.isInt {
+		dp.Value = &otel.NumberDataPoint_AsInt{AsInt: s.intValue}
+	} else {
+		dp.Value = &otel.NumberDataPoint_AsDouble{AsDouble: s.doubleValue}
+	}
+	return dp
+}
+
+func (a *aggregation) toMetric() *otel.Metric {
+	m := &otel.Metric{
+		Name:        a.metric.name,
+		Description: a.metric.description,
+		Unit:        a.metric.unit,
+	}
+
+	switch a.metric.kind {
+	case config.COUNTER:
+		sum := &otel.Sum{
+			AggregationTemporality: otel.AGGREGATION_TEMPORALITY_DELTA,
+			IsMonotonic:            true,
+		}
+		for _, s := range a.series {
+			sum.DataPoints = append(sum.DataPoints, s.numberDataPoint())
+		}
+		m.Data = &otel.Metric_Sum{Sum: sum}
+	case config.DISTRIBUTION:
+		histogram := &otel.Histogram{
+			AggregationTemporality: otel.AGGREGATION_TEMPORALITY_DELTA,
+		}
+		for _, s := range a.series {
+			histogram.DataPoints = append(histogram.DataPoints, &otel.HistogramDataPoint{
+				Attributes:        s.attributes,
+				StartTimeUnixNano: unixNano(s.start),
+				TimeUnixNano:      unixNano(s.end),
+				Count:             s.count,
+				Sum:               s.sum,
+				BucketCounts:      s.buckets,
+				ExplicitBounds:    a.metric.bounds,
+			})
+		}
+		m.Data = &otel.Metric_Histogram{Histogram: histogram}
+	default:
+		gauge := &otel.Gauge{}
+		for _, s := range a.series {
+			gauge.DataPoints = append(gauge.DataPoints, s.numberDataPoint())
+		}
+		m.Data = &otel.Metric_Gauge{Gauge: gauge}
+	}
+	return m
+}
+
+// resourceGroups groups records by resource, in the order the resources were first seen.
+func resourceGroups(batch []record) [][]record {
+	var groups [][]record
+	index := make(map[string]int)
+	for _, r := range batch {
+		i, found := index[r.resourceKey]
+		if !found {
+			i = len(groups)
+			index[r.resourceKey] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], r)
+	}
+	return groups
+}
+
+// newResource returns the resource of a group of records, which also holds the attributes configured
+// for all resources unless the records override them.
+func newResource(attributes, common []*otel.KeyValue) *otel.Resource {
+	res := &otel.Resource{Attributes: attributes}
+	for _, kv := range common {
+		if !hasAttribute(attributes, kv.Key) {
+			res.Attributes = append(res.Attributes, kv)
+		}
+	}
+	return res
+}
+
+func hasAttribute(attributes []*otel.KeyValue, key string) bool {
+	for _, kv := range attributes {
+		if kv.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func resourceMetrics(batch []record, common []*otel.KeyValue, lib *otel.InstrumentationLibrary) []*otel.ResourceMetrics {
+	var result []*otel.ResourceMetrics
+	for _, group := range resourceGroups(batch) {
+		var aggregations []*aggregation
+		byMetric := make(map[*metricInfo]*aggregation)
+		for _, r := range group {
+			p := r.point
+			a, found := byMetric[p.metric]
+			if !found {
+				a = &aggregation{metric: p.metric, byKey: make(map[string]*series)}
+				byMetric[p.metric] = a
+				aggregations = append(aggregations, a)
+			}
+			s, found := a.byKey[p.seriesKey]
+			if !found {
+				s = &series{attributes: p.attributes}
+				a.byKey[p.seriesKey] = s
+				a.series = append(a.series, s)
+			}
+			s.add(p.metric.kind, p.metric.bounds, p)
+		}
+
+		ilm := &otel.InstrumentationLibraryMetrics{InstrumentationLibrary: lib}
+		for _, a := range aggregations {
+			ilm.Metrics = append(ilm.Metrics, a.toMetric())
+		}
+		result = append(result, &otel.ResourceMetrics{
+			Resource:                      newResource(group[0].resource, common),
+			InstrumentationLibraryMetrics: []*otel.InstrumentationLibraryMetrics{ilm},
+		})
+	}
+	return result
+}
+
+func resourceLogs(batch []record, common []*otel.KeyValue, lib *otel.InstrumentationLibrary) []*otel.ResourceLogs {
+	var result []*otel.ResourceLogs
+	for _, group := range resourceGroups(batch) {
+		ill := &otel.InstrumentationLibraryLogs{InstrumentationLibrary: lib}
+		for _, r := range group {
+			ill.Logs = append(ill.Logs, r.log)
+		}
+		result = append(result, &otel.ResourceLogs{
+			Resource:                   newResource(group[0].resource, common),
+			InstrumentationLibraryLogs: []*otel.InstrumentationLibraryLogs{ill},
+		})
+	}
+	return result
+}
+
+func resourceSpans(batch []record, common []*otel.KeyValue, lib *otel.InstrumentationLibrary) []*otel.ResourceSpans {
+	var result []*otel.ResourceSpans
+	for _, group := range resourceGroups(batch) {
+		ils := &otel.InstrumentationLibrarySpans{InstrumentationLibrary: lib}
+		for _, r := range group {
+			ils.Spans = append(ils.Spans, r.span)
+		}
+		result = append(result, &otel.ResourceSpans{
+			Resource:                    newResource(group[0].resource, common),
+			InstrumentationLibrarySpans: []*otel.InstrumentationLibrarySpans{ils},
+		})
+	}
+	return result
+}
+
+// newPoint converts the value of a metric instance.
+func newPoint(info *metricInfo, value interface{}, dimensions map[string]interface{}, t time.Time) (*point, error) {
+	p := &point{metric: info, time: t}
+	switch v := value.(type) {
+	case int64:
+		p.isInt, p.intValue = true, v
+	case float64:
+		p.doubleValue = v
+	case time.Duration:
+		p.doubleValue = v.Seconds()
+	default:
+		return nil, fmt.Errorf("unsupported value type %T for metric %s", value, info.name)
+	}
+	p.attributes, p.seriesKey = attributes(dimensions)
+	return p, nil
+}
+
+// newLogRecord converts a logentry instance.
+func newLogRecord(inst *logentry.Instance) *otel.LogRecord {
+	body, _ := attributes(inst.Variables)
+	return &otel.LogRecord{
+		TimeUnixNano:   unixNano(inst.Timestamp),
+		SeverityNumber: severityNumber(inst.Severity),
+		SeverityText:   inst.Severity,
+		Body:           &otel.AnyValue{Value: &otel.AnyValue_KvlistValue{KvlistValue: &otel.KeyValueList{Values: body}}},
+		Attributes:     []*otel.KeyValue{stringAttribute(logNameAttribute, inst.Name)},
+	}
+}
+
+// newSpan converts a tracespan instance, and returns false if its trace or span id is invalid. The
+// resource of a span describes the service that reported it.
+func newSpan(inst *tracespan.Instance) (*otel.Span, []*otel.KeyValue, bool) {
+	parentContext, ok := opencensus.ExtractParentContext(inst.TraceId, inst.ParentSpanId)
+	if !ok {
+		return nil, nil, false
+	}
+	spanContext, ok := opencensus.ExtractSpanContext(inst.SpanId, parentContext)
+	if !ok {
+		return nil, nil, false
+	}
+
+	var (
+		kind         otel.Span_SpanKind
+		serviceName  string
+		peerName     string
+		peerIP       net.IP
+		spanID       = spanContext.SpanID
+		parentSpanID = parentContext.SpanID
+	)
+	if inst.ClientSpan {
+		kind = otel.SPAN_KIND_CLIENT
+		serviceName, peerName, peerIP = inst.SourceName, inst.DestinationName, inst.DestinationIp
+		// The client and server spans share the same span id; give the client span its own id,
+		// which is used as the parent of the server span.
+		if inst.RewriteClientSpanId {
+			spanID = opencensus.RewriteSpanID(spanID)
+		}
+	} else {
+		kind = otel.SPAN_KIND_SERVER
+		serviceName, peerName, peerIP = inst.DestinationName, inst.SourceName, inst.SourceIp
+		if inst.RewriteClientSpanId {
+			parentSpanID = opencensus.RewriteSpanID(spanID)
+		}
+	}
+
+	span := &otel.Span{
+		TraceId:           spanContext.TraceID[:],
+		SpanId:            spanID[:],
+		Name:              inst.SpanName,
+		Kind:              kind,
+		StartTimeUnixNano: unixNano(inst.StartTime),
+		EndTimeUnixNano:   unixNano(inst.EndTime),
+	}
+	if parentSpanID != (trace.SpanID{}) {
+		span.ParentSpanId = parentSpanID[:]
+	}
+
+	span.Attributes, _ = attributes(inst.SpanTags)
+	if peerName != "" && !hasAttribute(span.Attributes, peerServiceAttribute) {
+		span.Attributes = append(span.Attributes, stringAttribute(peerServiceAttribute, peerName))
+	}
+	if peerIP != nil && !hasAttribute(span.Attributes, peerIPAttribute) {
+		span.Attributes = append(span.Attributes, stringAttribute(peerIPAttribute, peerIP.String()))
+	}
+	if inst.HttpStatusCode > 0 {
+		span.Attributes = append(span.Attributes, &otel.KeyValue{
+			Key:   httpStatusCodeAttribute,
+			Value: &otel.AnyValue{Value: &otel.AnyValue_IntValue{IntValue: inst.HttpStatusCode}},
+		})
+		// Server errors are errors on both sides, while client errors are only errors of the client.
+		if inst.HttpStatusCode >= 500 || (inst.ClientSpan && inst.HttpStatusCode >= 400) {
+			span.Status = &otel.Status{Code: otel.STATUS_CODE_ERROR}
+		}
+	}
+
+	var resource []*otel.KeyValue
+	if serviceName != "" {
+		resource = append(resource, stringAttribute(serviceNameAttribute, serviceName))
+	}
+	return span, resource, true
+}
+
+// monitoredResource returns the attributes of the resource of a metric or log entry.
+func monitoredResource(typ string, dimensions map[string]interface{}) ([]*otel.KeyValue, string) {
+	res, key := attributes(dimensions)
+	if typ != "" {
+		res = append(res, stringAttribute(resourceTypeAttribute, typ))
+		key += resourceTypeAttribute + "=" + typ
+	}
+	return res, key
+}
+
+// attributes converts the values of an instance to attributes, sorted by name, and returns them along
+// with a key identifying the set of attributes.
+func attributes(values map[string]interface{}) ([]*otel.KeyValue, string) {
+	if len(values) == 0 {
+		return nil, ""
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	kvs := make([]*otel.KeyValue, 0, len(names))
+	for _, name := range names {
+		kvs = append(kvs, &otel.KeyValue{Key: name, Value: anyValue(values[name])})
+		_, _ = fmt.Fprintf(&b, "%s=%v;", name, values[name])
+	}
+	return kvs, b.String()
+}
+
+func stringAttribute(key, value string) *otel.KeyValue {
+	return &otel.KeyValue{Key: key, Value: &otel.AnyValue{Value: &otel.AnyValue_StringValue{StringValue: value}}}
+}
+
+// anyValue converts the value of an attribute of a Mixer instance.
+func anyValue(value interface{}) *otel.AnyValue {
+	switch v := value.(type) {
+	case string:
+		return &otel.AnyValue{Value: &otel.AnyValue_StringValue{StringValue: v}}
+	case bool:
+		return &otel.AnyValue{Value: &otel.AnyValue_BoolValue{BoolValue: v}}
+	case int64:
+		return &otel.AnyValue{Value: &otel.AnyValue_IntValue{IntValue: v}}
+	case float64:
+		return &otel.AnyValue{Value: &otel.AnyValue_DoubleValue{DoubleValue: v}}
+	case time.Time:
+		return &otel.AnyValue{Value: &otel.AnyValue_StringValue{StringValue: v.Format(time.RFC3339Nano)}}
+	case net.IP:
+		return &otel.AnyValue{Value: &otel.AnyValue_StringValue{StringValue: v.String()}}
+	case []byte:
+		// IP addresses are represented as bytes.
+		return &otel.AnyValue{Value: &otel.AnyValue_StringValue{StringValue: net.IP(v).String()}}
+	case map[string]string:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		kvs := make([]*otel.KeyValue, 0, len(names))
+		for _, name := range names {
+			kvs = append(kvs, stringAttribute(name, v[name]))
+		}
+		return &otel.AnyValue{Value: &otel.AnyValue_KvlistValue{KvlistValue: &otel.KeyValueList{Values: kvs}}}
+	default:
+		return &otel.AnyValue{Value: &otel.AnyValue_StringValue{StringValue: fmt.Sprintf("%v", v)}}
+	}
+}
+
+// severityNumber maps the severity of a log entry to the OpenTelemetry log data model.
+func severityNumber(severity string) otel.SeverityNumber {
+	switch strings.ToLower(severity) {
+	case "trace":
+		return otel.SEVERITY_NUMBER_TRACE
+	case "debug":
+		return otel.SEVERITY_NUMBER_DEBUG
+	case "info", "default", "notice":
+		return otel.SEVERITY_NUMBER_INFO
+	case "warn", "warning":
+		return otel.SEVERITY_NUMBER_WARN
+	case "error":
+		return otel.SEVERITY_NUMBER_ERROR
+	case "critical", "alert", "emergency", "fatal":
+		return otel.SEVERITY_NUMBER_FATAL
+	default:
+		return otel.SEVERITY_NUMBER_UNSPECIFIED
+	}
+}
+
+func unixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
@@ -0,0 +1,198 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	otel "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry"
+	"istio.io/istio/mixer/pkg/adapter"
+)
+
+type (
+	// record is a metric point, log record or span waiting to be exported, along with the
+	// attributes of the resource it originates from.
+	record struct {
+		resource    []*otel.KeyValue
+		resourceKey string
+
+		point *point
+		log   *otel.LogRecord
+		span  *otel.Span
+	}
+
+	// sendFunc sends a batch of records to the collector.
+	sendFunc func(ctx context.Context, batch []record) error
+
+	retryPolicy struct {
+		disabled       bool
+		initialBackoff time.Duration
+		maxBackoff     time.Duration
+		maxElapsedTime time.Duration
+	}
+
+	// exporter queues the records of one kind and sends them to the collector in batches, from a
+	// single goroutine. The queue is bounded: records are dropped once it is full, which includes
+	// while a failed batch is being retried.
+	exporter struct {
+		kind          string
+		records       chan record
+		maxBatchSize  int
+		flushInterval time.Duration
+		timeout       time.Duration
+		retry         retryPolicy
+		send          sendFunc
+		logger        adapter.Logger
+
+		stopCh chan struct{}
+		doneCh chan struct{}
+	}
+)
+
+func newExporter(kind string, send sendFunc, maxQueueSize, maxBatchSize int, flushInterval, timeout time.Duration,
+	retry retryPolicy, logger adapter.Logger) *exporter {
+	return &exporter{
+		kind:          kind,
+		records:       make(chan record, maxQueueSize),
+		maxBatchSize:  maxBatchSize,
+		flushInterval: flushInterval,
+		timeout:       timeout,
+		retry:         retry,
+		send:          send,
+		logger:        logger,
+		stopCh:        make(chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+}
+
+// enqueue adds a record to the queue, and returns false if the queue is full and the record was dropped.
+func (e *exporter) enqueue(r record) bool {
+	select {
+	case e.records <- r:
+		return true
+	default:
+		return false
+	}
+}
+
+// run collects the queued records in batches and exports them, until the exporter is closed.
+func (e *exporter) run() {
+	defer close(e.doneCh)
+
+	tick := time.NewTicker(e.flushInterval)
+	defer tick.Stop()
+
+	batch := make([]record, 0, e.maxBatchSize)
+	for {
+		select {
+		case r := <-e.records:
+			batch = append(batch, r)
+			if len(batch) >= e.maxBatchSize {
+				e.export(batch)
+				batch = batch[:0]
+			}
+		case <-tick.C:
+			if len(batch) > 0 {
+				e.export(batch)
+				batch = batch[:0]
+			}
+		case <-e.stopCh:
+			e.drain(batch)
+			return
+		}
+	}
+}
+
+// drain makes a last attempt at exporting the batch and the queued records once the exporter is closed.
+// Failed batches are not retried, as the stop channel interrupts the backoff, and the remaining records
+// are dropped after a failure, rather than waiting for the export timeout of each of their batches.
+func (e *exporter) drain(batch []record) {
+	for {
+		select {
+		case r := <-e.records:
+			batch = append(batch, r)
+			if len(batch) < e.maxBatchSize {
+				continue
+			}
+		default:
+		}
+		if len(batch) == 0 {
+			return
+		}
+		if !e.export(batch) {
+			if n := len(e.records); n > 0 {
+				_ = e.logger.Errorf("dropping %d queued %s after failed export", n, e.kind)
+			}
+			return
+		}
+		batch = batch[:0]
+	}
+}
+
+// export sends a batch to the collector, retrying transient failures with an exponential backoff.
+// Returns false if the batch was dropped.
+func (e *exporter) export(batch []record) bool {
+	backoff := e.retry.initialBackoff
+	deadline := time.Now().Add(e.retry.maxElapsedTime)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+		err := e.send(ctx, batch)
+		cancel()
+		if err == nil {
+			return true
+		}
+
+		if e.retry.disabled || !retryable(err) || time.Now().Add(backoff).After(deadline) {
+			_ = e.logger.Errorf("dropping %d %s after failed export: %v", len(batch), e.kind, err)
+			return false
+		}
+		e.logger.Warningf("failed to export %d %s, retrying in %v: %v", len(batch), e.kind, backoff, err)
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-t.C:
+		case <-e.stopCh:
+			t.Stop()
+			_ = e.logger.Errorf("dropping %d %s after failed export: %v", len(batch), e.kind, err)
+			return false
+		}
+
+		backoff *= 2
+		if backoff > e.retry.maxBackoff {
+			backoff = e.retry.maxBackoff
+		}
+	}
+}
+
+// close stops the exporter once the queued records have been exported.
+func (e *exporter) close() {
+	close(e.stopCh)
+	<-e.doneCh
+}
+
+// retryable returns whether an export failure is transient, according to the OTLP specification.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted,
+		codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"istio.io/istio/mixer/pkg/adapter/test"
+)
+
+// fakeSender records the size of the batches it is given, and fails the first calls with the given errors.
+type fakeSender struct {
+	sync.Mutex
+	errs    []error
+	batches []int
+	calls   int
+}
+
+func (s *fakeSender) send(_ context.Context, batch []record) error {
+	s.Lock()
+	defer s.Unlock()
+	s.calls++
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return err
+	}
+	s.batches = append(s.batches, len(batch))
+	return nil
+}
+
+func (s *fakeSender) results() ([]int, int) {
+	s.Lock()
+	defer s.Unlock()
+	return append([]int(nil), s.batches...), s.calls
+}
+
+func newTestExporter(t *testing.T, s *fakeSender, queueSize, batchSize int, interval time.Duration, retry retryPolicy) *exporter {
+	return newExporter("records", s.send, queueSize, batchSize, interval, time.Second, retry, test.NewEnv(t))
+}
+
+func TestExporterBatching(t *testing.T) {
+	s := &fakeSender{}
+	e := newTestExporter(t, s, 10, 2, time.Hour, retryPolicy{})
+	for i := 0; i < 5; i++ {
+		if !e.enqueue(record{}) {
+			t.Fatalf("enqueue() => false, expecting the record to be queued")
+		}
+	}
+
+	go e.run()
+	e.close()
+
+	batches, calls := s.results()
+	if len(batches) != 3 || batches[0] != 2 || batches[1] != 2 || batches[2] != 1 || calls != 3 {
+		t.Errorf("Got batches %v in %d calls, expecting [2 2 1]", batches, calls)
+	}
+}
+
+func TestExporterFlushInterval(t *testing.T) {
+	s := &fakeSender{}
+	e := newTestExporter(t, s, 10, 100, time.Millisecond, retryPolicy{})
+	go e.run()
+	defer e.close()
+
+	e.enqueue(record{})
+	for i := 0; ; i++ {
+		if batches, _ := s.results(); len(batches) == 1 {
+			break
+		}
+		if i == 500 {
+			t.Fatal("record was not exported")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestExporterQueueFull(t *testing.T) {
+	s := &fakeSender{}
+	e := newTestExporter(t, s, 2, 100, time.Hour, retryPolicy{})
+	if !e.enqueue(record{}) || !e.enqueue(record{}) {
+		t.Fatal("enqueue() => false, expecting the records to be queued")
+	}
+	if e.enqueue(record{}) {
+		t.Error("enqueue() => true, expecting the record to be dropped")
+	}
+
+	go e.run()
+	e.close()
+
+	if batches, _ := s.results(); len(batches) != 1 || batches[0] != 2 {
+		t.Errorf("Got batches %v, expecting [2]", batches)
+	}
+}
+
+func TestExporterRetry(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+
+	cases := []struct {
+		name    string
+		errs    []error
+		retry   retryPolicy
+		calls   int
+		dropped bool
+	}{
+		{"success", nil,
+			retryPolicy{initialBackoff: time.Millisecond, maxBackoff: time.Millisecond, maxElapsedTime: time.Minute}, 1, false},
+		{"transient failures", []error{unavailable, status.Error(codes.ResourceExhausted, "throttled")},
+			retryPolicy{initialBackoff: time.Millisecond, maxBackoff: time.Millisecond, maxElapsedTime: time.Minute}, 3, false},
+		{"permanent failure", []error{status.Error(codes.InvalidArgument, "bad request")},
+			retryPolicy{initialBackoff: time.Millisecond, maxBackoff: time.Millisecond, maxElapsedTime: time.Minute}, 1, true},
+		{"unknown failure", []error{errors.New("bad")},
+			retryPolicy{initialBackoff: time.Millisecond, maxBackoff: time.Millisecond, maxElapsedTime: time.Minute}, 1, true},
+		{"retry disabled", []error{unavailable},
+			retryPolicy{disabled: true, initialBackoff: time.Millisecond, maxBackoff: time.Millisecond, maxElapsedTime: time.Minute}, 1, true},
+		{"max elapsed time", []error{unavailable, unavailable, unavailable, unavailable},
+			retryPolicy{initialBackoff: 10 * time.Millisecond, maxBackoff: time.Second, maxElapsedTime: 50 * time.Millisecond}, 3, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := &fakeSender{errs: c.errs}
+			e := newTestExporter(t, s, 10, 100, time.Hour, c.retry)
+			e.export([]record{{}})
+
+			batches, calls := s.results()
+			if calls != c.calls {
+				t.Errorf("Got %d calls, expecting %d", calls, c.calls)
+			}
+			if dropped := len(batches) == 0; dropped != c.dropped {
+				t.Errorf("Got dropped %v, expecting %v", dropped, c.dropped)
+			}
+		})
+	}
+}
+
+func TestExporterCloseInterruptsBackoff(t *testing.T) {
+	s := &fakeSender{errs: []error{status.Error(codes.Unavailable, "unavailable")}}
+	e := newTestExporter(t, s, 10, 1, time.Hour,
+		retryPolicy{initialBackoff: time.Hour, maxBackoff: time.Hour, maxElapsedTime: 24 * time.Hour})
+	e.enqueue(record{})
+	go e.run()
+
+	for i := 0; ; i++ {
+		if _, calls := s.results(); calls == 1 {
+			break
+		}
+		if i == 500 {
+			t.Fatal("record was not exported")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		e.close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("close() did not interrupt the backoff")
+	}
+
+	if batches, calls := s.results(); len(batches) != 0 || calls != 1 {
+		t.Errorf("Got batches %v in %d calls, expecting the batch to be dropped", batches, calls)
+	}
+}
+
+func TestExporterDrainDropsAfterFailure(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	s := &fakeSender{errs: []error{unavailable, unavailable, unavailable}}
+	e := newTestExporter(t, s, 10, 2, time.Hour,
+		retryPolicy{initialBackoff: time.Hour, maxBackoff: time.Hour, maxElapsedTime: 24 * time.Hour})
+	for i := 0; i < 5; i++ {
+		e.enqueue(record{})
+	}
+
+	close(e.stopCh)
+	e.drain(nil)
+
+	if batches, calls := s.results(); len(batches) != 0 || calls != 1 {
+		t.Errorf("Got batches %v in %d calls, expecting the records to be dropped after the first failure", batches, calls)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint: lll
+//go:generate $REPO_ROOT/bin/mixer_codegen.sh -d false -f mixer/adapter/otlp/internal/opentelemetry/common.proto
+//go:generate $REPO_ROOT/bin/mixer_codegen.sh -d false -f mixer/adapter/otlp/internal/opentelemetry/resource.proto
+//go:generate $REPO_ROOT/bin/mixer_codegen.sh -d false -f mixer/adapter/otlp/internal/opentelemetry/metrics.proto
+//go:generate $REPO_ROOT/bin/mixer_codegen.sh -d false -f mixer/adapter/otlp/internal/opentelemetry/logs.proto
+//go:generate $REPO_ROOT/bin/mixer_codegen.sh -d false -f mixer/adapter/otlp/internal/opentelemetry/trace.proto
+//go:generate $REPO_ROOT/bin/mixer_codegen.sh -d false -f mixer/adapter/otlp/internal/opentelemetry/metrics_service.proto
+//go:generate $REPO_ROOT/bin/mixer_codegen.sh -d false -f mixer/adapter/otlp/internal/opentelemetry/logs_service.proto
+//go:generate $REPO_ROOT/bin/mixer_codegen.sh -d false -f mixer/adapter/otlp/internal/opentelemetry/trace_service.proto
+
+// Package opentelemetry contains the subset of the OpenTelemetry protocol (OTLP)
+// messages and collector services used by the otlp adapter. The messages keep
+// the proto package names and field numbers of the upstream definitions at
+// https://github.com/open-telemetry/opentelemetry-proto, so that they are wire
+// compatible with any OTLP/gRPC receiver.
+package opentelemetry
@@ -0,0 +1,1520 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: mixer/adapter/otlp/internal/opentelemetry/logs.proto
+
+// Subset of opentelemetry/proto/logs/v1/logs.proto used by the otlp adapter.
+// Field numbers are kept identical to the upstream definition.
+
+package opentelemetry
+
+import (
+	bytes "bytes"
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	reflect "reflect"
+	strconv "strconv"
+	strings "strings"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Possible values for LogRecord.SeverityNumber.
+type SeverityNumber int32
+
+const (
+	SEVERITY_NUMBER_UNSPECIFIED SeverityNumber = 0
+	SEVERITY_NUMBER_TRACE       SeverityNumber = 1
+	SEVERITY_NUMBER_DEBUG       SeverityNumber = 5
+	SEVERITY_NUMBER_INFO        SeverityNumber = 9
+	SEVERITY_NUMBER_WARN        SeverityNumber = 13
+	SEVERITY_NUMBER_ERROR       SeverityNumber = 17
+	SEVERITY_NUMBER_FATAL       SeverityNumber = 21
+)
+
+var SeverityNumber_name = map[int32]string{
+	0:  "SEVERITY_NUMBER_UNSPECIFIED",
+	1:  "SEVERITY_NUMBER_TRACE",
+	5:  "SEVERITY_NUMBER_DEBUG",
+	9:  "SEVERITY_NUMBER_INFO",
+	13: "SEVERITY_NUMBER_WARN",
+	17: "SEVERITY_NUMBER_ERROR",
+	21: "SEVERITY_NUMBER_FATAL",
+}
+
+var SeverityNumber_value = map[string]int32{
+	"SEVERITY_NUMBER_UNSPECIFIED": 0,
+	"SEVERITY_NUMBER_TRACE":       1,
+	"SEVERITY_NUMBER_DEBUG":       5,
+	"SEVERITY_NUMBER_INFO":        9,
+	"SEVERITY_NUMBER_WARN":        13,
+	"SEVERITY_NUMBER_ERROR":       17,
+	"SEVERITY_NUMBER_FATAL":       21,
+}
+
+func (SeverityNumber) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ca47f63361fdb93b, []int{0}
+}
+
+// A collection of InstrumentationLibraryLogs from a Resource.
+type ResourceLogs struct {
+	// The resource for the logs in this message.
+	Resource *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// A list of InstrumentationLibraryLogs that originate from a resource.
+	InstrumentationLibraryLogs []*InstrumentationLibraryLogs `protobuf:"bytes,2,rep,name=instrumentation_library_logs,json=instrumentationLibraryLogs,proto3" json:"instrumentation_library_logs,omitempty"`
+}
+
+func (m *ResourceLogs) Reset()      { *m = ResourceLogs{} }
+func (*ResourceLogs) ProtoMessage() {}
+func (*ResourceLogs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca47f63361fdb93b, []int{0}
+}
+func (m *ResourceLogs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResourceLogs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResourceLogs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResourceLogs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceLogs.Merge(m, src)
+}
+func (m *ResourceLogs) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResourceLogs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceLogs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceLogs proto.InternalMessageInfo
+
+func (m *ResourceLogs) GetResource() *Resource {
+	if m != nil {
+		return m.Resource
+	}
+	return nil
+}
+
+func (m *ResourceLogs) GetInstrumentationLibraryLogs() []*InstrumentationLibraryLogs {
+	if m != nil {
+		return m.InstrumentationLibraryLogs
+	}
+	return nil
+}
+
+// A collection of Logs produced by an InstrumentationLibrary.
+type InstrumentationLibraryLogs struct {
+	// The instrumentation library information for the logs in this message.
+	InstrumentationLibrary *InstrumentationLibrary `protobuf:"bytes,1,opt,name=instrumentation_library,json=instrumentationLibrary,proto3" json:"instrumentation_library,omitempty"`
+	// A list of log records.
+	Logs []*LogRecord `protobuf:"bytes,2,rep,name=logs,proto3" json:"logs,omitempty"`
+}
+
+func (m *InstrumentationLibraryLogs) Reset()      { *m = InstrumentationLibraryLogs{} }
+func (*InstrumentationLibraryLogs) ProtoMessage() {}
+func (*InstrumentationLibraryLogs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca47f63361fdb93b, []int{1}
+}
+func (m *InstrumentationLibraryLogs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InstrumentationLibraryLogs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InstrumentationLibraryLogs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InstrumentationLibraryLogs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstrumentationLibraryLogs.Merge(m, src)
+}
+func (m *InstrumentationLibraryLogs) XXX_Size() int {
+	return m.Size()
+}
+func (m *InstrumentationLibraryLogs) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstrumentationLibraryLogs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstrumentationLibraryLogs proto.InternalMessageInfo
+
+func (m *InstrumentationLibraryLogs) GetInstrumentationLibrary() *InstrumentationLibrary {
+	if m != nil {
+		return m.InstrumentationLibrary
+	}
+	return nil
+}
+
+func (m *InstrumentationLibraryLogs) GetLogs() []*LogRecord {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+// A log record according to OpenTelemetry Log Data Model.
+type LogRecord struct {
+	// time_unix_nano is the time when the event occurred.
+	TimeUnixNano uint64 `protobuf:"fixed64,1,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`
+	// Numerical value of the severity, normalized to values described in Log Data Model.
+	SeverityNumber SeverityNumber `protobuf:"varint,2,opt,name=severity_number,json=severityNumber,proto3,enum=opentelemetry.proto.logs.v1.SeverityNumber" json:"severity_number,omitempty"`
+	// The severity text (also known as log level).
+	SeverityText string `protobuf:"bytes,3,opt,name=severity_text,json=severityText,proto3" json:"severity_text,omitempty"`
+	// A value containing the body of the log record.
+	Body *AnyValue `protobuf:"bytes,5,opt,name=body,proto3" json:"body,omitempty"`
+	// Additional attributes that describe the specific event occurrence.
+	Attributes             []*KeyValue `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	DroppedAttributesCount uint32      `protobuf:"varint,7,opt,name=dropped_attributes_count,json=droppedAttributesCount,proto3" json:"dropped_attributes_count,omitempty"`
+	// Flags, a bit field.
+	Flags uint32 `protobuf:"fixed32,8,opt,name=flags,proto3" json:"flags,omitempty"`
+	// A unique identifier for a trace, as a 16-byte array.
+	TraceId []byte `protobuf:"bytes,9,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// A unique identifier for a span within a trace, as an 8-byte array.
+	SpanId []byte `protobuf:"bytes,10,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`
+}
+
+func (m *LogRecord) Reset()      { *m = LogRecord{} }
+func (*LogRecord) ProtoMessage() {}
+func (*LogRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca47f63361fdb93b, []int{2}
+}
+func (m *LogRecord) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogRecord.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LogRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogRecord.Merge(m, src)
+}
+func (m *LogRecord) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogRecord proto.InternalMessageInfo
+
+func (m *LogRecord) GetTimeUnixNano() uint64 {
+	if m != nil {
+		return m.TimeUnixNano
+	}
+	return 0
+}
+
+func (m *LogRecord) GetSeverityNumber() SeverityNumber {
+	if m != nil {
+		return m.SeverityNumber
+	}
+	return SEVERITY_NUMBER_UNSPECIFIED
+}
+
+func (m *LogRecord) GetSeverityText() string {
+	if m != nil {
+		return m.SeverityText
+	}
+	return ""
+}
+
+func (m *LogRecord) GetBody() *AnyValue {
+	if m != nil {
+		return m.Body
+	}
+	return nil
+}
+
+func (m *LogRecord) GetAttributes() []*KeyValue {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *LogRecord) GetDroppedAttributesCount() uint32 {
+	if m != nil {
+		return m.DroppedAttributesCount
+	}
+	return 0
+}
+
+func (m *LogRecord) GetFlags() uint32 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
+func (m *LogRecord) GetTraceId() []byte {
+	if m != nil {
+		return m.TraceId
+	}
+	return nil
+}
+
+func (m *LogRecord) GetSpanId() []byte {
+	if m != nil {
+		return m.SpanId
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("opentelemetry.proto.logs.v1.SeverityNumber", SeverityNumber_name, SeverityNumber_value)
+	proto.RegisterType((*ResourceLogs)(nil), "opentelemetry.proto.logs.v1.ResourceLogs")
+	proto.RegisterType((*InstrumentationLibraryLogs)(nil), "opentelemetry.proto.logs.v1.InstrumentationLibraryLogs")
+	proto.RegisterType((*LogRecord)(nil), "opentelemetry.proto.logs.v1.LogRecord")
+}
+
+func init() {
+	proto.RegisterFile("mixer/adapter/otlp/internal/opentelemetry/logs.proto", fileDescriptor_ca47f63361fdb93b)
+}
+
+var fileDescriptor_ca47f63361fdb93b = []byte{
+	// 636 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0xcd, 0x4e, 0xdb, 0x4e,
+	0x14, 0xc5, 0x33, 0x04, 0x12, 0x32, 0x24, 0xf9, 0xfb, 0x3f, 0xe2, 0xc3, 0x84, 0xca, 0xb5, 0x68,
+	0xd5, 0xba, 0xad, 0x94, 0x08, 0xfa, 0x85, 0xda, 0x55, 0x08, 0x06, 0x59, 0x4d, 0x43, 0x35, 0x24,
+	0x54, 0xed, 0xc6, 0x72, 0xe2, 0x69, 0x64, 0xc9, 0x9e, 0xb1, 0xc6, 0x63, 0x94, 0xec, 0xfa, 0x08,
+	0x7d, 0x8c, 0x3e, 0x41, 0x9f, 0x81, 0x65, 0xba, 0x63, 0x59, 0xcc, 0xa6, 0x4b, 0x1e, 0xa1, 0x8a,
+	0x13, 0x52, 0x82, 0x1c, 0xca, 0xf2, 0x9e, 0x9f, 0xcf, 0xf1, 0xb9, 0x77, 0xe0, 0x0b, 0xcf, 0xe9,
+	0x11, 0x5e, 0xb1, 0x6c, 0xcb, 0x17, 0x84, 0x57, 0x98, 0x70, 0xfd, 0x8a, 0x43, 0x05, 0xe1, 0xd4,
+	0x72, 0x2b, 0xcc, 0x27, 0x54, 0x10, 0x97, 0x78, 0x44, 0xf0, 0x7e, 0xc5, 0x65, 0xdd, 0xa0, 0xec,
+	0x73, 0x26, 0x18, 0xda, 0x98, 0x22, 0x23, 0xb1, 0x1c, 0xf3, 0x93, 0xad, 0xd2, 0xab, 0xbb, 0x47,
+	0x76, 0x98, 0xe7, 0x31, 0x3a, 0xf2, 0x97, 0x76, 0xee, 0xee, 0xe3, 0x24, 0x60, 0x21, 0xef, 0x90,
+	0x91, 0x73, 0x73, 0x00, 0x60, 0x1e, 0x8f, 0xa5, 0x3a, 0xeb, 0x06, 0x48, 0x87, 0x8b, 0x57, 0x9f,
+	0xc8, 0x40, 0x05, 0xda, 0xd2, 0xf6, 0x93, 0x72, 0x52, 0xe5, 0x49, 0xce, 0xc9, 0x56, 0xf9, 0x2a,
+	0x00, 0x4f, 0xac, 0xa8, 0x0f, 0xef, 0x39, 0x34, 0x10, 0x3c, 0xf4, 0x08, 0x15, 0x96, 0x70, 0x18,
+	0x35, 0x5d, 0xa7, 0xcd, 0x2d, 0xde, 0x37, 0x87, 0xcb, 0xca, 0x73, 0x6a, 0x5a, 0x5b, 0xda, 0x7e,
+	0x5d, 0xbe, 0xe5, 0x1a, 0x65, 0x63, 0x3a, 0xa0, 0x3e, 0xf2, 0x0f, 0x5b, 0xe2, 0x92, 0x33, 0x93,
+	0x6d, 0x9e, 0x02, 0x58, 0x9a, 0x6d, 0x45, 0x14, 0xae, 0xcd, 0x68, 0x36, 0xde, 0xf7, 0x65, 0x62,
+	0xa9, 0xf1, 0xbd, 0x67, 0xd6, 0xc2, 0xab, 0xc9, 0x95, 0xd0, 0x1b, 0x38, 0x7f, 0x6d, 0xe3, 0x47,
+	0xb7, 0x6e, 0x5c, 0x67, 0x5d, 0x4c, 0x3a, 0x8c, 0xdb, 0x38, 0xf6, 0x6c, 0xfe, 0x48, 0xc3, 0xdc,
+	0x44, 0x43, 0x0f, 0x61, 0x51, 0x38, 0x1e, 0x31, 0x43, 0xea, 0xf4, 0x4c, 0x6a, 0x51, 0x16, 0x17,
+	0xce, 0xe0, 0xfc, 0x50, 0x6d, 0x51, 0xa7, 0xd7, 0xb0, 0x28, 0x43, 0x4d, 0xf8, 0x5f, 0x40, 0x4e,
+	0x08, 0x77, 0x44, 0xdf, 0xa4, 0xa1, 0xd7, 0x26, 0x5c, 0x9e, 0x53, 0x81, 0x56, 0xdc, 0x7e, 0x76,
+	0xeb, 0xaf, 0x8f, 0xc6, 0x9e, 0x46, 0x6c, 0xc1, 0xc5, 0x60, 0x6a, 0x46, 0x0f, 0x60, 0x61, 0x92,
+	0x2a, 0x48, 0x4f, 0xc8, 0x69, 0x15, 0x68, 0x39, 0x9c, 0xbf, 0x12, 0x9b, 0xa4, 0x27, 0xd0, 0x5b,
+	0x38, 0xdf, 0x66, 0x76, 0x5f, 0x5e, 0x88, 0xef, 0xf8, 0xf8, 0x1f, 0x77, 0xac, 0xd2, 0xfe, 0xb1,
+	0xe5, 0x86, 0x04, 0xc7, 0x26, 0x74, 0x00, 0xa1, 0x25, 0x04, 0x77, 0xda, 0xa1, 0x20, 0x81, 0x9c,
+	0x51, 0xd3, 0x77, 0x88, 0x78, 0x47, 0xc6, 0x11, 0xd7, 0xac, 0x68, 0x07, 0xca, 0x36, 0x67, 0xbe,
+	0x4f, 0x6c, 0xf3, 0xaf, 0x6a, 0x76, 0x58, 0x48, 0x85, 0x9c, 0x55, 0x81, 0x56, 0xc0, 0xab, 0x63,
+	0x5e, 0x9d, 0xe0, 0xda, 0x90, 0xa2, 0x65, 0xb8, 0xf0, 0xc5, 0xb5, 0xba, 0x81, 0xbc, 0xa8, 0x02,
+	0x2d, 0x8b, 0x47, 0x03, 0x5a, 0x87, 0x8b, 0x82, 0x5b, 0x1d, 0x62, 0x3a, 0xb6, 0x9c, 0x53, 0x81,
+	0x96, 0xc7, 0xd9, 0x78, 0x36, 0x6c, 0xb4, 0x06, 0xb3, 0x81, 0x6f, 0xd1, 0x21, 0x81, 0x31, 0xc9,
+	0x0c, 0x47, 0xc3, 0x7e, 0xfa, 0x13, 0xc0, 0xe2, 0xf4, 0x45, 0xd1, 0x7d, 0xb8, 0x71, 0xa4, 0x1f,
+	0xeb, 0xd8, 0x68, 0x7e, 0x32, 0x1b, 0xad, 0xf7, 0xbb, 0x3a, 0x36, 0x5b, 0x8d, 0xa3, 0x0f, 0x7a,
+	0xcd, 0xd8, 0x37, 0xf4, 0x3d, 0x29, 0x85, 0xd6, 0xe1, 0xca, 0xcd, 0x0f, 0x9a, 0xb8, 0x5a, 0xd3,
+	0x25, 0x90, 0x84, 0xf6, 0xf4, 0xdd, 0xd6, 0x81, 0xb4, 0x80, 0x64, 0xb8, 0x7c, 0x13, 0x19, 0x8d,
+	0xfd, 0x43, 0x29, 0x97, 0x44, 0x3e, 0x56, 0x71, 0x43, 0x2a, 0x24, 0xc5, 0xe9, 0x18, 0x1f, 0x62,
+	0xe9, 0xff, 0x24, 0xb4, 0x5f, 0x6d, 0x56, 0xeb, 0xd2, 0xca, 0x6e, 0x6d, 0x70, 0xae, 0xa4, 0xce,
+	0xce, 0x95, 0xd4, 0xe5, 0xb9, 0x02, 0xbe, 0x46, 0x0a, 0xf8, 0x1e, 0x29, 0xe0, 0x34, 0x52, 0xc0,
+	0x20, 0x52, 0xc0, 0xaf, 0x48, 0x01, 0xbf, 0x23, 0x25, 0x75, 0x19, 0x29, 0xe0, 0xdb, 0x85, 0x92,
+	0x1a, 0x5c, 0x28, 0xa9, 0xb3, 0x0b, 0x25, 0xf5, 0xb9, 0x30, 0xf5, 0x82, 0xed, 0x4c, 0xfc, 0x84,
+	0xcf, 0xff, 0x0c, 0x00, 0x1b, 0x5f, 0x24, 0x6e, 0x3d, 0x05, 0x00, 0x00,
+}
+
+func (x SeverityNumber) String() string {
+	s, ok := SeverityNumber_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (this *ResourceLogs) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ResourceLogs)
+	if !ok {
+		that2, ok := that.(ResourceLogs)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Resource.Equal(that1.Resource) {
+		return false
+	}
+	if len(this.InstrumentationLibraryLogs) != len(that1.InstrumentationLibraryLogs) {
+		return false
+	}
+	for i := range this.InstrumentationLibraryLogs {
+		if !this.InstrumentationLibraryLogs[i].Equal(that1.InstrumentationLibraryLogs[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *InstrumentationLibraryLogs) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*InstrumentationLibraryLogs)
+	if !ok {
+		that2, ok := that.(InstrumentationLibraryLogs)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.InstrumentationLibrary.Equal(that1.InstrumentationLibrary) {
+		return false
+	}
+	if len(this.Logs) != len(that1.Logs) {
+		return false
+	}
+	for i := range this.Logs {
+		if !this.Logs[i].Equal(that1.Logs[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *LogRecord) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LogRecord)
+	if !ok {
+		that2, ok := that.(LogRecord)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.TimeUnixNano != that1.TimeUnixNano {
+		return false
+	}
+	if this.SeverityNumber != that1.SeverityNumber {
+		return false
+	}
+	if this.SeverityText != that1.SeverityText {
+		return false
+	}
+	if !this.Body.Equal(that1.Body) {
+		return false
+	}
+	if len(this.Attributes) != len(that1.Attributes) {
+		return false
+	}
+	for i := range this.Attributes {
+		if !this.Attributes[i].Equal(that1.Attributes[i]) {
+			return false
+		}
+	}
+	if this.DroppedAttributesCount != that1.DroppedAttributesCount {
+		return false
+	}
+	if this.Flags != that1.Flags {
+		return false
+	}
+	if !bytes.Equal(this.TraceId, that1.TraceId) {
+		return false
+	}
+	if !bytes.Equal(this.SpanId, that1.SpanId) {
+		return false
+	}
+	return true
+}
+func (this *ResourceLogs) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&opentelemetry.ResourceLogs{")
+	if this.Resource != nil {
+		s = append(s, "Resource: "+fmt.Sprintf("%#v", this.Resource)+",\n")
+	}
+	if this.InstrumentationLibraryLogs != nil {
+		s = append(s, "InstrumentationLibraryLogs: "+fmt.Sprintf("%#v", this.InstrumentationLibraryLogs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *InstrumentationLibraryLogs) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&opentelemetry.InstrumentationLibraryLogs{")
+	if this.InstrumentationLibrary != nil {
+		s = append(s, "InstrumentationLibrary: "+fmt.Sprintf("%#v", this.InstrumentationLibrary)+",\n")
+	}
+	if this.Logs != nil {
+		s = append(s, "Logs: "+fmt.Sprintf("%#v", this.Logs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LogRecord) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 13)
+	s = append(s, "&opentelemetry.LogRecord{")
+	s = append(s, "TimeUnixNano: "+fmt.Sprintf("%#v", this.TimeUnixNano)+",\n")
+	s = append(s, "SeverityNumber: "+fmt.Sprintf("%#v", this.SeverityNumber)+",\n")
+	s = append(s, "SeverityText: "+fmt.Sprintf("%#v", this.SeverityText)+",\n")
+	if this.Body != nil {
+		s = append(s, "Body: "+fmt.Sprintf("%#v", this.Body)+",\n")
+	}
+	if this.Attributes != nil {
+		s = append(s, "Attributes: "+fmt.Sprintf("%#v", this.Attributes)+",\n")
+	}
+	s = append(s, "DroppedAttributesCount: "+fmt.Sprintf("%#v", this.DroppedAttributesCount)+",\n")
+	s = append(s, "Flags: "+fmt.Sprintf("%#v", this.Flags)+",\n")
+	s = append(s, "TraceId: "+fmt.Sprintf("%#v", this.TraceId)+",\n")
+	s = append(s, "SpanId: "+fmt.Sprintf("%#v", this.SpanId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringLogs(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *ResourceLogs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResourceLogs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResourceLogs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.InstrumentationLibraryLogs) > 0 {
+		for iNdEx := len(m.InstrumentationLibraryLogs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.InstrumentationLibraryLogs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLogs(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Resource != nil {
+		{
+			size, err := m.Resource.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogs(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InstrumentationLibraryLogs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InstrumentationLibraryLogs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InstrumentationLibraryLogs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLogs(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.InstrumentationLibrary != nil {
+		{
+			size, err := m.InstrumentationLibrary.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogs(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LogRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.SpanId) > 0 {
+		i -= len(m.SpanId)
+		copy(dAtA[i:], m.SpanId)
+		i = encodeVarintLogs(dAtA, i, uint64(len(m.SpanId)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.TraceId) > 0 {
+		i -= len(m.TraceId)
+		copy(dAtA[i:], m.TraceId)
+		i = encodeVarintLogs(dAtA, i, uint64(len(m.TraceId)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Flags != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.Flags))
+		i--
+		dAtA[i] = 0x45
+	}
+	if m.DroppedAttributesCount != 0 {
+		i = encodeVarintLogs(dAtA, i, uint64(m.DroppedAttributesCount))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.Attributes) > 0 {
+		for iNdEx := len(m.Attributes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Attributes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLogs(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.Body != nil {
+		{
+			size, err := m.Body.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogs(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.SeverityText) > 0 {
+		i -= len(m.SeverityText)
+		copy(dAtA[i:], m.SeverityText)
+		i = encodeVarintLogs(dAtA, i, uint64(len(m.SeverityText)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.SeverityNumber != 0 {
+		i = encodeVarintLogs(dAtA, i, uint64(m.SeverityNumber))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.TimeUnixNano != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.TimeUnixNano))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintLogs(dAtA []byte, offset int, v uint64) int {
+	offset -= sovLogs(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ResourceLogs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Resource != nil {
+		l = m.Resource.Size()
+		n += 1 + l + sovLogs(uint64(l))
+	}
+	if len(m.InstrumentationLibraryLogs) > 0 {
+		for _, e := range m.InstrumentationLibraryLogs {
+			l = e.Size()
+			n += 1 + l + sovLogs(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *InstrumentationLibraryLogs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.InstrumentationLibrary != nil {
+		l = m.InstrumentationLibrary.Size()
+		n += 1 + l + sovLogs(uint64(l))
+	}
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovLogs(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LogRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TimeUnixNano != 0 {
+		n += 9
+	}
+	if m.SeverityNumber != 0 {
+		n += 1 + sovLogs(uint64(m.SeverityNumber))
+	}
+	l = len(m.SeverityText)
+	if l > 0 {
+		n += 1 + l + sovLogs(uint64(l))
+	}
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovLogs(uint64(l))
+	}
+	if len(m.Attributes) > 0 {
+		for _, e := range m.Attributes {
+			l = e.Size()
+			n += 1 + l + sovLogs(uint64(l))
+		}
+	}
+	if m.DroppedAttributesCount != 0 {
+		n += 1 + sovLogs(uint64(m.DroppedAttributesCount))
+	}
+	if m.Flags != 0 {
+		n += 5
+	}
+	l = len(m.TraceId)
+	if l > 0 {
+		n += 1 + l + sovLogs(uint64(l))
+	}
+	l = len(m.SpanId)
+	if l > 0 {
+		n += 1 + l + sovLogs(uint64(l))
+	}
+	return n
+}
+
+func sovLogs(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozLogs(x uint64) (n int) {
+	return sovLogs(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *ResourceLogs) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForInstrumentationLibraryLogs := "[]*InstrumentationLibraryLogs{"
+	for _, f := range this.InstrumentationLibraryLogs {
+		repeatedStringForInstrumentationLibraryLogs += strings.Replace(f.String(), "InstrumentationLibraryLogs", "InstrumentationLibraryLogs", 1) + ","
+	}
+	repeatedStringForInstrumentationLibraryLogs += "}"
+	s := strings.Join([]string{`&ResourceLogs{`,
+		`Resource:` + strings.Replace(fmt.Sprintf("%v", this.Resource), "Resource", "Resource", 1) + `,`,
+		`InstrumentationLibraryLogs:` + repeatedStringForInstrumentationLibraryLogs + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *InstrumentationLibraryLogs) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForLogs := "[]*LogRecord{"
+	for _, f := range this.Logs {
+		repeatedStringForLogs += strings.Replace(f.String(), "LogRecord", "LogRecord", 1) + ","
+	}
+	repeatedStringForLogs += "}"
+	s := strings.Join([]string{`&InstrumentationLibraryLogs{`,
+		`InstrumentationLibrary:` + strings.Replace(fmt.Sprintf("%v", this.InstrumentationLibrary), "InstrumentationLibrary", "InstrumentationLibrary", 1) + `,`,
+		`Logs:` + repeatedStringForLogs + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LogRecord) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForAttributes := "[]*KeyValue{"
+	for _, f := range this.Attributes {
+		repeatedStringForAttributes += strings.Replace(fmt.Sprintf("%v", f), "KeyValue", "KeyValue", 1) + ","
+	}
+	repeatedStringForAttributes += "}"
+	s := strings.Join([]string{`&LogRecord{`,
+		`TimeUnixNano:` + fmt.Sprintf("%v", this.TimeUnixNano) + `,`,
+		`SeverityNumber:` + fmt.Sprintf("%v", this.SeverityNumber) + `,`,
+		`SeverityText:` + fmt.Sprintf("%v", this.SeverityText) + `,`,
+		`Body:` + strings.Replace(fmt.Sprintf("%v", this.Body), "AnyValue", "AnyValue", 1) + `,`,
+		`Attributes:` + repeatedStringForAttributes + `,`,
+		`DroppedAttributesCount:` + fmt.Sprintf("%v", this.DroppedAttributesCount) + `,`,
+		`Flags:` + fmt.Sprintf("%v", this.Flags) + `,`,
+		`TraceId:` + fmt.Sprintf("%v", this.TraceId) + `,`,
+		`SpanId:` + fmt.Sprintf("%v", this.SpanId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringLogs(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *ResourceLogs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResourceLogs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResourceLogs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Resource == nil {
+				m.Resource = &Resource{}
+			}
+			if err := m.Resource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstrumentationLibraryLogs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InstrumentationLibraryLogs = append(m.InstrumentationLibraryLogs, &InstrumentationLibraryLogs{})
+			if err := m.InstrumentationLibraryLogs[len(m.InstrumentationLibraryLogs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InstrumentationLibraryLogs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InstrumentationLibraryLogs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InstrumentationLibraryLogs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstrumentationLibrary", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InstrumentationLibrary == nil {
+				m.InstrumentationLibrary = &InstrumentationLibrary{}
+			}
+			if err := m.InstrumentationLibrary.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, &LogRecord{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogs
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeUnixNano", wireType)
+			}
+			m.TimeUnixNano = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TimeUnixNano = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeverityNumber", wireType)
+			}
+			m.SeverityNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SeverityNumber |= SeverityNumber(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeverityText", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SeverityText = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Body", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Body == nil {
+				m.Body = &AnyValue{}
+			}
+			if err := m.Body.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attributes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attributes = append(m.Attributes, &KeyValue{})
+			if err := m.Attributes[len(m.Attributes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DroppedAttributesCount", wireType)
+			}
+			m.DroppedAttributesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DroppedAttributesCount |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flags", wireType)
+			}
+			m.Flags = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Flags = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthLogs
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TraceId = append(m.TraceId[:0], dAtA[iNdEx:postIndex]...)
+			if m.TraceId == nil {
+				m.TraceId = []byte{}
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpanId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthLogs
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SpanId = append(m.SpanId[:0], dAtA[iNdEx:postIndex]...)
+			if m.SpanId == nil {
+				m.SpanId = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogs(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLogs
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipLogs(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowLogs
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowLogs
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthLogs
+			}
+			iNdEx += length
+			if iNdEx < 0 {
+				return 0, ErrInvalidLengthLogs
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowLogs
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipLogs(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+				if iNdEx < 0 {
+					return 0, ErrInvalidLengthLogs
+				}
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthLogs = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowLogs   = fmt.Errorf("proto: integer overflow")
+)
@@ -0,0 +1,400 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint: lll
+//go:generate $REPO_ROOT/bin/mixer_codegen.sh -a mixer/adapter/otlp/config/config.proto -x "-n otlp -t logentry -t metric -t tracespan"
+
+// Package otlp contains an adapter that exports metrics, logs and traces to an
+// OpenTelemetry collector, using the OpenTelemetry protocol (OTLP) over gRPC.
+package otlp
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sort"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	grpcMetadata "google.golang.org/grpc/metadata"
+
+	descriptor "istio.io/api/policy/v1beta1"
+	"istio.io/istio/mixer/adapter/metadata"
+	"istio.io/istio/mixer/adapter/otlp/config"
+	otel "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry"
+	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/template/logentry"
+	"istio.io/istio/mixer/template/metric"
+	"istio.io/istio/mixer/template/tracespan"
+	"istio.io/pkg/version"
+)
+
+const (
+	defaultExportTimeout  = 10 * time.Second
+	defaultMaxBatchSize   = 512
+	defaultFlushInterval  = 5 * time.Second
+	defaultMaxQueueSize   = 2048
+	defaultInitialBackoff = 1 * time.Second
+	defaultMaxBackoff     = 30 * time.Second
+	defaultMaxElapsedTime = 1 * time.Minute
+)
+
+type (
+	builder struct {
+		adpCfg      *config.Params
+		metricTypes map[string]*metric.Type
+		logTypes    map[string]*logentry.Type
+		spanTypes   map[string]*tracespan.Type
+	}
+
+	handler struct {
+		env                adapter.Env
+		conn               *grpc.ClientConn
+		headers            grpcMetadata.MD
+		resourceAttributes []*otel.KeyValue
+		library            *otel.InstrumentationLibrary
+		metricInfos        map[string]*metricInfo
+
+		metricsClient otel.MetricsServiceClient
+		logsClient    otel.LogsServiceClient
+		traceClient   otel.TraceServiceClient
+
+		metrics *exporter
+		logs    *exporter
+		spans   *exporter
+	}
+)
+
+// ensure types implement the requisite interfaces
+var (
+	_ metric.HandlerBuilder    = &builder{}
+	_ metric.Handler           = &handler{}
+	_ logentry.HandlerBuilder  = &builder{}
+	_ logentry.Handler         = &handler{}
+	_ tracespan.HandlerBuilder = &builder{}
+	_ tracespan.Handler        = &handler{}
+)
+
+///////////////// Configuration-time Methods ///////////////
+
+// adapter.HandlerBuilder#Build
+func (b *builder) Build(ctx context.Context, env adapter.Env) (adapter.Handler, error) {
+	cfg := b.adpCfg
+
+	var opts []grpc.DialOption
+	if cfg.Insecure {
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+	}
+	conn, err := grpc.DialContext(ctx, cfg.Endpoint, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to collector %s: %v", cfg.Endpoint, err)
+	}
+
+	h := &handler{
+		env:                env,
+		conn:               conn,
+		headers:            grpcMetadata.New(cfg.Headers),
+		resourceAttributes: resourceAttributes(cfg.ResourceAttributes),
+		library:            &otel.InstrumentationLibrary{Name: "istio.io/mixer", Version: version.Info.Version},
+		metricInfos:        make(map[string]*metricInfo, len(b.metricTypes)),
+		metricsClient:      otel.NewMetricsServiceClient(conn),
+		logsClient:         otel.NewLogsServiceClient(conn),
+		traceClient:        otel.NewTraceServiceClient(conn),
+	}
+
+	for name := range b.metricTypes {
+		h.metricInfos[name] = &metricInfo{name: name}
+	}
+	for _, m := range cfg.Metrics {
+		info := &metricInfo{
+			name:        m.Name,
+			description: m.Description,
+			unit:        m.Unit,
+			kind:        m.Kind,
+			bounds:      m.Bounds,
+		}
+		if info.name == "" {
+			info.name = m.InstanceName
+		}
+		h.metricInfos[m.InstanceName] = info
+	}
+
+	timeout := defaultExportTimeout
+	if cfg.ExportTimeout > 0 {
+		timeout = cfg.ExportTimeout
+	}
+	batchSize := defaultMaxBatchSize
+	if cfg.MaxBatchSize > 0 {
+		batchSize = int(cfg.MaxBatchSize)
+	}
+	interval := defaultFlushInterval
+	if cfg.FlushInterval > 0 {
+		interval = cfg.FlushInterval
+	}
+	queueSize := defaultMaxQueueSize
+	if cfg.MaxQueueSize > 0 {
+		queueSize = int(cfg.MaxQueueSize)
+	}
+	retry := retryPolicy{
+		disabled:       cfg.Retry.Disabled,
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+		maxElapsedTime: defaultMaxElapsedTime,
+	}
+	if cfg.Retry.InitialBackoff > 0 {
+		retry.initialBackoff = cfg.Retry.InitialBackoff
+	}
+	if cfg.Retry.MaxBackoff > 0 {
+		retry.maxBackoff = cfg.Retry.MaxBackoff
+	}
+	if cfg.Retry.MaxElapsedTime > 0 {
+		retry.maxElapsedTime = cfg.Retry.MaxElapsedTime
+	}
+
+	if len(b.metricTypes) > 0 {
+		h.metrics = newExporter("metrics", h.sendMetrics, queueSize, batchSize, interval, timeout, retry, env.Logger())
+		env.ScheduleDaemon(h.metrics.run)
+	}
+	if len(b.logTypes) > 0 {
+		h.logs = newExporter("log entries", h.sendLogs, queueSize, batchSize, interval, timeout, retry, env.Logger())
+		env.ScheduleDaemon(h.logs.run)
+	}
+	if len(b.spanTypes) > 0 {
+		h.spans = newExporter("spans", h.sendSpans, queueSize, batchSize, interval, timeout, retry, env.Logger())
+		env.ScheduleDaemon(h.spans.run)
+	}
+
+	return h, nil
+}
+
+// adapter.HandlerBuilder#SetAdapterConfig
+func (b *builder) SetAdapterConfig(cfg adapter.Config) {
+	b.adpCfg = cfg.(*config.Params)
+}
+
+// adapter.HandlerBuilder#Validate
+func (b *builder) Validate() (ce *adapter.ConfigErrors) {
+	cfg := b.adpCfg
+	if cfg.Endpoint == "" {
+		ce = ce.Appendf("endpoint", "endpoint must be specified")
+	}
+	if cfg.ExportTimeout < 0 {
+		ce = ce.Appendf("exportTimeout", "exportTimeout must be positive")
+	}
+	if cfg.MaxBatchSize < 0 {
+		ce = ce.Appendf("maxBatchSize", "maxBatchSize must be positive")
+	}
+	if cfg.FlushInterval < 0 {
+		ce = ce.Appendf("flushInterval", "flushInterval must be positive")
+	}
+	if cfg.MaxQueueSize < 0 {
+		ce = ce.Appendf("maxQueueSize", "maxQueueSize must be positive")
+	}
+	if cfg.Retry.InitialBackoff < 0 {
+		ce = ce.Appendf("retry.initialBackoff", "initialBackoff must be positive")
+	}
+	if cfg.Retry.MaxBackoff < 0 {
+		ce = ce.Appendf("retry.maxBackoff", "maxBackoff must be positive")
+	}
+	if cfg.Retry.MaxElapsedTime < 0 {
+		ce = ce.Appendf("retry.maxElapsedTime", "maxElapsedTime must be positive")
+	}
+
+	seen := make(map[string]bool, len(cfg.Metrics))
+	for i, m := range cfg.Metrics {
+		field := fmt.Sprintf("metrics[%d]", i)
+		if m.InstanceName == "" {
+			ce = ce.Appendf(field+".instanceName", "instanceName must be specified")
+		} else if seen[m.InstanceName] {
+			ce = ce.Appendf(field+".instanceName", "duplicate metric info for instance %s", m.InstanceName)
+		}
+		seen[m.InstanceName] = true
+
+		if m.Kind == config.DISTRIBUTION {
+			if len(m.Bounds) == 0 {
+				ce = ce.Appendf(field+".bounds", "bounds must be specified for DISTRIBUTION metrics")
+			} else if !sort.Float64sAreSorted(m.Bounds) || hasDuplicates(m.Bounds) {
+				ce = ce.Appendf(field+".bounds", "bounds must be monotonically increasing")
+			}
+		}
+	}
+
+	for name, t := range b.metricTypes {
+		switch t.Value {
+		case descriptor.INT64, descriptor.DOUBLE, descriptor.DURATION:
+		default:
+			ce = ce.Appendf("metrics", "metric %s has unsupported value type %v", name, t.Value)
+		}
+	}
+	return
+}
+
+func hasDuplicates(sorted []float64) bool {
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return true
+		}
+	}
+	return false
+}
+
+// metric.HandlerBuilder#SetMetricTypes
+func (b *builder) SetMetricTypes(types map[string]*metric.Type) {
+	b.metricTypes = types
+}
+
+// logentry.HandlerBuilder#SetLogEntryTypes
+func (b *builder) SetLogEntryTypes(types map[string]*logentry.Type) {
+	b.logTypes = types
+}
+
+// tracespan.HandlerBuilder#SetTraceSpanTypes
+func (b *builder) SetTraceSpanTypes(types map[string]*tracespan.Type) {
+	b.spanTypes = types
+}
+
+func resourceAttributes(attrs map[string]string) []*otel.KeyValue {
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	kvs := make([]*otel.KeyValue, 0, len(names))
+	for _, name := range names {
+		kvs = append(kvs, stringAttribute(name, attrs[name]))
+	}
+	return kvs
+}
+
+////////////////// Request-time Methods //////////////////////////
+
+// metric.Handler#HandleMetric
+func (h *handler) HandleMetric(_ context.Context, insts []*metric.Instance) error {
+	if h.metrics == nil {
+		return nil
+	}
+
+	var result *multierror.Error
+	dropped := 0
+	now := time.Now()
+	for _, inst := range insts {
+		info, found := h.metricInfos[inst.Name]
+		if !found {
+			result = multierror.Append(result, fmt.Errorf("no metric info found for instance %s", inst.Name))
+			continue
+		}
+		p, err := newPoint(info, inst.Value, inst.Dimensions, now)
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+		r := record{point: p}
+		r.resource, r.resourceKey = monitoredResource(inst.MonitoredResourceType, inst.MonitoredResourceDimensions)
+		if !h.metrics.enqueue(r) {
+			dropped++
+		}
+	}
+	if dropped > 0 {
+		result = multierror.Append(result, fmt.Errorf("otlp metrics queue full; dropped %d of %d instances", dropped, len(insts)))
+	}
+	return result.ErrorOrNil()
+}
+
+// logentry.Handler#HandleLogEntry
+func (h *handler) HandleLogEntry(_ context.Context, insts []*logentry.Instance) error {
+	if h.logs == nil {
+		return nil
+	}
+
+	dropped := 0
+	for _, inst := range insts {
+		r := record{log: newLogRecord(inst)}
+		r.resource, r.resourceKey = monitoredResource(inst.MonitoredResourceType, inst.MonitoredResourceDimensions)
+		if !h.logs.enqueue(r) {
+			dropped++
+		}
+	}
+	if dropped > 0 {
+		return fmt.Errorf("otlp log entries queue full; dropped %d of %d instances", dropped, len(insts))
+	}
+	return nil
+}
+
+// tracespan.Handler#HandleTraceSpan
+func (h *handler) HandleTraceSpan(_ context.Context, insts []*tracespan.Instance) error {
+	if h.spans == nil {
+		return nil
+	}
+
+	dropped := 0
+	for _, inst := range insts {
+		span, resource, ok := newSpan(inst)
+		if !ok {
+			continue
+		}
+		r := record{span: span, resource: resource}
+		if len(resource) > 0 {
+			r.resourceKey = resource[0].Value.GetStringValue()
+		}
+		if !h.spans.enqueue(r) {
+			dropped++
+		}
+	}
+	if dropped > 0 {
+		return fmt.Errorf("otlp spans queue full; dropped %d of %d instances", dropped, len(insts))
+	}
+	return nil
+}
+
+func (h *handler) sendMetrics(ctx context.Context, batch []record) error {
+	req := &otel.ExportMetricsServiceRequest{ResourceMetrics: resourceMetrics(batch, h.resourceAttributes, h.library)}
+	_, err := h.metricsClient.Export(grpcMetadata.NewOutgoingContext(ctx, h.headers), req)
+	return err
+}
+
+func (h *handler) sendLogs(ctx context.Context, batch []record) error {
+	req := &otel.ExportLogsServiceRequest{ResourceLogs: resourceLogs(batch, h.resourceAttributes, h.library)}
+	_, err := h.logsClient.Export(grpcMetadata.NewOutgoingContext(ctx, h.headers), req)
+	return err
+}
+
+func (h *handler) sendSpans(ctx context.Context, batch []record) error {
+	req := &otel.ExportTraceServiceRequest{ResourceSpans: resourceSpans(batch, h.resourceAttributes, h.library)}
+	_, err := h.traceClient.Export(grpcMetadata.NewOutgoingContext(ctx, h.headers), req)
+	return err
+}
+
+// adapter.Handler#Close
+func (h *handler) Close() error {
+	for _, e := range []*exporter{h.metrics, h.logs, h.spans} {
+		if e != nil {
+			e.close()
+		}
+	}
+	return h.conn.Close()
+}
+
+////////////////// Bootstrap //////////////////////////
+
+// GetInfo returns the adapter.Info specific to this adapter.
+func GetInfo() adapter.Info {
+	info := metadata.GetInfo("otlp")
+	info.NewBuilder = func() adapter.HandlerBuilder { return &builder{} }
+	return info
+}
@@ -0,0 +1,448 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcMetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	descriptor "istio.io/api/policy/v1beta1"
+	"istio.io/istio/mixer/adapter/otlp/config"
+	otel "istio.io/istio/mixer/adapter/otlp/internal/opentelemetry"
+	"istio.io/istio/mixer/pkg/adapter/test"
+	"istio.io/istio/mixer/template/logentry"
+	"istio.io/istio/mixer/template/metric"
+	"istio.io/istio/mixer/template/tracespan"
+)
+
+// collector is a stub OTLP/gRPC collector that records the export requests it receives.
+type collector struct {
+	sync.Mutex
+	server   *grpc.Server
+	listener net.Listener
+
+	metrics  []*otel.ExportMetricsServiceRequest
+	logs     []*otel.ExportLogsServiceRequest
+	spans    []*otel.ExportTraceServiceRequest
+	headers  []grpcMetadata.MD
+	attempts int
+	failures int
+	code     codes.Code
+}
+
+func newCollector(t *testing.T) *collector {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %v", err)
+	}
+	c := &collector{server: grpc.NewServer(), listener: l}
+	otel.RegisterMetricsServiceServer(c.server, &metricsService{c})
+	otel.RegisterLogsServiceServer(c.server, &logsService{c})
+	otel.RegisterTraceServiceServer(c.server, &traceService{c})
+	go func() { _ = c.server.Serve(l) }()
+	return c
+}
+
+func (c *collector) address() string {
+	return c.listener.Addr().String()
+}
+
+// failNext makes the next n export requests fail with the given code.
+func (c *collector) failNext(n int, code codes.Code) {
+	c.Lock()
+	defer c.Unlock()
+	c.failures = n
+	c.code = code
+}
+
+func (c *collector) receive(ctx context.Context, record func()) error {
+	c.Lock()
+	defer c.Unlock()
+	c.attempts++
+	if c.failures > 0 {
+		c.failures--
+		return status.Error(c.code, "injected failure")
+	}
+	md, _ := grpcMetadata.FromIncomingContext(ctx)
+	c.headers = append(c.headers, md)
+	record()
+	return nil
+}
+
+func (c *collector) received() (int, int, int) {
+	c.Lock()
+	defer c.Unlock()
+	return len(c.metrics), len(c.logs), len(c.spans)
+}
+
+type metricsService struct{ c *collector }
+
+func (s *metricsService) Export(ctx context.Context, req *otel.ExportMetricsServiceRequest) (*otel.ExportMetricsServiceResponse, error) {
+	return &otel.ExportMetricsServiceResponse{}, s.c.receive(ctx, func() { s.c.metrics = append(s.c.metrics, req) })
+}
+
+type logsService struct{ c *collector }
+
+func (s *logsService) Export(ctx context.Context, req *otel.ExportLogsServiceRequest) (*otel.ExportLogsServiceResponse, error) {
+	return &otel.ExportLogsServiceResponse{}, s.c.receive(ctx, func() { s.c.logs = append(s.c.logs, req) })
+}
+
+type traceService struct{ c *collector }
+
+func (s *traceService) Export(ctx context.Context, req *otel.ExportTraceServiceRequest) (*otel.ExportTraceServiceResponse, error) {
+	return &otel.ExportTraceServiceResponse{}, s.c.receive(ctx, func() { s.c.spans = append(s.c.spans, req) })
+}
+
+func TestBasic(t *testing.T) {
+	info := GetInfo()
+
+	expected := []string{logentry.TemplateName, metric.TemplateName, tracespan.TemplateName}
+	if !reflect.DeepEqual(info.SupportedTemplates, expected) {
+		t.Errorf("Got templates %v, expecting %v", info.SupportedTemplates, expected)
+	}
+
+	b := info.NewBuilder().(*builder)
+	b.SetAdapterConfig(info.DefaultConfig)
+	if err := b.Validate(); err != nil {
+		t.Errorf("Got error %v, expecting success", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		config  config.Params
+		types   map[string]*metric.Type
+		success bool
+	}{
+		{"valid", config.Params{Endpoint: "localhost:55680"}, nil, true},
+		{"no endpoint", config.Params{}, nil, false},
+		{"negative export timeout", config.Params{Endpoint: "localhost:55680", ExportTimeout: -1}, nil, false},
+		{"negative batch size", config.Params{Endpoint: "localhost:55680", MaxBatchSize: -1}, nil, false},
+		{"negative flush interval", config.Params{Endpoint: "localhost:55680", FlushInterval: -1}, nil, false},
+		{"negative queue size", config.Params{Endpoint: "localhost:55680", MaxQueueSize: -1}, nil, false},
+		{"negative initial backoff",
+			config.Params{Endpoint: "localhost:55680", Retry: config.Params_RetryPolicy{InitialBackoff: -1}}, nil, false},
+		{"negative max backoff",
+			config.Params{Endpoint: "localhost:55680", Retry: config.Params_RetryPolicy{MaxBackoff: -1}}, nil, false},
+		{"negative max elapsed time",
+			config.Params{Endpoint: "localhost:55680", Retry: config.Params_RetryPolicy{MaxElapsedTime: -1}}, nil, false},
+		{"metric without instance name",
+			config.Params{Endpoint: "localhost:55680", Metrics: []*config.Params_MetricInfo{{Name: "m"}}}, nil, false},
+		{"duplicate metric",
+			config.Params{Endpoint: "localhost:55680", Metrics: []*config.Params_MetricInfo{
+				{InstanceName: "m"}, {InstanceName: "m"},
+			}}, nil, false},
+		{"distribution without bounds",
+			config.Params{Endpoint: "localhost:55680", Metrics: []*config.Params_MetricInfo{
+				{InstanceName: "m", Kind: config.DISTRIBUTION},
+			}}, nil, false},
+		{"distribution with unsorted bounds",
+			config.Params{Endpoint: "localhost:55680", Metrics: []*config.Params_MetricInfo{
+				{InstanceName: "m", Kind: config.DISTRIBUTION, Bounds: []float64{1, 3, 2}},
+			}}, nil, false},
+		{"distribution with duplicate bounds",
+			config.Params{Endpoint: "localhost:55680", Metrics: []*config.Params_MetricInfo{
+				{InstanceName: "m", Kind: config.DISTRIBUTION, Bounds: []float64{1, 2, 2}},
+			}}, nil, false},
+		{"distribution",
+			config.Params{Endpoint: "localhost:55680", Metrics: []*config.Params_MetricInfo{
+				{InstanceName: "m", Kind: config.DISTRIBUTION, Bounds: []float64{1, 2, 3}},
+			}}, map[string]*metric.Type{"m": {Value: descriptor.DURATION}}, true},
+		{"string metric", config.Params{Endpoint: "localhost:55680"},
+			map[string]*metric.Type{"m": {Value: descriptor.STRING}}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			b := GetInfo().NewBuilder().(*builder)
+			b.SetAdapterConfig(&c.config)
+			b.SetMetricTypes(c.types)
+
+			ce := b.Validate()
+			if ce != nil && c.success {
+				t.Errorf("Got %v, expecting success", ce)
+			} else if ce == nil && !c.success {
+				t.Errorf("Got success, expecting failure")
+			}
+		})
+	}
+}
+
+func newTestHandler(t *testing.T, cfg *config.Params) *handler {
+	b := GetInfo().NewBuilder().(*builder)
+	b.SetAdapterConfig(cfg)
+	b.SetMetricTypes(map[string]*metric.Type{
+		"requestcount": {Value: descriptor.INT64},
+		"latency":      {Value: descriptor.DURATION},
+		"temperature":  {Value: descriptor.DOUBLE},
+	})
+	b.SetLogEntryTypes(map[string]*logentry.Type{"accesslog": {}})
+	b.SetTraceSpanTypes(map[string]*tracespan.Type{"span": {}})
+	if err := b.Validate(); err != nil {
+		t.Fatalf("Got error %v, expecting success", err)
+	}
+
+	h, err := b.Build(context.Background(), test.NewEnv(t))
+	if err != nil {
+		t.Fatalf("Got error %v, expecting success", err)
+	}
+	return h.(*handler)
+}
+
+func TestExport(t *testing.T) {
+	c := newCollector(t)
+	defer c.server.Stop()
+
+	h := newTestHandler(t, &config.Params{
+		Endpoint:           c.address(),
+		Insecure:           true,
+		Headers:            map[string]string{"authorization": "token"},
+		ResourceAttributes: map[string]string{"deployment.environment": "test"},
+		FlushInterval:      time.Hour,
+		Metrics: []*config.Params_MetricInfo{
+			{InstanceName: "requestcount", Name: "istio.requests", Kind: config.COUNTER},
+			{InstanceName: "latency", Name: "istio.latency", Unit: "s", Kind: config.DISTRIBUTION, Bounds: []float64{0.1, 1}},
+		},
+	})
+
+	ctx := context.Background()
+	dims := map[string]interface{}{"destination": "ratings"}
+	resource := map[string]interface{}{"cluster": "c1"}
+	if err := h.HandleMetric(ctx, []*metric.Instance{
+		{Name: "requestcount", Value: int64(1), Dimensions: dims, MonitoredResourceType: "k8s", MonitoredResourceDimensions: resource},
+		{Name: "requestcount", Value: int64(2), Dimensions: dims, MonitoredResourceType: "k8s", MonitoredResourceDimensions: resource},
+		{Name: "latency", Value: 50 * time.Millisecond, Dimensions: dims, MonitoredResourceType: "k8s", MonitoredResourceDimensions: resource},
+		{Name: "latency", Value: 500 * time.Millisecond, Dimensions: dims, MonitoredResourceType: "k8s", MonitoredResourceDimensions: resource},
+		{Name: "latency", Value: 2 * time.Second, Dimensions: dims, MonitoredResourceType: "k8s", MonitoredResourceDimensions: resource},
+		{Name: "temperature", Value: 21.5},
+		{Name: "temperature", Value: 22.5},
+	}); err != nil {
+		t.Errorf("HandleMetric() => %v, expecting success", err)
+	}
+
+	if err := h.HandleMetric(ctx, []*metric.Instance{{Name: "unknown", Value: int64(1)}}); err == nil {
+		t.Error("HandleMetric() => success, expecting failure for an unknown instance")
+	}
+
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := h.HandleLogEntry(ctx, []*logentry.Instance{{
+		Name:                        "accesslog",
+		Timestamp:                   ts,
+		Severity:                    "Warning",
+		Variables:                   map[string]interface{}{"method": "GET", "size": int64(10)},
+		MonitoredResourceType:       "k8s",
+		MonitoredResourceDimensions: resource,
+	}}); err != nil {
+		t.Errorf("HandleLogEntry() => %v, expecting success", err)
+	}
+
+	if err := h.HandleTraceSpan(ctx, []*tracespan.Instance{
+		{
+			Name:                "span",
+			TraceId:             "463ac35c9f6413ad48485a3953bb6124",
+			SpanId:              "a2fb4a1d1a96d312",
+			ParentSpanId:        "0020000000000001",
+			SpanName:            "/ratings",
+			StartTime:           ts,
+			EndTime:             ts.Add(time.Second),
+			HttpStatusCode:      503,
+			ClientSpan:          true,
+			RewriteClientSpanId: true,
+			SourceName:          "productpage",
+			DestinationName:     "ratings",
+			DestinationIp:       net.ParseIP("10.0.0.1"),
+		},
+		{
+			Name:                "span",
+			TraceId:             "463ac35c9f6413ad48485a3953bb6124",
+			SpanId:              "a2fb4a1d1a96d312",
+			ParentSpanId:        "0020000000000001",
+			SpanName:            "/ratings",
+			StartTime:           ts,
+			EndTime:             ts.Add(time.Second),
+			HttpStatusCode:      404,
+			RewriteClientSpanId: true,
+			SourceName:          "productpage",
+			DestinationName:     "ratings",
+		},
+		{Name: "span", TraceId: "invalid", SpanId: "a2fb4a1d1a96d312"},
+	}); err != nil {
+		t.Errorf("HandleTraceSpan() => %v, expecting success", err)
+	}
+
+	// Closing the handler exports the queued instances.
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close() => %v, expecting success", err)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if len(c.metrics) != 1 || len(c.logs) != 1 || len(c.spans) != 1 {
+		t.Fatalf("Got %d metrics, %d logs and %d traces requests, expecting 1 of each", len(c.metrics), len(c.logs), len(c.spans))
+	}
+	for _, md := range c.headers {
+		if got := md.Get("authorization"); !reflect.DeepEqual(got, []string{"token"}) {
+			t.Errorf("Got authorization header %v, expecting [token]", got)
+		}
+	}
+
+	// metrics
+	rms := c.metrics[0].ResourceMetrics
+	if len(rms) != 2 {
+		t.Fatalf("Got %d metric resources, expecting 2", len(rms))
+	}
+	wantResource := []*otel.KeyValue{
+		stringAttribute("cluster", "c1"),
+		stringAttribute(resourceTypeAttribute, "k8s"),
+		stringAttribute("deployment.environment", "test"),
+	}
+	if !reflect.DeepEqual(rms[0].Resource.Attributes, wantResource) {
+		t.Errorf("Got resource %v, expecting %v", rms[0].Resource.Attributes, wantResource)
+	}
+	metrics := rms[0].InstrumentationLibraryMetrics[0].Metrics
+	if len(metrics) != 2 {
+		t.Fatalf("Got %d metrics, expecting 2", len(metrics))
+	}
+
+	sum := metrics[0].GetSum()
+	if metrics[0].Name != "istio.requests" || sum == nil {
+		t.Fatalf("Got metric %v, expecting the istio.requests sum", metrics[0])
+	}
+	if !sum.IsMonotonic || sum.AggregationTemporality != otel.AGGREGATION_TEMPORALITY_DELTA {
+		t.Errorf("Got sum %v, expecting a monotonic delta sum", sum)
+	}
+	if len(sum.DataPoints) != 1 || sum.DataPoints[0].GetAsInt() != 3 {
+		t.Errorf("Got data points %v, expecting a single point of value 3", sum.DataPoints)
+	}
+	if want := []*otel.KeyValue{stringAttribute("destination", "ratings")}; !reflect.DeepEqual(sum.DataPoints[0].Attributes, want) {
+		t.Errorf("Got attributes %v, expecting %v", sum.DataPoints[0].Attributes, want)
+	}
+
+	histogram := metrics[1].GetHistogram()
+	if metrics[1].Name != "istio.latency" || metrics[1].Unit != "s" || histogram == nil {
+		t.Fatalf("Got metric %v, expecting the istio.latency histogram", metrics[1])
+	}
+	hdp := histogram.DataPoints[0]
+	if hdp.Count != 3 || hdp.Sum != 2.55 || !reflect.DeepEqual(hdp.BucketCounts, []uint64{1, 1, 1}) ||
+		!reflect.DeepEqual(hdp.ExplicitBounds, []float64{0.1, 1}) {
+		t.Errorf("Got histogram point %v, expecting 3 values in 3 buckets", hdp)
+	}
+
+	gauge := rms[1].InstrumentationLibraryMetrics[0].Metrics[0]
+	if gauge.Name != "temperature" || len(gauge.GetGauge().DataPoints) != 1 || gauge.GetGauge().DataPoints[0].GetAsDouble() != 22.5 {
+		t.Errorf("Got metric %v, expecting the last temperature gauge value", gauge)
+	}
+	if want := []*otel.KeyValue{stringAttribute("deployment.environment", "test")}; !reflect.DeepEqual(rms[1].Resource.Attributes, want) {
+		t.Errorf("Got resource %v, expecting %v", rms[1].Resource.Attributes, want)
+	}
+
+	// logs
+	logs := c.logs[0].ResourceLogs[0].InstrumentationLibraryLogs[0].Logs
+	if len(logs) != 1 {
+		t.Fatalf("Got %d log records, expecting 1", len(logs))
+	}
+	if logs[0].TimeUnixNano != uint64(ts.UnixNano()) || logs[0].SeverityNumber != otel.SEVERITY_NUMBER_WARN || logs[0].SeverityText != "Warning" {
+		t.Errorf("Got log record %v, expecting a warning at %v", logs[0], ts)
+	}
+	body := logs[0].Body.GetKvlistValue().GetValues()
+	wantBody := []*otel.KeyValue{
+		stringAttribute("method", "GET"),
+		{Key: "size", Value: &otel.AnyValue{Value: &otel.AnyValue_IntValue{IntValue: 10}}},
+	}
+	if !reflect.DeepEqual(body, wantBody) {
+		t.Errorf("Got log body %v, expecting %v", body, wantBody)
+	}
+
+	// spans
+	rss := c.spans[0].ResourceSpans
+	if len(rss) != 2 {
+		t.Fatalf("Got %d span resources, expecting 2", len(rss))
+	}
+	client := rss[0].InstrumentationLibrarySpans[0].Spans[0]
+	server := rss[1].InstrumentationLibrarySpans[0].Spans[0]
+	if got := rss[0].Resource.Attributes[0]; !reflect.DeepEqual(got, stringAttribute(serviceNameAttribute, "productpage")) {
+		t.Errorf("Got client resource %v, expecting productpage", got)
+	}
+	if got := rss[1].Resource.Attributes[0]; !reflect.DeepEqual(got, stringAttribute(serviceNameAttribute, "ratings")) {
+		t.Errorf("Got server resource %v, expecting ratings", got)
+	}
+	if client.Kind != otel.SPAN_KIND_CLIENT || server.Kind != otel.SPAN_KIND_SERVER {
+		t.Errorf("Got span kinds %v and %v, expecting client and server", client.Kind, server.Kind)
+	}
+	if !reflect.DeepEqual(client.TraceId, server.TraceId) || len(client.TraceId) != 16 {
+		t.Errorf("Got trace ids %x and %x, expecting the same 16 bytes id", client.TraceId, server.TraceId)
+	}
+	if !reflect.DeepEqual(client.SpanId, server.ParentSpanId) || reflect.DeepEqual(client.SpanId, server.SpanId) {
+		t.Errorf("Got client span id %x and server span %x with parent %x, expecting the client span to be the parent",
+			client.SpanId, server.SpanId, server.ParentSpanId)
+	}
+	if client.StartTimeUnixNano != uint64(ts.UnixNano()) || client.EndTimeUnixNano != uint64(ts.Add(time.Second).UnixNano()) {
+		t.Errorf("Got span times %d-%d, expecting %v", client.StartTimeUnixNano, client.EndTimeUnixNano, ts)
+	}
+	if client.Status == nil || client.Status.Code != otel.STATUS_CODE_ERROR {
+		t.Errorf("Got client span status %v, expecting an error", client.Status)
+	}
+	if server.Status != nil {
+		t.Errorf("Got server span status %v, expecting no error for a 404", server.Status)
+	}
+	if !hasAttribute(client.Attributes, peerServiceAttribute) || !hasAttribute(client.Attributes, peerIPAttribute) {
+		t.Errorf("Got client span attributes %v, expecting the peer service and ip", client.Attributes)
+	}
+}
+
+func TestExportRetry(t *testing.T) {
+	c := newCollector(t)
+	defer c.server.Stop()
+	c.failNext(2, codes.Unavailable)
+
+	h := newTestHandler(t, &config.Params{
+		Endpoint:      c.address(),
+		Insecure:      true,
+		FlushInterval: time.Millisecond,
+		Retry:         config.Params_RetryPolicy{InitialBackoff: time.Millisecond},
+	})
+	defer func() { _ = h.Close() }()
+
+	if err := h.HandleLogEntry(context.Background(), []*logentry.Instance{{Name: "accesslog"}}); err != nil {
+		t.Fatalf("HandleLogEntry() => %v, expecting success", err)
+	}
+
+	for i := 0; ; i++ {
+		if _, logs, _ := c.received(); logs == 1 {
+			break
+		}
+		if i == 500 {
+			t.Fatal("log entry was not exported")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if c.attempts != 3 {
+		t.Errorf("Got %d attempts, expecting 3", c.attempts)
+	}
+}
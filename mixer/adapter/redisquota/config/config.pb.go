@@ -44,24 +44,36 @@ const (
 	FIXED_WINDOW Params_QuotaAlgorithm = 0
 	// `ROLLING_WINDOW` The rolling window algorithm's additional precision comes at the cost of increased redis resource usage.
 	ROLLING_WINDOW Params_QuotaAlgorithm = 1
+	// `TOKEN_BUCKET` The token bucket algorithm refills `maxAmount` tokens every `validDuration`, and allows bursts
+	// of up to `burstSize` tokens.
+	TOKEN_BUCKET Params_QuotaAlgorithm = 2
+	// `SLIDING_LOG` The sliding log algorithm records every allocation, and allows exactly `maxAmount` within any
+	// `validDuration` interval. Its precision comes at the cost of one redis entry per allocation, so it is best
+	// suited to low volume quotas.
+	SLIDING_LOG Params_QuotaAlgorithm = 3
 )
 
 var Params_QuotaAlgorithm_name = map[int32]string{
 	0: "FIXED_WINDOW",
 	1: "ROLLING_WINDOW",
+	2: "TOKEN_BUCKET",
+	3: "SLIDING_LOG",
 }
 
 var Params_QuotaAlgorithm_value = map[string]int32{
 	"FIXED_WINDOW":   0,
 	"ROLLING_WINDOW": 1,
+	"TOKEN_BUCKET":   2,
+	"SLIDING_LOG":    3,
 }
 
 func (Params_QuotaAlgorithm) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_b4ec77e3e2f5a044, []int{0, 0}
 }
 
-// redisquota adapter supports the rate limit quota using either fixed or
-// rolling window algorithm. And it is using Redis as a shared data storage.
+// redisquota adapter supports the rate limit quota using either fixed window,
+// rolling window, token bucket or sliding log algorithm. And it is using Redis
+// as a shared data storage.
 //
 // Example configuration:
 //
@@ -82,6 +94,21 @@ func (Params_QuotaAlgorithm) EnumDescriptor() ([]byte, []int) {
 //   - dimensions:
 //       destination: reviews
 //     maxAmount: 5
+// - name: burstable.quota.istio-system
+//   maxAmount: 100
+//   validDuration: 1s
+//   burstSize: 500
+//   rateLimitAlgorithm: TOKEN_BUCKET
+// - name: peruser.quota.istio-system
+//   maxAmount: 10
+//   validDuration: 1s
+//   levels:
+//   - name: tenant
+//     dimensions:
+//     - tenant
+//     maxAmount: 100
+//   - name: global
+//     maxAmount: 1000
 // ```
 type Params struct {
 	// The set of known quotas. At least one quota configuration is required
@@ -134,6 +161,9 @@ type Params_Override struct {
 	// The upper limit for this quota override.
 	// This value should be bigger than 0
 	MaxAmount int64 `protobuf:"varint,2,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	// The burst size for this quota override, used by the `TOKEN_BUCKET` algorithm.
+	// If 0, `max_amount` of the override is used.
+	BurstSize int64 `protobuf:"varint,3,opt,name=burst_size,json=burstSize,proto3" json:"burst_size,omitempty"`
 }
 
 func (m *Params_Override) Reset()      { *m = Params_Override{} }
@@ -182,6 +212,89 @@ func (m *Params_Override) GetMaxAmount() int64 {
 	return 0
 }
 
+func (m *Params_Override) GetBurstSize() int64 {
+	if m != nil {
+		return m.BurstSize
+	}
+	return 0
+}
+
+// Defines an enclosing limit of a hierarchical quota. The instances of the quota are grouped
+// by the given dimensions, and each group shares the limit of the level.
+type Params_Level struct {
+	// The name of the level, used to build the keys of the level. `name` should not be empty
+	// and should be unique within the quota.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The instance dimensions that identify a group of the level. If empty, all the instances of
+	// the quota share the limit of the level.
+	Dimensions []string `protobuf:"bytes,2,rep,name=dimensions,proto3" json:"dimensions,omitempty"`
+	// The upper limit for each group of the level. This value should be bigger than 0
+	MaxAmount int64 `protobuf:"varint,3,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	// The burst size for each group of the level, used by the `TOKEN_BUCKET` algorithm.
+	// If 0, `max_amount` of the level is used.
+	BurstSize int64 `protobuf:"varint,4,opt,name=burst_size,json=burstSize,proto3" json:"burst_size,omitempty"`
+}
+
+func (m *Params_Level) Reset()      { *m = Params_Level{} }
+func (*Params_Level) ProtoMessage() {}
+func (*Params_Level) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4ec77e3e2f5a044, []int{0, 1}
+}
+func (m *Params_Level) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params_Level) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params_Level.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params_Level) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params_Level.Merge(m, src)
+}
+func (m *Params_Level) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params_Level) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params_Level.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params_Level proto.InternalMessageInfo
+
+func (m *Params_Level) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Params_Level) GetDimensions() []string {
+	if m != nil {
+		return m.Dimensions
+	}
+	return nil
+}
+
+func (m *Params_Level) GetMaxAmount() int64 {
+	if m != nil {
+		return m.MaxAmount
+	}
+	return 0
+}
+
+func (m *Params_Level) GetBurstSize() int64 {
+	if m != nil {
+		return m.BurstSize
+	}
+	return 0
+}
+
 type Params_Quota struct {
 	// The name of the quota
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -199,12 +312,20 @@ type Params_Quota struct {
 	// Overrides associated with this quota.
 	// The first matching override is applied.
 	Overrides []*Params_Override `protobuf:"bytes,6,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	// The maximum number of tokens the bucket holds, for the `TOKEN_BUCKET` algorithm.
+	// If 0, `max_amount` is used.
+	BurstSize int64 `protobuf:"varint,7,opt,name=burst_size,json=burstSize,proto3" json:"burst_size,omitempty"`
+	// Enclosing limits of a hierarchical quota, from the innermost to the outermost, e.g. a per-tenant
+	// and a global limit around a per-user quota. A request is only granted if the quota and every
+	// level have capacity, and it is charged to all of them in a single redis round-trip.
+	// The levels use the `validDuration`, `bucketDuration` and `rateLimitAlgorithm` of the quota.
+	Levels []*Params_Level `protobuf:"bytes,8,rep,name=levels,proto3" json:"levels,omitempty"`
 }
 
 func (m *Params_Quota) Reset()      { *m = Params_Quota{} }
 func (*Params_Quota) ProtoMessage() {}
 func (*Params_Quota) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b4ec77e3e2f5a044, []int{0, 1}
+	return fileDescriptor_b4ec77e3e2f5a044, []int{0, 2}
 }
 func (m *Params_Quota) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -275,11 +396,26 @@ func (m *Params_Quota) GetOverrides() []*Params_Override {
 	return nil
 }
 
+func (m *Params_Quota) GetBurstSize() int64 {
+	if m != nil {
+		return m.BurstSize
+	}
+	return 0
+}
+
+func (m *Params_Quota) GetLevels() []*Params_Level {
+	if m != nil {
+		return m.Levels
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("adapter.redisquota.config.Params_QuotaAlgorithm", Params_QuotaAlgorithm_name, Params_QuotaAlgorithm_value)
 	proto.RegisterType((*Params)(nil), "adapter.redisquota.config.Params")
 	proto.RegisterType((*Params_Override)(nil), "adapter.redisquota.config.Params.Override")
 	proto.RegisterMapType((map[string]string)(nil), "adapter.redisquota.config.Params.Override.DimensionsEntry")
+	proto.RegisterType((*Params_Level)(nil), "adapter.redisquota.config.Params.Level")
 	proto.RegisterType((*Params_Quota)(nil), "adapter.redisquota.config.Params.Quota")
 }
 
@@ -288,44 +424,49 @@ func init() {
 }
 
 var fileDescriptor_b4ec77e3e2f5a044 = []byte{
-	// 577 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0xb1, 0x6f, 0xd3, 0x4e,
-	0x18, 0xf5, 0xd5, 0xa9, 0xd5, 0x5e, 0x7f, 0x3f, 0x37, 0x3a, 0x65, 0x70, 0x23, 0x71, 0x8d, 0xba,
-	0x10, 0x21, 0x64, 0x57, 0x45, 0x42, 0xa8, 0x12, 0x12, 0xad, 0x52, 0xa0, 0x28, 0x6a, 0x8a, 0x2b,
-	0x54, 0xc4, 0x62, 0x2e, 0xf1, 0xd5, 0x9c, 0x6a, 0xfb, 0xc2, 0xd9, 0x8e, 0xd2, 0x4e, 0x8c, 0x1d,
-	0x19, 0x19, 0x19, 0xf9, 0x37, 0xd8, 0x32, 0x66, 0xec, 0x04, 0xc4, 0x59, 0x18, 0xfb, 0x27, 0x20,
-	0x9f, 0xed, 0x04, 0x90, 0x50, 0x33, 0xf9, 0xf3, 0xbb, 0xf7, 0xde, 0x7d, 0xdf, 0xf7, 0x0e, 0xde,
-	0x0f, 0xd8, 0x90, 0x0a, 0x8b, 0xb8, 0xa4, 0x1f, 0x53, 0x61, 0x09, 0xea, 0xb2, 0xe8, 0x7d, 0xc2,
-	0x63, 0x62, 0xf5, 0x78, 0x78, 0xc6, 0xbc, 0xe2, 0x63, 0xf6, 0x05, 0x8f, 0x39, 0xda, 0x28, 0x78,
-	0xe6, 0x9c, 0x67, 0xe6, 0x84, 0x3a, 0xf6, 0x38, 0xf7, 0x7c, 0x6a, 0x49, 0x62, 0x37, 0x39, 0xb3,
-	0xdc, 0x44, 0x90, 0x98, 0xf1, 0x30, 0x97, 0xd6, 0x6b, 0x1e, 0xf7, 0xb8, 0x2c, 0xad, 0xac, 0xca,
-	0xd1, 0xad, 0xaf, 0x1a, 0xd4, 0x8e, 0x89, 0x20, 0x41, 0x84, 0x0e, 0xa0, 0x26, 0x0d, 0x23, 0x03,
-	0x34, 0xd4, 0xe6, 0xda, 0xce, 0x5d, 0xf3, 0x9f, 0x97, 0x99, 0xb9, 0xc4, 0x7c, 0x99, 0x61, 0xfb,
-	0x95, 0xd1, 0xb7, 0x4d, 0xc5, 0x2e, 0xc4, 0xa8, 0x09, 0xab, 0x92, 0xef, 0x44, 0x54, 0x0c, 0xa8,
-	0x70, 0x12, 0xe1, 0x1b, 0x4b, 0x0d, 0xd0, 0x5c, 0xb5, 0x75, 0x89, 0x9f, 0x48, 0xf8, 0x95, 0xf0,
-	0xd1, 0x36, 0xac, 0xf5, 0x78, 0x18, 0xd2, 0x5e, 0xd6, 0xa5, 0xd3, 0xe7, 0xdc, 0x77, 0x22, 0x76,
-	0x49, 0x0d, 0xb5, 0x01, 0x9a, 0xaa, 0x8d, 0xe6, 0x67, 0xc7, 0x9c, 0xfb, 0x27, 0xec, 0x92, 0xd6,
-	0xc7, 0x00, 0xae, 0x74, 0x06, 0x54, 0x08, 0xe6, 0x52, 0xf4, 0x16, 0x42, 0x97, 0x05, 0x34, 0x8c,
-	0x18, 0x0f, 0xcb, 0x9e, 0x77, 0x6f, 0xef, 0xb9, 0xd4, 0x9b, 0xad, 0x99, 0xf8, 0x20, 0x8c, 0xc5,
-	0x45, 0x31, 0xc6, 0x6f, 0x9e, 0xe8, 0x0e, 0x84, 0x01, 0x19, 0x3a, 0x24, 0xe0, 0x49, 0x18, 0xcb,
-	0x21, 0x54, 0x7b, 0x35, 0x20, 0xc3, 0x3d, 0x09, 0xd4, 0x1f, 0xc3, 0xf5, 0xbf, 0x3c, 0x50, 0x15,
-	0xaa, 0xe7, 0xf4, 0xc2, 0x00, 0x72, 0xde, 0xac, 0x44, 0x35, 0xb8, 0x3c, 0x20, 0x7e, 0x42, 0x8b,
-	0x1d, 0xe4, 0x3f, 0xbb, 0x4b, 0x8f, 0xc0, 0x6e, 0xe5, 0xea, 0xf3, 0x26, 0xa8, 0x5f, 0xa9, 0x70,
-	0x59, 0xae, 0x11, 0x21, 0x58, 0x09, 0x49, 0x40, 0x0b, 0xb1, 0xac, 0x6f, 0xe9, 0x00, 0xbd, 0x80,
-	0xfa, 0x80, 0xf8, 0xcc, 0x75, 0xca, 0xac, 0xe5, 0xee, 0xd6, 0x76, 0x36, 0xcc, 0xfc, 0x31, 0x98,
-	0xe5, 0x63, 0x30, 0x5b, 0x05, 0x61, 0x7f, 0x25, 0x9b, 0xf2, 0xd3, 0xf7, 0x4d, 0x60, 0xff, 0x2f,
-	0xa5, 0xe5, 0x01, 0x6a, 0xc3, 0xf5, 0x6e, 0xd2, 0x3b, 0xa7, 0xf1, 0xdc, 0xac, 0xb2, 0xb8, 0x99,
-	0x9e, 0x6b, 0x67, 0x6e, 0x5d, 0x58, 0x13, 0x24, 0xa6, 0x8e, 0xcf, 0x02, 0x16, 0x3b, 0xc4, 0xf7,
-	0xb8, 0x60, 0xf1, 0xbb, 0xc0, 0x58, 0x6e, 0x80, 0xa6, 0xbe, 0xb3, 0xbd, 0xe0, 0xd3, 0xda, 0x2b,
-	0x75, 0x36, 0xca, 0xdc, 0xda, 0x99, 0xd9, 0x0c, 0x43, 0xcf, 0xe1, 0x2a, 0x2f, 0xc2, 0x8c, 0x0c,
-	0x4d, 0xe6, 0x7f, 0x6f, 0xf1, 0xfc, 0xed, 0xb9, 0x38, 0x8f, 0x62, 0xeb, 0x21, 0xd4, 0xff, 0xbc,
-	0x15, 0x55, 0xe1, 0x7f, 0x4f, 0x0f, 0x5f, 0x1f, 0xb4, 0x9c, 0xd3, 0xc3, 0xa3, 0x56, 0xe7, 0xb4,
-	0xaa, 0x20, 0x04, 0x75, 0xbb, 0xd3, 0x6e, 0x1f, 0x1e, 0x3d, 0x2b, 0x31, 0xb0, 0xff, 0x64, 0x34,
-	0xc1, 0xca, 0x78, 0x82, 0x95, 0xeb, 0x09, 0x56, 0x6e, 0x26, 0x58, 0xf9, 0x90, 0x62, 0xf0, 0x25,
-	0xc5, 0xca, 0x28, 0xc5, 0x60, 0x9c, 0x62, 0xf0, 0x23, 0xc5, 0xe0, 0x67, 0x8a, 0x95, 0x9b, 0x14,
-	0x83, 0x8f, 0x53, 0xac, 0x8c, 0xa7, 0x58, 0xb9, 0x9e, 0x62, 0xe5, 0x8d, 0x96, 0xb7, 0xd6, 0xd5,
-	0xe4, 0x6a, 0x1f, 0xfc, 0x0a, 0x00, 0x00, 0xff, 0xff, 0xea, 0x07, 0x8f, 0x2c, 0x0d, 0x04, 0x00,
-	0x00,
+	// 663 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x31, 0x6f, 0xd3, 0x40,
+	0x18, 0xf5, 0xc5, 0x49, 0x48, 0xae, 0x90, 0x46, 0xa7, 0x0c, 0x6e, 0x24, 0xae, 0x51, 0x17, 0x22,
+	0x84, 0x9c, 0xaa, 0x2c, 0xa8, 0x12, 0x82, 0x86, 0x84, 0x12, 0x6a, 0x35, 0xc5, 0x6d, 0x55, 0x60,
+	0x31, 0x97, 0xe4, 0x1a, 0xac, 0xda, 0xbe, 0x72, 0xb6, 0xa3, 0xb6, 0x0b, 0x8c, 0x8c, 0x8c, 0x8c,
+	0x8c, 0xfc, 0x94, 0x8e, 0x1d, 0x3b, 0x01, 0x71, 0x19, 0xba, 0xd1, 0x9f, 0x80, 0x7c, 0xb6, 0x93,
+	0x36, 0x82, 0x36, 0x53, 0xbe, 0xbc, 0x7b, 0xef, 0xbb, 0x77, 0xdf, 0xf7, 0x0c, 0x1f, 0xd8, 0xe6,
+	0x01, 0xe5, 0x35, 0xd2, 0x23, 0xfb, 0x1e, 0xe5, 0x35, 0x4e, 0x7b, 0xa6, 0xfb, 0xc1, 0x67, 0x1e,
+	0xa9, 0x75, 0x99, 0xb3, 0x6b, 0xf6, 0xe3, 0x1f, 0x75, 0x9f, 0x33, 0x8f, 0xa1, 0xb9, 0x98, 0xa7,
+	0x8e, 0x79, 0x6a, 0x44, 0x28, 0xe3, 0x3e, 0x63, 0x7d, 0x8b, 0xd6, 0x04, 0xb1, 0xe3, 0xef, 0xd6,
+	0x7a, 0x3e, 0x27, 0x9e, 0xc9, 0x9c, 0x48, 0x5a, 0x2e, 0xf5, 0x59, 0x9f, 0x89, 0xb2, 0x16, 0x56,
+	0x11, 0xba, 0xf0, 0x3b, 0x07, 0xb3, 0x1b, 0x84, 0x13, 0xdb, 0x45, 0x4d, 0x98, 0x15, 0x0d, 0x5d,
+	0x05, 0x54, 0xe4, 0xea, 0xcc, 0xd2, 0x3d, 0xf5, 0xbf, 0x97, 0xa9, 0x91, 0x44, 0x7d, 0x15, 0x62,
+	0xf5, 0xf4, 0xf1, 0x8f, 0x79, 0x49, 0x8f, 0xc5, 0xa8, 0x0a, 0x8b, 0x82, 0x6f, 0xb8, 0x94, 0x0f,
+	0x28, 0x37, 0x7c, 0x6e, 0x29, 0xa9, 0x0a, 0xa8, 0xe6, 0xf5, 0x82, 0xc0, 0x37, 0x05, 0xbc, 0xcd,
+	0x2d, 0xb4, 0x08, 0x4b, 0x5d, 0xe6, 0x38, 0xb4, 0x1b, 0xba, 0x34, 0xf6, 0x19, 0xb3, 0x0c, 0xd7,
+	0x3c, 0xa2, 0x8a, 0x5c, 0x01, 0x55, 0x59, 0x47, 0xe3, 0xb3, 0x0d, 0xc6, 0xac, 0x4d, 0xf3, 0x88,
+	0x96, 0xff, 0x00, 0x98, 0x6b, 0x0f, 0x28, 0xe7, 0x66, 0x8f, 0xa2, 0x77, 0x10, 0xf6, 0x4c, 0x9b,
+	0x3a, 0xae, 0xc9, 0x9c, 0xc4, 0xf3, 0xf2, 0xcd, 0x9e, 0x13, 0xbd, 0xda, 0x18, 0x89, 0x9b, 0x8e,
+	0xc7, 0x0f, 0xe3, 0x67, 0x5c, 0xea, 0x89, 0xee, 0x42, 0x68, 0x93, 0x03, 0x83, 0xd8, 0xcc, 0x77,
+	0x3c, 0xf1, 0x08, 0x59, 0xcf, 0xdb, 0xe4, 0x60, 0x45, 0x00, 0xe1, 0x71, 0xc7, 0xe7, 0xae, 0x77,
+	0xd9, 0x75, 0x5e, 0x20, 0xc2, 0xec, 0x63, 0x38, 0x3b, 0x71, 0x05, 0x2a, 0x42, 0x79, 0x8f, 0x1e,
+	0x2a, 0x40, 0x8c, 0x23, 0x2c, 0x51, 0x09, 0x66, 0x06, 0xc4, 0xf2, 0x69, 0x3c, 0xa2, 0xe8, 0xcf,
+	0x72, 0xea, 0x11, 0x58, 0x4e, 0x7f, 0xfe, 0x36, 0x0f, 0xca, 0x1f, 0x61, 0x46, 0xa3, 0x03, 0x6a,
+	0x21, 0x04, 0xd3, 0x0e, 0xb1, 0x69, 0xac, 0x15, 0x35, 0xc2, 0x57, 0x26, 0x90, 0xaa, 0xc8, 0xd5,
+	0xfc, 0x35, 0xfe, 0xe5, 0xeb, 0xfd, 0xa7, 0x27, 0xfc, 0xc7, 0x06, 0xce, 0x65, 0x98, 0x11, 0x6b,
+	0xfe, 0xa7, 0x83, 0x1b, 0x26, 0xf4, 0x12, 0x16, 0x06, 0xc4, 0x32, 0x7b, 0x46, 0x92, 0x45, 0x61,
+	0x62, 0x66, 0x69, 0x4e, 0x8d, 0xc2, 0xaa, 0x26, 0x61, 0x55, 0x1b, 0x31, 0xa1, 0x9e, 0x0b, 0xb7,
+	0xf0, 0xf5, 0xe7, 0x3c, 0xd0, 0xef, 0x08, 0x69, 0x72, 0x80, 0x34, 0x38, 0xdb, 0xf1, 0xbb, 0x7b,
+	0xd4, 0x1b, 0x37, 0x4b, 0x4f, 0xdf, 0xac, 0x10, 0x69, 0x47, 0xdd, 0x3a, 0xb0, 0xc4, 0x89, 0x47,
+	0x0d, 0xcb, 0xb4, 0x4d, 0xcf, 0x20, 0x56, 0x9f, 0x71, 0xd3, 0x7b, 0x6f, 0x2b, 0x99, 0x0a, 0xa8,
+	0x16, 0x96, 0x16, 0xa7, 0x8c, 0xfe, 0x4a, 0xa2, 0xd3, 0x51, 0xd8, 0x4d, 0x0b, 0x9b, 0x8d, 0x30,
+	0xf4, 0x02, 0xe6, 0x59, 0x1c, 0x36, 0x57, 0xc9, 0x8a, 0x7c, 0xde, 0x9f, 0x3e, 0x9f, 0xfa, 0x58,
+	0x3c, 0xb1, 0xa9, 0x5b, 0x13, 0x9b, 0x42, 0x4f, 0x60, 0xd6, 0x0a, 0x43, 0xe2, 0x2a, 0xb9, 0x69,
+	0xbf, 0x5c, 0x11, 0x2a, 0x3d, 0x96, 0x45, 0xab, 0x5e, 0x78, 0x03, 0x0b, 0x57, 0x5f, 0x85, 0x8a,
+	0xf0, 0xf6, 0xf3, 0xd6, 0xeb, 0x66, 0xc3, 0xd8, 0x69, 0xad, 0x37, 0xda, 0x3b, 0x45, 0x09, 0x21,
+	0x58, 0xd0, 0xdb, 0x9a, 0xd6, 0x5a, 0x5f, 0x4d, 0x30, 0x10, 0xb2, 0xb6, 0xda, 0x6b, 0xcd, 0x75,
+	0xa3, 0xbe, 0xfd, 0x6c, 0xad, 0xb9, 0x55, 0x4c, 0xa1, 0x59, 0x38, 0xb3, 0xa9, 0xb5, 0x1a, 0x21,
+	0x4b, 0x6b, 0xaf, 0x16, 0xe5, 0xfa, 0xd3, 0xe3, 0x21, 0x96, 0x4e, 0x86, 0x58, 0x3a, 0x1d, 0x62,
+	0xe9, 0x62, 0x88, 0xa5, 0x4f, 0x01, 0x06, 0xdf, 0x03, 0x2c, 0x1d, 0x07, 0x18, 0x9c, 0x04, 0x18,
+	0xfc, 0x0a, 0x30, 0x38, 0x0f, 0xb0, 0x74, 0x11, 0x60, 0xf0, 0xe5, 0x0c, 0x4b, 0x27, 0x67, 0x58,
+	0x3a, 0x3d, 0xc3, 0xd2, 0xdb, 0x6c, 0xe4, 0xbb, 0x93, 0x15, 0xdb, 0x7d, 0xf8, 0x77, 0x00, 0x67,
+	0x56, 0x47, 0xf4, 0x30, 0x05, 0x00, 0x00,
 }
 
 func (x Params_QuotaAlgorithm) String() string {
@@ -404,6 +545,11 @@ func (m *Params_Override) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BurstSize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.BurstSize))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.MaxAmount != 0 {
 		i = encodeVarintConfig(dAtA, i, uint64(m.MaxAmount))
 		i--
@@ -431,6 +577,55 @@ func (m *Params_Override) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Params_Level) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params_Level) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params_Level) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BurstSize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.BurstSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MaxAmount != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxAmount))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Dimensions) > 0 {
+		for iNdEx := len(m.Dimensions) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Dimensions[iNdEx])
+			copy(dAtA[i:], m.Dimensions[iNdEx])
+			i = encodeVarintConfig(dAtA, i, uint64(len(m.Dimensions[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Params_Quota) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -451,6 +646,25 @@ func (m *Params_Quota) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Levels) > 0 {
+		for iNdEx := len(m.Levels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Levels[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintConfig(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if m.BurstSize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.BurstSize))
+		i--
+		dAtA[i] = 0x38
+	}
 	if len(m.Overrides) > 0 {
 		for iNdEx := len(m.Overrides) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -551,6 +765,34 @@ func (m *Params_Override) Size() (n int) {
 	if m.MaxAmount != 0 {
 		n += 1 + sovConfig(uint64(m.MaxAmount))
 	}
+	if m.BurstSize != 0 {
+		n += 1 + sovConfig(uint64(m.BurstSize))
+	}
+	return n
+}
+
+func (m *Params_Level) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if len(m.Dimensions) > 0 {
+		for _, s := range m.Dimensions {
+			l = len(s)
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
+	if m.MaxAmount != 0 {
+		n += 1 + sovConfig(uint64(m.MaxAmount))
+	}
+	if m.BurstSize != 0 {
+		n += 1 + sovConfig(uint64(m.BurstSize))
+	}
 	return n
 }
 
@@ -580,6 +822,15 @@ func (m *Params_Quota) Size() (n int) {
 			n += 1 + l + sovConfig(uint64(l))
 		}
 	}
+	if m.BurstSize != 0 {
+		n += 1 + sovConfig(uint64(m.BurstSize))
+	}
+	if len(m.Levels) > 0 {
+		for _, e := range m.Levels {
+			l = e.Size()
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -623,6 +874,20 @@ func (this *Params_Override) String() string {
 	s := strings.Join([]string{`&Params_Override{`,
 		`Dimensions:` + mapStringForDimensions + `,`,
 		`MaxAmount:` + fmt.Sprintf("%v", this.MaxAmount) + `,`,
+		`BurstSize:` + fmt.Sprintf("%v", this.BurstSize) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Params_Level) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Params_Level{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Dimensions:` + fmt.Sprintf("%v", this.Dimensions) + `,`,
+		`MaxAmount:` + fmt.Sprintf("%v", this.MaxAmount) + `,`,
+		`BurstSize:` + fmt.Sprintf("%v", this.BurstSize) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -636,6 +901,11 @@ func (this *Params_Quota) String() string {
 		repeatedStringForOverrides += strings.Replace(fmt.Sprintf("%v", f), "Params_Override", "Params_Override", 1) + ","
 	}
 	repeatedStringForOverrides += "}"
+	repeatedStringForLevels := "[]*Params_Level{"
+	for _, f := range this.Levels {
+		repeatedStringForLevels += strings.Replace(fmt.Sprintf("%v", f), "Params_Level", "Params_Level", 1) + ","
+	}
+	repeatedStringForLevels += "}"
 	s := strings.Join([]string{`&Params_Quota{`,
 		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
 		`MaxAmount:` + fmt.Sprintf("%v", this.MaxAmount) + `,`,
@@ -643,6 +913,8 @@ func (this *Params_Quota) String() string {
 		`BucketDuration:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.BucketDuration), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
 		`RateLimitAlgorithm:` + fmt.Sprintf("%v", this.RateLimitAlgorithm) + `,`,
 		`Overrides:` + repeatedStringForOverrides + `,`,
+		`BurstSize:` + fmt.Sprintf("%v", this.BurstSize) + `,`,
+		`Levels:` + repeatedStringForLevels + `,`,
 		`}`,
 	}, "")
 	return s
@@ -968,6 +1240,180 @@ func (m *Params_Override) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BurstSize", wireType)
+			}
+			m.BurstSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BurstSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Params_Level) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Level: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Level: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dimensions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dimensions = append(m.Dimensions, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAmount", wireType)
+			}
+			m.MaxAmount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAmount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BurstSize", wireType)
+			}
+			m.BurstSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BurstSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -1191,6 +1637,59 @@ func (m *Params_Quota) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BurstSize", wireType)
+			}
+			m.BurstSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BurstSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Levels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Levels = append(m.Levels, &Params_Level{})
+			if err := m.Levels[len(m.Levels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -0,0 +1,271 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisquota
+
+import (
+	"istio.io/istio/mixer/adapter/redisquota/config"
+	"istio.io/istio/mixer/template/quota"
+)
+
+// The scripts of hierarchical quotas enforce the limits of several levels in a single redis
+// round-trip. Each script is made of the header, the level functions of an algorithm and the body.
+//
+// The level functions are:
+//
+//	peek(key_meta, key_data, credit, burst): returns the token available at the level and the
+//	  validity of an allocation, after reclaiming the expired token.
+//	take(key_meta, key_data, credit, burst, amount): allocates the given amount at the level.
+//
+// The keys and limits of the levels are passed from the innermost to the outermost level:
+//
+//	KEYS[2i-1], KEYS[2i]: meta and data keys of the level i
+//	ARGV[5+2i], ARGV[6+2i]: credit and burst size of the level i
+const (
+	// LUA script header for hierarchical quotas
+	luaHierarchicalHeader = `
+local windowLength = tonumber(ARGV[1])
+local bucketLength = tonumber(ARGV[2])
+local bestEffort = tonumber(ARGV[3])
+local token = tonumber(ARGV[4])
+local timestamp = tonumber(ARGV[5])
+local deduplicationid = ARGV[6]
+
+local levels = #KEYS / 2
+
+-- lookup previous response for the deduplicationid and returns if it is still valid
+--------------------------------------------------------------------------------
+if (deduplicationid or '') ~= '' then
+	local previous_token = tonumber(redis.call("HGET", deduplicationid .. "-" .. KEYS[1], "token"))
+	local previous_expire = tonumber(redis.call("HGET", deduplicationid .. "-" .. KEYS[1], "expire"))
+
+	if previous_token and previous_expire then
+		if timestamp < previous_expire then
+			return {previous_token, previous_expire - timestamp}
+		end
+	end
+end
+`
+
+	// LUA level functions for fixed window
+	luaFixedWindowLevel = `
+local function peek(key_meta, key_data, credit, burst)
+  local info_token = tonumber(redis.call("HGET", key_meta, "token"))
+  local info_expire = tonumber(redis.call("HGET", key_meta, "expire"))
+
+  if (not info_token or not info_expire) or (timestamp >= info_expire) then
+    info_token = 0
+    info_expire = windowLength + timestamp
+
+    redis.call("HMSET", key_meta, "token", info_token, "expire", info_expire)
+    -- set the expiration time for automatic cleanup
+    redis.call("PEXPIRE", key_meta, math.ceil(windowLength / 1000000))
+  end
+
+  return credit - info_token, info_expire - timestamp
+end
+
+local function take(key_meta, key_data, credit, burst, amount)
+  redis.call("HINCRBY", key_meta, "token", amount)
+end
+`
+
+	// LUA level functions for rolling window
+	luaRollingWindowLevel = `
+local function peek(key_meta, key_data, credit, burst)
+  local info_token = tonumber(redis.call("HGET", key_meta, "token"))
+  local info_bucket_token = tonumber(redis.call("HGET", key_meta, "bucket.token"))
+  local info_bucket_timestamp = tonumber(redis.call("HGET", key_meta, "bucket.timestamp"))
+
+  -- initialize meta
+  if not info_token or not info_bucket_token or not info_bucket_timestamp then
+    info_token = 0
+    info_bucket_token = 0
+    info_bucket_timestamp = timestamp
+
+    redis.call("HMSET", key_meta,
+      "token", info_token,
+      "bucket.token", info_bucket_token,
+      "bucket.timestamp", info_bucket_timestamp,
+      "key", 0)
+  end
+
+  -- move buffer to bucket list if bucket timer is older than bucket window
+  if (timestamp - info_bucket_timestamp + 1) > bucketLength then
+    if info_bucket_token > 0 then
+      local nextKey = redis.call("HINCRBY", key_meta, "key", 1)
+      redis.call("ZADD", key_data, info_bucket_timestamp, tostring(nextKey) .. "." .. tostring(info_bucket_token))
+    end
+    redis.call("HMSET", key_meta,
+      "bucket.token", 0,
+      "bucket.timestamp", timestamp)
+  end
+
+  -- reclaim tokens from expired records
+  local time_to_expire = timestamp - windowLength
+  local reclaimed = 0
+  for idx, value in ipairs(redis.call("ZRANGEBYSCORE", key_data, 0, time_to_expire)) do
+    reclaimed = reclaimed + tonumber(string.sub(value, string.find(value, "%.")+1))
+  end
+  redis.call("ZREMRANGEBYSCORE", key_data, 0, time_to_expire)
+
+  if reclaimed > 0 then
+    info_token = info_token - reclaimed
+    if info_token < 0 then
+      info_token = 0
+    end
+    redis.call("HSET", key_meta, "token", info_token)
+  end
+
+  -- update the expiration time for automatic cleanup
+  redis.call("PEXPIRE", key_meta, math.ceil(windowLength / 1000000))
+  redis.call("PEXPIRE", key_data, math.ceil(windowLength / 1000000))
+
+  return credit - info_token, windowLength
+end
+
+local function take(key_meta, key_data, credit, burst, amount)
+  redis.call("HINCRBY", key_meta, "token", amount)
+  redis.call("HINCRBY", key_meta, "bucket.token", amount)
+end
+`
+
+	// LUA level functions for token bucket
+	luaTokenBucketLevel = `
+local function capacity(credit, burst)
+  if not burst or burst <= 0 then
+    return credit
+  end
+  return burst
+end
+
+local function peek(key_meta, key_data, credit, burst)
+  local info_token = tonumber(redis.call("HGET", key_meta, "token"))
+  local info_timestamp = tonumber(redis.call("HGET", key_meta, "timestamp"))
+
+  -- a new bucket is full
+  if not info_token or not info_timestamp then
+    info_token = capacity(credit, burst)
+    info_timestamp = timestamp
+  end
+
+  -- refill the bucket for the time elapsed since the last request
+  if timestamp > info_timestamp then
+    info_token = math.min(capacity(credit, burst), info_token + (timestamp - info_timestamp) * credit / windowLength)
+    info_timestamp = timestamp
+  end
+
+  redis.call("HMSET", key_meta, "token", info_token, "timestamp", info_timestamp)
+  redis.call("PEXPIRE", key_meta, math.ceil((capacity(credit, burst) - info_token) * windowLength / credit / 1000000) + 1)
+
+  return math.floor(info_token), windowLength
+end
+
+local function take(key_meta, key_data, credit, burst, amount)
+  local info_token = tonumber(redis.call("HGET", key_meta, "token")) - amount
+  redis.call("HSET", key_meta, "token", info_token)
+  redis.call("PEXPIRE", key_meta, math.ceil((capacity(credit, burst) - info_token) * windowLength / credit / 1000000) + 1)
+end
+`
+
+	// LUA level functions for sliding log
+	luaSlidingLogLevel = `
+local function peek(key_meta, key_data, credit, burst)
+  -- remove the allocations that are out of the window, and sum the others
+  redis.call("ZREMRANGEBYSCORE", key_data, 0, timestamp - windowLength)
+
+  local used = 0
+  for idx, value in ipairs(redis.call("ZRANGE", key_data, 0, -1)) do
+    used = used + tonumber(string.sub(value, string.find(value, "%.")+1))
+  end
+
+  return credit - used, windowLength
+end
+
+local function take(key_meta, key_data, credit, burst, amount)
+  local nextKey = redis.call("HINCRBY", key_meta, "key", 1)
+  redis.call("ZADD", key_data, timestamp, tostring(nextKey) .. "." .. tostring(amount))
+
+  -- update the expiration time for automatic cleanup
+  redis.call("PEXPIRE", key_meta, math.ceil(windowLength / 1000000))
+  redis.call("PEXPIRE", key_data, math.ceil(windowLength / 1000000))
+end
+`
+
+	// LUA script body for hierarchical quotas
+	luaHierarchicalBody = `
+-- find the token that every level can allocate
+--------------------------------------------------------------------------------
+local allocated = token
+local validity = -1
+
+for level = 1, levels do
+  local available, valid = peek(KEYS[2*level-1], KEYS[2*level], tonumber(ARGV[5+2*level]), tonumber(ARGV[6+2*level]))
+
+  if available < allocated then
+    if bestEffort == 1 then
+      -- allocate available token only
+      allocated = available
+    else
+      allocated = 0
+    end
+  end
+
+  if allocated <= 0 then
+    -- not enough token
+    return {0, 0}
+  end
+
+  if validity < 0 or valid < validity then
+    validity = valid
+  end
+end
+
+-- charge every level
+--------------------------------------------------------------------------------
+for level = 1, levels do
+  take(KEYS[2*level-1], KEYS[2*level], tonumber(ARGV[5+2*level]), tonumber(ARGV[6+2*level]), allocated)
+end
+
+-- save current request and set expiration time for auto cleanup
+if (deduplicationid or '') ~= '' then
+	redis.call("HMSET", deduplicationid .. "-" .. KEYS[1], "token", allocated, "expire", timestamp + validity)
+	redis.call("PEXPIRE", deduplicationid .. "-" .. KEYS[1], math.ceil(validity / 1000000))
+end
+
+return {allocated, validity}
+`
+)
+
+var (
+	// LUA rate-limiting algorithm scripts for hierarchical quotas
+	hierarchicalLUAScripts = map[config.Params_QuotaAlgorithm]string{
+		config.FIXED_WINDOW:   luaHierarchicalHeader + luaFixedWindowLevel + luaHierarchicalBody,
+		config.ROLLING_WINDOW: luaHierarchicalHeader + luaRollingWindowLevel + luaHierarchicalBody,
+		config.TOKEN_BUCKET:   luaHierarchicalHeader + luaTokenBucketLevel + luaHierarchicalBody,
+		config.SLIDING_LOG:    luaHierarchicalHeader + luaSlidingLogLevel + luaHierarchicalBody,
+	}
+)
+
+// getLevelKey returns the key of the group of the level the instance belongs to.
+func getLevelKey(instance *quota.Instance, quota *config.Params_Quota, level *config.Params_Level) string {
+	dimensions := make(map[string]interface{}, len(level.Dimensions))
+	for _, d := range level.Dimensions {
+		if v, ok := instance.Dimensions[d]; ok {
+			dimensions[d] = v
+		}
+	}
+
+	return makeKey(quota.Name+"/"+level.Name, dimensions)
+}
@@ -43,6 +43,8 @@ var (
 	rateLimitingLUAScripts = map[config.Params_QuotaAlgorithm]string{
 		config.FIXED_WINDOW:   luaFixedWindow,
 		config.ROLLING_WINDOW: luaRollingWindow,
+		config.TOKEN_BUCKET:   luaTokenBucket,
+		config.SLIDING_LOG:    luaSlidingLog,
 	}
 )
 
@@ -66,6 +68,9 @@ type (
 		// list of algorithm LUA scripts
 		scripts map[config.Params_QuotaAlgorithm]*redis.Script
 
+		// list of algorithm LUA scripts for hierarchical quotas
+		hierarchicalScripts map[config.Params_QuotaAlgorithm]*redis.Script
+
 		// indirection to support fast deterministic tests
 		getTime func() time.Time
 
@@ -125,6 +130,11 @@ func (b *builder) Validate() (ce *adapter.ConfigErrors) {
 			}
 		}
 
+		if quotas.BurstSize < 0 {
+			ce = ce.Appendf("burst_size", "quotas.burst_size must be >= 0")
+			continue
+		}
+
 		for index := range quotas.Overrides {
 			if quotas.Overrides[index].MaxAmount <= 0 {
 				ce = ce.Appendf("max_amount", "quotas.overrides.max_amount must be > 0")
@@ -135,6 +145,37 @@ func (b *builder) Validate() (ce *adapter.ConfigErrors) {
 				ce = ce.Appendf("dimensions", "quotas.overrides.dimensions is empty")
 				continue
 			}
+
+			if quotas.Overrides[index].BurstSize < 0 {
+				ce = ce.Appendf("burst_size", "quotas.overrides.burst_size must be >= 0")
+				continue
+			}
+		}
+
+		levels := make(map[string]bool, len(quotas.Levels))
+		for index := range quotas.Levels {
+			level := quotas.Levels[index]
+
+			if len(level.Name) == 0 {
+				ce = ce.Appendf("levels", "quotas.levels.name should not be empty")
+				continue
+			}
+
+			if levels[level.Name] {
+				ce = ce.Appendf("levels", "quotas.levels.name: %v is not unique", level.Name)
+				continue
+			}
+			levels[level.Name] = true
+
+			if level.MaxAmount <= 0 {
+				ce = ce.Appendf("max_amount", "quotas.levels.max_amount must be > 0")
+				continue
+			}
+
+			if level.BurstSize < 0 {
+				ce = ce.Appendf("burst_size", "quotas.levels.burst_size must be >= 0")
+				continue
+			}
 		}
 	}
 
@@ -170,7 +211,7 @@ func (b *builder) Validate() (ce *adapter.ConfigErrors) {
 	}
 
 	// check scripts loading to redis
-	scripts := make(map[config.Params_QuotaAlgorithm]*redis.Script, 2)
+	scripts := make(map[config.Params_QuotaAlgorithm]*redis.Script, len(rateLimitingLUAScripts))
 	for algorithm, script := range rateLimitingLUAScripts {
 		scripts[algorithm] = redis.NewScript(script)
 		if _, err := scripts[algorithm].Load(client).Result(); err != nil {
@@ -179,6 +220,13 @@ func (b *builder) Validate() (ce *adapter.ConfigErrors) {
 		}
 	}
 
+	for _, script := range hierarchicalLUAScripts {
+		if _, err := redis.NewScript(script).Load(client).Result(); err != nil {
+			ce = ce.Appendf(info.Name, "unable to initialized redis service: %v", err)
+			return
+		}
+	}
+
 	_ = client.Close()
 	return
 }
@@ -228,18 +276,24 @@ func (b *builder) Build(context context.Context, env adapter.Env) (adapter.Handl
 	}
 
 	// load scripts into redis
-	scripts := make(map[config.Params_QuotaAlgorithm]*redis.Script, 2)
+	scripts := make(map[config.Params_QuotaAlgorithm]*redis.Script, len(rateLimitingLUAScripts))
 	for algorithm, script := range rateLimitingLUAScripts {
 		scripts[algorithm] = redis.NewScript(script)
 	}
 
+	hierarchicalScripts := make(map[config.Params_QuotaAlgorithm]*redis.Script, len(hierarchicalLUAScripts))
+	for algorithm, script := range hierarchicalLUAScripts {
+		hierarchicalScripts[algorithm] = redis.NewScript(script)
+	}
+
 	h := &handler{
-		client:        client,
-		limits:        limits,
-		scripts:       scripts,
-		logger:        env.Logger(),
-		getTime:       time.Now,
-		dimensionHash: dimensionHash,
+		client:              client,
+		limits:              limits,
+		scripts:             scripts,
+		hierarchicalScripts: hierarchicalScripts,
+		logger:              env.Logger(),
+		getTime:             time.Now,
+		dimensionHash:       dimensionHash,
 	}
 
 	return h, nil
@@ -287,8 +341,9 @@ func getAllocatedTokenFromResult(result *interface{}) (int64, time.Duration, err
 }
 
 // find override
-func (h *handler) getKeyAndQuotaAmount(instance *quota.Instance, quota *config.Params_Quota) (string, int64, error) {
+func (h *handler) getKeyAndQuotaAmount(instance *quota.Instance, quota *config.Params_Quota) (string, int64, int64, error) {
 	maxAmount := quota.MaxAmount
+	burstSize := quota.BurstSize
 	key := makeKey(quota.Name, instance.Dimensions)
 
 	for idx := range quota.Overrides {
@@ -296,19 +351,20 @@ func (h *handler) getKeyAndQuotaAmount(instance *quota.Instance, quota *config.P
 			h.logger.Debugf("quota override: %v selected for %v", quota.Overrides[idx], *instance)
 
 			if hash, ok := h.dimensionHash[&quota.Overrides[idx].Dimensions]; ok {
-				// override key and max amount
+				// override key, max amount and burst size
 				key = key + "-" + hash
 				maxAmount = quota.Overrides[idx].MaxAmount
-				return key, maxAmount, nil
+				burstSize = quota.Overrides[idx].BurstSize
+				return key, maxAmount, burstSize, nil
 			}
 
 			// This should not be happen
-			return "", 0, fmt.Errorf("quota override dimension hash lookup failed: %v in %v",
+			return "", 0, 0, fmt.Errorf("quota override dimension hash lookup failed: %v in %v",
 				h.limits[instance.Name].Overrides[idx].Dimensions, h.dimensionHash)
 		}
 	}
 
-	return key, maxAmount, nil
+	return key, maxAmount, burstSize, nil
 }
 
 func (h *handler) HandleQuota(context context.Context, instance *quota.Instance, args adapter.QuotaArgs) (adapter.QuotaResult, error) {
@@ -318,7 +374,7 @@ func (h *handler) HandleQuota(context context.Context, instance *quota.Instance,
 			ret := status.OK
 
 			// get overridden quotaAmount and quotaKey
-			key, maxAmount, err := h.getKeyAndQuotaAmount(instance, limit)
+			key, maxAmount, burstSize, err := h.getKeyAndQuotaAmount(instance, limit)
 			if err != nil {
 				_ = h.logger.Errorf("%v", err.Error())
 				return adapter.QuotaResult{
@@ -328,21 +384,27 @@ func (h *handler) HandleQuota(context context.Context, instance *quota.Instance,
 
 			h.logger.Debugf("key: %v maxAmount: %v", key, maxAmount)
 
-			// execute lua algorithm script
-			result, err := script.Run(
-				h.client,
-				[]string{
-					key + ".meta", // KEY[1]
-					key + ".data", // KEY[2]
-				},
-				maxAmount,                               // ARGV[1] credit
-				limit.GetValidDuration().Nanoseconds(),  // ARGV[2] window length
-				limit.GetBucketDuration().Nanoseconds(), // ARGV[3] bucket length
-				args.BestEffort,                         // ARGV[4] best effort
-				args.QuotaAmount,                        // ARGV[5] token
-				now.UnixNano(),                          // ARGV[6] timestamp
-				args.DeduplicationID,                    // ARGS[8] deduplication id
-			).Result()
+			var result interface{}
+			if len(limit.Levels) == 0 {
+				// execute lua algorithm script
+				result, err = script.Run(
+					h.client,
+					[]string{
+						key + ".meta", // KEY[1]
+						key + ".data", // KEY[2]
+					},
+					maxAmount,                               // ARGV[1] credit
+					limit.GetValidDuration().Nanoseconds(),  // ARGV[2] window length
+					limit.GetBucketDuration().Nanoseconds(), // ARGV[3] bucket length
+					args.BestEffort,                         // ARGV[4] best effort
+					args.QuotaAmount,                        // ARGV[5] token
+					now.UnixNano(),                          // ARGV[6] timestamp
+					args.DeduplicationID,                    // ARGV[7] deduplication id
+					burstSize,                               // ARGV[8] burst size
+				).Result()
+			} else {
+				result, err = h.runHierarchicalScript(instance, limit, key, maxAmount, burstSize, args, now)
+			}
 
 			if err != nil {
 				_ = h.logger.Errorf("failed to run quota script: %v", err)
@@ -376,6 +438,37 @@ func (h *handler) HandleQuota(context context.Context, instance *quota.Instance,
 	}, nil
 }
 
+// runHierarchicalScript executes the lua algorithm script of a hierarchical quota, which enforces the
+// limit of the quota and of every level in a single round-trip.
+func (h *handler) runHierarchicalScript(instance *quota.Instance, limit *config.Params_Quota, key string, maxAmount int64,
+	burstSize int64, args adapter.QuotaArgs, now time.Time) (interface{}, error) {
+	keys := make([]string, 0, 2*(len(limit.Levels)+1))
+	argv := make([]interface{}, 0, 6+2*(len(limit.Levels)+1))
+
+	argv = append(argv,
+		limit.GetValidDuration().Nanoseconds(),  // ARGV[1] window length
+		limit.GetBucketDuration().Nanoseconds(), // ARGV[2] bucket length
+		args.BestEffort,                         // ARGV[3] best effort
+		args.QuotaAmount,                        // ARGV[4] token
+		now.UnixNano(),                          // ARGV[5] timestamp
+		args.DeduplicationID,                    // ARGV[6] deduplication id
+	)
+
+	// the quota itself is the innermost level
+	keys = append(keys, key+".meta", key+".data")
+	argv = append(argv, maxAmount, burstSize)
+
+	for idx := range limit.Levels {
+		level := limit.Levels[idx]
+		levelKey := getLevelKey(instance, limit, level)
+
+		keys = append(keys, levelKey+".meta", levelKey+".data")
+		argv = append(argv, level.MaxAmount, level.BurstSize)
+	}
+
+	return h.hierarchicalScripts[limit.RateLimitAlgorithm].Run(h.client, keys, argv...).Result()
+}
+
 func (h handler) Close() error {
 	return h.client.Close()
 }
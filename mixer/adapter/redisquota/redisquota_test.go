@@ -19,6 +19,7 @@ package redisquota
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -242,6 +243,90 @@ func TestBuilderValidate(t *testing.T) {
 				"dimensions: quotas.overrides.dimensions is empty",
 			},
 		},
+		"Invalid burst_size": {
+			quotaTypes: map[string]*quota.Type{
+				"token-bucket": {},
+			},
+			config: &config.Params{
+				RedisServerUrl: mockRedis.Addr(),
+				Quotas: []config.Params_Quota{
+					{
+						Name:               "token-bucket",
+						MaxAmount:          10,
+						ValidDuration:      time.Minute,
+						BurstSize:          -1,
+						RateLimitAlgorithm: config.TOKEN_BUCKET,
+					},
+				},
+			},
+			errMsg: []string{
+				"burst_size: quotas.burst_size must be >= 0",
+			},
+		},
+		"Override invalid burst_size": {
+			quotaTypes: map[string]*quota.Type{
+				"token-bucket": {},
+			},
+			config: &config.Params{
+				RedisServerUrl: mockRedis.Addr(),
+				Quotas: []config.Params_Quota{
+					{
+						Name:               "token-bucket",
+						MaxAmount:          10,
+						ValidDuration:      time.Minute,
+						RateLimitAlgorithm: config.TOKEN_BUCKET,
+						Overrides: []*config.Params_Override{
+							{
+								MaxAmount: 5,
+								BurstSize: -1,
+								Dimensions: map[string]string{
+									"source": "test",
+								},
+							},
+						},
+					},
+				},
+			},
+			errMsg: []string{
+				"burst_size: quotas.overrides.burst_size must be >= 0",
+			},
+		},
+		"Invalid levels": {
+			quotaTypes: map[string]*quota.Type{
+				"fixed-window": {},
+			},
+			config: &config.Params{
+				RedisServerUrl: mockRedis.Addr(),
+				Quotas: []config.Params_Quota{
+					{
+						Name:          "fixed-window",
+						MaxAmount:     10,
+						ValidDuration: time.Minute,
+						Levels: []*config.Params_Level{
+							{
+								MaxAmount: 100,
+							},
+							{
+								Name:      "global",
+								MaxAmount: 100,
+							},
+							{
+								Name:      "global",
+								MaxAmount: 100,
+							},
+							{
+								Name: "tenant",
+							},
+						},
+					},
+				},
+			},
+			errMsg: []string{
+				"levels: quotas.levels.name should not be empty",
+				"levels: quotas.levels.name: global is not unique",
+				"max_amount: quotas.levels.max_amount must be > 0",
+			},
+		},
 	}
 
 	info := GetInfo()
@@ -468,6 +553,145 @@ func TestHandleQuota(t *testing.T) {
 				{false, 1, 1, 0, 0, ""},
 			},
 		},
+		"algorithm = token bucket, best effort = true": {
+			quotaType: map[string]*quota.Type{
+				"token_bucket_best_effort": {},
+			},
+			quotaConfig: []config.Params_Quota{
+				{
+					Name:               "token_bucket_best_effort",
+					MaxAmount:          10,
+					ValidDuration:      time.Second * time.Duration(10),
+					BurstSize:          20,
+					RateLimitAlgorithm: config.TOKEN_BUCKET,
+				},
+			},
+			instance: quota.Instance{
+				Name:       "token_bucket_best_effort",
+				Dimensions: map[string]interface{}{},
+			},
+			request: []RequestInfo{
+				{true, 15, 0, 15, 10, "test"}, // record current response
+				{true, 15, 1, 15, 9, "test"},  // from the previous response
+				{true, 10, 1, 6, 10, ""},
+				{true, 3, 2, 1, 10, ""},
+				{true, 3, 2, 0, 0, ""},
+				{true, 30, 50, 20, 10, ""},
+			},
+		},
+		"algorithm = token bucket, best effort = false": {
+			quotaType: map[string]*quota.Type{
+				"token_bucket": {},
+			},
+			quotaConfig: []config.Params_Quota{
+				{
+					Name:               "token_bucket",
+					MaxAmount:          10,
+					ValidDuration:      time.Second * time.Duration(10),
+					BurstSize:          20,
+					RateLimitAlgorithm: config.TOKEN_BUCKET,
+				},
+			},
+			instance: quota.Instance{
+				Name:       "token_bucket",
+				Dimensions: map[string]interface{}{},
+			},
+			request: []RequestInfo{
+				{false, 15, 0, 15, 10, ""},
+				{false, 10, 1, 0, 0, ""},
+				{false, 7, 2, 7, 10, ""},
+				{false, 2, 3, 0, 0, ""},
+				{false, 8, 10, 8, 10, ""},
+				{false, 20, 100, 20, 10, ""},
+				{false, 1, 100, 0, 0, ""},
+			},
+		},
+		"algorithm = token bucket, best effort = false, limit override": {
+			quotaType: map[string]*quota.Type{
+				"token_bucket_override": {},
+			},
+			quotaConfig: []config.Params_Quota{
+				{
+					Name:               "token_bucket_override",
+					MaxAmount:          10,
+					ValidDuration:      time.Second * time.Duration(10),
+					BurstSize:          20,
+					RateLimitAlgorithm: config.TOKEN_BUCKET,
+					Overrides: []*config.Params_Override{
+						{
+							MaxAmount: 5,
+							Dimensions: map[string]string{
+								"source": "none",
+							},
+						},
+					},
+				},
+			},
+			instance: quota.Instance{
+				Name: "token_bucket_override",
+				Dimensions: map[string]interface{}{
+					"source": "none",
+				},
+			},
+			request: []RequestInfo{
+				{false, 6, 0, 0, 0, ""},
+				{false, 5, 0, 5, 10, ""},
+				{false, 1, 1, 0, 0, ""},
+				{false, 1, 2, 1, 10, ""},
+			},
+		},
+		"algorithm = sliding log, best effort = true": {
+			quotaType: map[string]*quota.Type{
+				"sliding_log_best_effort": {},
+			},
+			quotaConfig: []config.Params_Quota{
+				{
+					Name:               "sliding_log_best_effort",
+					MaxAmount:          10,
+					ValidDuration:      time.Second * time.Duration(10),
+					RateLimitAlgorithm: config.SLIDING_LOG,
+				},
+			},
+			instance: quota.Instance{
+				Name:       "sliding_log_best_effort",
+				Dimensions: map[string]interface{}{},
+			},
+			request: []RequestInfo{
+				{true, 6, 0, 6, 10, "test"}, // record current response
+				{true, 6, 3, 6, 7, "test"},  // from the previous response
+				{true, 6, 3, 4, 10, ""},
+				{true, 1, 4, 0, 0, ""},
+				{true, 8, 10, 6, 10, ""},
+			},
+		},
+		"algorithm = sliding log, best effort = false": {
+			quotaType: map[string]*quota.Type{
+				"sliding_log": {},
+			},
+			quotaConfig: []config.Params_Quota{
+				{
+					Name:               "sliding_log",
+					MaxAmount:          10,
+					ValidDuration:      time.Second * time.Duration(10),
+					RateLimitAlgorithm: config.SLIDING_LOG,
+				},
+			},
+			instance: quota.Instance{
+				Name:       "sliding_log",
+				Dimensions: map[string]interface{}{},
+			},
+			request: []RequestInfo{
+				{false, 3, 0, 3, 10, ""},
+				{false, 3, 1, 3, 10, ""},
+				{false, 5, 5, 0, 0, ""},
+				{false, 4, 5, 4, 10, ""},
+				{false, 1, 9, 0, 0, ""},
+				{false, 3, 10, 3, 10, ""},
+				{false, 4, 11, 0, 0, ""},
+				{false, 3, 11, 3, 10, ""},
+				{false, 10, 30, 10, 10, ""},
+			},
+		},
 	}
 
 	for id, c := range cases {
@@ -514,6 +738,144 @@ func TestHandleQuota(t *testing.T) {
 	}
 }
 
+func TestHandleHierarchicalQuota(t *testing.T) {
+	mockRedis, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("Unable to start mock redis server: %v", err)
+		return
+	}
+	defer mockRedis.Close()
+
+	levels := []*config.Params_Level{
+		{
+			Name:       "tenant",
+			Dimensions: []string{"tenant"},
+			MaxAmount:  8,
+		},
+		{
+			Name:      "global",
+			MaxAmount: 10,
+		},
+	}
+
+	type request struct {
+		RequestInfo
+		user   string
+		tenant string
+	}
+
+	cases := map[string]struct {
+		algorithm config.Params_QuotaAlgorithm
+		request   []request
+	}{
+		"algorithm = fixed window": {
+			algorithm: config.FIXED_WINDOW,
+			request: []request{
+				{RequestInfo{false, 5, 0, 5, 10, "test"}, "u1", "t1"},
+				{RequestInfo{false, 5, 1, 5, 9, "test"}, "u1", "t1"}, // from the previous response
+				{RequestInfo{false, 5, 1, 0, 0, ""}, "u2", "t1"},     // tenant t1 has 3 left
+				{RequestInfo{true, 5, 1, 3, 9, ""}, "u2", "t1"},
+				{RequestInfo{true, 5, 2, 2, 8, ""}, "u3", "t2"}, // global has 2 left
+				{RequestInfo{true, 1, 2, 0, 0, ""}, "u3", "t2"},
+				{RequestInfo{true, 5, 12, 5, 10, ""}, "u3", "t2"},
+			},
+		},
+		"algorithm = rolling window": {
+			algorithm: config.ROLLING_WINDOW,
+			request: []request{
+				{RequestInfo{false, 5, 0, 5, 10, ""}, "u1", "t1"},
+				{RequestInfo{false, 5, 0, 0, 0, ""}, "u2", "t1"}, // tenant t1 has 3 left
+				{RequestInfo{true, 5, 1, 3, 10, ""}, "u2", "t1"},
+				{RequestInfo{true, 5, 2, 2, 10, ""}, "u3", "t2"}, // global has 2 left
+				{RequestInfo{true, 1, 2, 0, 0, ""}, "u3", "t2"},
+			},
+		},
+		"algorithm = token bucket": {
+			algorithm: config.TOKEN_BUCKET,
+			request: []request{
+				{RequestInfo{false, 5, 0, 5, 10, ""}, "u1", "t1"},
+				{RequestInfo{false, 4, 0, 0, 0, ""}, "u2", "t1"}, // tenant t1 has 3 left
+				{RequestInfo{true, 4, 0, 3, 10, ""}, "u2", "t1"},
+				{RequestInfo{true, 5, 0, 2, 10, ""}, "u3", "t2"}, // global has 2 left
+				{RequestInfo{true, 5, 5, 5, 10, ""}, "u3", "t2"}, // each level is refilled at its own rate
+			},
+		},
+		"algorithm = sliding log": {
+			algorithm: config.SLIDING_LOG,
+			request: []request{
+				{RequestInfo{false, 5, 0, 5, 10, ""}, "u1", "t1"},
+				{RequestInfo{false, 5, 0, 0, 0, ""}, "u2", "t1"}, // tenant t1 has 3 left
+				{RequestInfo{true, 5, 1, 3, 10, ""}, "u2", "t1"},
+				{RequestInfo{true, 5, 2, 2, 10, ""}, "u3", "t2"},  // global has 2 left
+				{RequestInfo{true, 5, 10, 3, 10, ""}, "u3", "t2"}, // allocations of u1 left the window
+			},
+		},
+	}
+
+	info := GetInfo()
+
+	for id, c := range cases {
+		t.Logf("Executing test case '%s'", id)
+		name := strings.Replace(id, " ", "_", -1)
+		b := info.NewBuilder().(*builder)
+
+		b.SetAdapterConfig(&config.Params{
+			Quotas: []config.Params_Quota{
+				{
+					Name:               name,
+					MaxAmount:          5,
+					ValidDuration:      time.Second * time.Duration(10),
+					BucketDuration:     time.Second,
+					RateLimitAlgorithm: c.algorithm,
+					Levels:             levels,
+				},
+			},
+			RedisServerUrl:     mockRedis.Addr(),
+			ConnectionPoolSize: 10,
+		})
+
+		adapterHandler, err := b.Build(context.Background(), test.NewEnv(t))
+		if err != nil {
+			t.Fatalf("Got error %v, expecting success", err)
+		}
+
+		quotaHandler := adapterHandler.(*handler)
+
+		now := time.Now()
+
+		for _, req := range c.request {
+			quotaHandler.getTime = func() time.Time {
+				return now.Add(time.Duration(req.timestamp) * time.Second)
+			}
+
+			instance := quota.Instance{
+				Name: name,
+				Dimensions: map[string]interface{}{
+					"user":   req.user,
+					"tenant": req.tenant,
+				},
+			}
+
+			qr, err := quotaHandler.HandleQuota(context.Background(), &instance, adapter.QuotaArgs{
+				QuotaAmount:     req.token,
+				BestEffort:      req.bestEffort,
+				DeduplicationID: req.deduplicationid,
+			})
+			if err != nil {
+				t.Errorf("%v: Unexpected error %v", id, err)
+			}
+
+			if qr.ValidDuration != time.Duration(req.validDuration)*time.Second {
+				t.Errorf("%v: Expecting valid duration %d, got %d at %v", id, req.validDuration, qr.ValidDuration, req.timestamp)
+			}
+
+			if qr.Amount != req.allocated {
+				t.Errorf("%v: Expecting token %d, got %d at %v", id, req.allocated, qr.Amount, req.timestamp)
+			}
+		}
+	}
+}
+
 func TestHandleQuotaErrorMsg(t *testing.T) {
 	cases := map[string]struct {
 		quotaType   map[string]*quota.Type
@@ -0,0 +1,94 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisquota
+
+const (
+	// LUA script for sliding log algorithm
+	luaSlidingLog = `
+local key_meta = KEYS[1]
+local key_data = KEYS[2]
+
+local credit = tonumber(ARGV[1])
+local windowLength = tonumber(ARGV[2])
+local bucketLength = tonumber(ARGV[3])
+local bestEffort = tonumber(ARGV[4])
+local token = tonumber(ARGV[5])
+local timestamp = tonumber(ARGV[6])
+local deduplicationid = ARGV[7]
+
+-- lookup previous response for the deduplicationid and returns if it is still valid
+--------------------------------------------------------------------------------
+if (deduplicationid or '') ~= '' then
+	local previous_token = tonumber(redis.call("HGET", deduplicationid .. "-" .. key_meta, "token"))
+	local previous_expire = tonumber(redis.call("HGET", deduplicationid .. "-" .. key_meta, "expire"))
+
+	if previous_token and previous_expire then
+		if timestamp < previous_expire then
+			return {previous_token, previous_expire - timestamp}
+		end
+	end
+end
+
+-- remove the allocations that are out of the window
+--------------------------------------------------------------------------------
+redis.call("ZREMRANGEBYSCORE", key_data, 0, timestamp - windowLength)
+
+-- sum the allocations in the window
+--------------------------------------------------------------------------------
+local used = 0
+local allocations = redis.call("ZRANGE", key_data, 0, -1)
+
+for idx, value in ipairs(allocations) do
+  used = used + tonumber(string.sub(value, string.find(value, "%.")+1))
+end
+
+-- check available token and requested token
+--------------------------------------------------------------------------------
+local available_token = credit - used
+local allocated = token
+
+if available_token < token then
+  if bestEffort == 1 and available_token > 0 then
+    -- allocate available token only
+    allocated = available_token
+  else
+    allocated = 0
+  end
+end
+
+if allocated <= 0 then
+  -- not enough token
+  return {0, 0}
+end
+
+-- record the allocation, the key keeps the members of the log unique
+--------------------------------------------------------------------------------
+local nextKey = redis.call("HINCRBY", key_meta, "key", 1)
+redis.call("ZADD", key_data, timestamp, tostring(nextKey) .. "." .. tostring(allocated))
+
+-- update the expiration time for automatic cleanup
+--------------------------------------------------------------------------------
+redis.call("PEXPIRE", key_meta, math.ceil(windowLength / 1000000))
+redis.call("PEXPIRE", key_data, math.ceil(windowLength / 1000000))
+
+-- save current request and set expiration time for auto cleanup
+if (deduplicationid or '') ~= '' then
+	redis.call("HMSET", deduplicationid .. "-" .. key_meta, "token", allocated, "expire", timestamp + windowLength)
+	redis.call("PEXPIRE", deduplicationid .. "-" .. key_meta, math.ceil(windowLength / 1000000))
+end
+
+return {allocated, windowLength}
+`
+)
@@ -55,7 +55,12 @@ func serverCmd(info map[string]template.Info, adapters []adapter.InfoFn, printf,
 	serverCmd.PersistentFlags().BoolVarP(&sa.SingleThreaded, "singleThreaded", "", sa.SingleThreaded,
 		"If true, each request to Mixer will be executed in a single go routine (useful for debugging)")
 	serverCmd.PersistentFlags().Int32VarP(&sa.NumCheckCacheEntries, "numCheckCacheEntries", "", sa.NumCheckCacheEntries,
-		"Max number of entries in the check result cache")
+		"Max number of entries in the check result cache. The cache is disabled if 0, "+
+			"see https://github.com/istio/istio/issues/9596.")
+	serverCmd.PersistentFlags().StringVarP(&sa.CheckCacheInvalidationRedisAddress, "checkCacheInvalidationRedisAddress", "",
+		sa.CheckCacheInvalidationRedisAddress,
+		"Address of the Redis server used to propagate check cache invalidations to the peer Mixer replicas. "+
+			"If empty, invalidations only apply to this replica.")
 
 	serverCmd.PersistentFlags().StringVarP(&sa.ConfigStoreURL, "configStoreURL", "", sa.ConfigStoreURL,
 		"URL of the config store. Use k8s://path_to_kubeconfig, fs:// for file system, or mcps://<address> for MCP/Galley. "+
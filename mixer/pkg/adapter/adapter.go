@@ -56,6 +56,14 @@ type (
 		// use this method or ScheduleWork instead.
 		ScheduleDaemon(fn DaemonFunc)
 
+		// InvalidateCheckCache drops the cached check results tagged with any of the given
+		// invalidation keys, on this Mixer replica and on its peers.
+		//
+		// Adapters tag their check results through CheckResult.InvalidationKeys, and
+		// should call this method whenever the data backing these results changes,
+		// e.g. when the content of a list is refreshed.
+		InvalidateCheckCache(keys ...string)
+
 		// Possible other features for Env:
 		// Return how much time remains until Mixer considers the adapter call having timed out and kills it
 		// Return true/false to indicate this is a 'recovery mode' execution following a prior crash of the adapter
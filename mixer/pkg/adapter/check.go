@@ -34,11 +34,13 @@ type CheckResult struct {
 	ValidUseCount int32
 	// RouteDirective represents the route directive return result
 	RouteDirective *mixerpb.RouteDirective
+	// InvalidationKeys tag the cached result, so that it can be dropped through Env.InvalidateCheckCache
+	InvalidationKeys []string
 }
 
 // IsDefault returns true if the CheckResult is in its zero state
 func (r *CheckResult) IsDefault() bool {
-	return status.IsOK(r.Status) && r.ValidDuration == 0 && r.ValidUseCount == 0 && r.RouteDirective == nil
+	return status.IsOK(r.Status) && r.ValidDuration == 0 && r.ValidUseCount == 0 && r.RouteDirective == nil && len(r.InvalidationKeys) == 0
 }
 
 func (r *CheckResult) String() string {
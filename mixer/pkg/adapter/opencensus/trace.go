@@ -157,7 +157,7 @@ func buildSpanData(val *tracespan.Instance, parentContext trace.SpanContext, spa
 		// ID and rewrite span id to that one. This id should also be used as server span's parent span
 		// id.
 		if val.RewriteClientSpanId {
-			spanID = rewriteSpanID(spanID)
+			spanID = RewriteSpanID(spanID)
 		}
 	} else {
 		spanKind = trace.SpanKindServer
@@ -166,7 +166,7 @@ func buildSpanData(val *tracespan.Instance, parentContext trace.SpanContext, spa
 		// ID and rewrite parent id to that one, which makes this span attached to the client span as a
 		// child span.
 		if val.RewriteClientSpanId {
-			parentSpanID = rewriteSpanID(spanID)
+			parentSpanID = RewriteSpanID(spanID)
 		}
 	}
 
@@ -190,17 +190,6 @@ func buildSpanData(val *tracespan.Instance, parentContext trace.SpanContext, spa
 	return span
 }
 
-var pad = [8]byte{0x3f, 0x6a, 0x2e, 0xc3, 0xc8, 0x10, 0xc2, 0xab}
-
-// rewriteSpanID deterministically creates a new span id base on the given span id by XOR with a pad.
-func rewriteSpanID(spanID trace.SpanID) trace.SpanID {
-	var newID trace.SpanID
-	for i, b := range spanID {
-		newID[i] = b ^ pad[i]
-	}
-	return newID
-}
-
 func extractMessageEvents(ts *tracespan.Instance) []trace.MessageEvent {
 	var requestMessageType, responseMessageType trace.MessageEventType
 	if ts.ClientSpan {
@@ -49,3 +49,15 @@ func ExtractSpanContext(spanID string, parent trace.SpanContext) (trace.SpanCont
 	}
 	return spanContext, true
 }
+
+var pad = [8]byte{0x3f, 0x6a, 0x2e, 0xc3, 0xc8, 0x10, 0xc2, 0xab}
+
+// RewriteSpanID deterministically creates a new span id base on the given span id by XOR with a pad.
+// It is used to split the client and server sides of a span that share the same span id.
+func RewriteSpanID(spanID trace.SpanID) trace.SpanID {
+	var newID trace.SpanID
+	for i, b := range spanID {
+		newID[i] = b ^ pad[i]
+	}
+	return newID
+}
@@ -25,13 +25,15 @@ import (
 // Env is an adapter environment that defers to the testing context t. Tracks all messages logged so they can be tested against.
 type Env struct {
 	done chan struct{} // A channel to notify async work done
-	lock sync.Mutex    // guards logs
+	lock sync.Mutex    // guards logs and invalidations
 	logs []string
+
+	invalidations []string
 }
 
 // NewEnv returns an adapter environment that redirects logging output to the given testing context.
 func NewEnv(_ *testing.T) *Env {
-	return &Env{make(chan struct{}), sync.Mutex{}, make([]string, 0)}
+	return &Env{done: make(chan struct{}), logs: make([]string, 0)}
 }
 
 // Logger returns a logger that writes to testing.T.Log
@@ -55,6 +57,22 @@ func (e *Env) ScheduleDaemon(fn adapter.DaemonFunc) {
 	}()
 }
 
+// InvalidateCheckCache records the given invalidation keys.
+func (e *Env) InvalidateCheckCache(keys ...string) {
+	e.lock.Lock()
+	e.invalidations = append(e.invalidations, keys...)
+	e.lock.Unlock()
+}
+
+// GetInvalidations returns a snapshot of all the check cache invalidation keys published by this environment
+func (e *Env) GetInvalidations() []string {
+	e.lock.Lock()
+	snapshot := make([]string, len(e.invalidations))
+	_ = copy(snapshot, e.invalidations)
+	e.lock.Unlock()
+	return snapshot
+}
+
 // Infof logs the provided message.
 func (e *Env) Infof(format string, args ...interface{}) {
 	e.log(format, args...)
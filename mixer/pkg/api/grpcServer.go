@@ -185,6 +185,7 @@ func (s *grpcServer) check(ctx context.Context, req *mixerpb.CheckRequest,
 			ValidUseCount:        resp.Precondition.ValidUseCount,
 			ReferencedAttributes: *resp.Precondition.ReferencedAttributes,
 			RouteDirective:       resp.Precondition.RouteDirective,
+			InvalidationKeys:     cr.InvalidationKeys,
 		})
 	}
 
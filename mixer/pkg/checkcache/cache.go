@@ -17,6 +17,10 @@
 // Entries are added into the cache by supplying an attribute bag along with a ReferencedAttributes struct
 // which determines the set of attributes in the bag should be used as a cache lookup key. Entries are looked up
 // from the cache using an attribute bag.
+//
+// Entries can be dropped ahead of their expiration through invalidation keys. An entry is tagged with the
+// InvalidationKeys of its value, and with an AttributeKey for each string attribute used to form its lookup key.
+// An Invalidator propagates invalidation keys to the caches of the peer Mixer replicas through a Transport.
 package checkcache
 
 // TODO: This code should optimize the storage of Value. It's likely that a great many entries in the cache will
@@ -47,10 +51,22 @@ type Cache struct {
 	keyShapesLock sync.RWMutex
 	globalWords   []string
 
+	// index of the cache keys tagged with each invalidation key
+	index map[string]map[string]struct{}
+	// invalidation keys and expiration of each indexed cache key, at most maxIndexSize of them
+	indexed      map[string]indexedKey
+	maxIndexSize int
+	indexLock    sync.Mutex
+
 	// allowing patch for testing
 	getTime func() time.Time
 }
 
+type indexedKey struct {
+	invalidationKeys []string
+	expiration       time.Time
+}
+
 // Value holds the data that the check cache stores.
 type Value struct {
 	// StatusMessage for the Check operation
@@ -70,6 +86,9 @@ type Value struct {
 
 	// RouteDirective for the completed Check operation
 	RouteDirective *mixerpb.RouteDirective
+
+	// InvalidationKeys tag the entry, so that it can be dropped through Invalidate
+	InvalidationKeys []string
 }
 
 var (
@@ -82,11 +101,14 @@ var (
 		"mixer/checkcache/cache_misses_total", "The number of times a cache lookup operation failed to find an entry in the cache.", stats.UnitDimensionless)
 	evictionsTotal = stats.Int64(
 		"mixer/checkcache/cache_evictions_total", "The number of entries that have been evicted from the cache.", stats.UnitDimensionless)
-
-	writesView    = newView(writesTotal, []tag.Key{}, view.LastValue())
-	hitsView      = newView(hitsTotal, []tag.Key{}, view.LastValue())
-	missesView    = newView(missesTotal, []tag.Key{}, view.LastValue())
-	evictionsView = newView(evictionsTotal, []tag.Key{}, view.LastValue())
+	invalidationsTotal = stats.Int64(
+		"mixer/checkcache/cache_invalidations_total", "The number of entries that have been dropped from the cache by invalidation keys.", stats.UnitDimensionless)
+
+	writesView        = newView(writesTotal, []tag.Key{}, view.LastValue())
+	hitsView          = newView(hitsTotal, []tag.Key{}, view.LastValue())
+	missesView        = newView(missesTotal, []tag.Key{}, view.LastValue())
+	evictionsView     = newView(evictionsTotal, []tag.Key{}, view.LastValue())
+	invalidationsView = newView(invalidationsTotal, []tag.Key{}, view.Sum())
 )
 
 func newView(measure stats.Measure, keys []tag.Key, aggregation *view.Aggregation) *view.View {
@@ -103,19 +125,22 @@ func newView(measure stats.Measure, keys []tag.Key, aggregation *view.Aggregatio
 // cache then its capacity will cause eviction of older entries.
 func New(capacity int32) *Cache {
 	cc := &Cache{
-		cache:       cache.NewLRU(time.Minute*60, 1*time.Minute, capacity),
-		globalWords: attribute.GlobalList(),
-		getTime:     time.Now,
+		cache:        cache.NewLRU(time.Minute*60, 1*time.Minute, capacity),
+		globalWords:  attribute.GlobalList(),
+		index:        make(map[string]map[string]struct{}),
+		indexed:      make(map[string]indexedKey),
+		maxIndexSize: int(capacity),
+		getTime:      time.Now,
 	}
 
-	_ = view.Register(writesView, hitsView, missesView, evictionsView)
+	_ = view.Register(writesView, hitsView, missesView, evictionsView, invalidationsView)
 
 	return cc
 }
 
 // Close releases any resources used by the check cache.
 func (cc *Cache) Close() error {
-	view.Unregister(writesView, hitsView, missesView, evictionsView)
+	view.Unregister(writesView, hitsView, missesView, evictionsView, invalidationsView)
 	return nil
 }
 
@@ -170,8 +195,7 @@ func (cc *Cache) Set(attrs attribute.Bag, value Value) {
 	// find a matching key shape
 	for _, shape := range shapes {
 		if shape.isCompatible(attrs) {
-			cc.cache.SetWithExpiration(shape.makeKey(attrs), value, value.Expiration.Sub(now))
-			cc.recordStats()
+			cc.set(shape, attrs, value, now)
 			return
 		}
 	}
@@ -184,10 +208,109 @@ func (cc *Cache) Set(attrs attribute.Bag, value Value) {
 	cc.keyShapes = append(cc.keyShapes, shape)
 	cc.keyShapesLock.Unlock()
 
-	cc.cache.SetWithExpiration(shape.makeKey(attrs), value, value.Expiration.Sub(now))
+	cc.set(shape, attrs, value, now)
+}
+
+func (cc *Cache) set(shape keyShape, attrs attribute.Bag, value Value, now time.Time) {
+	key := shape.makeKey(attrs)
+	cc.cache.SetWithExpiration(key, value, value.Expiration.Sub(now))
+	invalidationKeys := append(shape.attributeKeys(attrs), value.InvalidationKeys...)
+	cc.indexKey(key, invalidationKeys, value.Expiration, now)
 	cc.recordStats()
 }
 
+// indexKey records that the cache key is tagged with the given invalidation keys.
+func (cc *Cache) indexKey(key string, invalidationKeys []string, expiration time.Time, now time.Time) {
+	cc.indexLock.Lock()
+	defer cc.indexLock.Unlock()
+
+	// a key that is set again may be tagged differently
+	cc.unindex(key)
+	if len(invalidationKeys) == 0 {
+		return
+	}
+
+	if len(cc.indexed) >= cc.maxIndexSize {
+		cc.pruneIndex(now)
+	}
+
+	cc.indexed[key] = indexedKey{invalidationKeys: invalidationKeys, expiration: expiration}
+	for _, ik := range invalidationKeys {
+		keys, ok := cc.index[ik]
+		if !ok {
+			keys = make(map[string]struct{})
+			cc.index[ik] = keys
+		}
+		keys[key] = struct{}{}
+	}
+}
+
+// pruneIndex makes room in the index. The index lock must be held.
+//
+// The cache holds at most maxIndexSize entries, but the underlying ExpiringCache doesn't report evictions. Once the
+// expired keys are removed, a full index thus still holds keys that have been evicted. As they can't be told apart
+// from the live ones, a tenth of the keys, and at least one, are dropped from both the index and the cache, so
+// that no cached entry escapes invalidation.
+func (cc *Cache) pruneIndex(now time.Time) {
+	for key, ik := range cc.indexed {
+		if ik.expiration.Before(now) {
+			cc.unindex(key)
+		}
+	}
+
+	target := cc.maxIndexSize - cc.maxIndexSize/10 - 1
+	for key := range cc.indexed {
+		if len(cc.indexed) <= target {
+			break
+		}
+		cc.unindex(key)
+		cc.cache.Remove(key)
+	}
+}
+
+// unindex removes the cache key from the index. The index lock must be held.
+func (cc *Cache) unindex(key string) {
+	indexed, ok := cc.indexed[key]
+	if !ok {
+		return
+	}
+
+	delete(cc.indexed, key)
+	for _, ik := range indexed.invalidationKeys {
+		if keys, ok := cc.index[ik]; ok {
+			delete(keys, key)
+			if len(keys) == 0 {
+				delete(cc.index, ik)
+			}
+		}
+	}
+}
+
+// Invalidate drops all the entries tagged with any of the given invalidation keys, and returns the number of
+// dropped entries.
+func (cc *Cache) Invalidate(invalidationKeys ...string) int {
+	dropped := 0
+
+	cc.indexLock.Lock()
+	for _, ik := range invalidationKeys {
+		for key := range cc.index[ik] {
+			cc.unindex(key)
+			cc.cache.Remove(key)
+			dropped++
+		}
+	}
+	cc.indexLock.Unlock()
+
+	stats.Record(context.Background(), invalidationsTotal.M(int64(dropped)))
+	return dropped
+}
+
+// AttributeKey returns the invalidation key of the entries which have been looked up with the given value of a
+// string attribute. Entries of string map attributes are named as "<attribute>[<map key>]".
+func AttributeKey(name string, value string) string {
+	return "attribute:" + name + "=" + value
+}
+
 func (cc *Cache) recordStats() {
 	s := cc.cache.Stats()
 	stats.Record(context.Background(),
@@ -225,6 +225,153 @@ func TestCache(t *testing.T) {
 	}
 }
 
+func TestInvalidate(t *testing.T) {
+	cache := New(10)
+	defer func() { _ = cache.Close() }()
+
+	ra := mixerpb.ReferencedAttributes{
+		Words: []string{"user", "headers", "x-tenant"},
+		AttributeMatches: []mixerpb.ReferencedAttributes_AttributeMatch{
+			{Name: -1, Condition: mixerpb.EXACT},
+			{Name: -2, MapKey: -3, Condition: mixerpb.EXACT},
+		},
+	}
+
+	bag := func(user, tenant string) attribute.Bag {
+		return attribute.GetMutableBagForTesting(map[string]interface{}{
+			"user":    user,
+			"headers": attribute.WrapStringMap(map[string]string{"x-tenant": tenant}),
+		})
+	}
+
+	set := func() {
+		for _, user := range []string{"alice", "bob"} {
+			cache.Set(bag(user, "acme"), Value{
+				Expiration:           time.Now().Add(time.Hour),
+				ReferencedAttributes: ra,
+				InvalidationKeys:     []string{"listchecker", "listchecker/" + user},
+			})
+		}
+	}
+
+	cases := []struct {
+		name    string
+		keys    []string
+		dropped int
+		alice   bool
+		bob     bool
+	}{
+		{"unknown key", []string{"denier"}, 0, true, true},
+		{"value key", []string{"listchecker/alice"}, 1, false, true},
+		{"shared value key", []string{"listchecker"}, 2, false, false},
+		{"attribute key", []string{AttributeKey("user", "bob")}, 1, true, false},
+		{"string map attribute key", []string{AttributeKey("headers[x-tenant]", "acme")}, 2, false, false},
+		{"multiple keys", []string{"listchecker/alice", AttributeKey("user", "bob")}, 2, false, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			set()
+
+			if dropped := cache.Invalidate(c.keys...); dropped != c.dropped {
+				t.Errorf("Invalidate() dropped %d entries, expected %d", dropped, c.dropped)
+			}
+
+			if _, ok := cache.Get(bag("alice", "acme")); ok != c.alice {
+				t.Errorf("Got %v for alice, expected %v", ok, c.alice)
+			}
+			if _, ok := cache.Get(bag("bob", "acme")); ok != c.bob {
+				t.Errorf("Got %v for bob, expected %v", ok, c.bob)
+			}
+
+			// invalidation is idempotent
+			if dropped := cache.Invalidate(c.keys...); dropped != 0 {
+				t.Errorf("Second Invalidate() dropped %d entries, expected 0", dropped)
+			}
+		})
+	}
+}
+
+func TestInvalidationIndexPruning(t *testing.T) {
+	cache := New(4)
+	defer func() { _ = cache.Close() }()
+
+	ra := mixerpb.ReferencedAttributes{
+		Words: []string{"a"},
+		AttributeMatches: []mixerpb.ReferencedAttributes_AttributeMatch{
+			{Name: -1, Condition: mixerpb.EXACT},
+		},
+	}
+
+	now := time.Now()
+	cache.getTime = func() time.Time { return now }
+
+	for i := 0; i < 4; i++ {
+		cache.Set(attribute.GetMutableBagForTesting(map[string]interface{}{"a": int64(i)}), Value{
+			Expiration:           now.Add(time.Second),
+			ReferencedAttributes: ra,
+			InvalidationKeys:     []string{"key" + strconv.Itoa(i)},
+		})
+	}
+
+	if len(cache.indexed) != 4 {
+		t.Fatalf("Got index size %d, expected 4", len(cache.indexed))
+	}
+
+	// once the entries have expired, the next write prunes them from the index
+	now = now.Add(time.Minute)
+	cache.Set(attribute.GetMutableBagForTesting(map[string]interface{}{"a": int64(4)}), Value{
+		Expiration:           now.Add(time.Second),
+		ReferencedAttributes: ra,
+		InvalidationKeys:     []string{"key4"},
+	})
+
+	if len(cache.indexed) != 1 || len(cache.index) != 1 {
+		t.Errorf("Got index size %d with %d keys, expected 1 with 1 key", len(cache.indexed), len(cache.index))
+	}
+}
+
+func TestInvalidationIndexBound(t *testing.T) {
+	cache := New(4)
+	defer func() { _ = cache.Close() }()
+
+	ra := mixerpb.ReferencedAttributes{
+		Words: []string{"a"},
+		AttributeMatches: []mixerpb.ReferencedAttributes_AttributeMatch{
+			{Name: -1, Condition: mixerpb.EXACT},
+		},
+	}
+	bag := func(i int) attribute.Bag {
+		return attribute.GetMutableBagForTesting(map[string]interface{}{"a": int64(i)})
+	}
+
+	// the entries don't expire, the LRU evicts the oldest ones without telling the index
+	for i := 0; i < 100; i++ {
+		cache.Set(bag(i), Value{
+			Expiration:           time.Now().Add(time.Hour),
+			ReferencedAttributes: ra,
+			InvalidationKeys:     []string{"key" + strconv.Itoa(i)},
+		})
+
+		if len(cache.indexed) > 4 {
+			t.Fatalf("Got index size %d after %d writes, expected at most 4", len(cache.indexed), i+1)
+		}
+	}
+
+	// every entry still in the cache can be invalidated
+	for i := 0; i < 100; i++ {
+		if _, ok := cache.Get(bag(i)); !ok {
+			continue
+		}
+		if dropped := cache.Invalidate("key" + strconv.Itoa(i)); dropped != 1 {
+			t.Errorf("Invalidate() dropped %d entries for cached entry %d, expected 1", dropped, i)
+		}
+		if _, ok := cache.Get(bag(i)); ok {
+			t.Errorf("Got cached entry %d after invalidation", i)
+		}
+	}
+}
+
 const (
 	benchmarkCacheCapacity = 4096
 	benchmarkIterations    = 16000
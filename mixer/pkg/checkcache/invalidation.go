@@ -0,0 +1,125 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkcache
+
+import (
+	"io"
+	"sync"
+
+	"istio.io/pkg/log"
+)
+
+// Transport propagates invalidation keys between the Mixer replicas.
+type Transport interface {
+	io.Closer
+
+	// Publish sends the invalidation keys to the subscribers of all the replicas.
+	Publish(keys []string) error
+
+	// Subscribe registers a function to be invoked with the invalidation keys published by any replica.
+	Subscribe(fn func(keys []string)) error
+}
+
+// Invalidator drops the entries of a check cache tagged with invalidation keys, and propagates the keys
+// to the peer replicas so that their caches drop the affected entries as well.
+type Invalidator struct {
+	cache     *Cache
+	transport Transport
+}
+
+// NewInvalidator returns an Invalidator for the given cache, which may be nil if this replica doesn't cache
+// check results, and subscribes it to the invalidation keys published through the transport.
+func NewInvalidator(cache *Cache, transport Transport) (*Invalidator, error) {
+	inv := &Invalidator{
+		cache:     cache,
+		transport: transport,
+	}
+
+	if err := transport.Subscribe(inv.invalidateLocal); err != nil {
+		return nil, err
+	}
+
+	return inv, nil
+}
+
+// Invalidate drops the entries tagged with any of the given keys from the local cache, and publishes
+// the keys to the peer replicas. It is a no-op on a nil Invalidator.
+func (inv *Invalidator) Invalidate(keys ...string) {
+	if inv == nil || len(keys) == 0 {
+		return
+	}
+
+	inv.invalidateLocal(keys)
+
+	if err := inv.transport.Publish(keys); err != nil {
+		log.Warnf("Unable to publish check cache invalidation keys %v: %v", keys, err)
+	}
+}
+
+// invalidateLocal drops the entries tagged with any of the given keys from the local cache. Since invalidation is
+// idempotent, the keys published by this replica are simply applied again when they are received back.
+func (inv *Invalidator) invalidateLocal(keys []string) {
+	if inv.cache == nil {
+		return
+	}
+
+	if dropped := inv.cache.Invalidate(keys...); dropped > 0 {
+		log.Debugf("Dropped %d check cache entries for invalidation keys %v", dropped, keys)
+	}
+}
+
+// Close releases the transport of the invalidator.
+func (inv *Invalidator) Close() error {
+	if inv == nil {
+		return nil
+	}
+	return inv.transport.Close()
+}
+
+type inProcessTransport struct {
+	lock        sync.RWMutex
+	subscribers []func(keys []string)
+}
+
+// NewInProcessTransport returns a Transport which delivers the invalidation keys to the subscribers within the
+// current process. This is the default transport of a Mixer replica without peers.
+func NewInProcessTransport() Transport {
+	return &inProcessTransport{}
+}
+
+func (t *inProcessTransport) Publish(keys []string) error {
+	t.lock.RLock()
+	subscribers := t.subscribers
+	t.lock.RUnlock()
+
+	for _, fn := range subscribers {
+		fn(keys)
+	}
+	return nil
+}
+
+func (t *inProcessTransport) Subscribe(fn func(keys []string)) error {
+	t.lock.Lock()
+	t.subscribers = append(t.subscribers, fn)
+	t.lock.Unlock()
+	return nil
+}
+
+func (t *inProcessTransport) Close() error {
+	t.lock.Lock()
+	t.subscribers = nil
+	t.lock.Unlock()
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkcache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	mixerpb "istio.io/api/mixer/v1"
+	"istio.io/pkg/attribute"
+)
+
+func TestInvalidator(t *testing.T) {
+	ra := mixerpb.ReferencedAttributes{
+		Words: []string{"a"},
+		AttributeMatches: []mixerpb.ReferencedAttributes_AttributeMatch{
+			{Name: -1, Condition: mixerpb.EXACT},
+		},
+	}
+	bag := attribute.GetMutableBagForTesting(map[string]interface{}{"a": "x"})
+
+	// three replicas sharing a transport, the last one without a cache
+	transport := NewInProcessTransport()
+	var caches []*Cache
+	var invalidators []*Invalidator
+	for i := 0; i < 3; i++ {
+		var c *Cache
+		if i < 2 {
+			c = New(10)
+			defer func() { _ = c.Close() }()
+			c.Set(bag, Value{
+				Expiration:           time.Now().Add(time.Hour),
+				ReferencedAttributes: ra,
+				InvalidationKeys:     []string{"list"},
+			})
+		}
+
+		inv, err := NewInvalidator(c, transport)
+		if err != nil {
+			t.Fatalf("NewInvalidator() failed: %v", err)
+		}
+		caches = append(caches, c)
+		invalidators = append(invalidators, inv)
+	}
+
+	// the replica without a cache still propagates the keys to its peers
+	invalidators[2].Invalidate("list")
+
+	for i, c := range caches[:2] {
+		if _, ok := c.Get(bag); ok {
+			t.Errorf("Expecting the entry of replica %d to be invalidated", i)
+		}
+	}
+
+	if err := invalidators[0].Close(); err != nil {
+		t.Errorf("Close() failed: %v", err)
+	}
+}
+
+func TestNilInvalidator(t *testing.T) {
+	var inv *Invalidator
+	inv.Invalidate("list")
+	if err := inv.Close(); err != nil {
+		t.Errorf("Close() failed: %v", err)
+	}
+}
+
+type failingTransport struct {
+	subscribeErr error
+	published    [][]string
+}
+
+func (t *failingTransport) Publish(keys []string) error {
+	t.published = append(t.published, keys)
+	return errors.New("publish failed")
+}
+
+func (t *failingTransport) Subscribe(func([]string)) error { return t.subscribeErr }
+func (t *failingTransport) Close() error                   { return nil }
+
+func TestInvalidatorTransportErrors(t *testing.T) {
+	if _, err := NewInvalidator(nil, &failingTransport{subscribeErr: errors.New("subscribe failed")}); err == nil {
+		t.Error("Expecting NewInvalidator() to fail")
+	}
+
+	transport := &failingTransport{}
+	inv, err := NewInvalidator(nil, transport)
+	if err != nil {
+		t.Fatalf("NewInvalidator() failed: %v", err)
+	}
+
+	// publishing errors are only logged
+	inv.Invalidate("a", "b")
+	inv.Invalidate()
+
+	if len(transport.published) != 1 || len(transport.published[0]) != 2 {
+		t.Errorf("Got published keys %v, expected [[a b]]", transport.published)
+	}
+}
@@ -253,3 +253,23 @@ func getString(index int32, globalWords []string, localWords []string) string {
 
 	return localWords[index]
 }
+
+// attributeKeys returns the invalidation keys for the string attributes used to form the cache key of the given
+// attribute bag. This function assumes that the bag has previously been deemed compatible via isCompatible.
+func (ks keyShape) attributeKeys(attrs attribute.Bag) []string {
+	var keys []string
+	for _, ar := range ks.presentAttrs {
+		v, _ := attrs.Get(ar.Name)
+
+		switch v := v.(type) {
+		case string:
+			keys = append(keys, AttributeKey(ar.Name, v))
+
+		case attribute.StringMap:
+			v2, _ := v.Get(ar.MapKey)
+			keys = append(keys, AttributeKey(ar.Name+"["+ar.MapKey+"]", v2))
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkcache
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-redis/redis"
+
+	"istio.io/pkg/log"
+)
+
+// DefaultRedisChannel is the Redis channel on which the invalidation keys are published by default.
+const DefaultRedisChannel = "istio-mixer-checkcache-invalidation"
+
+type redisTransport struct {
+	client  *redis.Client
+	channel string
+	pubsub  *redis.PubSub
+}
+
+// NewRedisTransport returns a Transport which propagates the invalidation keys between the Mixer replicas through
+// the given channel of a Redis server, using Redis pub/sub.
+func NewRedisTransport(address string, channel string) (Transport, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr: address,
+	})
+
+	if _, err := client.Ping().Result(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("could not create a connection to redis server: %v", err)
+	}
+
+	return &redisTransport{
+		client:  client,
+		channel: channel,
+	}, nil
+}
+
+func (t *redisTransport) Publish(keys []string) error {
+	msg, err := encodeKeys(keys)
+	if err != nil {
+		return err
+	}
+	return t.client.Publish(t.channel, msg).Err()
+}
+
+func (t *redisTransport) Subscribe(fn func(keys []string)) error {
+	if t.pubsub != nil {
+		return fmt.Errorf("already subscribed to redis channel %s", t.channel)
+	}
+
+	pubsub := t.client.Subscribe(t.channel)
+
+	// wait for the confirmation, so that no key published after this call is missed
+	if _, err := pubsub.Receive(); err != nil {
+		_ = pubsub.Close()
+		return fmt.Errorf("could not subscribe to redis channel %s: %v", t.channel, err)
+	}
+	t.pubsub = pubsub
+
+	ch := pubsub.Channel()
+	go func() {
+		for msg := range ch {
+			keys, err := decodeKeys(msg.Payload)
+			if err != nil {
+				log.Warnf("Ignoring malformed check cache invalidation message %q: %v", msg.Payload, err)
+				continue
+			}
+			fn(keys)
+		}
+	}()
+
+	return nil
+}
+
+func (t *redisTransport) Close() error {
+	if t.pubsub != nil {
+		_ = t.pubsub.Close()
+	}
+	return t.client.Close()
+}
+
+func encodeKeys(keys []string) (string, error) {
+	b, err := json.Marshal(keys)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func decodeKeys(payload string) ([]string, error) {
+	var keys []string
+	if err := json.Unmarshal([]byte(payload), &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
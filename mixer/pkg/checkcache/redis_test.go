@@ -0,0 +1,238 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkcache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEncodeKeys(t *testing.T) {
+	keys := []string{"listchecker", AttributeKey("source.ip", "10.0.0.1"), "with \"quotes\"\n"}
+
+	payload, err := encodeKeys(keys)
+	if err != nil {
+		t.Fatalf("encodeKeys() failed: %v", err)
+	}
+
+	got, err := decodeKeys(payload)
+	if err != nil {
+		t.Fatalf("decodeKeys() failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, keys) {
+		t.Errorf("Got %v, expected %v", got, keys)
+	}
+
+	if _, err := decodeKeys("not json"); err == nil {
+		t.Error("Expecting decodeKeys() to fail")
+	}
+}
+
+func TestNewRedisTransport_Unreachable(t *testing.T) {
+	if _, err := NewRedisTransport("127.0.0.1:1", DefaultRedisChannel); err == nil {
+		t.Error("Expecting NewRedisTransport() to fail")
+	}
+}
+
+func TestRedisTransport_RoundTrip(t *testing.T) {
+	server := newPubSubServer(t)
+	defer server.close()
+
+	publisher, err := NewRedisTransport(server.address(), DefaultRedisChannel)
+	if err != nil {
+		t.Fatalf("NewRedisTransport() failed: %v", err)
+	}
+	defer func() { _ = publisher.Close() }()
+
+	subscriber, err := NewRedisTransport(server.address(), DefaultRedisChannel)
+	if err != nil {
+		t.Fatalf("NewRedisTransport() failed: %v", err)
+	}
+	defer func() { _ = subscriber.Close() }()
+
+	received := make(chan []string, 1)
+	if err := subscriber.Subscribe(func(keys []string) { received <- keys }); err != nil {
+		t.Fatalf("Subscribe() failed: %v", err)
+	}
+	if err := subscriber.Subscribe(func([]string) {}); err == nil {
+		t.Error("Expecting a second Subscribe() to fail")
+	}
+
+	keys := []string{"listchecker", AttributeKey("source.ip", "10.0.0.1")}
+	if err := publisher.Publish(keys); err != nil {
+		t.Fatalf("Publish() failed: %v", err)
+	}
+
+	select {
+	case got := <-received:
+		if !reflect.DeepEqual(got, keys) {
+			t.Errorf("Got %v, expected %v", got, keys)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the published keys")
+	}
+}
+
+// pubSubServer is a minimal Redis server supporting the PING, PUBLISH and SUBSCRIBE commands, since miniredis
+// doesn't support pub/sub.
+type pubSubServer struct {
+	t        *testing.T
+	listener net.Listener
+
+	mu          sync.Mutex
+	subscribers map[string][]net.Conn
+	conns       []net.Conn
+}
+
+func newPubSubServer(t *testing.T) *pubSubServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %v", err)
+	}
+	s := &pubSubServer{t: t, listener: l, subscribers: make(map[string][]net.Conn)}
+	go s.serve()
+	return s
+}
+
+func (s *pubSubServer) address() string {
+	return s.listener.Addr().String()
+}
+
+func (s *pubSubServer) close() {
+	_ = s.listener.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.conns {
+		_ = c.Close()
+	}
+}
+
+func (s *pubSubServer) serve() {
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns = append(s.conns, c)
+		s.mu.Unlock()
+		go s.handle(c)
+	}
+}
+
+func (s *pubSubServer) handle(c net.Conn) {
+	r := bufio.NewReader(c)
+	subscribed := false
+	for {
+		cmd, err := readCommand(r)
+		if err != nil {
+			if err != io.EOF && !strings.Contains(err.Error(), "use of closed network connection") {
+				s.t.Logf("Unable to read command: %v", err)
+			}
+			return
+		}
+
+		var reply string
+		switch strings.ToUpper(cmd[0]) {
+		case "PING":
+			if subscribed {
+				reply = bulkArray("pong", "")
+			} else {
+				reply = "+PONG\r\n"
+			}
+		case "SUBSCRIBE":
+			s.mu.Lock()
+			for i, channel := range cmd[1:] {
+				s.subscribers[channel] = append(s.subscribers[channel], c)
+				reply += "*3\r\n" + bulk("subscribe") + bulk(channel) + ":" + strconv.Itoa(i+1) + "\r\n"
+			}
+			s.mu.Unlock()
+			subscribed = true
+		case "PUBLISH":
+			s.mu.Lock()
+			subscribers := s.subscribers[cmd[1]]
+			for _, sub := range subscribers {
+				_, _ = io.WriteString(sub, bulkArray("message", cmd[1], cmd[2]))
+			}
+			s.mu.Unlock()
+			reply = ":" + strconv.Itoa(len(subscribers)) + "\r\n"
+		default:
+			reply = "-ERR unknown command '" + cmd[0] + "'\r\n"
+		}
+
+		s.mu.Lock()
+		_, err = io.WriteString(c, reply)
+		s.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// readCommand reads a command sent by a client as an array of bulk strings.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	n, err := readLength(r, '*')
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+	cmd := make([]string, n)
+	for i := range cmd {
+		l, err := readLength(r, '$')
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, l+2)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+		cmd[i] = string(b[:l])
+	}
+	return cmd, nil
+}
+
+func readLength(r *bufio.Reader, prefix byte) (int, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+	if len(line) < 3 || line[0] != prefix {
+		return 0, fmt.Errorf("unexpected line %q", line)
+	}
+	return strconv.Atoi(strings.TrimSuffix(line[1:], "\r\n"))
+}
+
+func bulk(s string) string {
+	return "$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n"
+}
+
+func bulkArray(values ...string) string {
+	out := "*" + strconv.Itoa(len(values)) + "\r\n"
+	for _, v := range values {
+		out += bulk(v)
+	}
+	return out
+}
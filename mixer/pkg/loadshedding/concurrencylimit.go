@@ -0,0 +1,201 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadshedding
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/stats"
+)
+
+const (
+	// ConcurrencyLimitEvaluatorName is the name of the adaptive Concurrency Limit LoadEvaluator.
+	ConcurrencyLimitEvaluatorName = "concurrencyLimit"
+	// DefaultMinConcurrencyLimit is the lower bound of the learned concurrency limit.
+	DefaultMinConcurrencyLimit = 20
+	// DefaultMaxConcurrencyLimit is the upper bound of the learned concurrency limit.
+	DefaultMaxConcurrencyLimit = 5000
+	// DefaultConcurrencyLatencyTolerance is the ratio of the recent response latency to the long-term
+	// response latency above which the concurrency limit is decreased.
+	DefaultConcurrencyLatencyTolerance = 1.5
+
+	// the recent response latency reacts quickly to queueing, whereas the long-term one approximates
+	// the response latency of the server without queueing.
+	shortLatencyHalfLife = 500 * time.Millisecond
+	longLatencyHalfLife  = 30 * time.Second
+
+	// the limit is adjusted at most once per interval, by a fraction of the computed change.
+	limitUpdateInterval = 100 * time.Millisecond
+	limitSmoothing      = 0.2
+)
+
+var (
+	_ stats.Handler = &ConcurrencyLimitEvaluator{}
+	_ LoadEvaluator = &ConcurrencyLimitEvaluator{}
+)
+
+// ConcurrencyLimitEvaluator limits the number of in-flight requests (as reported via the gRPC stats.Handler interface).
+// The limit is learned from the observed response latencies with a gradient algorithm: while the recent response
+// latency stays within tolerance of the long-term one, the limit is increased by its square root, and as the
+// latency grows due to queueing, the limit is decreased in proportion, down to half of its value. Only the requests
+// in excess of the limit are rejected.
+type ConcurrencyLimitEvaluator struct {
+	inflight int64 // atomic
+	limit    int64 // atomic
+
+	minLimit  float64
+	maxLimit  float64
+	tolerance float64
+
+	// guards the fields below
+	lock           sync.Mutex
+	shortLatency   *exponentialMovingAverage
+	longLatency    *exponentialMovingAverage
+	estimatedLimit float64
+	lastUpdate     time.Time
+}
+
+// NewConcurrencyLimitEvaluator creates a new LoadEvaluator that learns a limit of in-flight requests, starting from the
+// given initial limit. Zero values for the bounds and the tolerance select the defaults.
+func NewConcurrencyLimitEvaluator(initialLimit, minLimit, maxLimit int, tolerance float64) *ConcurrencyLimitEvaluator {
+	if minLimit <= 0 {
+		minLimit = DefaultMinConcurrencyLimit
+	}
+
+	if maxLimit <= 0 {
+		maxLimit = DefaultMaxConcurrencyLimit
+	}
+
+	if maxLimit < minLimit {
+		maxLimit = minLimit
+	}
+
+	if tolerance <= 0 {
+		tolerance = DefaultConcurrencyLatencyTolerance
+	}
+
+	e := &ConcurrencyLimitEvaluator{
+		minLimit:  float64(minLimit),
+		maxLimit:  float64(maxLimit),
+		tolerance: tolerance,
+	}
+	e.setLimit(float64(initialLimit))
+
+	return e
+}
+
+// Name implements the LoadEvaluator interface.
+func (c *ConcurrencyLimitEvaluator) Name() string {
+	return ConcurrencyLimitEvaluatorName
+}
+
+// Limit returns the current limit of in-flight requests.
+func (c *ConcurrencyLimitEvaluator) Limit() int64 {
+	return atomic.LoadInt64(&c.limit)
+}
+
+// EvaluateAgainst implements the LoadEvaluator interface. The threshold caps the learned limit.
+func (c *ConcurrencyLimitEvaluator) EvaluateAgainst(ri RequestInfo, threshold float64) LoadEvaluation {
+	limit := c.Limit()
+	if threshold > 0 && threshold < float64(limit) {
+		limit = int64(threshold)
+	}
+
+	// the in-flight requests include the current one
+	inflight := atomic.LoadInt64(&c.inflight)
+	if inflight <= limit {
+		return LoadEvaluation{Status: BelowThreshold}
+	}
+	return LoadEvaluation{
+		Status:  ExceedsThreshold,
+		Message: fmt.Sprintf("Current number of in-flight requests (%d) exceeds the concurrency limit (%d). Please retry request.", inflight, limit),
+	}
+}
+
+// HandleRPC processes the RPC stats.
+func (c *ConcurrencyLimitEvaluator) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	switch st := rs.(type) {
+	case *stats.Begin:
+		atomic.AddInt64(&c.inflight, 1)
+	case *stats.End:
+		inflight := atomic.AddInt64(&c.inflight, -1) + 1
+		if st.Error != nil {
+			// rejected and failed requests don't reflect the latency of the server
+			return
+		}
+		c.addSample(st.EndTime.Sub(st.BeginTime).Seconds(), inflight, st.EndTime)
+	}
+}
+
+// TagRPC can attach some information to the given context.
+func (c *ConcurrencyLimitEvaluator) TagRPC(ctx context.Context, rti *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+// TagConn can attach some information to the given context.
+func (c *ConcurrencyLimitEvaluator) TagConn(ctx context.Context, cti *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn processes the Conn stats.
+func (c *ConcurrencyLimitEvaluator) HandleConn(context.Context, stats.ConnStats) {}
+
+func (c *ConcurrencyLimitEvaluator) addSample(latency float64, inflight int64, now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.shortLatency == nil {
+		c.shortLatency = newExponentialMovingAverage(shortLatencyHalfLife, latency, now)
+		c.longLatency = newExponentialMovingAverage(longLatencyHalfLife, latency, now)
+		c.lastUpdate = now
+		return
+	}
+
+	c.shortLatency.addSample(latency, now)
+	c.longLatency.addSample(latency, now)
+
+	if now.Sub(c.lastUpdate) < limitUpdateInterval {
+		return
+	}
+	c.lastUpdate = now
+
+	short := c.shortLatency.currentValue(now)
+	long := c.longLatency.currentValue(now)
+	if short <= 0 {
+		return
+	}
+
+	gradient := math.Max(0.5, math.Min(1.0, c.tolerance*long/short))
+
+	// don't increase a limit which isn't used, otherwise it would grow unbounded while the load is low
+	if gradient == 1.0 && float64(inflight) < c.estimatedLimit/2 {
+		return
+	}
+
+	// the square root of the limit leaves room for some queueing, which is how the limit can grow
+	newLimit := c.estimatedLimit*gradient + math.Sqrt(c.estimatedLimit)
+	c.setLimit(c.estimatedLimit*(1-limitSmoothing) + newLimit*limitSmoothing)
+}
+
+func (c *ConcurrencyLimitEvaluator) setLimit(limit float64) {
+	c.estimatedLimit = math.Max(c.minLimit, math.Min(c.maxLimit, limit))
+	atomic.StoreInt64(&c.limit, int64(c.estimatedLimit))
+	concurrencyLimit.Record(c.estimatedLimit)
+}
@@ -0,0 +1,134 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadshedding_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/stats"
+
+	"istio.io/istio/mixer/pkg/loadshedding"
+)
+
+// concurrencyLoad simulates a server with the given number of in-flight requests, which complete with the given
+// latency, for the given duration.
+type concurrencyLoad struct {
+	inflight int
+	latency  time.Duration
+	duration time.Duration
+}
+
+func applyLoad(e *loadshedding.ConcurrencyLimitEvaluator, now time.Time, loads ...concurrencyLoad) time.Time {
+	ctx := context.Background()
+	for _, l := range loads {
+		for i := 0; i < l.inflight; i++ {
+			e.HandleRPC(ctx, &stats.Begin{})
+		}
+
+		// every 10ms, one request completes and another one starts
+		for end := now.Add(l.duration); now.Before(end); now = now.Add(10 * time.Millisecond) {
+			e.HandleRPC(ctx, &stats.End{BeginTime: now.Add(-l.latency), EndTime: now})
+			e.HandleRPC(ctx, &stats.Begin{})
+		}
+
+		for i := 0; i < l.inflight; i++ {
+			e.HandleRPC(ctx, &stats.End{BeginTime: now.Add(-l.latency), EndTime: now, Error: errors.New("drained")})
+		}
+	}
+	return now
+}
+
+func TestConcurrencyLimitEvaluator_Limit(t *testing.T) {
+	cases := []struct {
+		name      string
+		loads     []concurrencyLoad
+		wantBelow int64
+		wantAbove int64
+	}{
+		{"idle", nil, 101, 99},
+		{"steady latency at low load", []concurrencyLoad{{10, 10 * time.Millisecond, 10 * time.Second}}, 101, 99},
+		{"steady latency at high load", []concurrencyLoad{{90, 10 * time.Millisecond, 10 * time.Second}}, 1000, 150},
+		{"growing latency", []concurrencyLoad{
+			{90, 10 * time.Millisecond, 10 * time.Second},
+			{90, 100 * time.Millisecond, 5 * time.Second},
+		}, 100, 19},
+		{"recovery", []concurrencyLoad{
+			{90, 10 * time.Millisecond, 10 * time.Second},
+			{90, 100 * time.Millisecond, 5 * time.Second},
+			{90, 10 * time.Millisecond, 10 * time.Second},
+		}, 1000, 150},
+		{"recovery at low load", []concurrencyLoad{
+			{90, 10 * time.Millisecond, 10 * time.Second},
+			{90, 100 * time.Millisecond, 5 * time.Second},
+			{20, 10 * time.Millisecond, 10 * time.Second},
+		}, 50, 30},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			e := loadshedding.NewConcurrencyLimitEvaluator(100, 20, 1000, 0)
+			applyLoad(e, start, c.loads...)
+
+			if got := e.Limit(); got >= c.wantBelow || got <= c.wantAbove {
+				tt.Errorf("Limit() => %d; wanted between %d and %d", got, c.wantAbove, c.wantBelow)
+			}
+		})
+	}
+}
+
+func TestConcurrencyLimitEvaluator_Bounds(t *testing.T) {
+	e := loadshedding.NewConcurrencyLimitEvaluator(1, 0, 0, 0)
+	if got := e.Limit(); got != loadshedding.DefaultMinConcurrencyLimit {
+		t.Errorf("Limit() => %d; wanted %d", got, loadshedding.DefaultMinConcurrencyLimit)
+	}
+
+	e = loadshedding.NewConcurrencyLimitEvaluator(1000000, 0, 0, 0)
+	if got := e.Limit(); got != loadshedding.DefaultMaxConcurrencyLimit {
+		t.Errorf("Limit() => %d; wanted %d", got, loadshedding.DefaultMaxConcurrencyLimit)
+	}
+}
+
+func TestConcurrencyLimitEvaluator_EvaluateAgainst(t *testing.T) {
+	cases := []struct {
+		name         string
+		inflight     int
+		threshold    float64
+		shouldExceed bool
+	}{
+		{"BelowLimit", 20, 0, false},
+		{"BeyondLimit", 21, 0, true},
+		{"BeyondThreshold", 11, 10, true},
+		{"ThresholdAboveLimit", 20, 100, false},
+	}
+
+	for _, v := range cases {
+		t.Run(v.name, func(tt *testing.T) {
+			e := loadshedding.NewConcurrencyLimitEvaluator(20, 10, 100, 0)
+			for i := 0; i < v.inflight; i++ {
+				e.HandleRPC(context.Background(), &stats.Begin{})
+			}
+
+			pc := loadshedding.RequestInfo{PredictedCost: 1.0}
+			le := e.EvaluateAgainst(pc, v.threshold)
+			if got, want := loadshedding.ThresholdExceeded(le), v.shouldExceed; got != want {
+				tt.Logf("Got: %#v", le)
+				tt.Errorf("EvaluateAgainst(%#v, %f) => got exceeded == %t; wanted exceeded == %t", pc, v.threshold, got, want)
+			}
+		})
+	}
+}
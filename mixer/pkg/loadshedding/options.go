@@ -64,6 +64,28 @@ type Options struct {
 	// configured maximum for a period of time. This allows for handling bursty
 	// traffic patterns. If this is set to 0, no traffic will be allowed.
 	BurstSize int
+
+	// Options for the adaptive concurrency limit evaluator
+
+	// InitialConcurrencyLimit is the number of in-flight requests over which
+	// the server will start rejecting requests (Unavailable), until a limit
+	// has been learned from the observed response latencies. Providing a
+	// value for InitialConcurrencyLimit will enable the concurrency limit
+	// evaluator.
+	InitialConcurrencyLimit int
+
+	// MinConcurrencyLimit is the lower bound of the learned concurrency limit.
+	// If this is set to 0, DefaultMinConcurrencyLimit is used.
+	MinConcurrencyLimit int
+
+	// MaxConcurrencyLimit is the upper bound of the learned concurrency limit.
+	// If this is set to 0, DefaultMaxConcurrencyLimit is used.
+	MaxConcurrencyLimit int
+
+	// ConcurrencyLatencyTolerance is the ratio of the recent response latency
+	// to the long-term response latency above which the concurrency limit is
+	// decreased. If this is set to 0, DefaultConcurrencyLatencyTolerance is used.
+	ConcurrencyLatencyTolerance float64
 }
 
 // DefaultOptions returns a new set of options, initialized to the defaults
@@ -105,6 +127,19 @@ func (o *Options) AttachCobraFlags(cmd *cobra.Command) {
 
 	cmd.PersistentFlags().VarP(newLimitValue(DefaultEnforcementThreshold, &o.LatencyEnforcementThreshold), "latencyEnforcementThreshold", "",
 		"Controls the threshold, in requests per second, above which the average latency threshold will be enforced for load-shedding")
+
+	cmd.PersistentFlags().IntVarP(&o.InitialConcurrencyLimit, "initialConcurrencyLimit", "", 0,
+		"Initial number of in-flight requests supported by the server. When set, the server learns the limit from the observed response latencies, and drops the traffic in excess.")
+
+	cmd.PersistentFlags().IntVarP(&o.MinConcurrencyLimit, "minConcurrencyLimit", "", 0,
+		"Lower bound of the learned concurrency limit. Defaults to "+strconv.Itoa(DefaultMinConcurrencyLimit)+". Only valid when used with 'initialConcurrencyLimit'.")
+
+	cmd.PersistentFlags().IntVarP(&o.MaxConcurrencyLimit, "maxConcurrencyLimit", "", 0,
+		"Upper bound of the learned concurrency limit. Defaults to "+strconv.Itoa(DefaultMaxConcurrencyLimit)+". Only valid when used with 'initialConcurrencyLimit'.")
+
+	cmd.PersistentFlags().Float64VarP(&o.ConcurrencyLatencyTolerance, "concurrencyLatencyTolerance", "", 0,
+		"Ratio of the recent to the long-term average response time above which the concurrency limit is decreased. "+
+			"Defaults to "+strconv.FormatFloat(DefaultConcurrencyLatencyTolerance, 'g', -1, 64)+". Only valid when used with 'initialConcurrencyLimit'.")
 }
 
 type modeValue ThrottlerMode
@@ -69,6 +69,16 @@ func TestOpts(t *testing.T) {
 			SampleHalfLife:              loadshedding.DefaultHalfLife,
 			LatencyEnforcementThreshold: loadshedding.DefaultEnforcementThreshold,
 		}},
+
+		{"--initialConcurrencyLimit 100 --minConcurrencyLimit 10 --maxConcurrencyLimit 1000 --concurrencyLatencyTolerance 2", loadshedding.Options{
+			InitialConcurrencyLimit:     100,
+			MinConcurrencyLimit:         10,
+			MaxConcurrencyLimit:         1000,
+			ConcurrencyLatencyTolerance: 2,
+			SamplesPerSecond:            loadshedding.DefaultSampleFrequency,
+			SampleHalfLife:              loadshedding.DefaultHalfLife,
+			LatencyEnforcementThreshold: loadshedding.DefaultEnforcementThreshold,
+		}},
 	}
 
 	for _, c := range cases {
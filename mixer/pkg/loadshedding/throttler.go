@@ -75,10 +75,14 @@ var (
 	throttled = monitoring.NewSum(
 		"mixer/loadshedding/requests_throttled",
 		"The number of requests that have been dropped by the loadshedder.")
+
+	concurrencyLimit = monitoring.NewGauge(
+		"mixer/loadshedding/concurrency_limit",
+		"The current limit of in-flight requests learned by the adaptive concurrency limit evaluator.")
 )
 
 func init() {
-	monitoring.MustRegister(throttled, predictedCost)
+	monitoring.MustRegister(throttled, predictedCost, concurrencyLimit)
 }
 
 // NewThrottler builds a Throttler based on the configured options.
@@ -107,6 +111,13 @@ func NewThrottler(opts Options) *Throttler {
 		t.thresholds[e.Name()] = float64(opts.MaxRequestsPerSecond)
 	}
 
+	if opts.InitialConcurrencyLimit > 0 {
+		e := NewConcurrencyLimitEvaluator(opts.InitialConcurrencyLimit, opts.MinConcurrencyLimit, opts.MaxConcurrencyLimit,
+			opts.ConcurrencyLatencyTolerance)
+		t.evaluators[e.Name()] = e
+		t.thresholds[e.Name()] = e.maxLimit
+	}
+
 	scope.Debugf("Built Throttler(%#v) from opts(%#v)", t, opts)
 	return t
 }
@@ -52,6 +52,12 @@ var (
 		SamplesPerSecond:        rate.Every(1 * time.Nanosecond),
 	}
 
+	concurrencyLimitOpts = loadshedding.Options{
+		Mode:                    loadshedding.Enforce,
+		InitialConcurrencyLimit: 100,
+		MaxConcurrencyLimit:     200,
+	}
+
 	disabledOpts = loadshedding.Options{
 		Mode:                    loadshedding.Disabled,
 		MaxRequestsPerSecond:    maxRPS,
@@ -76,6 +82,11 @@ func TestNewThrottler(t *testing.T) {
 		return ok
 	}
 
+	concurrencyLimitEvalFn := func(got loadshedding.LoadEvaluator) bool {
+		e, ok := got.(*loadshedding.ConcurrencyLimitEvaluator)
+		return ok && e.Limit() == 100
+	}
+
 	cases := []struct {
 		name       string
 		opts       loadshedding.Options
@@ -85,6 +96,7 @@ func TestNewThrottler(t *testing.T) {
 		{"rate limit", rateLimitOpts, evalMap{loadshedding.RateLimitEvaluatorName: rateLimitEvalFn}},
 		{"latency", grpcLatencyOpts, evalMap{loadshedding.GRPCLatencyEvaluatorName: latencyEvalFn}},
 		{"hybrid", hybridOpts, evalMap{loadshedding.RateLimitEvaluatorName: rateLimitEvalFn, loadshedding.GRPCLatencyEvaluatorName: latencyEvalFn}},
+		{"concurrency limit", concurrencyLimitOpts, evalMap{loadshedding.ConcurrencyLimitEvaluatorName: concurrencyLimitEvalFn}},
 		{"disabled mode", disabledOpts, evalMap{}},
 	}
 
@@ -169,6 +169,47 @@ ident                         : dest.istio-system
 `,
 	},
 
+	{
+		name: "CheckResultCombinationInvalidationKeys",
+		templates: []data.FakeTemplateSettings{{
+			Name: "tcheck",
+			CheckResults: []adapter.CheckResult{
+				{ValidUseCount: 10, ValidDuration: time.Minute},
+				{ValidUseCount: 20, ValidDuration: time.Millisecond, InvalidationKeys: []string{"list"}},
+			},
+		}},
+		config: []string{
+			data.HandlerACheck1,
+			data.InstanceCheck1,
+			data.InstanceCheck2,
+			data.RuleCheck1WithInstance1And2,
+		},
+		variety: tpb.TEMPLATE_VARIETY_CHECK,
+		expectedCheckResult: adapter.CheckResult{
+			ValidUseCount:    10,
+			ValidDuration:    time.Millisecond,
+			InvalidationKeys: []string{"list"},
+		},
+		log: `
+[tcheck] InstanceBuilderFn() => name: 'tcheck', bag: '---
+ident                         : dest.istio-system
+'
+[tcheck] InstanceBuilderFn() <= (SUCCESS)
+[tcheck] DispatchCheck => context exists: 'true'
+[tcheck] DispatchCheck => handler exists: 'true'
+[tcheck] DispatchCheck => instance:       '&Struct{Fields:map[string]*Value{},XXX_unrecognized:[],}'
+[tcheck] DispatchCheck <= (SUCCESS)
+[tcheck] InstanceBuilderFn() => name: 'tcheck', bag: '---
+ident                         : dest.istio-system
+'
+[tcheck] InstanceBuilderFn() <= (SUCCESS)
+[tcheck] DispatchCheck => context exists: 'true'
+[tcheck] DispatchCheck => handler exists: 'true'
+[tcheck] DispatchCheck => instance:       '&Struct{Fields:map[string]*Value{},XXX_unrecognized:[],}'
+[tcheck] DispatchCheck <= (SUCCESS)
+`,
+	},
+
 	{
 		name: "CheckResultCombinationWithError",
 		templates: []data.FakeTemplateSettings{{
@@ -1002,7 +1043,7 @@ func TestDispatcher(t *testing.T) {
 			cfg := data.JoinConfigs(tst.config...)
 
 			s, _ := config.GetSnapshotForTest(templates, adapters, data.ServiceConfig, cfg)
-			h := handler.NewTable(handler.Empty(), s, pool.NewGoroutinePool(1, false))
+			h := handler.NewTable(handler.Empty(), s, pool.NewGoroutinePool(1, false), nil)
 
 			r := routing.BuildTable(h, s, "istio-system", true)
 			_ = dispatcher.ChangeRoute(r)
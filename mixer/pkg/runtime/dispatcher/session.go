@@ -319,6 +319,12 @@ func (s *session) waitForDispatched() {
 				if s.checkResult.ValidUseCount > state.checkResult.ValidUseCount {
 					s.checkResult.ValidUseCount = state.checkResult.ValidUseCount
 				}
+				if len(state.checkResult.InvalidationKeys) > 0 {
+					// copy, so that the keys of the adapter results are never modified
+					keys := make([]string, 0, len(s.checkResult.InvalidationKeys)+len(state.checkResult.InvalidationKeys))
+					keys = append(keys, s.checkResult.InvalidationKeys...)
+					s.checkResult.InvalidationKeys = append(keys, state.checkResult.InvalidationKeys...)
+				}
 			}
 			st = state.checkResult.Status
 
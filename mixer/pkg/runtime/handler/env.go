@@ -22,6 +22,7 @@ import (
 	"go.opencensus.io/tag"
 
 	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/checkcache"
 	"istio.io/istio/mixer/pkg/runtime/monitoring"
 	"istio.io/pkg/log"
 	"istio.io/pkg/pool"
@@ -32,10 +33,11 @@ type env struct {
 	gp               *pool.GoroutinePool
 	monitoringCtx    context.Context
 	daemons, workers *int64
+	invalidator      *checkcache.Invalidator
 }
 
 // NewEnv returns a new environment instance.
-func NewEnv(cfgID int64, name string, gp *pool.GoroutinePool) adapter.Env {
+func NewEnv(cfgID int64, name string, gp *pool.GoroutinePool, invalidator *checkcache.Invalidator) adapter.Env {
 	ctx := context.Background()
 	var err error
 	if ctx, err = tag.New(ctx, tag.Insert(monitoring.HandlerTag, name)); err != nil {
@@ -47,6 +49,7 @@ func NewEnv(cfgID int64, name string, gp *pool.GoroutinePool) adapter.Env {
 		monitoringCtx: ctx,
 		daemons:       new(int64),
 		workers:       new(int64),
+		invalidator:   invalidator,
 	}
 }
 
@@ -112,6 +115,11 @@ func (e env) ScheduleDaemon(fn adapter.DaemonFunc) {
 	}()
 }
 
+// InvalidateCheckCache from adapter.Env.
+func (e env) InvalidateCheckCache(keys ...string) {
+	e.invalidator.Invalidate(keys...)
+}
+
 func (e env) Workers() int64 {
 	return atomic.LoadInt64(e.workers)
 }
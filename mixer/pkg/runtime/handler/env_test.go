@@ -32,7 +32,7 @@ func TestEnv(t *testing.T) {
 		o := log.DefaultOptions()
 		_ = log.Configure(o)
 
-		e := NewEnv(0, "Foo", gp)
+		e := NewEnv(0, "Foo", gp, nil)
 		log := e.Logger()
 		log.Infof("Test%s", "ing")
 		log.Warningf("Test%s", "ing")
@@ -21,6 +21,7 @@ import (
 	"go.opencensus.io/stats"
 
 	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/checkcache"
 	"istio.io/istio/mixer/pkg/protobuf/yaml/dynamic"
 	"istio.io/istio/mixer/pkg/runtime/config"
 	"istio.io/istio/mixer/pkg/runtime/monitoring"
@@ -63,8 +64,9 @@ type Entry struct {
 }
 
 // NewTable returns a new table, based on the given config snapshot. The table will re-use existing handlers as much as
-// possible from the old table.
-func NewTable(old *Table, snapshot *config.Snapshot, gp *pool.GoroutinePool) *Table {
+// possible from the old table. The invalidator, which may be nil, is handed to the adapters to drop the check
+// results they tagged from the check cache.
+func NewTable(old *Table, snapshot *config.Snapshot, gp *pool.GoroutinePool, invalidator *checkcache.Invalidator) *Table {
 	// Find all handlers, as referenced by instances, and associate to handlers.
 	instancesByHandler := config.GetInstancesGroupedByHandlers(snapshot)
 	instancesByHandlerDynamic := config.GetInstancesGroupedByHandlersDynamic(snapshot)
@@ -80,7 +82,7 @@ func NewTable(old *Table, snapshot *config.Snapshot, gp *pool.GoroutinePool) *Ta
 	for handler, instances := range instancesByHandler {
 		n, r, be := createEntry(old, t, handler, instances, snapshot.ID,
 			func(handler hndlr, instances interface{}) (h adapter.Handler, e env, err error) {
-				e = NewEnv(snapshot.ID, handler.GetName(), gp).(env)
+				e = NewEnv(snapshot.ID, handler.GetName(), gp, invalidator).(env)
 				h, err = config.BuildHandler(handler.(*config.HandlerStatic), instances.([]*config.InstanceStatic),
 					e, snapshot.Templates)
 				return h, e, err
@@ -94,7 +96,7 @@ func NewTable(old *Table, snapshot *config.Snapshot, gp *pool.GoroutinePool) *Ta
 	for handler, instances := range instancesByHandlerDynamic {
 		n, r, be := createEntry(old, t, handler, instances, snapshot.ID,
 			func(_ hndlr, _ interface{}) (h adapter.Handler, e env, err error) {
-				e = NewEnv(snapshot.ID, handler.GetName(), gp).(env)
+				e = NewEnv(snapshot.ID, handler.GetName(), gp, invalidator).(env)
 				tmplCfg := make([]*dynamic.TemplateConfig, 0, len(instances))
 				for _, inst := range instances {
 					tmplCfg = append(tmplCfg, &dynamic.TemplateConfig{
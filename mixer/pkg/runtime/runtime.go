@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"istio.io/istio/mixer/pkg/adapter"
+	"istio.io/istio/mixer/pkg/checkcache"
 	"istio.io/istio/mixer/pkg/config/store"
 	"istio.io/istio/mixer/pkg/runtime/config"
 	"istio.io/istio/mixer/pkg/runtime/dispatcher"
@@ -52,6 +53,8 @@ type Runtime struct {
 
 	handlerPool *pool.GoroutinePool
 
+	invalidator *checkcache.Invalidator
+
 	*probe.Probe
 
 	stateLock            sync.Mutex
@@ -67,7 +70,8 @@ func New(
 	defaultConfigNamespace string,
 	executorPool *pool.GoroutinePool,
 	handlerPool *pool.GoroutinePool,
-	enableTracing bool) *Runtime {
+	enableTracing bool,
+	invalidator *checkcache.Invalidator) *Runtime {
 
 	// Ignoring the errors for bad configuration that has already made it to the store.
 	// during snapshot creation the bad configuration errors are already logged.
@@ -79,6 +83,7 @@ func New(
 		handlers:               handler.Empty(),
 		dispatcher:             dispatcher.New(executorPool, enableTracing),
 		handlerPool:            handlerPool,
+		invalidator:            invalidator,
 		Probe:                  probe.NewProbe(),
 		store:                  s,
 	}
@@ -156,7 +161,7 @@ func (c *Runtime) processNewConfig() {
 
 	oldHandlers := c.handlers
 
-	newHandlers := handler.NewTable(oldHandlers, newSnapshot, c.handlerPool)
+	newHandlers := handler.NewTable(oldHandlers, newSnapshot, c.handlerPool, c.invalidator)
 
 	newRoutes := routing.BuildTable(
 		newHandlers, newSnapshot, c.defaultConfigNamespace, log.DebugEnabled())
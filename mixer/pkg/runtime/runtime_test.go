@@ -49,7 +49,8 @@ func TestRuntime_Basic(t *testing.T) {
 		adapters, "istio-system",
 		egp,
 		hgp,
-		true)
+		true,
+		nil)
 
 	d := rt.Dispatcher()
 	if d == nil {
@@ -95,7 +96,8 @@ func TestRuntime_ErrorDuringWatch(t *testing.T) {
 		adapters, "istio-system",
 		egp,
 		hgp,
-		true)
+		true,
+		nil)
 
 	err := rt.StartListening()
 	if err == nil {
@@ -114,7 +116,8 @@ func TestRuntime_OnConfigChange(t *testing.T) {
 		adapters, "istio-system",
 		egp,
 		hgp,
-		true)
+		true,
+		nil)
 
 	err := rt.StartListening()
 	if err != nil {
@@ -190,7 +193,8 @@ func TestRuntime_InFlightRequestsDuringConfigChange(t *testing.T) {
 		adapters, "istio-system",
 		egp,
 		hgp,
-		true)
+		true,
+		nil)
 
 	err := rt.StartListening()
 	if err != nil {
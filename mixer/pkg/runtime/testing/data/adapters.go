@@ -82,6 +82,9 @@ func (f *FakeEnv) ScheduleWork(fn adapter.WorkFunc) { panic("should not be calle
 // ScheduleDaemon is an implementation of adapter.Env.ScheduleDaemon.
 func (f *FakeEnv) ScheduleDaemon(fn adapter.DaemonFunc) { panic("should not be called") }
 
+// InvalidateCheckCache is an implementation of adapter.Env.InvalidateCheckCache.
+func (f *FakeEnv) InvalidateCheckCache(keys ...string) { panic("should not be called") }
+
 var _ adapter.Env = &FakeEnv{}
 
 // FakeHandlerBuilder is a fake of HandlerBuilder.
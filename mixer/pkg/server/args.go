@@ -93,9 +93,14 @@ type Args struct {
 	// Port to use for exposing mixer self-monitoring information
 	MonitoringPort uint16
 
-	// Maximum number of entries in the check cache
+	// Maximum number of entries in the check cache. The check cache is disabled if 0, which is the default,
+	// see https://github.com/istio/istio/issues/9596.
 	NumCheckCacheEntries int32
 
+	// Address of the Redis server used to propagate check cache invalidations to the peer Mixer replicas.
+	// If empty, invalidations only apply to the check cache of this replica.
+	CheckCacheInvalidationRedisAddress string
+
 	// Enable profiling via web interface host:port/debug/pprof
 	EnableProfiling bool
 
@@ -132,7 +137,6 @@ func DefaultArgs() *Args {
 		ReadinessProbeOptions:  &probe.Options{},
 		IntrospectionOptions:   ctrlz.DefaultOptions(),
 		EnableProfiling:        true,
-		NumCheckCacheEntries:   5000 * 5 * 60, // 5000 QPS with average TTL of 5 minutes
 		UseAdapterCRDs:         true,
 		UseTemplateCRDs:        true,
 		LoadSheddingOptions:    loadshedding.DefaultOptions(),
@@ -181,6 +185,7 @@ func (a *Args) String() string {
 	fmt.Fprintln(buf, "EnableProfiling: ", a.EnableProfiling)
 	fmt.Fprintln(buf, "SingleThreaded: ", a.SingleThreaded)
 	fmt.Fprintln(buf, "NumCheckCacheEntries: ", a.NumCheckCacheEntries)
+	fmt.Fprintln(buf, "CheckCacheInvalidationRedisAddress: ", a.CheckCacheInvalidationRedisAddress)
 	fmt.Fprintln(buf, "ConfigStoreURL: ", a.ConfigStoreURL)
 	fmt.Fprintln(buf, "CertificateFile: ", a.CredentialOptions.CertificateFile)
 	fmt.Fprintln(buf, "KeyFile: ", a.CredentialOptions.KeyFile)
@@ -27,6 +27,7 @@ import (
 	"go.opencensus.io/plugin/ocgrpc"
 	"go.opencensus.io/stats/view"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	mixerpb "istio.io/api/mixer/v1"
@@ -59,9 +60,10 @@ type Server struct {
 	monitor   *monitor
 	tracer    io.Closer
 
-	checkCache *checkcache.Cache
-	dispatcher dispatcher.Dispatcher
-	controlZ   *ctrlz.Server
+	checkCache  *checkcache.Cache
+	invalidator *checkcache.Invalidator
+	dispatcher  dispatcher.Dispatcher
+	controlZ    *ctrlz.Server
 
 	// probes
 	livenessProbe  probe.Controller
@@ -76,13 +78,14 @@ type listenFunc func(network string, address string) (net.Listener, error)
 type patchTable struct {
 	newRuntime func(s store.Store, templates map[string]*template.Info, adapters map[string]*adapter.Info,
 		defaultConfigNamespace string, executorPool *pool.GoroutinePool,
-		handlerPool *pool.GoroutinePool, enableTracing bool) *runtime.Runtime
-	configTracing func(serviceName string, options *tracing.Options) (io.Closer, error)
-	startMonitor  func(port uint16, enableProfiling bool, lf listenFunc) (*monitor, error)
-	listen        listenFunc
-	configLog     func(options *log.Options) error
-	runtimeListen func(runtime *runtime.Runtime) error
-	remove        func(name string) error
+		handlerPool *pool.GoroutinePool, enableTracing bool, invalidator *checkcache.Invalidator) *runtime.Runtime
+	newInvalidationTransport func(a *Args) (checkcache.Transport, error)
+	configTracing            func(serviceName string, options *tracing.Options) (io.Closer, error)
+	startMonitor             func(port uint16, enableProfiling bool, lf listenFunc) (*monitor, error)
+	listen                   listenFunc
+	configLog                func(options *log.Options) error
+	runtimeListen            func(runtime *runtime.Runtime) error
+	remove                   func(name string) error
 
 	// monitoring-related setup
 	newOpenCensusExporter   func() (view.Exporter, error)
@@ -96,7 +99,13 @@ func New(a *Args) (*Server, error) {
 
 func newPatchTable() *patchTable {
 	return &patchTable{
-		newRuntime:    runtime.New,
+		newRuntime: runtime.New,
+		newInvalidationTransport: func(a *Args) (checkcache.Transport, error) {
+			if a.CheckCacheInvalidationRedisAddress == "" {
+				return checkcache.NewInProcessTransport(), nil
+			}
+			return checkcache.NewRedisTransport(a.CheckCacheInvalidationRedisAddress, checkcache.DefaultRedisChannel)
+		},
 		configTracing: tracing.Configure,
 		startMonitor:  startMonitor,
 		listen:        net.Listen,
@@ -213,9 +222,25 @@ func newServer(a *Args, p *patchTable) (server *Server, err error) {
 		return nil, err
 	}
 	s.configStore = st
+
+	// the check cache is disabled by default, see issue https://github.com/istio/istio/issues/9596
+	if a.NumCheckCacheEntries > 0 {
+		s.checkCache = checkcache.New(a.NumCheckCacheEntries)
+	}
+
+	// the invalidations are propagated to the peer replicas even if this one doesn't cache check results.
+	transport, err := p.newInvalidationTransport(a)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create check cache invalidation transport: %v", err)
+	}
+	if s.invalidator, err = checkcache.NewInvalidator(s.checkCache, transport); err != nil {
+		_ = transport.Close()
+		return nil, fmt.Errorf("unable to subscribe to check cache invalidations: %v", err)
+	}
+
 	log.Info("Starting runtime config watch...")
 	rt := p.newRuntime(st, templateMap, adapterMap, a.ConfigDefaultNamespace,
-		s.gp, s.adapterGP, a.TracingOptions.TracingEnabled())
+		s.gp, s.adapterGP, a.TracingOptions.TracingEnabled(), s.invalidator)
 
 	if err = p.runtimeListen(rt); err != nil {
 		return nil, fmt.Errorf("unable to listen: %v", err)
@@ -223,13 +248,6 @@ func newServer(a *Args, p *patchTable) (server *Server, err error) {
 
 	s.dispatcher = rt.Dispatcher()
 
-	// see issue https://github.com/istio/istio/issues/9596
-	a.NumCheckCacheEntries = 0
-
-	if a.NumCheckCacheEntries > 0 {
-		s.checkCache = checkcache.New(a.NumCheckCacheEntries)
-	}
-
 	// get the grpc server wired up
 	grpc.EnableTracing = a.EnableGRPCTracing
 
@@ -245,10 +263,16 @@ func newServer(a *Args, p *patchTable) (server *Server, err error) {
 	}
 
 	throttler := loadshedding.NewThrottler(a.LoadSheddingOptions)
-	if eval := throttler.Evaluator(loadshedding.GRPCLatencyEvaluatorName); eval != nil {
-		grpcOptions = append(grpcOptions, grpc.StatsHandler(newMultiStatsHandler(&ocgrpc.ServerHandler{}, eval.(*loadshedding.GRPCLatencyEvaluator))))
+	statsHandlers := []stats.Handler{&ocgrpc.ServerHandler{}}
+	for _, name := range []string{loadshedding.GRPCLatencyEvaluatorName, loadshedding.ConcurrencyLimitEvaluatorName} {
+		if eval, ok := throttler.Evaluator(name).(stats.Handler); ok {
+			statsHandlers = append(statsHandlers, eval)
+		}
+	}
+	if len(statsHandlers) > 1 {
+		grpcOptions = append(grpcOptions, grpc.StatsHandler(newMultiStatsHandler(statsHandlers...)))
 	} else {
-		grpcOptions = append(grpcOptions, grpc.StatsHandler(&ocgrpc.ServerHandler{}))
+		grpcOptions = append(grpcOptions, grpc.StatsHandler(statsHandlers[0]))
 	}
 
 	s.server = grpc.NewServer(grpcOptions...)
@@ -326,6 +350,10 @@ func (s *Server) Close() error {
 		s.configStore = nil
 	}
 
+	if s.invalidator != nil {
+		_ = s.invalidator.Close()
+	}
+
 	if s.checkCache != nil {
 		_ = s.checkCache.Close()
 	}
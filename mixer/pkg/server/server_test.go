@@ -23,15 +23,18 @@ import (
 	"net/url"
 	"strconv"
 	"testing"
+	"time"
 
 	"go.opencensus.io/stats/view"
 	"google.golang.org/grpc"
 
 	mixerpb "istio.io/api/mixer/v1"
+	"istio.io/istio/mixer/pkg/checkcache"
 	"istio.io/istio/mixer/pkg/config/storetest"
 	"istio.io/istio/mixer/pkg/runtime"
 	generatedTmplRepo "istio.io/istio/mixer/template"
 	"istio.io/istio/pkg/tracing"
+	"istio.io/pkg/attribute"
 	"istio.io/pkg/log"
 	"istio.io/pkg/version"
 )
@@ -119,6 +122,15 @@ func createClient(addr net.Addr) (mixerpb.MixerClient, error) {
 }
 
 func newTestServer(globalCfg, serviceCfg string) (*Server, error) {
+	a, err := newTestServerArgs(globalCfg, serviceCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(a)
+}
+
+func newTestServerArgs(globalCfg, serviceCfg string) (*Args, error) {
 	a := defaultTestArgs()
 	a.APIPort = 0
 	a.MonitoringPort = 0
@@ -134,7 +146,7 @@ func newTestServer(globalCfg, serviceCfg string) (*Server, error) {
 		return nil, err
 	}
 
-	return New(a)
+	return a, nil
 }
 
 func TestBasic(t *testing.T) {
@@ -154,6 +166,39 @@ func TestBasic(t *testing.T) {
 	}
 }
 
+func TestCheckCache(t *testing.T) {
+	a, err := newTestServerArgs(globalCfg, serviceCfg)
+	if err != nil {
+		t.Fatalf("Unable to create server args: %v", err)
+	}
+	a.NumCheckCacheEntries = 10
+
+	s, err := New(a)
+	if err != nil {
+		t.Fatalf("Unable to create server: %v", err)
+	}
+	defer func() { _ = s.Close() }()
+
+	if s.checkCache == nil {
+		t.Fatal("Got no check cache, expected one")
+	}
+
+	bag := attribute.GetMutableBagForTesting(map[string]interface{}{})
+	s.checkCache.Set(bag, checkcache.Value{
+		Expiration:       time.Now().Add(time.Hour),
+		InvalidationKeys: []string{"listchecker"},
+	})
+	if _, ok := s.checkCache.Get(bag); !ok {
+		t.Fatal("Got no cached check result, expected one")
+	}
+
+	// the runtime invalidates the cached results through the invalidator of the server
+	s.invalidator.Invalidate("listchecker")
+	if _, ok := s.checkCache.Get(bag); ok {
+		t.Error("Got a cached check result after invalidation, expected none")
+	}
+}
+
 func TestClient(t *testing.T) {
 	s, err := newTestServer(globalCfg, serviceCfg)
 	if err != nil {
@@ -268,6 +313,18 @@ func TestErrors(t *testing.T) {
 				}
 			},
 		},
+		{"failed check cache invalidation transport setup",
+			func(a *Args, pt *patchTable) {
+				pt.newInvalidationTransport = func(a *Args) (checkcache.Transport, error) {
+					return nil, errors.New("BAD")
+				}
+			},
+		},
+		{"unreachable check cache invalidation redis server",
+			func(a *Args, pt *patchTable) {
+				a.CheckCacheInvalidationRedisAddress = "127.0.0.1:1"
+			},
+		},
 		{"unix socket removal",
 			func(a *Args, pt *patchTable) {
 				a.APIAddress = "unix:///dev"
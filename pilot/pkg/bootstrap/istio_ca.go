@@ -42,6 +42,8 @@ import (
 	"istio.io/istio/pkg/spiffe"
 	"istio.io/istio/security/pkg/cmd"
 	"istio.io/istio/security/pkg/pki/ca"
+	"istio.io/istio/security/pkg/pki/ca/vault"
+	"istio.io/istio/security/pkg/pki/util"
 	caserver "istio.io/istio/security/pkg/server/ca"
 	"istio.io/istio/security/pkg/server/ca/authenticate"
 )
@@ -96,6 +98,41 @@ var (
 		cmd.DefaultRootCertGracePeriodPercentile,
 		"Grace period percentile for self-signed root cert.")
 
+	selfSignedCAKeyAlgorithm = env.RegisterStringVar("CITADEL_SELF_SIGNED_CA_KEY_ALGORITHM",
+		string(util.RSA),
+		"The algorithm of the self-signed CA private key: RSA, ECDSA-P256 or ECDSA-P384.")
+
+	pluggedCertCheckInterval = env.RegisterDurationVar("CITADEL_PLUGGED_CERT_CHECK_INTERVAL",
+		cmd.DefaultPluggedCertCheckInterval,
+		"The interval that a CA using plugged certificates checks whether its key and "+
+			"certificate files changed, and reloads them. Setting this interval to zero "+
+			"or a negative value disables the reloading.")
+
+	pluggedRootCertOverlap = env.RegisterDurationVar("CITADEL_PLUGGED_ROOT_CERT_OVERLAP",
+		cmd.DefaultPluggedRootCertOverlap,
+		"The duration the previous root certificate stays in the trust bundle after "+
+			"the plugged root certificate changed.")
+
+	vaultAddr = env.RegisterStringVar("CITADEL_VAULT_ADDR", "",
+		"The address of a Vault server signing the certificates with its PKI secrets engine, "+
+			"instead of the CA key. The Vault token is read from VAULT_TOKEN, or obtained with "+
+			"the Kubernetes auth method if it is not set.")
+
+	vaultTLSRootCertFile = env.RegisterStringVar("CITADEL_VAULT_TLS_ROOT_CERT_FILE", "",
+		"The root cert file verifying the Vault server.")
+
+	vaultLoginPath = env.RegisterStringVar("CITADEL_VAULT_LOGIN_PATH", "auth/kubernetes/login",
+		"The login path of the Vault Kubernetes auth method.")
+
+	vaultLoginRole = env.RegisterStringVar("CITADEL_VAULT_LOGIN_ROLE", "istio-ca",
+		"The role of the Vault Kubernetes auth method.")
+
+	vaultSignPath = env.RegisterStringVar("CITADEL_VAULT_SIGN_PATH", "pki/sign/istio",
+		"The path of the Vault PKI endpoint signing the CSRs.")
+
+	vaultCAChainPath = env.RegisterStringVar("CITADEL_VAULT_CA_CHAIN_PATH", "pki/cert/ca_chain",
+		"The path of the Vault PKI CA chain.")
+
 	enableJitterForRootCertRotator = env.RegisterBoolVar("CITADEL_ENABLE_JITTER_FOR_ROOT_CERT_ROTATOR",
 		true,
 		"If true, set up a jitter to start root cert rotator. "+
@@ -429,7 +466,21 @@ func (s *Server) createCA(client corev1.CoreV1Interface, opts *CAOptions) (*ca.I
 		rootCertFile = ""
 	}
 
-	if _, err := os.Stat(signingKeyFile); err != nil {
+	if vaultAddr.Get() != "" {
+		log.Infof("Use Vault at %s to sign the certificates", vaultAddr.Get())
+		caOpts, err = vault.NewIstioCAOptions(&vault.Config{
+			Addr:            vaultAddr.Get(),
+			TLSRootCertFile: vaultTLSRootCertFile.Get(),
+			LoginPath:       vaultLoginPath.Get(),
+			LoginRole:       vaultLoginRole.Get(),
+			JWTPath:         s.jwtPath,
+			SignPath:        vaultSignPath.Get(),
+			CAChainPath:     vaultCAChainPath.Get(),
+		}, rootCertFile, workloadCertTTL.Get(), maxCertTTL, opts.Namespace, client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create a Citadel signing with Vault: %v", err)
+		}
+	} else if _, err := os.Stat(signingKeyFile); err != nil {
 		// The user-provided certs are missing - create a self-signed cert.
 
 		log.Info("Use self-signed certificate as the CA certificate")
@@ -438,6 +489,10 @@ func (s *Server) createCA(client corev1.CoreV1Interface, opts *CAOptions) (*ca.I
 		ctx, cancel := context.WithTimeout(context.Background(), time.Minute*20)
 		defer cancel()
 		// rootCertFile will be added to "ca-cert.pem".
+		keyAlgorithm, err := util.ParseKeyAlgorithm(selfSignedCAKeyAlgorithm.Get())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create a self-signed Citadel: %v", err)
+		}
 
 		// readSigningCertOnly set to false - it doesn't seem to be used in Citadel, nor do we have a way
 		// to set it only for one job.
@@ -449,7 +504,7 @@ func (s *Server) createCA(client corev1.CoreV1Interface, opts *CAOptions) (*ca.I
 			selfSignedRootCertCheckInterval.Get(), workloadCertTTL.Get(),
 			maxCertTTL, opts.TrustDomain, true,
 			opts.Namespace, -1, client, rootCertFile,
-			enableJitterForRootCertRotator.Get())
+			enableJitterForRootCertRotator.Get(), keyAlgorithm)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create a self-signed Citadel: %v", err)
 		}
@@ -468,6 +523,8 @@ func (s *Server) createCA(client corev1.CoreV1Interface, opts *CAOptions) (*ca.I
 		if err != nil {
 			return nil, fmt.Errorf("failed to create an Citadel: %v", err)
 		}
+		caOpts.PluggedCertRotatorConfig.CheckInterval = pluggedCertCheckInterval.Get()
+		caOpts.PluggedCertRotatorConfig.RootCertOverlap = pluggedRootCertOverlap.Get()
 	}
 
 	istioCA, err := ca.NewIstioCA(caOpts)
@@ -479,7 +536,7 @@ func (s *Server) createCA(client corev1.CoreV1Interface, opts *CAOptions) (*ca.I
 	// ca.go saves or uses the secret, but also writes to the configmap "istio-security", under caTLSRootCert
 
 	// rootCertRotatorChan channel accepts signals to stop root cert rotator for
-	// self-signed CA.
+	// self-signed CA, or plugged cert rotator for plugged cert CA.
 	rootCertRotatorChan := make(chan struct{})
 	// Start root cert rotator in a separate goroutine.
 	istioCA.Run(rootCertRotatorChan)
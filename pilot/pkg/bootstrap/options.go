@@ -58,10 +58,16 @@ type ConsulArgs struct {
 	ServerURL string
 }
 
+// EurekaArgs provides configuration for the Eureka service registry.
+type EurekaArgs struct {
+	ServerURL string
+}
+
 // ServiceArgs provides the composite configuration for all service registries in the system.
 type ServiceArgs struct {
 	Registries []string
 	Consul     ConsulArgs
+	Eureka     EurekaArgs
 }
 
 // PilotArgs provides all of the configuration parameters for the Pilot discovery service.
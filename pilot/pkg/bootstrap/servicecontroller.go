@@ -24,6 +24,7 @@ import (
 	"istio.io/istio/pilot/pkg/serviceregistry"
 	"istio.io/istio/pilot/pkg/serviceregistry/aggregate"
 	"istio.io/istio/pilot/pkg/serviceregistry/consul"
+	"istio.io/istio/pilot/pkg/serviceregistry/eureka"
 	"istio.io/istio/pilot/pkg/serviceregistry/external"
 	kubecontroller "istio.io/istio/pilot/pkg/serviceregistry/kube/controller"
 	"istio.io/istio/pilot/pkg/serviceregistry/memory"
@@ -55,6 +56,8 @@ func (s *Server) initServiceControllers(args *PilotArgs) error {
 			if err := s.initConsulRegistry(serviceControllers, args); err != nil {
 				return err
 			}
+		case serviceregistry.Eureka:
+			s.initEurekaRegistry(serviceControllers, args)
 		case serviceregistry.Mock:
 			s.initMemoryRegistry(serviceControllers)
 		default:
@@ -102,6 +105,12 @@ func (s *Server) initConsulRegistry(serviceControllers *aggregate.Controller, ar
 	return nil
 }
 
+func (s *Server) initEurekaRegistry(serviceControllers *aggregate.Controller, args *PilotArgs) {
+	log.Infof("Eureka url: %v", args.Service.Eureka.ServerURL)
+	client := eureka.NewClient(args.Service.Eureka.ServerURL)
+	serviceControllers.AddRegistry(eureka.NewController(client, ""))
+}
+
 func (s *Server) initMemoryRegistry(serviceControllers *aggregate.Controller) {
 	// MemServiceDiscovery implementation
 	discovery := memory.NewDiscovery(map[host.Name]*model.Service{}, 2)
@@ -50,6 +50,33 @@ var (
 		"Limits the number of concurrent pushes allowed. On larger machines this can be increased for faster pushes",
 	).Get()
 
+	PushThrottleHighPriority = env.RegisterIntVar(
+		"PILOT_PUSH_THROTTLE_HIGH_PRIORITY",
+		0,
+		"Limits the number of concurrent pushes to gateways and newly connected proxies. These are dequeued "+
+			"before any other push. If 0, only PILOT_PUSH_THROTTLE applies.",
+	).Get()
+
+	PushThrottleNormalPriority = env.RegisterIntVar(
+		"PILOT_PUSH_THROTTLE_NORMAL_PRIORITY",
+		0,
+		"Limits the number of concurrent full pushes to sidecars. If 0, only PILOT_PUSH_THROTTLE applies.",
+	).Get()
+
+	PushThrottleLowPriority = env.RegisterIntVar(
+		"PILOT_PUSH_THROTTLE_LOW_PRIORITY",
+		0,
+		"Limits the number of concurrent incremental EDS pushes to sidecars. These are dequeued after "+
+			"any other push. If 0, only PILOT_PUSH_THROTTLE applies.",
+	).Get()
+
+	PushMinInterval = env.RegisterDurationVar(
+		"PILOT_PUSH_MIN_INTERVAL",
+		0,
+		"The minimum time between two pushes to the same proxy. Changes in the meantime are merged into "+
+			"a single delayed push. If 0, pushes to a proxy are not rate limited.",
+	).Get()
+
 	// MaxRecvMsgSize The max receive buffer size of gRPC received channel of Pilot in bytes.
 	MaxRecvMsgSize = env.RegisterIntVar(
 		"ISTIO_GPRC_MAXRECVMSGSIZE",
@@ -145,6 +172,14 @@ var (
 		"EnableRedisFilter enables injection of `envoy.filters.network.redis_proxy` in the filter chain.",
 	)
 
+	// EnableKafkaFilter enables injection of `envoy.filters.network.kafka_broker` in the filter chain.
+	// Pilot injects this outbound filter if the service port name is `kafka`.
+	EnableKafkaFilter = env.RegisterBoolVar(
+		"PILOT_ENABLE_KAFKA_FILTER",
+		false,
+		"EnableKafkaFilter enables injection of `envoy.filters.network.kafka_broker` in the filter chain.",
+	)
+
 	// UseRemoteAddress sets useRemoteAddress to true for side car outbound listeners so that it picks up the localhost
 	// address of the sender, which is an internal address, so that trusted headers are not sanitized.
 	UseRemoteAddress = env.RegisterBoolVar(
@@ -265,6 +300,13 @@ var (
 		"If enabled, Pilot will keep track of old versions of distributed config for this duration.",
 	).Get()
 
+	ConfigHistorySize = env.RegisterIntVar(
+		"PILOT_CONFIG_HISTORY_SIZE",
+		20,
+		"The number of config versions Pilot keeps for /debug/config_history when config distribution "+
+			"tracking is enabled. Setting this to 0 disables the config history.",
+	).Get()
+
 	EnableEndpointSliceController = env.RegisterBoolVar(
 		"PILOT_USE_ENDPOINT_SLICE",
 		false,
@@ -833,7 +833,7 @@ func applyTrafficPolicy(opts buildClusterOpts, proxy *model.Proxy) {
 
 	applyConnectionPool(opts.push, opts.cluster, connectionPool)
 	applyOutlierDetection(opts.cluster, outlierDetection)
-	applyLoadBalancer(opts.cluster, loadBalancer, opts.port, proxy, opts.push.Mesh)
+	applyLoadBalancer(opts.cluster, loadBalancer, opts.port, proxy, opts.push.Mesh, opts.push.Networks)
 
 	if opts.clusterMode != SniDnatClusterMode && opts.direction != model.TrafficDirectionInbound {
 		autoMTLSEnabled := opts.push.Mesh.GetEnableAutoMtls().Value
@@ -1004,7 +1004,8 @@ func applyOutlierDetection(cluster *apiv2.Cluster, outlier *networking.OutlierDe
 	}
 }
 
-func applyLoadBalancer(cluster *apiv2.Cluster, lb *networking.LoadBalancerSettings, port *model.Port, proxy *model.Proxy, meshConfig *meshconfig.MeshConfig) {
+func applyLoadBalancer(cluster *apiv2.Cluster, lb *networking.LoadBalancerSettings, port *model.Port, proxy *model.Proxy,
+	meshConfig *meshconfig.MeshConfig, meshNetworks *meshconfig.MeshNetworks) {
 	if cluster.OutlierDetection != nil {
 		if cluster.CommonLbConfig == nil {
 			cluster.CommonLbConfig = &apiv2.Cluster_CommonLbConfig{}
@@ -1017,7 +1018,7 @@ func applyLoadBalancer(cluster *apiv2.Cluster, lb *networking.LoadBalancerSettin
 
 	// Use locality lb settings from load balancer settings if present, else use mesh wide locality lb settings
 	lbSetting := loadbalancer.GetLocalityLbSetting(meshConfig.GetLocalityLbSetting(), lb.GetLocalityLbSetting())
-	applyLocalityLBSetting(proxy.Locality, cluster, lbSetting)
+	applyLocalityLBSetting(proxy, cluster, lbSetting, meshNetworks)
 
 	// The following order is important. If cluster type has been identified as Original DST since Resolution is PassThrough,
 	// and port is named as redis-xxx we end up creating a cluster with type Original DST and LbPolicy as MAGLEV which would be
@@ -1074,18 +1075,19 @@ func applyLoadBalancer(cluster *apiv2.Cluster, lb *networking.LoadBalancerSettin
 }
 
 func applyLocalityLBSetting(
-	locality *core.Locality,
+	proxy *model.Proxy,
 	cluster *apiv2.Cluster,
 	localityLB *networking.LocalityLoadBalancerSetting,
+	meshNetworks *meshconfig.MeshNetworks,
 ) {
-	if locality == nil || localityLB == nil {
+	if proxy.Locality == nil || localityLB == nil {
 		return
 	}
 
 	// Failover should only be applied with outlier detection, or traffic will never failover.
 	enabledFailover := cluster.OutlierDetection != nil
 	if cluster.LoadAssignment != nil {
-		loadbalancer.ApplyLocalityLBSetting(locality, cluster.LoadAssignment, localityLB, enabledFailover)
+		loadbalancer.ApplyLocalityLBSetting(proxy.Locality, proxy.Metadata.Network, cluster.LoadAssignment, localityLB, enabledFailover, meshNetworks)
 	}
 }
 
@@ -2163,7 +2163,7 @@ func TestApplyLoadBalancer(t *testing.T) {
 				defer os.Unsetenv("PILOT_ENABLE_REDIS_FILTER")
 			}
 
-			applyLoadBalancer(cluster, test.lbSettings, test.port, &proxy, &meshconfig.MeshConfig{})
+			applyLoadBalancer(cluster, test.lbSettings, test.port, &proxy, &meshconfig.MeshConfig{}, nil)
 
 			if cluster.LbPolicy != test.expectedLbPolicy {
 				t.Errorf("cluster LbPolicy %s != expected %s", cluster.LbPolicy, test.expectedLbPolicy)
@@ -1845,7 +1845,7 @@ func buildSidecarInboundMgmtListeners(node *model.Proxy, push *model.PushContext
 	for _, mPort := range managementPorts {
 		switch mPort.Protocol {
 		case protocol.HTTP, protocol.HTTP2, protocol.GRPC, protocol.GRPCWeb, protocol.TCP,
-			protocol.HTTPS, protocol.TLS, protocol.Mongo, protocol.Redis, protocol.MySQL:
+			protocol.HTTPS, protocol.TLS, protocol.Mongo, protocol.Redis, protocol.MySQL, protocol.Kafka:
 
 			instance := &model.ServiceInstance{
 				Service: &model.Service{
@@ -0,0 +1,184 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancer
+
+import (
+	"strings"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/api/v2/endpoint"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	meshconfig "istio.io/api/mesh/v1alpha1"
+	"istio.io/api/networking/v1alpha3"
+
+	"istio.io/istio/pilot/pkg/networking/util"
+	"istio.io/istio/pkg/config/constants"
+)
+
+// failoverTarget selects the endpoints of a failover target, either by locality or by network.
+type failoverTarget struct {
+	// locality is matched against the locality of the endpoints, if not empty
+	locality string
+	// networks are matched against the network of the endpoints, if locality is empty
+	networks map[string]bool
+}
+
+func (t *failoverTarget) matchLocality(l *core.Locality) bool {
+	return t.locality != "" && util.LocalityMatch(l, t.locality)
+}
+
+// failoverTargets are the failover targets applying to a proxy, in priority order.
+type failoverTargets []*failoverTarget
+
+// buildFailoverTargets returns the targets of the failover settings which apply to the proxy locality.
+func buildFailoverTargets(
+	locality *core.Locality,
+	failover []*v1alpha3.LocalityLoadBalancerSetting_Failover,
+	meshNetworks *meshconfig.MeshNetworks) failoverTargets {
+	var out failoverTargets
+	for _, failoverSetting := range failover {
+		if failoverSetting == nil || !util.LocalityMatch(locality, failoverSetting.From) {
+			continue
+		}
+		switch to := failoverSetting.To; {
+		case strings.HasPrefix(to, constants.FailoverNetworkPrefix):
+			out = append(out, &failoverTarget{
+				networks: map[string]bool{strings.TrimPrefix(to, constants.FailoverNetworkPrefix): true},
+			})
+		case strings.HasPrefix(to, constants.FailoverClusterPrefix):
+			out = append(out, &failoverTarget{
+				networks: clusterNetworks(strings.TrimPrefix(to, constants.FailoverClusterPrefix), meshNetworks),
+			})
+		default:
+			out = append(out, &failoverTarget{locality: to})
+		}
+	}
+	return out
+}
+
+// clusterNetworks returns the networks which endpoints are read from the registry of a cluster.
+func clusterNetworks(cluster string, meshNetworks *meshconfig.MeshNetworks) map[string]bool {
+	out := make(map[string]bool)
+	for name, network := range meshNetworks.GetNetworks() {
+		for _, ep := range network.GetEndpoints() {
+			if ep.GetFromRegistry() == cluster {
+				out[name] = true
+			}
+		}
+	}
+	return out
+}
+
+// hasNetworkTargets returns true if some of the targets select the endpoints by network.
+func (targets failoverTargets) hasNetworkTargets() bool {
+	for _, t := range targets {
+		if t.locality == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// index returns the index of the first target matching an endpoint of another region than the
+// proxy, or the number of targets if none matches.
+func (targets failoverTargets) index(l *core.Locality, network string) int {
+	for i, t := range targets {
+		if t.matchLocality(l) || (t.locality == "" && t.networks[network]) {
+			return i
+		}
+	}
+	return len(targets)
+}
+
+// networkIndex orders the endpoints in the region of the proxy by network. It returns 0 for the
+// network of the proxy, 1 + the index of the first target matching the network, or 1 + the number
+// of targets if none matches. If the network of the proxy or of the endpoint is unknown, the
+// endpoints matching no target are not told apart from the ones of the proxy network.
+func (targets failoverTargets) networkIndex(network, proxyNetwork string) int {
+	if network == proxyNetwork || network == "" {
+		return 0
+	}
+	for i, t := range targets {
+		if t.locality == "" && t.networks[network] {
+			return i + 1
+		}
+	}
+	if proxyNetwork == "" {
+		return 0
+	}
+	return len(targets) + 1
+}
+
+// failoverSplit holds endpoints of a locality with the index of their failover target.
+type failoverSplit struct {
+	localityEndpoint *endpoint.LocalityLbEndpoints
+	index            int
+}
+
+// split returns the endpoints of a locality in another region than the proxy by failover target.
+// The endpoints of a locality belong to a single target, unless some targets select networks and
+// the locality spans several networks.
+func (targets failoverTargets) split(localityEndpoint *endpoint.LocalityLbEndpoints) []failoverSplit {
+	return splitByNetwork(localityEndpoint, func(network string) int {
+		return targets.index(localityEndpoint.Locality, network)
+	})
+}
+
+// splitByNetwork returns the endpoints of a locality grouped by the index of their network. The
+// weight of the locality is split along with its endpoints.
+func splitByNetwork(localityEndpoint *endpoint.LocalityLbEndpoints, index func(network string) int) []failoverSplit {
+	// index -> endpoints, keeping the order of the endpoints
+	var indexes []int
+	byIndex := map[int][]*endpoint.LbEndpoint{}
+	for _, lbEndpoint := range localityEndpoint.LbEndpoints {
+		index := index(endpointNetwork(lbEndpoint))
+		if _, f := byIndex[index]; !f {
+			indexes = append(indexes, index)
+		}
+		byIndex[index] = append(byIndex[index], lbEndpoint)
+	}
+	switch len(indexes) {
+	case 0:
+		return []failoverSplit{{localityEndpoint, index("")}}
+	case 1:
+		return []failoverSplit{{localityEndpoint, indexes[0]}}
+	}
+
+	out := make([]failoverSplit, 0, len(indexes))
+	for _, index := range indexes {
+		clone := *localityEndpoint
+		clone.LbEndpoints = byIndex[index]
+		if localityEndpoint.LoadBalancingWeight != nil {
+			var weight uint32
+			for _, lbEndpoint := range clone.LbEndpoints {
+				if lbEndpoint.LoadBalancingWeight != nil {
+					weight += lbEndpoint.LoadBalancingWeight.Value
+				} else {
+					weight++
+				}
+			}
+			clone.LoadBalancingWeight = &wrappers.UInt32Value{Value: weight}
+		}
+		out = append(out, failoverSplit{&clone, index})
+	}
+	return out
+}
+
+// endpointNetwork returns the network of an endpoint from the Istio endpoint metadata.
+func endpointNetwork(lbEndpoint *endpoint.LbEndpoint) string {
+	istioMetadata := lbEndpoint.GetMetadata().GetFilterMetadata()[util.IstioMetadataKey]
+	return istioMetadata.GetFields()["network"].GetStringValue()
+}
@@ -21,8 +21,10 @@ import (
 
 	apiv2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/api/v2/endpoint"
 	"github.com/golang/protobuf/ptypes/wrappers"
 
+	meshconfig "istio.io/api/mesh/v1alpha1"
 	"istio.io/api/networking/v1alpha3"
 	"istio.io/istio/pilot/pkg/networking/util"
 )
@@ -49,11 +51,19 @@ func GetLocalityLbSetting(
 	return mesh
 }
 
+// ApplyLocalityLBSetting sets the locality weights or the failover priorities of the endpoints in the
+// load assignment, relative to the locality and network of the calling proxy.
+//
+// Besides a region, the To field of a failover setting may hold a network target, "network:<name>",
+// or a cluster target, "cluster:<name>". The mesh networks are used to resolve the networks of the
+// clusters named in failover targets, they may be nil.
 func ApplyLocalityLBSetting(
 	locality *core.Locality,
+	network string,
 	loadAssignment *apiv2.ClusterLoadAssignment,
 	localityLB *v1alpha3.LocalityLoadBalancerSetting,
 	enableFailover bool,
+	meshNetworks *meshconfig.MeshNetworks,
 ) {
 	if locality == nil || loadAssignment == nil {
 		return
@@ -64,7 +74,7 @@ func ApplyLocalityLBSetting(
 		applyLocalityWeight(locality, loadAssignment, localityLB.GetDistribute())
 	} else if enableFailover {
 		// Failover needs outlier detection, otherwise Envoy will never drop down to a lower priority.
-		applyLocalityFailover(locality, loadAssignment, localityLB.GetFailover())
+		applyLocalityFailover(locality, network, loadAssignment, localityLB.GetFailover(), meshNetworks)
 	}
 }
 
@@ -126,34 +136,56 @@ func applyLocalityWeight(
 }
 
 // set locality loadbalancing priority
+//
+// Endpoints in the region of the proxy are prioritized by proximity: same subzone, same zone, then
+// same region. The endpoints in other regions come next. When failover settings apply to the proxy
+// locality, their targets are tried in the order they are declared, and the endpoints matching none
+// of the targets come last. A failover target is either a locality ("region", "region/zone" or
+// "region/zone/subzone"), a network ("network:<name>") or a cluster ("cluster:<name>").
+//
+// Network and cluster targets also apply in the region of the proxy: at each proximity level, the
+// endpoints of the proxy network come first, then the endpoints of the targets in order, then the
+// endpoints of the other networks.
 func applyLocalityFailover(
 	locality *core.Locality,
+	network string,
 	loadAssignment *apiv2.ClusterLoadAssignment,
-	failover []*v1alpha3.LocalityLoadBalancerSetting_Failover) {
-	// key is priority, value is the index of the LocalityLbEndpoints in ClusterLoadAssignment
-	priorityMap := map[int][]int{}
+	failover []*v1alpha3.LocalityLoadBalancerSetting_Failover,
+	meshNetworks *meshconfig.MeshNetworks) {
+	targets := buildFailoverTargets(locality, failover, meshNetworks)
+	networkTargets := targets.hasNetworkTargets()
+	// the priorities of the proximity levels are spaced, to leave room for the failover targets.
+	stride := len(targets) + 2
+
+	// key is priority, value is the LocalityLbEndpoints with this priority
+	priorityMap := map[int][]*endpoint.LocalityLbEndpoints{}
+	localityEndpoints := make([]*endpoint.LocalityLbEndpoints, 0, len(loadAssignment.Endpoints))
 
 	// 1. calculate the LocalityLbEndpoints.Priority compared with proxy locality
-	for i, localityEndpoint := range loadAssignment.Endpoints {
+	for _, localityEndpoint := range loadAssignment.Endpoints {
 		// if region/zone/subZone all match, the priority is 0.
 		// if region/zone match, the priority is 1.
 		// if region matches, the priority is 2.
 		// if locality not match, the priority is 3.
 		priority := util.LbPriority(locality, localityEndpoint.Locality)
-		// region not match, apply failover settings when specified
-		// update localityLbEndpoints' priority to 4 if failover not match
-		if priority == 3 {
-			for _, failoverSetting := range failover {
-				if failoverSetting.From == locality.Region {
-					if localityEndpoint.Locality == nil || localityEndpoint.Locality.Region != failoverSetting.To {
-						priority = 4
-					}
-					break
-				}
-			}
+		splits := []failoverSplit{{localityEndpoint, 0}}
+		switch {
+		case priority == 3 && len(targets) > 0:
+			// region not match, apply failover settings when specified:
+			// the index is the one of the first matching target,
+			// or the number of targets if no target matches.
+			splits = targets.split(localityEndpoint)
+		case priority < 3 && networkTargets:
+			// region matches, order the endpoints by network.
+			splits = splitByNetwork(localityEndpoint, func(endpointNetwork string) int {
+				return targets.networkIndex(endpointNetwork, network)
+			})
+		}
+		for _, split := range splits {
+			key := priority*stride + split.index
+			localityEndpoints = append(localityEndpoints, split.localityEndpoint)
+			priorityMap[key] = append(priorityMap[key], split.localityEndpoint)
 		}
-		loadAssignment.Endpoints[i].Priority = uint32(priority)
-		priorityMap[priority] = append(priorityMap[priority], i)
 	}
 
 	// since Priorities should range from 0 (highest) to N (lowest) without skipping.
@@ -164,15 +196,12 @@ func applyLocalityFailover(
 		priorities = append(priorities, priority)
 	}
 	sort.Ints(priorities)
-	// 2.2 adjust LocalityLbEndpoints priority
-	// if the index and value of priorities array is not equal.
+	// 2.2 set LocalityLbEndpoints priority to the index of their priority in the priorities array.
 	for i, priority := range priorities {
-		if i != priority {
-			// the LocalityLbEndpoints index in ClusterLoadAssignment.Endpoints
-			for _, index := range priorityMap[priority] {
-				loadAssignment.Endpoints[index].Priority = uint32(i)
-			}
+		for _, localityEndpoint := range priorityMap[priority] {
+			localityEndpoint.Priority = uint32(i)
 		}
 	}
 
+	loadAssignment.Endpoints = localityEndpoints
 }
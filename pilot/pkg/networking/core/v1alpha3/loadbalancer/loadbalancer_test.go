@@ -22,6 +22,8 @@ import (
 	envoycore "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	endpoint "github.com/envoyproxy/go-control-plane/envoy/api/v2/endpoint"
 	"github.com/gogo/protobuf/types"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	. "github.com/onsi/gomega"
 
 	meshconfig "istio.io/api/mesh/v1alpha1"
@@ -29,6 +31,7 @@ import (
 
 	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pilot/pkg/networking/core/v1alpha3/fakes"
+	"istio.io/istio/pilot/pkg/networking/util"
 	"istio.io/istio/pkg/config/mesh"
 	"istio.io/istio/pkg/config/protocol"
 	"istio.io/istio/pkg/config/schema/collections"
@@ -66,7 +69,7 @@ func TestApplyLocalitySetting(t *testing.T) {
 			t.Run(tt.name, func(t *testing.T) {
 				env := buildEnvForClustersWithDistribute(tt.distribute)
 				cluster := buildFakeCluster()
-				ApplyLocalityLBSetting(locality, cluster.LoadAssignment, env.Mesh().LocalityLbSetting, true)
+				ApplyLocalityLBSetting(locality, "", cluster.LoadAssignment, env.Mesh().LocalityLbSetting, true, nil)
 				weights := make([]int, 0)
 				for _, localityEndpoint := range cluster.LoadAssignment.Endpoints {
 					weights = append(weights, int(localityEndpoint.LoadBalancingWeight.GetValue()))
@@ -82,7 +85,7 @@ func TestApplyLocalitySetting(t *testing.T) {
 		g := NewGomegaWithT(t)
 		env := buildEnvForClustersWithFailover()
 		cluster := buildFakeCluster()
-		ApplyLocalityLBSetting(locality, cluster.LoadAssignment, env.Mesh().LocalityLbSetting, true)
+		ApplyLocalityLBSetting(locality, "", cluster.LoadAssignment, env.Mesh().LocalityLbSetting, true, nil)
 		for _, localityEndpoint := range cluster.LoadAssignment.Endpoints {
 			if localityEndpoint.Locality.Region == locality.Region {
 				if localityEndpoint.Locality.Zone == locality.Zone {
@@ -108,7 +111,7 @@ func TestApplyLocalitySetting(t *testing.T) {
 		g := NewGomegaWithT(t)
 		env := buildEnvForClustersWithFailover()
 		cluster := buildSmallCluster()
-		ApplyLocalityLBSetting(locality, cluster.LoadAssignment, env.Mesh().LocalityLbSetting, true)
+		ApplyLocalityLBSetting(locality, "", cluster.LoadAssignment, env.Mesh().LocalityLbSetting, true, nil)
 		for _, localityEndpoint := range cluster.LoadAssignment.Endpoints {
 			if localityEndpoint.Locality.Region == locality.Region {
 				if localityEndpoint.Locality.Zone == locality.Zone {
@@ -134,7 +137,7 @@ func TestApplyLocalitySetting(t *testing.T) {
 		g := NewGomegaWithT(t)
 		env := buildEnvForClustersWithFailover()
 		cluster := buildSmallClusterWithNilLocalities()
-		ApplyLocalityLBSetting(locality, cluster.LoadAssignment, env.Mesh().LocalityLbSetting, true)
+		ApplyLocalityLBSetting(locality, "", cluster.LoadAssignment, env.Mesh().LocalityLbSetting, true, nil)
 		for _, localityEndpoint := range cluster.LoadAssignment.Endpoints {
 			if localityEndpoint.Locality == nil {
 				g.Expect(localityEndpoint.Priority).To(Equal(uint32(2)))
@@ -158,6 +161,290 @@ func TestApplyLocalitySetting(t *testing.T) {
 	})
 }
 
+func TestApplyLocalityFailover(t *testing.T) {
+	locality := &envoycore.Locality{
+		Region:  "region1",
+		Zone:    "zone1",
+		SubZone: "subzone1",
+	}
+	meshNetworks := &meshconfig.MeshNetworks{
+		Networks: map[string]*meshconfig.Network{
+			"network2": {
+				Endpoints: []*meshconfig.Network_NetworkEndpoints{
+					{Ne: &meshconfig.Network_NetworkEndpoints_FromRegistry{FromRegistry: "cluster2"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		failover []*networking.LocalityLoadBalancerSetting_Failover
+		cluster  *apiv2.Cluster
+		// expected priorities by locality and network of the endpoints
+		expected map[string]uint32
+	}{
+		{
+			name:    "no failover settings",
+			cluster: buildMultiNetworkCluster(),
+			expected: map[string]uint32{
+				"region1/zone1/subzone1/network1": 0,
+				"region1/zone1/subzone2/network1": 1,
+				"region1/zone2/subzone1/network1": 2,
+				"region2/zone1/subzone1/network1": 3,
+				"region2/zone1/subzone1/network2": 3,
+				"region2/zone2/subzone1/network2": 3,
+				"region3/zone1/subzone1/network1": 3,
+			},
+		},
+		{
+			name: "failover settings for another region",
+			failover: []*networking.LocalityLoadBalancerSetting_Failover{
+				{From: "region4", To: "region3"},
+			},
+			cluster: buildMultiNetworkCluster(),
+			expected: map[string]uint32{
+				"region1/zone1/subzone1/network1": 0,
+				"region1/zone1/subzone2/network1": 1,
+				"region1/zone2/subzone1/network1": 2,
+				"region2/zone1/subzone1/network1": 3,
+				"region2/zone1/subzone1/network2": 3,
+				"region2/zone2/subzone1/network2": 3,
+				"region3/zone1/subzone1/network1": 3,
+			},
+		},
+		{
+			name: "ordered region targets",
+			failover: []*networking.LocalityLoadBalancerSetting_Failover{
+				{From: "region1", To: "region3"},
+				{From: "region1", To: "region2"},
+			},
+			cluster: buildMultiNetworkCluster(),
+			expected: map[string]uint32{
+				"region1/zone1/subzone1/network1": 0,
+				"region1/zone1/subzone2/network1": 1,
+				"region1/zone2/subzone1/network1": 2,
+				"region3/zone1/subzone1/network1": 3,
+				"region2/zone1/subzone1/network1": 4,
+				"region2/zone1/subzone1/network2": 4,
+				"region2/zone2/subzone1/network2": 4,
+			},
+		},
+		{
+			name: "zone targets",
+			failover: []*networking.LocalityLoadBalancerSetting_Failover{
+				{From: "region1/zone1", To: "region2/zone2"},
+				{From: "region1/zone1", To: "region2"},
+			},
+			cluster: buildMultiNetworkCluster(),
+			expected: map[string]uint32{
+				"region1/zone1/subzone1/network1": 0,
+				"region1/zone1/subzone2/network1": 1,
+				"region1/zone2/subzone1/network1": 2,
+				"region2/zone2/subzone1/network2": 3,
+				"region2/zone1/subzone1/network1": 4,
+				"region2/zone1/subzone1/network2": 4,
+				"region3/zone1/subzone1/network1": 5,
+			},
+		},
+		{
+			name: "failover settings for another zone of the proxy region",
+			failover: []*networking.LocalityLoadBalancerSetting_Failover{
+				{From: "region1/zone2", To: "region3"},
+			},
+			cluster: buildMultiNetworkCluster(),
+			expected: map[string]uint32{
+				"region1/zone1/subzone1/network1": 0,
+				"region1/zone1/subzone2/network1": 1,
+				"region1/zone2/subzone1/network1": 2,
+				"region2/zone1/subzone1/network1": 3,
+				"region2/zone1/subzone1/network2": 3,
+				"region2/zone2/subzone1/network2": 3,
+				"region3/zone1/subzone1/network1": 3,
+			},
+		},
+		{
+			name: "network target splits localities",
+			failover: []*networking.LocalityLoadBalancerSetting_Failover{
+				{From: "region1", To: "network:network2"},
+				{From: "region1", To: "region3"},
+			},
+			cluster: buildMultiNetworkCluster(),
+			expected: map[string]uint32{
+				"region1/zone1/subzone1/network1": 0,
+				"region1/zone1/subzone2/network1": 1,
+				"region1/zone2/subzone1/network1": 2,
+				"region2/zone1/subzone1/network2": 3,
+				"region2/zone2/subzone1/network2": 3,
+				"region3/zone1/subzone1/network1": 4,
+				"region2/zone1/subzone1/network1": 5,
+			},
+		},
+		{
+			name: "remote cluster before another region",
+			failover: []*networking.LocalityLoadBalancerSetting_Failover{
+				{From: "region1", To: "cluster:cluster2"},
+				{From: "region1", To: "region2"},
+			},
+			cluster: buildMultiNetworkCluster(),
+			expected: map[string]uint32{
+				"region1/zone1/subzone1/network1": 0,
+				"region1/zone1/subzone2/network1": 1,
+				"region1/zone2/subzone1/network1": 2,
+				"region2/zone1/subzone1/network2": 3,
+				"region2/zone2/subzone1/network2": 3,
+				"region2/zone1/subzone1/network1": 4,
+				"region3/zone1/subzone1/network1": 5,
+			},
+		},
+		{
+			name: "unknown remote cluster",
+			failover: []*networking.LocalityLoadBalancerSetting_Failover{
+				{From: "region1", To: "cluster:cluster3"},
+				{From: "region1", To: "region3"},
+			},
+			cluster: buildMultiNetworkCluster(),
+			expected: map[string]uint32{
+				"region1/zone1/subzone1/network1": 0,
+				"region1/zone1/subzone2/network1": 1,
+				"region1/zone2/subzone1/network1": 2,
+				"region3/zone1/subzone1/network1": 3,
+				"region2/zone1/subzone1/network1": 4,
+				"region2/zone1/subzone1/network2": 4,
+				"region2/zone2/subzone1/network2": 4,
+			},
+		},
+		{
+			name: "region targets keep the proxy region localities whole",
+			failover: []*networking.LocalityLoadBalancerSetting_Failover{
+				{From: "region1", To: "region2"},
+			},
+			cluster: buildSameRegionMultiNetworkCluster(),
+			expected: map[string]uint32{
+				"region1/zone1/subzone1/network1": 0,
+				"region1/zone1/subzone1/network2": 0,
+				"region1/zone1/subzone1/network3": 0,
+				"region1/zone2/subzone1/network1": 1,
+				"region1/zone2/subzone1/network2": 1,
+				"region2/zone1/subzone1/network1": 2,
+				"region2/zone1/subzone1/network2": 2,
+				"region3/zone1/subzone1/network1": 3,
+			},
+		},
+		{
+			name: "network target in the proxy region",
+			failover: []*networking.LocalityLoadBalancerSetting_Failover{
+				{From: "region1", To: "network:network2"},
+				{From: "region1", To: "region2"},
+			},
+			cluster: buildSameRegionMultiNetworkCluster(),
+			expected: map[string]uint32{
+				"region1/zone1/subzone1/network1": 0,
+				"region1/zone1/subzone1/network2": 1,
+				"region1/zone1/subzone1/network3": 2,
+				"region1/zone2/subzone1/network1": 3,
+				"region1/zone2/subzone1/network2": 4,
+				"region2/zone1/subzone1/network2": 5,
+				"region2/zone1/subzone1/network1": 6,
+				"region3/zone1/subzone1/network1": 7,
+			},
+		},
+		{
+			name: "cluster target in the proxy region",
+			failover: []*networking.LocalityLoadBalancerSetting_Failover{
+				{From: "region1", To: "cluster:cluster2"},
+			},
+			cluster: buildSameRegionMultiNetworkCluster(),
+			expected: map[string]uint32{
+				"region1/zone1/subzone1/network1": 0,
+				"region1/zone1/subzone1/network2": 1,
+				"region1/zone1/subzone1/network3": 2,
+				"region1/zone2/subzone1/network1": 3,
+				"region1/zone2/subzone1/network2": 4,
+				"region2/zone1/subzone1/network2": 5,
+				"region2/zone1/subzone1/network1": 6,
+				"region3/zone1/subzone1/network1": 6,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localityLB := &networking.LocalityLoadBalancerSetting{Failover: tt.failover}
+			loadAssignment := tt.cluster.LoadAssignment
+			ApplyLocalityLBSetting(locality, "network1", loadAssignment, localityLB, true, meshNetworks)
+
+			if got := priorities(t, loadAssignment); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Got priorities %v expected %v", got, tt.expected)
+			}
+		})
+	}
+
+	t.Run("unknown proxy network", func(t *testing.T) {
+		localityLB := &networking.LocalityLoadBalancerSetting{
+			Failover: []*networking.LocalityLoadBalancerSetting_Failover{
+				{From: "region1", To: "network:network2"},
+				{From: "region1", To: "region2"},
+			},
+		}
+		loadAssignment := buildSameRegionMultiNetworkCluster().LoadAssignment
+		ApplyLocalityLBSetting(locality, "", loadAssignment, localityLB, true, meshNetworks)
+
+		// the networks matching no target can't be told apart from the one of the proxy.
+		expected := map[string]uint32{
+			"region1/zone1/subzone1/network1": 0,
+			"region1/zone1/subzone1/network2": 1,
+			"region1/zone1/subzone1/network3": 0,
+			"region1/zone2/subzone1/network1": 2,
+			"region1/zone2/subzone1/network2": 3,
+			"region2/zone1/subzone1/network2": 4,
+			"region2/zone1/subzone1/network1": 5,
+			"region3/zone1/subzone1/network1": 6,
+		}
+		if got := priorities(t, loadAssignment); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Got priorities %v expected %v", got, expected)
+		}
+	})
+
+	t.Run("split locality weights", func(t *testing.T) {
+		localityLB := &networking.LocalityLoadBalancerSetting{
+			Failover: []*networking.LocalityLoadBalancerSetting_Failover{
+				{From: "region1", To: "network:network2"},
+			},
+		}
+		loadAssignment := buildMultiNetworkCluster().LoadAssignment
+		ApplyLocalityLBSetting(locality, "network1", loadAssignment, localityLB, true, meshNetworks)
+
+		weights := map[uint32]uint32{}
+		for _, localityEndpoint := range loadAssignment.Endpoints {
+			l := localityEndpoint.Locality
+			if l.Region == "region2" && l.Zone == "zone1" {
+				weights[localityEndpoint.Priority] = localityEndpoint.LoadBalancingWeight.GetValue()
+			}
+		}
+		expected := map[uint32]uint32{3: 2, 4: 1}
+		if !reflect.DeepEqual(weights, expected) {
+			t.Errorf("Got weights by priority %v expected %v", weights, expected)
+		}
+	})
+}
+
+// priorities returns the priorities of the endpoints by locality and network.
+func priorities(t *testing.T, loadAssignment *apiv2.ClusterLoadAssignment) map[string]uint32 {
+	t.Helper()
+	got := map[string]uint32{}
+	for _, localityEndpoint := range loadAssignment.Endpoints {
+		l := localityEndpoint.Locality
+		for _, lbEndpoint := range localityEndpoint.LbEndpoints {
+			key := l.Region + "/" + l.Zone + "/" + l.SubZone + "/" + endpointNetwork(lbEndpoint)
+			if priority, f := got[key]; f && priority != localityEndpoint.Priority {
+				t.Errorf("endpoints of %s have priorities %d and %d", key, priority, localityEndpoint.Priority)
+			}
+			got[key] = localityEndpoint.Priority
+		}
+	}
+	return got
+}
+
 func TestGetLocalityLbSetting(t *testing.T) {
 	// dummy config for test
 	failover := []*networking.LocalityLoadBalancerSetting_Failover{nil}
@@ -440,3 +727,72 @@ func buildSmallClusterWithNilLocalities() *apiv2.Cluster {
 		},
 	}
 }
+
+func buildLbEndpoint(network string, weight uint32) *endpoint.LbEndpoint {
+	return &endpoint.LbEndpoint{
+		LoadBalancingWeight: &wrappers.UInt32Value{Value: weight},
+		Metadata: &envoycore.Metadata{
+			FilterMetadata: map[string]*structpb.Struct{
+				util.IstioMetadataKey: {
+					Fields: map[string]*structpb.Value{
+						"network": {Kind: &structpb.Value_StringValue{StringValue: network}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildLocalityLbEndpoints(region, zone, subzone string, lbEndpoints ...*endpoint.LbEndpoint) *endpoint.LocalityLbEndpoints {
+	var weight uint32
+	for _, lbEndpoint := range lbEndpoints {
+		weight += lbEndpoint.LoadBalancingWeight.GetValue()
+	}
+	return &endpoint.LocalityLbEndpoints{
+		Locality: &envoycore.Locality{
+			Region:  region,
+			Zone:    zone,
+			SubZone: subzone,
+		},
+		LbEndpoints:         lbEndpoints,
+		LoadBalancingWeight: &wrappers.UInt32Value{Value: weight},
+	}
+}
+
+func buildMultiNetworkCluster() *apiv2.Cluster {
+	localityEndpoints := buildLocalityLbEndpoints
+	return &apiv2.Cluster{
+		Name: "outbound|8080||test.example.org",
+		LoadAssignment: &apiv2.ClusterLoadAssignment{
+			ClusterName: "outbound|8080||test.example.org",
+			Endpoints: []*endpoint.LocalityLbEndpoints{
+				localityEndpoints("region1", "zone1", "subzone1", buildLbEndpoint("network1", 1)),
+				localityEndpoints("region1", "zone1", "subzone2", buildLbEndpoint("network1", 1)),
+				localityEndpoints("region1", "zone2", "subzone1", buildLbEndpoint("network1", 1)),
+				localityEndpoints("region2", "zone1", "subzone1",
+					buildLbEndpoint("network1", 1), buildLbEndpoint("network2", 1), buildLbEndpoint("network2", 1)),
+				localityEndpoints("region2", "zone2", "subzone1", buildLbEndpoint("network2", 1)),
+				localityEndpoints("region3", "zone1", "subzone1", buildLbEndpoint("network1", 1)),
+			},
+		},
+	}
+}
+
+// buildSameRegionMultiNetworkCluster builds a cluster with endpoints of several networks in the
+// region of the proxy.
+func buildSameRegionMultiNetworkCluster() *apiv2.Cluster {
+	localityEndpoints := buildLocalityLbEndpoints
+	return &apiv2.Cluster{
+		Name: "outbound|8080||test.example.org",
+		LoadAssignment: &apiv2.ClusterLoadAssignment{
+			ClusterName: "outbound|8080||test.example.org",
+			Endpoints: []*endpoint.LocalityLbEndpoints{
+				localityEndpoints("region1", "zone1", "subzone1",
+					buildLbEndpoint("network1", 1), buildLbEndpoint("network2", 1), buildLbEndpoint("network3", 1)),
+				localityEndpoints("region1", "zone2", "subzone1", buildLbEndpoint("network1", 1), buildLbEndpoint("network2", 1)),
+				localityEndpoints("region2", "zone1", "subzone1", buildLbEndpoint("network1", 1), buildLbEndpoint("network2", 1)),
+				localityEndpoints("region3", "zone1", "subzone1", buildLbEndpoint("network1", 1)),
+			},
+		},
+	}
+}
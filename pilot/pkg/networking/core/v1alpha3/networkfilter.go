@@ -22,6 +22,7 @@ import (
 	listener "github.com/envoyproxy/go-control-plane/envoy/api/v2/listener"
 	accesslogconfig "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v2"
 	accesslog "github.com/envoyproxy/go-control-plane/envoy/config/filter/accesslog/v2"
+	kafka_broker "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/kafka_broker/v2alpha1"
 	mongo_proxy "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/mongo_proxy/v2"
 	mysql_proxy "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/mysql_proxy/v1alpha1"
 	redis_proxy "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/redis_proxy/v2"
@@ -194,6 +195,11 @@ func buildNetworkFiltersStack(_ *model.Proxy, port *model.Port, tcpFilter *liste
 			filterstack = append(filterstack, buildMySQLFilter(statPrefix))
 		}
 		filterstack = append(filterstack, tcpFilter)
+	case protocol.Kafka:
+		if features.EnableKafkaFilter.Get() {
+			filterstack = append(filterstack, buildKafkaBrokerFilter(statPrefix))
+		}
+		filterstack = append(filterstack, tcpFilter)
 	case protocol.Thrift:
 		if features.EnableThriftFilter.Get() {
 			// Thrift filter has route config, it is a terminating filter, no need append tcp filter.
@@ -313,3 +319,17 @@ func buildMySQLFilter(statPrefix string) *listener.Filter {
 
 	return out
 }
+
+// buildKafkaBrokerFilter builds an outbound Envoy KafkaBroker filter.
+func buildKafkaBrokerFilter(statPrefix string) *listener.Filter {
+	kafkaBroker := &kafka_broker.KafkaBroker{
+		StatPrefix: statPrefix, // Kafka stats are prefixed with kafka.<statPrefix> by Envoy.
+	}
+
+	out := &listener.Filter{
+		Name:       util.KafkaBrokerFilter,
+		ConfigType: &listener.Filter_TypedConfig{TypedConfig: util.MessageToAny(kafkaBroker)},
+	}
+
+	return out
+}
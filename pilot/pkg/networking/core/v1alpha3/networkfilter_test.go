@@ -15,9 +15,11 @@
 package v1alpha3
 
 import (
+	"os"
 	"testing"
 
 	listener "github.com/envoyproxy/go-control-plane/envoy/api/v2/listener"
+	kafka_broker "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/kafka_broker/v2alpha1"
 	redis_proxy "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/redis_proxy/v2"
 	tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/config/filter/network/tcp_proxy/v2"
 	xdsutil "github.com/envoyproxy/go-control-plane/pkg/wellknown"
@@ -25,7 +27,9 @@ import (
 	"github.com/golang/protobuf/ptypes"
 
 	networking "istio.io/api/networking/v1alpha3"
+	"istio.io/istio/pilot/pkg/features"
 	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/networking/util"
 	"istio.io/istio/pkg/config/protocol"
 )
 
@@ -53,6 +57,42 @@ func TestBuildRedisFilter(t *testing.T) {
 	}
 }
 
+func TestBuildKafkaBrokerFilter(t *testing.T) {
+	kafkaFilter := buildKafkaBrokerFilter("kafka")
+	if kafkaFilter.Name != util.KafkaBrokerFilter {
+		t.Errorf("kafka filter name is %s not %s", kafkaFilter.Name, util.KafkaBrokerFilter)
+	}
+	if config, ok := kafkaFilter.ConfigType.(*listener.Filter_TypedConfig); ok {
+		kafkaBroker := kafka_broker.KafkaBroker{}
+		if err := ptypes.UnmarshalAny(config.TypedConfig, &kafkaBroker); err != nil {
+			t.Errorf("unmarshal failed: %v", err)
+		}
+		if kafkaBroker.StatPrefix != "kafka" {
+			t.Errorf("kafka broker statPrefix is %s", kafkaBroker.StatPrefix)
+		}
+	} else {
+		t.Errorf("kafka filter type is %T not listener.Filter_TypedConfig ", kafkaFilter.ConfigType)
+	}
+}
+
+func TestBuildNetworkFiltersStackKafka(t *testing.T) {
+	port := &model.Port{Name: "kafka-broker", Port: 9092, Protocol: protocol.Kafka}
+	tcpFilter := &listener.Filter{Name: xdsutil.TCPProxy}
+
+	filters := buildNetworkFiltersStack(nil, port, tcpFilter, "kafka", "kafka-cluster")
+	if len(filters) != 1 || filters[0].Name != xdsutil.TCPProxy {
+		t.Errorf("expected only the tcp_proxy filter when the kafka filter is disabled, got %v", filters)
+	}
+
+	_ = os.Setenv(features.EnableKafkaFilter.Name, "true")
+	defer func() { _ = os.Unsetenv(features.EnableKafkaFilter.Name) }()
+
+	filters = buildNetworkFiltersStack(nil, port, tcpFilter, "kafka", "kafka-cluster")
+	if len(filters) != 2 || filters[0].Name != util.KafkaBrokerFilter || filters[1].Name != xdsutil.TCPProxy {
+		t.Errorf("expected the kafka_broker filter ahead of the tcp_proxy filter, got %v", filters)
+	}
+}
+
 func TestInboundNetworkFilterStatPrefix(t *testing.T) {
 	cases := []struct {
 		name               string
@@ -79,6 +79,15 @@ func TestModelProtocolToListenerProtocol(t *testing.T) {
 			true,
 			ListenerProtocolTCP,
 		},
+		{
+			"Kafka to TCP",
+			proxy,
+			protocol.Kafka,
+			core.TrafficDirection_INBOUND,
+			true,
+			true,
+			ListenerProtocolTCP,
+		},
 		{
 			"Inbound unknown to Auto",
 			proxy,
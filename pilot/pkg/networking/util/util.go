@@ -70,6 +70,8 @@ const (
 
 	// SniClusterFilter is the name of the sni_cluster envoy filter
 	SniClusterFilter = "envoy.filters.network.sni_cluster"
+	// KafkaBrokerFilter is the name of the kafka_broker envoy filter
+	KafkaBrokerFilter = "envoy.filters.network.kafka_broker"
 	// ForwardDownstreamSniFilter forwards the sni from downstream connections to upstream
 	// Used only in the fallthrough filter stack for TLS connections
 	ForwardDownstreamSniFilter = "forward_downstream_sni"
@@ -15,6 +15,7 @@
 package v2
 
 import (
+	"context"
 	"errors"
 	"io"
 	"sync"
@@ -91,6 +92,14 @@ type XdsConnection struct {
 	// Both ADS and EDS streams implement this interface
 	stream DiscoveryStream
 
+	// deltaStream is set instead of stream for connections using incremental (delta) ADS.
+	deltaStream DeltaDiscoveryStream
+
+	// ResourceVersions holds, for delta connections, the version of each resource known
+	// to the client, keyed by type URL and resource name. Only resources whose version
+	// differs are sent on a push.
+	ResourceVersions map[string]map[string]string `json:"-"`
+
 	// Routes is the list of watched Routes.
 	Routes []string
 
@@ -498,11 +507,6 @@ func (s *DiscoveryServer) setProxyState(proxy *model.Proxy, push *model.PushCont
 	return nil
 }
 
-// DeltaAggregatedResources is not implemented.
-func (s *DiscoveryServer) DeltaAggregatedResources(stream ads.AggregatedDiscoveryService_DeltaAggregatedResourcesServer) error {
-	return status.Errorf(codes.Unimplemented, "not implemented")
-}
-
 // Compute and send the new configuration for a connection. This is blocking and may be slow
 // for large configs. The method will hold a lock on con.pushMutex.
 func (s *DiscoveryServer) pushConnection(con *XdsConnection, pushEv *XdsEvent) error {
@@ -705,13 +709,43 @@ func (s *DiscoveryServer) removeCon(conID string, con *XdsConnection) {
 	xdsClients.Record(float64(len(s.adsClients)))
 }
 
+// streamContext returns the context of the underlying gRPC stream, which is cancelled when
+// the client goes away.
+func (conn *XdsConnection) streamContext() context.Context {
+	if conn.deltaStream != nil {
+		return conn.deltaStream.Context()
+	}
+	return conn.stream.Context()
+}
+
 // Send with timeout
 func (conn *XdsConnection) send(res *xdsapi.DiscoveryResponse) error {
+	return conn.sendResponse(res, true)
+}
+
+// sendPartial sends a response holding only some of the watched resources of its type,
+// for example an incremental EDS push. On delta connections the resources missing from
+// the response are not reported as removed.
+func (conn *XdsConnection) sendPartial(res *xdsapi.DiscoveryResponse) error {
+	return conn.sendResponse(res, false)
+}
+
+func (conn *XdsConnection) sendResponse(res *xdsapi.DiscoveryResponse, complete bool) error {
+	sendFn := func() error { return conn.stream.Send(res) }
+	if conn.deltaStream != nil {
+		delta := conn.deltaResponse(res, complete)
+		if delta == nil {
+			// Nothing changed since the last push of this type.
+			return nil
+		}
+		sendFn = func() error { return conn.deltaStream.Send(delta) }
+	}
+
 	done := make(chan error, 1)
 	// hardcoded for now - not sure if we need a setting
 	t := time.NewTimer(SendTimeout)
 	go func() {
-		err := conn.stream.Send(res)
+		err := sendFn()
 		conn.mu.Lock()
 		if res.Nonce != "" {
 			switch res.TypeUrl {
@@ -147,7 +147,7 @@ func BenchmarkEDS(b *testing.B) {
 					clonedCLA := util.CloneClusterLoadAssignment(l)
 					l = &clonedCLA
 
-					loadbalancer.ApplyLocalityLBSetting(proxy.Locality, l, s.Env.Mesh().LocalityLbSetting, true)
+					loadbalancer.ApplyLocalityLBSetting(proxy.Locality, proxy.Metadata.Network, l, s.Env.Mesh().LocalityLbSetting, true, nil)
 					loadAssignments = append(loadAssignments, l)
 				}
 				response = endpointDiscoveryResponse(loadAssignments, version, push.Version)
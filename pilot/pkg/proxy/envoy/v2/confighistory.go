@@ -0,0 +1,201 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config/schema/collection"
+)
+
+// ConfigHistoryEntry describes a config version, identified by the ledger RootHash, and the
+// configs that changed since the previous recorded version.
+type ConfigHistoryEntry struct {
+	Version string         `json:"version"`
+	Time    time.Time      `json:"time"`
+	Changes []ConfigChange `json:"changes,omitempty"`
+}
+
+// ConfigChange is a single config change between two recorded versions.
+type ConfigChange struct {
+	// Key is the ledger key of the config, in the form kind/namespace/name.
+	Key string `json:"key"`
+	// Event is one of add, update or delete.
+	Event string `json:"event"`
+	// ResourceVersion is the resource version of the config after the change. It is empty for deletes.
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type configSnapshot struct {
+	ConfigHistoryEntry
+	configs map[string]model.Config
+}
+
+// ConfigHistory keeps a bounded list of config snapshots, keyed by the version of the config store.
+// Since the ledger only retains the resource version of each config, the snapshots are what allows
+// retrieving the content of a config at a previous version.
+type ConfigHistory struct {
+	mutex     sync.RWMutex
+	size      int
+	snapshots []*configSnapshot
+
+	// notify wakes up Run. Notifications received while a snapshot is taken are coalesced.
+	notify chan struct{}
+}
+
+// NewConfigHistory creates a ConfigHistory which retains at most size versions.
+func NewConfigHistory(size int) *ConfigHistory {
+	return &ConfigHistory{size: size, notify: make(chan struct{}, 1)}
+}
+
+// Notify signals that the config may have changed. It does not block: the snapshot is taken
+// asynchronously by Run, so that listing the configs stays off the push path.
+func (h *ConfigHistory) Notify() {
+	if h == nil {
+		return
+	}
+	select {
+	case h.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run records a snapshot of the store after each Notify, until stop is closed.
+func (h *ConfigHistory) Run(store model.ConfigStore, stop <-chan struct{}) {
+	for {
+		select {
+		case <-h.notify:
+			h.Record(store)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Record takes a snapshot of the configs in the store, if the store version changed since the
+// last recorded snapshot. Stores without a ledger report an empty version and are ignored.
+func (h *ConfigHistory) Record(store model.ConfigStore) {
+	if h == nil || h.size <= 0 || store == nil {
+		return
+	}
+	version := store.Version()
+	if version == "" {
+		return
+	}
+
+	h.mutex.RLock()
+	var last *configSnapshot
+	if len(h.snapshots) > 0 {
+		last = h.snapshots[len(h.snapshots)-1]
+	}
+	h.mutex.RUnlock()
+	if last != nil && last.Version == version {
+		return
+	}
+
+	configs := map[string]model.Config{}
+	store.Schemas().ForEach(func(schema collection.Schema) bool {
+		cfgs, err := store.List(schema.Resource().GroupVersionKind(), model.NamespaceAll)
+		if err != nil {
+			adsLog.Warnf("Config history: failed to list %s: %v", schema.Resource().Kind(), err)
+			return false
+		}
+		for _, cfg := range cfgs {
+			configs[cfg.Key()] = cfg
+		}
+		return false
+	})
+
+	snapshot := &configSnapshot{
+		ConfigHistoryEntry: ConfigHistoryEntry{
+			Version: version,
+			Time:    time.Now(),
+		},
+		configs: configs,
+	}
+	var previous map[string]model.Config
+	if last != nil {
+		previous = last.configs
+	}
+	snapshot.Changes = diffConfigs(previous, configs)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.snapshots = append(h.snapshots, snapshot)
+	if len(h.snapshots) > h.size {
+		h.snapshots = h.snapshots[len(h.snapshots)-h.size:]
+	}
+}
+
+// Versions returns the recorded versions, oldest first.
+func (h *ConfigHistory) Versions() []ConfigHistoryEntry {
+	if h == nil {
+		return nil
+	}
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	out := make([]ConfigHistoryEntry, 0, len(h.snapshots))
+	for _, s := range h.snapshots {
+		out = append(out, s.ConfigHistoryEntry)
+	}
+	return out
+}
+
+// GetAtVersion returns the config with the given key as it was at version. A nil config is returned
+// if the config did not exist at that version, and an error if the version is not known.
+func (h *ConfigHistory) GetAtVersion(version, key string) (*model.Config, error) {
+	if h == nil {
+		return nil, fmt.Errorf("config history is disabled")
+	}
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	for _, s := range h.snapshots {
+		if s.Version != version {
+			continue
+		}
+		cfg, f := s.configs[key]
+		if !f {
+			return nil, nil
+		}
+		return &cfg, nil
+	}
+	return nil, fmt.Errorf("version %s not found in config history", version)
+}
+
+func diffConfigs(previous, current map[string]model.Config) []ConfigChange {
+	var changes []ConfigChange
+	for key, cfg := range current {
+		old, f := previous[key]
+		switch {
+		case !f:
+			changes = append(changes, ConfigChange{Key: key, Event: model.EventAdd.String(), ResourceVersion: cfg.ResourceVersion})
+		case old.ResourceVersion != cfg.ResourceVersion:
+			changes = append(changes, ConfigChange{Key: key, Event: model.EventUpdate.String(), ResourceVersion: cfg.ResourceVersion})
+		}
+	}
+	for key := range previous {
+		if _, f := current[key]; !f {
+			changes = append(changes, ConfigChange{Key: key, Event: model.EventDelete.String()})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
@@ -0,0 +1,170 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	networking "istio.io/api/networking/v1alpha3"
+	"istio.io/pkg/ledger"
+
+	"istio.io/istio/pilot/pkg/config/memory"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config/schema/collections"
+)
+
+// countingLedger changes its RootHash on every write, regardless of the key and value.
+type countingLedger struct {
+	ledger.Ledger
+	writes int
+}
+
+func (l *countingLedger) Put(key, value string) (string, error) {
+	l.writes++
+	return l.RootHash(), nil
+}
+
+func (l *countingLedger) Delete(key string) error {
+	l.writes++
+	return nil
+}
+
+func (l *countingLedger) RootHash() string {
+	return strconv.Itoa(l.writes)
+}
+
+func virtualService(name string, hosts ...string) model.Config {
+	schema := collections.IstioNetworkingV1Alpha3Virtualservices.Resource()
+	return model.Config{
+		ConfigMeta: model.ConfigMeta{
+			Type:      schema.Kind(),
+			Group:     schema.Group(),
+			Version:   schema.Version(),
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: &networking.VirtualService{
+			Hosts: hosts,
+			Http: []*networking.HTTPRoute{{
+				Route: []*networking.HTTPRouteDestination{{
+					Destination: &networking.Destination{Host: "reviews.default.svc.cluster.local"},
+				}},
+			}},
+		},
+	}
+}
+
+func changedKeys(entry ConfigHistoryEntry) map[string]string {
+	out := map[string]string{}
+	for _, c := range entry.Changes {
+		out[c.Key] = c.Event
+	}
+	return out
+}
+
+func TestConfigHistory(t *testing.T) {
+	store := memory.MakeWithLedger(collections.Pilot, &countingLedger{})
+	h := NewConfigHistory(2)
+
+	fooKey := model.Key("VirtualService", "foo", "default")
+	barKey := model.Key("VirtualService", "bar", "default")
+
+	if _, err := store.Create(virtualService("foo", "a.example.com")); err != nil {
+		t.Fatal(err)
+	}
+	h.Record(store)
+	first := store.Version()
+
+	// Recording again without a change must not add a version.
+	h.Record(store)
+	if got := len(h.Versions()); got != 1 {
+		t.Fatalf("expected 1 version, got %d", got)
+	}
+
+	if _, err := store.Create(virtualService("bar", "b.example.com")); err != nil {
+		t.Fatal(err)
+	}
+	h.Record(store)
+
+	foo := store.Get(collections.IstioNetworkingV1Alpha3Virtualservices.Resource().GroupVersionKind(), "foo", "default")
+	updated := virtualService("foo", "c.example.com")
+	updated.ResourceVersion = foo.ResourceVersion
+	if _, err := store.Update(updated); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete(collections.IstioNetworkingV1Alpha3Virtualservices.Resource().GroupVersionKind(), "bar", "default"); err != nil {
+		t.Fatal(err)
+	}
+	h.Record(store)
+	last := store.Version()
+
+	versions := h.Versions()
+	if len(versions) != 2 {
+		t.Fatalf("expected the history to be limited to 2 versions, got %d", len(versions))
+	}
+	if want := map[string]string{barKey: "add"}; !reflect.DeepEqual(changedKeys(versions[0]), want) {
+		t.Errorf("got changes %v, want %v", changedKeys(versions[0]), want)
+	}
+	if want := map[string]string{fooKey: "update", barKey: "delete"}; !reflect.DeepEqual(changedKeys(versions[1]), want) {
+		t.Errorf("got changes %v, want %v", changedKeys(versions[1]), want)
+	}
+
+	cfg, err := h.GetAtVersion(versions[0].Version, fooKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hosts := cfg.Spec.(*networking.VirtualService).Hosts; !reflect.DeepEqual(hosts, []string{"a.example.com"}) {
+		t.Errorf("got hosts %v at version %s, want the original hosts", hosts, versions[0].Version)
+	}
+	cfg, err = h.GetAtVersion(last, fooKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hosts := cfg.Spec.(*networking.VirtualService).Hosts; !reflect.DeepEqual(hosts, []string{"c.example.com"}) {
+		t.Errorf("got hosts %v at version %s, want the updated hosts", hosts, last)
+	}
+	if cfg, err := h.GetAtVersion(last, barKey); err != nil || cfg != nil {
+		t.Errorf("expected deleted config to be missing at version %s, got %v, %v", last, cfg, err)
+	}
+	if _, err := h.GetAtVersion(first, fooKey); err == nil {
+		t.Errorf("expected version %s to be evicted from the history", first)
+	}
+}
+
+func TestConfigHistoryRun(t *testing.T) {
+	store := memory.MakeWithLedger(collections.Pilot, &countingLedger{})
+	h := NewConfigHistory(2)
+	stop := make(chan struct{})
+	defer close(stop)
+	go h.Run(store, stop)
+
+	if _, err := store.Create(virtualService("foo", "a.example.com")); err != nil {
+		t.Fatal(err)
+	}
+	h.Notify()
+
+	for i := 0; len(h.Versions()) != 1; i++ {
+		if i == 100 {
+			t.Fatal("expected a version to be recorded after Notify")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := h.Versions()[0].Version; got != store.Version() {
+		t.Errorf("got version %s, want %s", got, store.Version())
+	}
+}
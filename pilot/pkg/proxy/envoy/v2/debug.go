@@ -37,6 +37,7 @@ import (
 	authn "istio.io/api/authentication/v1alpha1"
 	networking "istio.io/api/networking/v1alpha3"
 
+	"istio.io/istio/pilot/pkg/config/kube/crd"
 	"istio.io/istio/pilot/pkg/model"
 	networking_core "istio.io/istio/pilot/pkg/networking/core/v1alpha3"
 	"istio.io/istio/pilot/pkg/networking/util"
@@ -131,6 +132,7 @@ func (s *DiscoveryServer) InitDebug(mux *http.ServeMux, sctl *aggregate.Controll
 
 	s.addDebugHandler(mux, "/debug/syncz", "Synchronization status of all Envoys connected to this Pilot instance", s.Syncz)
 	s.addDebugHandler(mux, "/debug/config_distribution", "Version status of all Envoys connected to this Pilot instance", s.distributedVersions)
+	s.addDebugHandler(mux, "/debug/config_history", "Previous config versions and the configs changed by each of them", s.configHistory)
 
 	s.addDebugHandler(mux, "/debug/registryz", "Debug support for registry", s.registryz)
 	s.addDebugHandler(mux, "/debug/endpointz", "Debug support for endpoints", s.endpointz)
@@ -332,6 +334,61 @@ func (s *DiscoveryServer) distributedVersions(w http.ResponseWriter, req *http.R
 	}
 }
 
+// configHistory lists the recorded config versions, or returns a single config in its Kubernetes
+// form as it was at a version if the 'version' and 'resource' querystring parameters are set.
+func (s *DiscoveryServer) configHistory(w http.ResponseWriter, req *http.Request) {
+	if !features.EnableDistributionTracking || s.ConfigHistory == nil {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = fmt.Fprint(w, "Pilot Version tracking is disabled.  Please set the "+
+			"PILOT_ENABLE_CONFIG_DISTRIBUTION_TRACKING environment variable to true to enable.")
+		return
+	}
+	var result interface{}
+	var err error
+	version, resourceID := req.URL.Query().Get("version"), req.URL.Query().Get("resource")
+	switch {
+	case version == "" && resourceID == "":
+		result = s.ConfigHistory.Versions()
+	case version == "" || resourceID == "":
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = fmt.Fprintf(w, "querystring parameters 'version' and 'resource' must be set together")
+		return
+	default:
+		var cfg *model.Config
+		cfg, err = s.ConfigHistory.GetAtVersion(version, resourceID)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprintf(w, "%v", err)
+			return
+		}
+		if cfg == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprintf(w, "resource %s does not exist at version %s", resourceID, version)
+			return
+		}
+		schema, f := s.Env.IstioConfigStore.Schemas().FindByGroupVersionKind(cfg.GroupVersionKind())
+		if !f {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = fmt.Fprintf(w, "unknown config type %v", cfg.GroupVersionKind())
+			return
+		}
+		if result, err = crd.ConvertConfig(schema, *cfg); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = fmt.Fprintf(w, "unable to convert %s: %v", resourceID, err)
+			return
+		}
+	}
+
+	out, err := json.MarshalIndent(result, "", "    ")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = fmt.Fprintf(w, "unable to marshal config history: %v", err)
+		return
+	}
+	w.Header().Add("Content-Type", "application/json")
+	_, _ = w.Write(out)
+}
+
 // The Config Version is only used as the nonce prefix, but we can reconstruct it because is is a
 // b64 encoding of a 64 bit array, which will always be 12 chars in length.
 // len = ceil(bitlength/(2^6))+1
@@ -0,0 +1,391 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"time"
+
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	ads "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"istio.io/pkg/monitoring"
+
+	"istio.io/istio/pilot/pkg/util/sets"
+)
+
+// DeltaDiscoveryStream is the server side of an incremental (delta) ADS stream.
+type DeltaDiscoveryStream interface {
+	Send(*xdsapi.DeltaDiscoveryResponse) error
+	Recv() (*xdsapi.DeltaDiscoveryRequest, error)
+	grpc.ServerStream
+}
+
+func newDeltaXdsConnection(peerAddr string, stream DeltaDiscoveryStream) *XdsConnection {
+	return &XdsConnection{
+		pushChannel:      make(chan *XdsEvent),
+		PeerAddr:         peerAddr,
+		Clusters:         []string{},
+		Connect:          time.Now(),
+		deltaStream:      stream,
+		LDSListeners:     []*xdsapi.Listener{},
+		RouteConfigs:     map[string]*xdsapi.RouteConfiguration{},
+		ResourceVersions: map[string]map[string]string{},
+	}
+}
+
+func receiveDeltaThread(con *XdsConnection, reqChannel chan *xdsapi.DeltaDiscoveryRequest, errP *error) {
+	defer close(reqChannel) // indicates close of the remote side.
+	for {
+		req, err := con.deltaStream.Recv()
+		if err != nil {
+			if isExpectedGRPCError(err) {
+				con.mu.RLock()
+				adsLog.Infof("ADS:DELTA: %q %s terminated %v", con.PeerAddr, con.ConID, err)
+				con.mu.RUnlock()
+				return
+			}
+			*errP = err
+			adsLog.Errorf("ADS:DELTA: %q %s terminated with error: %v", con.PeerAddr, con.ConID, err)
+			totalXDSInternalErrors.Increment()
+			return
+		}
+		select {
+		case reqChannel <- req:
+		case <-con.deltaStream.Context().Done():
+			adsLog.Infof("ADS:DELTA: %q %s terminated with stream closed", con.PeerAddr, con.ConID)
+			return
+		}
+	}
+}
+
+// DeltaAggregatedResources implements the incremental variant of ADS. Pushes are computed the
+// same way as for StreamAggregatedResources, but only resources that were added, changed or
+// removed since the last response of the same type are sent to the client.
+func (s *DiscoveryServer) DeltaAggregatedResources(stream ads.AggregatedDiscoveryService_DeltaAggregatedResourcesServer) error {
+	peerInfo, ok := peer.FromContext(stream.Context())
+	peerAddr := "0.0.0.0"
+	if ok {
+		peerAddr = peerInfo.Addr.String()
+	}
+
+	// See StreamAggregatedResources - lazy loading, in tests.
+	err := s.globalPushContext().InitContext(s.Env, nil, nil)
+	if err != nil {
+		adsLog.Warnf("Error reading config %v", err)
+		return err
+	}
+	con := newDeltaXdsConnection(peerAddr, stream)
+
+	var receiveError error
+	reqChannel := make(chan *xdsapi.DeltaDiscoveryRequest, 1)
+	go receiveDeltaThread(con, reqChannel, &receiveError)
+
+	for {
+		select {
+		case req, ok := <-reqChannel:
+			if !ok {
+				// Remote side closed connection.
+				return receiveError
+			}
+			// The node is only required on the first request of the stream.
+			if req.Node != nil && req.Node.Id != "" {
+				if cancel, err := s.initConnection(req.Node, con); err != nil {
+					return err
+				} else if cancel != nil {
+					defer cancel()
+				}
+			}
+			if con.node == nil {
+				return status.Errorf(codes.InvalidArgument, "missing node in first delta request")
+			}
+
+			if err := s.processDeltaRequest(con, req); err != nil {
+				return err
+			}
+
+		case pushEv := <-con.pushChannel:
+			// Full and incremental pushes reuse the state of the world code path, the
+			// responses are reduced to the changed resources in XdsConnection.send.
+			err := s.pushConnection(con, pushEv)
+			pushEv.done()
+			if err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// processDeltaRequest handles a single request on a delta stream: an ACK/NACK, the initial
+// wildcard watch for CDS and LDS, or a change in the RDS/EDS subscriptions.
+func (s *DiscoveryServer) processDeltaRequest(con *XdsConnection, req *xdsapi.DeltaDiscoveryRequest) error {
+	if req.ErrorDetail != nil {
+		errCode := codes.Code(req.ErrorDetail.Code)
+		adsLog.Warnf("ADS:DELTA: ACK ERROR %v %s %s %s:%s", con.PeerAddr, con.ConID, req.TypeUrl,
+			errCode.String(), req.ErrorDetail.GetMessage())
+		if metric := rejectMetric(req.TypeUrl); metric != nil {
+			incrementXDSRejects(metric, con.node.ID, errCode.String())
+		}
+		// The client kept its previous resources, make sure the next push resends them.
+		con.resetResourceVersions(req.TypeUrl)
+		return nil
+	}
+
+	con.mu.Lock()
+	versions := con.ResourceVersions[req.TypeUrl]
+	if versions == nil {
+		versions = map[string]string{}
+		con.ResourceVersions[req.TypeUrl] = versions
+	}
+	// On reconnect the client reports what it already has, so unchanged resources are not resent.
+	for name, version := range req.InitialResourceVersions {
+		versions[name] = version
+	}
+	for _, name := range req.ResourceNamesUnsubscribe {
+		delete(versions, name)
+	}
+	con.mu.Unlock()
+
+	switch req.TypeUrl {
+	case ClusterType:
+		if con.CDSWatch {
+			s.ackDelta(con, req)
+			return nil
+		}
+		adsLog.Infof("ADS:DELTA:CDS: REQ %v %s", con.PeerAddr, con.ConID)
+		con.CDSWatch = true
+		return s.pushCds(con, s.globalPushContext(), versionInfo())
+
+	case ListenerType:
+		if con.LDSWatch {
+			s.ackDelta(con, req)
+			return nil
+		}
+		adsLog.Debugf("ADS:DELTA:LDS: REQ %s %v", con.ConID, con.PeerAddr)
+		con.LDSWatch = true
+		return s.pushLds(con, s.globalPushContext(), versionInfo())
+
+	case RouteType:
+		routes, subscribed, _ := applyDeltaSubscriptions(con.Routes, req)
+		con.Routes = routes
+		if len(subscribed) == 0 {
+			s.ackDelta(con, req)
+			return nil
+		}
+		adsLog.Debugf("ADS:DELTA:RDS: REQ %s %s routes:%d", con.PeerAddr, con.ConID, len(con.Routes))
+		return s.pushRoute(con, s.globalPushContext(), versionInfo())
+
+	case EndpointType:
+		clusters, subscribed, unsubscribed := applyDeltaSubscriptions(con.Clusters, req)
+		s.updateEdsClients(subscribed, unsubscribed, con)
+		con.Clusters = clusters
+		if len(subscribed) == 0 {
+			s.ackDelta(con, req)
+			return nil
+		}
+		adsLog.Debugf("ADS:DELTA:EDS: REQ %s %s clusters:%d", con.PeerAddr, con.ConID, len(con.Clusters))
+		return s.pushEds(s.globalPushContext(), con, versionInfo(), nil)
+
+	default:
+		adsLog.Warnf("ADS:DELTA: Unknown watched resources %s", req.String())
+	}
+	return nil
+}
+
+// ackDelta records the nonce of an accepted delta response.
+func (s *DiscoveryServer) ackDelta(con *XdsConnection, req *xdsapi.DeltaDiscoveryRequest) {
+	if req.ResponseNonce == "" {
+		return
+	}
+	adsLog.Debugf("ADS:DELTA: ACK %s %s %s %s", con.PeerAddr, con.ConID, req.TypeUrl, req.ResponseNonce)
+	con.mu.Lock()
+	defer con.mu.Unlock()
+	switch req.TypeUrl {
+	case ClusterType:
+		con.ClusterNonceAcked = req.ResponseNonce
+	case ListenerType:
+		con.ListenerNonceAcked = req.ResponseNonce
+	case RouteType:
+		con.RouteNonceAcked = req.ResponseNonce
+	case EndpointType:
+		con.EndpointNonceAcked = req.ResponseNonce
+	}
+}
+
+// applyDeltaSubscriptions returns the watched resource names after applying the subscribe and
+// unsubscribe lists of req, along with the names that were added to and removed from the watch.
+func applyDeltaSubscriptions(current []string, req *xdsapi.DeltaDiscoveryRequest) ([]string, sets.Set, sets.Set) {
+	previous := sets.NewSet(current...)
+	watched := sets.NewSet(current...).Insert(req.ResourceNamesSubscribe...)
+	for _, name := range req.ResourceNamesUnsubscribe {
+		delete(watched, name)
+	}
+	names := watched.UnsortedList()
+	sort.Strings(names)
+	return names, watched.Difference(previous), previous.Difference(watched)
+}
+
+func rejectMetric(typeURL string) monitoring.Metric {
+	switch typeURL {
+	case ClusterType:
+		return cdsReject
+	case ListenerType:
+		return ldsReject
+	case RouteType:
+		return rdsReject
+	case EndpointType:
+		return edsReject
+	}
+	return nil
+}
+
+// resetResourceVersions marks all known resources of a type as out of date, without forgetting
+// them, so they are all sent on the next push and removals are still detected.
+func (conn *XdsConnection) resetResourceVersions(typeURL string) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	for name := range conn.ResourceVersions[typeURL] {
+		conn.ResourceVersions[typeURL][name] = ""
+	}
+}
+
+// deltaResponse converts a state of the world response into a delta response holding only the
+// resources the client does not have yet. If complete is set the response is expected to hold
+// all watched resources of its type, and known resources missing from it are reported as
+// removed. Returns nil if there is nothing to send.
+func (conn *XdsConnection) deltaResponse(res *xdsapi.DiscoveryResponse, complete bool) *xdsapi.DeltaDiscoveryResponse {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	known := conn.ResourceVersions[res.TypeUrl]
+	if known == nil {
+		known = map[string]string{}
+		conn.ResourceVersions[res.TypeUrl] = known
+	}
+
+	out := &xdsapi.DeltaDiscoveryResponse{
+		TypeUrl:           res.TypeUrl,
+		SystemVersionInfo: res.VersionInfo,
+		Nonce:             res.Nonce,
+	}
+	current := make(map[string]struct{}, len(res.Resources))
+	for _, r := range res.Resources {
+		if r == nil {
+			continue
+		}
+		name, err := resourceName(r)
+		if err != nil {
+			adsLog.Errorf("ADS:DELTA: failed to read name of %s for %s: %v", res.TypeUrl, conn.ConID, err)
+			totalXDSInternalErrors.Increment()
+			continue
+		}
+		version, err := resourceVersion(r)
+		if err != nil {
+			adsLog.Errorf("ADS:DELTA: failed to compute version of %s %s for %s: %v", res.TypeUrl, name, conn.ConID, err)
+			totalXDSInternalErrors.Increment()
+			continue
+		}
+		current[name] = struct{}{}
+		if known[name] == version {
+			continue
+		}
+		known[name] = version
+		out.Resources = append(out.Resources, &xdsapi.Resource{
+			Name:     name,
+			Version:  version,
+			Resource: r,
+		})
+	}
+	if complete {
+		for name := range known {
+			if _, f := current[name]; !f {
+				out.RemovedResources = append(out.RemovedResources, name)
+				delete(known, name)
+			}
+		}
+		sort.Strings(out.RemovedResources)
+	}
+
+	// The first response of each type is always sent, even if empty, so the client can
+	// finish its initial fetch.
+	if len(out.Resources) == 0 && len(out.RemovedResources) == 0 && conn.nonceSent(res.TypeUrl) != "" {
+		return nil
+	}
+	return out
+}
+
+// nonceSent returns the last nonce sent for a type. Must be called with conn.mu held.
+func (conn *XdsConnection) nonceSent(typeURL string) string {
+	switch typeURL {
+	case ClusterType:
+		return conn.ClusterNonceSent
+	case ListenerType:
+		return conn.ListenerNonceSent
+	case RouteType:
+		return conn.RouteNonceSent
+	case EndpointType:
+		return conn.EndpointNonceSent
+	}
+	return ""
+}
+
+// namedResource decodes only the name of a Cluster, Listener, RouteConfiguration or
+// ClusterLoadAssignment - all of them hold it in field 1 - and skips the rest of the message.
+type namedResource struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3"`
+}
+
+func (m *namedResource) Reset()         { *m = namedResource{} }
+func (m *namedResource) String() string { return proto.CompactTextString(m) }
+func (*namedResource) ProtoMessage()    {}
+
+func resourceName(r *any.Any) (string, error) {
+	n := &namedResource{}
+	if err := proto.Unmarshal(r.Value, n); err != nil {
+		return "", err
+	}
+	if n.Name == "" {
+		return "", fmt.Errorf("resource has no name")
+	}
+	return n.Name, nil
+}
+
+// resourceVersion returns a version derived from the serialized resource. The resource is
+// marshaled again deterministically, so the version only changes when the content does, whichever
+// way the resource was serialized.
+func resourceVersion(r *any.Any) (string, error) {
+	var msg ptypes.DynamicAny
+	if err := ptypes.UnmarshalAny(r, &msg); err != nil {
+		return "", err
+	}
+	b := proto.NewBuffer(nil)
+	b.SetDeterministic(true)
+	if err := b.Marshal(msg.Message); err != nil {
+		return "", err
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(b.Bytes())
+	return strconv.FormatUint(h.Sum64(), 16), nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"fmt"
+	"testing"
+
+	xdsapi "github.com/envoyproxy/go-control-plane/envoy/api/v2"
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+
+	"istio.io/istio/pilot/pkg/networking/util"
+)
+
+func TestResourceVersion(t *testing.T) {
+	cluster := &xdsapi.Cluster{
+		Name:     "outbound|80||a.default.svc.cluster.local",
+		Metadata: &core.Metadata{FilterMetadata: map[string]*structpb.Struct{}},
+	}
+	for i := 0; i < 10; i++ {
+		cluster.Metadata.FilterMetadata[fmt.Sprintf("filter%d", i)] = &structpb.Struct{}
+	}
+	want, err := resourceVersion(util.MessageToAny(cluster))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Map entries are marshaled in random order unless the marshaling is deterministic.
+	for i := 0; i < 10; i++ {
+		r, err := ptypes.MarshalAny(cluster)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := resourceVersion(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("resourceVersion() = %s, want %s", got, want)
+		}
+	}
+
+	cluster.Metadata.FilterMetadata["filter10"] = &structpb.Struct{}
+	got, err := resourceVersion(util.MessageToAny(cluster))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == want {
+		t.Errorf("resourceVersion() = %s did not change with the resource", got)
+	}
+}
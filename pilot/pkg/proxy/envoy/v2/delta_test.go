@@ -0,0 +1,104 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package v2_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"istio.io/istio/pilot/pkg/bootstrap"
+	"istio.io/istio/pilot/pkg/model"
+	v2 "istio.io/istio/pilot/pkg/proxy/envoy/v2"
+	"istio.io/istio/pkg/adsc"
+	"istio.io/istio/pkg/config/host"
+	"istio.io/istio/tests/util"
+)
+
+const (
+	deltaSvc     = "delta.test.svc.cluster.local"
+	deltaVip     = "10.10.1.3"
+	deltaCluster = "outbound|8080||delta.test.svc.cluster.local"
+)
+
+func TestDeltaAds(t *testing.T) {
+	server, tearDown := localPilotTestEnv(t, func(server *bootstrap.Server) {
+		server.EnvoyXdsServer.MemRegistry.AddHTTPService(deltaSvc, deltaVip, 8080)
+		server.EnvoyXdsServer.MemRegistry.SetEndpoints(deltaSvc, "",
+			newEndpointWithAccount("127.0.0.1", "hello-sa", "v1"))
+	})
+	defer tearDown()
+
+	con, err := adsc.Dial(util.MockPilotGrpcAddr, "", &adsc.Config{
+		IP:    testIP(0x0a0a0a0a),
+		Delta: true,
+	})
+	if err != nil {
+		t.Fatal("Error connecting ", err)
+	}
+	defer con.Close()
+
+	con.Watch()
+	if _, err := con.Wait(10*time.Second, "cds", "eds", "lds", "rds"); err != nil {
+		t.Fatal("Error getting initial config ", err)
+	}
+	if len(con.GetHTTPListeners()) == 0 || len(con.GetRoutes()) == 0 {
+		t.Fatal("Expecting listeners and routes in the initial config")
+	}
+	testEndpoints("127.0.0.1", deltaCluster, con, t)
+	clusters := len(con.GetEdsClusters())
+
+	t.Run("UnchangedPush", func(t *testing.T) {
+		con.WaitClear()
+		v2.AdsPushAll(server.EnvoyXdsServer)
+		if upd, err := con.Wait(2 * time.Second); err == nil {
+			t.Errorf("Expecting no resources to be sent for an unchanged config, got %v", upd)
+		}
+	})
+
+	t.Run("EndpointUpdate", func(t *testing.T) {
+		con.WaitClear()
+		server.EnvoyXdsServer.MemRegistry.SetEndpoints(deltaSvc, "",
+			newEndpointWithAccount("127.0.0.2", "hello-sa", "v1"))
+
+		upd, err := con.Wait(5 * time.Second)
+		if err != nil {
+			t.Fatal("Incremental push failed", err)
+		}
+		if !reflect.DeepEqual(upd, []string{"eds"}) {
+			t.Error("Expecting EDS only update, got", upd)
+		}
+		testEndpoints("127.0.0.2", deltaCluster, con, t)
+		// Clusters that did not change are not sent, but must be kept by the client.
+		if len(con.GetEndpoints()) != clusters {
+			t.Errorf("Expecting endpoints for %d clusters, got %d", clusters, len(con.GetEndpoints()))
+		}
+	})
+
+	t.Run("ServiceRemoval", func(t *testing.T) {
+		con.WaitClear()
+		server.EnvoyXdsServer.MemRegistry.RemoveService(host.Name(deltaSvc))
+		server.EnvoyXdsServer.Push(&model.PushRequest{Full: true})
+
+		if _, err := con.Wait(5*time.Second, "cds"); err != nil {
+			t.Fatal("Expecting CDS update after removing a service", err)
+		}
+		if _, f := con.GetEdsClusters()[deltaCluster]; f {
+			t.Errorf("Expecting cluster %s to be removed", deltaCluster)
+		}
+		if len(con.GetEdsClusters()) != clusters-1 {
+			t.Errorf("Expecting %d clusters, got %d", clusters-1, len(con.GetEdsClusters()))
+		}
+	})
+}
@@ -113,6 +113,10 @@ type DiscoveryServer struct {
 	// debugHandlers is the list of all the supported debug handlers.
 	debugHandlers map[string]string
 
+	// ConfigHistory keeps previous versions of the config, as identified by the ledger, for
+	// /debug/config_history. It is nil if config distribution tracking is disabled.
+	ConfigHistory *ConfigHistory
+
 	// adsClients reflect active gRPC channels, for both ADS and EDS.
 	adsClients      map[string]*XdsConnection
 	adsClientsMutex sync.RWMutex
@@ -146,12 +150,16 @@ func NewDiscoveryServer(env *model.Environment, plugins []string) *DiscoveryServ
 		EndpointShardsByService: map[string]map[string]*EndpointShards{},
 		concurrentPushLimit:     make(chan struct{}, features.PushThrottle),
 		pushChannel:             make(chan *model.PushRequest, 10),
-		pushQueue:               NewPushQueue(),
+		pushQueue:               newPushQueueFromFeatures(),
 		DebugConfigs:            features.DebugConfigs,
 		debugHandlers:           map[string]string{},
 		adsClients:              map[string]*XdsConnection{},
 	}
 
+	if features.EnableDistributionTracking {
+		out.ConfigHistory = NewConfigHistory(features.ConfigHistorySize)
+	}
+
 	// Flush cached discovery responses when detecting jwt public key change.
 	model.JwtKeyResolver.PushFunc = func() {
 		out.ConfigUpdate(&model.PushRequest{Full: true, Reason: []model.TriggerReason{model.UnknownTrigger}})
@@ -160,6 +168,17 @@ func NewDiscoveryServer(env *model.Environment, plugins []string) *DiscoveryServ
 	return out
 }
 
+func newPushQueueFromFeatures() *PushQueue {
+	return NewPushQueueWithOptions(PushQueueOptions{
+		PriorityLimits: map[PushPriority]int{
+			PushPriorityHigh:   features.PushThrottleHighPriority,
+			PushPriorityNormal: features.PushThrottleNormalPriority,
+			PushPriorityLow:    features.PushThrottleLowPriority,
+		},
+		MinPushInterval: features.PushMinInterval,
+	})
+}
+
 // Register adds the ADS and EDS handles to the grpc server
 func (s *DiscoveryServer) Register(rpcs *grpc.Server) {
 	ads.RegisterAggregatedDiscoveryServiceServer(rpcs, s)
@@ -170,6 +189,9 @@ func (s *DiscoveryServer) Start(stopCh <-chan struct{}) {
 	go s.handleUpdates(stopCh)
 	go s.periodicRefreshMetrics(stopCh)
 	go s.sendPushes(stopCh)
+	if s.ConfigHistory != nil {
+		go s.ConfigHistory.Run(s.Env.IstioConfigStore, stopCh)
+	}
 }
 
 // Push metrics are updated periodically (10s default)
@@ -230,6 +252,8 @@ func (s *DiscoveryServer) Push(req *model.PushRequest) {
 	s.Env.PushContext = push
 	s.updateMutex.Unlock()
 
+	s.ConfigHistory.Notify()
+
 	versionLocal := time.Now().Format(time.RFC3339) + "/" + strconv.FormatUint(versionNum.Load(), 10)
 	versionNum.Inc()
 	initContextTime := time.Since(t0)
@@ -370,8 +394,6 @@ func doSendPushes(stopCh <-chan struct{}, semaphore chan struct{}, queue *PushQu
 				<-semaphore
 			}
 
-			proxiesQueueTime.Record(time.Since(info.Start).Seconds())
-
 			go func() {
 				edsUpdates := info.EdsUpdates
 				if info.Full {
@@ -390,7 +412,7 @@ func doSendPushes(stopCh <-chan struct{}, semaphore chan struct{}, queue *PushQu
 					noncePrefix:        info.Push.Version,
 				}:
 					return
-				case <-client.stream.Context().Done(): // grpc stream was closed
+				case <-client.streamContext().Done(): // grpc stream was closed
 					doneFunc()
 					adsLog.Infof("Client closed connection %v", client.ConID)
 				}
@@ -16,6 +16,7 @@ package v2
 
 import (
 	"reflect"
+	"sort"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -642,7 +643,7 @@ func (s *DiscoveryServer) generateEndpoints(
 		// Make a shallow copy of the cla as we are mutating the endpoints with priorities/weights relative to the calling proxy
 		clonedCLA := util.CloneClusterLoadAssignment(l)
 		l = &clonedCLA
-		loadbalancer.ApplyLocalityLBSetting(proxy.Locality, l, lbSetting, enableFailover)
+		loadbalancer.ApplyLocalityLBSetting(proxy.Locality, proxy.Metadata.Network, l, lbSetting, enableFailover, push.Networks)
 	}
 	return l
 }
@@ -675,7 +676,12 @@ func (s *DiscoveryServer) pushEds(push *model.PushContext, con *XdsConnection, v
 	}
 
 	response := endpointDiscoveryResponse(loadAssignments, version, push.Version)
-	err := con.send(response)
+	var err error
+	if edsUpdatedServices != nil {
+		err = con.sendPartial(response)
+	} else {
+		err = con.send(response)
+	}
 	edsPushTime.Record(time.Since(pushStart).Seconds())
 	if err != nil {
 		adsLog.Warnf("EDS: Send failure %s: %v", con.ConID, err)
@@ -840,8 +846,15 @@ func buildLocalityLbEndpointsFromShards(
 
 	shards.mutex.Lock()
 	// The shards are updated independently, now need to filter and merge
-	// for this cluster
-	for _, endpoints := range shards.Shards {
+	// for this cluster. Shards and localities are visited in a stable order so the
+	// generated assignment only changes when the endpoints do.
+	shardKeys := make([]string, 0, len(shards.Shards))
+	for k := range shards.Shards {
+		shardKeys = append(shardKeys, k)
+	}
+	sort.Strings(shardKeys)
+	for _, k := range shardKeys {
+		endpoints := shards.Shards[k]
 		for _, ep := range endpoints {
 			if svcPort.Name != ep.ServicePortName {
 				continue
@@ -868,8 +881,15 @@ func buildLocalityLbEndpointsFromShards(
 	}
 	shards.mutex.Unlock()
 
+	localities := make([]string, 0, len(localityEpMap))
+	for l := range localityEpMap {
+		localities = append(localities, l)
+	}
+	sort.Strings(localities)
+
 	locEps := make([]*endpoint.LocalityLbEndpoints, 0, len(localityEpMap))
-	for _, locLbEps := range localityEpMap {
+	for _, l := range localities {
+		locLbEps := localityEpMap[l]
 		var weight uint32
 		for _, ep := range locLbEps.LbEndpoints {
 			weight += ep.LoadBalancingWeight.GetValue()
@@ -28,6 +28,8 @@ var (
 	nodeTag    = monitoring.MustCreateLabel("node")
 	typeTag    = monitoring.MustCreateLabel("type")
 
+	priorityTag = monitoring.MustCreateLabel("priority")
+
 	cdsReject = monitoring.NewGauge(
 		"pilot_xds_cds_reject",
 		"Pilot rejected CDS configs.",
@@ -127,6 +129,7 @@ var (
 		"pilot_proxy_queue_time",
 		"Time in seconds, a proxy is in the push queue before being dequeued.",
 		[]float64{.1, 1, 3, 5, 10, 20, 30},
+		monitoring.WithLabels(priorityTag),
 	)
 
 	pushTriggers = monitoring.NewSum(
@@ -16,56 +16,177 @@ package v2
 
 import (
 	"sync"
+	"time"
 
 	"istio.io/istio/pilot/pkg/model"
 )
 
+// PushPriority is the class of a queued push. Pushes of a higher priority (lower value) are
+// dequeued before any push of a lower priority.
+type PushPriority int
+
+const (
+	// PushPriorityHigh is used for gateways and for proxies that have just connected.
+	PushPriorityHigh PushPriority = iota
+	// PushPriorityNormal is used for full pushes to sidecars.
+	PushPriorityNormal
+	// PushPriorityLow is used for incremental EDS pushes to sidecars.
+	PushPriorityLow
+
+	numPushPriorities = int(PushPriorityLow) + 1
+)
+
+func (p PushPriority) String() string {
+	switch p {
+	case PushPriorityHigh:
+		return "high"
+	case PushPriorityNormal:
+		return "normal"
+	case PushPriorityLow:
+		return "low"
+	}
+	return "unknown"
+}
+
+// isGateway returns whether the proxy of a connection is a gateway.
+func isGateway(con *XdsConnection) bool {
+	con.mu.RLock()
+	defer con.mu.RUnlock()
+	return con.node != nil && con.node.Type == model.Router
+}
+
+// pushPriority returns the priority of a push to a connection, given whether its proxy is a gateway.
+func pushPriority(gateway bool, req *model.PushRequest) PushPriority {
+	if gateway {
+		return PushPriorityHigh
+	}
+	for _, reason := range req.Reason {
+		// Sent once the registry knows about a new proxy, right after it connected.
+		if reason == model.ProxyUpdate {
+			return PushPriorityHigh
+		}
+	}
+	if !req.Full {
+		return PushPriorityLow
+	}
+	return PushPriorityNormal
+}
+
+// PushQueueOptions configures prioritization and rate limiting of a PushQueue.
+type PushQueueOptions struct {
+	// PriorityLimits is the maximum number of concurrent pushes for each priority. A missing or
+	// zero limit means the priority is only limited by the callers of Dequeue.
+	PriorityLimits map[PushPriority]int
+
+	// MinPushInterval is the minimum time between the start of two pushes to the same proxy.
+	// Requests enqueued in the meantime are merged and wait in the queue. Zero disables it.
+	MinPushInterval time.Duration
+}
+
+type queuedPush struct {
+	request  *model.PushRequest
+	priority PushPriority
+	// gateway is whether the proxy was a gateway when the connection was enqueued.
+	gateway bool
+	// enqueued is when the connection was added to the queue, for the queue time metric.
+	enqueued time.Time
+}
+
+type inProgressPush struct {
+	priority PushPriority
+	// pending is set if the connection was enqueued while the push was in progress.
+	pending *model.PushRequest
+}
+
 type PushQueue struct {
 	mu   *sync.RWMutex
 	cond *sync.Cond
 
 	// eventsMap stores all connections in the queue. If the same connection is enqueued again, the
 	// PushEvents will be merged.
-	eventsMap map[*XdsConnection]*model.PushRequest
+	eventsMap map[*XdsConnection]*queuedPush
 
-	// connections maintains ordering of the queue
-	connections []*XdsConnection
+	// lanes maintains ordering of the queue, with one FIFO per priority.
+	lanes [numPushPriorities][]*XdsConnection
 
 	// inProgress stores all connections that have been Dequeue(), but not MarkDone().
-	// The value stored will be initially be nil, but may be populated if the connection is Enqueue().
-	// If model.PushRequest is not nil, it will be Enqueued again once MarkDone has been called.
-	inProgress map[*XdsConnection]*model.PushRequest
+	// The pending request will initially be nil, but may be populated if the connection is Enqueue().
+	// If it is not nil, it will be Enqueued again once MarkDone has been called.
+	inProgress map[*XdsConnection]*inProgressPush
+
+	// limits and active are the maximum and current number of in progress pushes per priority.
+	limits [numPushPriorities]int
+	active [numPushPriorities]int
+
+	minInterval time.Duration
+	// lastPush stores when connections were last dequeued, while rate limiting applies to them.
+	// Entries older than minInterval are pruned.
+	lastPush  map[*XdsConnection]time.Time
+	lastPrune time.Time
+	// wakeup unblocks Dequeue once a rate limited connection can be pushed.
+	wakeup *time.Timer
 }
 
 func NewPushQueue() *PushQueue {
+	return NewPushQueueWithOptions(PushQueueOptions{})
+}
+
+// NewPushQueueWithOptions creates a PushQueue with per priority concurrency limits and per proxy
+// rate limiting.
+func NewPushQueueWithOptions(opts PushQueueOptions) *PushQueue {
 	mu := &sync.RWMutex{}
-	return &PushQueue{
-		mu:         mu,
-		eventsMap:  make(map[*XdsConnection]*model.PushRequest),
-		inProgress: make(map[*XdsConnection]*model.PushRequest),
-		cond:       sync.NewCond(mu),
+	p := &PushQueue{
+		mu:          mu,
+		eventsMap:   make(map[*XdsConnection]*queuedPush),
+		inProgress:  make(map[*XdsConnection]*inProgressPush),
+		cond:        sync.NewCond(mu),
+		minInterval: opts.MinPushInterval,
+		lastPush:    make(map[*XdsConnection]time.Time),
+	}
+	for priority, limit := range opts.PriorityLimits {
+		if int(priority) >= 0 && int(priority) < numPushPriorities {
+			p.limits[priority] = limit
+		}
 	}
+	return p
 }
 
 // Add will mark a proxy as pending a push. If it is already pending, pushInfo will be merged.
 // edsUpdatedServices will be added together, and full will be set if either were full
 func (p *PushQueue) Enqueue(proxy *XdsConnection, pushInfo *model.PushRequest) {
+	// Read the proxy type before taking the queue lock, as it is guarded by the connection lock.
+	gateway := isGateway(proxy)
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	// If its already in progress, merge the info and return
-	if event, f := p.inProgress[proxy]; f {
-		p.inProgress[proxy] = event.Merge(pushInfo)
+	if push, f := p.inProgress[proxy]; f {
+		push.pending = push.pending.Merge(pushInfo)
 		return
 	}
 
 	if event, f := p.eventsMap[proxy]; f {
-		p.eventsMap[proxy] = event.Merge(pushInfo)
+		event.request = event.request.Merge(pushInfo)
+		// The merged request may need a higher priority, for example an incremental push
+		// that became full. Move it to the end of the higher priority lane.
+		if priority := pushPriority(event.gateway, event.request); priority < event.priority {
+			p.removeFromLane(event.priority, proxy)
+			p.lanes[priority] = append(p.lanes[priority], proxy)
+			event.priority = priority
+			p.cond.Signal()
+		}
 		return
 	}
 
-	p.eventsMap[proxy] = pushInfo
-	p.connections = append(p.connections, proxy)
+	priority := pushPriority(gateway, pushInfo)
+	p.eventsMap[proxy] = &queuedPush{
+		request:  pushInfo,
+		priority: priority,
+		gateway:  gateway,
+		enqueued: time.Now(),
+	}
+	p.lanes[priority] = append(p.lanes[priority], proxy)
 	// Signal waiters on Dequeue that a new item is available
 	p.cond.Signal()
 }
@@ -75,34 +196,112 @@ func (p *PushQueue) Dequeue() (*XdsConnection, *model.PushRequest) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	// Block until there is one to remove. Enqueue will signal when one is added.
-	for len(p.connections) == 0 {
+	// Block until there is one to remove. Enqueue and MarkDone will signal when one may be available.
+	var head *XdsConnection
+	var priority PushPriority
+	for {
+		var wait time.Duration
+		if head, priority, wait = p.next(time.Now()); head != nil {
+			break
+		}
+		if wait > 0 {
+			p.wakeAfter(wait)
+		}
 		p.cond.Wait()
 	}
 
-	head := p.connections[0]
-	p.connections = p.connections[1:]
-
-	info := p.eventsMap[head]
+	event := p.eventsMap[head]
 	delete(p.eventsMap, head)
 
 	// Mark the connection as in progress
-	p.inProgress[head] = nil
+	p.inProgress[head] = &inProgressPush{priority: priority}
+	p.active[priority]++
+	if p.minInterval > 0 {
+		p.lastPush[head] = time.Now()
+	}
 
-	return head, info
+	proxiesQueueTime.With(priorityTag.Value(priority.String())).Record(time.Since(event.enqueued).Seconds())
+	return head, event.request
+}
+
+// next removes and returns the first connection of the highest priority lane that is below its
+// concurrency limit and not rate limited. If there is none, the returned duration is the time
+// until a rate limited connection becomes ready, or 0 if no connection waits on the rate limit.
+func (p *PushQueue) next(now time.Time) (*XdsConnection, PushPriority, time.Duration) {
+	p.pruneLastPush(now)
+
+	var wait time.Duration
+	for priority := 0; priority < numPushPriorities; priority++ {
+		if p.limits[priority] > 0 && p.active[priority] >= p.limits[priority] {
+			continue
+		}
+		for i, con := range p.lanes[priority] {
+			if last, f := p.lastPush[con]; f {
+				if remaining := p.minInterval - now.Sub(last); remaining > 0 {
+					if wait == 0 || remaining < wait {
+						wait = remaining
+					}
+					continue
+				}
+			}
+			p.lanes[priority] = append(p.lanes[priority][:i], p.lanes[priority][i+1:]...)
+			return con, PushPriority(priority), 0
+		}
+	}
+	return nil, 0, wait
+}
+
+// pruneLastPush drops the connections that are no longer rate limited, at most once per interval.
+func (p *PushQueue) pruneLastPush(now time.Time) {
+	if len(p.lastPush) == 0 || now.Sub(p.lastPrune) < p.minInterval {
+		return
+	}
+	for con, last := range p.lastPush {
+		if now.Sub(last) >= p.minInterval {
+			delete(p.lastPush, con)
+		}
+	}
+	p.lastPrune = now
+}
+
+// wakeAfter wakes up blocked Dequeue calls after d. Must be called with p.mu held.
+func (p *PushQueue) wakeAfter(d time.Duration) {
+	if p.wakeup != nil {
+		p.wakeup.Stop()
+	}
+	p.wakeup = time.AfterFunc(d, func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.cond.Broadcast()
+	})
+}
+
+func (p *PushQueue) removeFromLane(priority PushPriority, con *XdsConnection) {
+	lane := p.lanes[priority]
+	for i, c := range lane {
+		if c == con {
+			p.lanes[priority] = append(lane[:i], lane[i+1:]...)
+			return
+		}
+	}
 }
 
 func (p *PushQueue) MarkDone(con *XdsConnection) {
 	p.mu.Lock()
 
-	info := p.inProgress[con]
+	push := p.inProgress[con]
 	delete(p.inProgress, con)
+	if push != nil {
+		p.active[push.priority]--
+		// A push slot was freed, Dequeue calls blocked on the priority limit may continue.
+		p.cond.Broadcast()
+	}
 	p.mu.Unlock()
 
 	// If the info is present, that means Enqueue was called while connection was not yet marked done.
 	// This means we need to add it back to the queue
-	if info != nil {
-		p.Enqueue(con, info)
+	if push != nil && push.pending != nil {
+		p.Enqueue(con, push.pending)
 	}
 }
 
@@ -110,5 +309,9 @@ func (p *PushQueue) MarkDone(con *XdsConnection) {
 func (p *PushQueue) Pending() int {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	return len(p.connections)
+	pending := 0
+	for _, lane := range p.lanes {
+		pending += len(lane)
+	}
+	return pending
 }
@@ -195,6 +195,106 @@ func TestProxyQueue(t *testing.T) {
 		}
 	})
 
+	t.Run("dequeue by priority", func(t *testing.T) {
+		p := NewPushQueue()
+		gateway := &XdsConnection{ConID: "gateway", node: &model.Proxy{Type: model.Router}}
+		p.Enqueue(proxies[0], &model.PushRequest{EdsUpdates: map[string]struct{}{"foo": {}}})
+		p.Enqueue(proxies[1], &model.PushRequest{Full: true})
+		p.Enqueue(proxies[2], &model.PushRequest{Full: true, Reason: []model.TriggerReason{model.ProxyUpdate}})
+		p.Enqueue(gateway, &model.PushRequest{EdsUpdates: map[string]struct{}{"foo": {}}})
+
+		ExpectDequeue(t, p, proxies[2])
+		ExpectDequeue(t, p, gateway)
+		ExpectDequeue(t, p, proxies[1])
+		ExpectDequeue(t, p, proxies[0])
+		ExpectTimeout(t, p)
+	})
+
+	t.Run("merge raises priority", func(t *testing.T) {
+		p := NewPushQueue()
+		p.Enqueue(proxies[0], &model.PushRequest{EdsUpdates: map[string]struct{}{"foo": {}}})
+		p.Enqueue(proxies[1], &model.PushRequest{EdsUpdates: map[string]struct{}{"foo": {}}})
+		p.Enqueue(proxies[1], &model.PushRequest{Full: true})
+
+		if p.Pending() != 2 {
+			t.Fatalf("Expected 2 pending proxies, got %v", p.Pending())
+		}
+		ExpectDequeue(t, p, proxies[1])
+		ExpectDequeue(t, p, proxies[0])
+	})
+
+	t.Run("priority limit", func(t *testing.T) {
+		p := NewPushQueueWithOptions(PushQueueOptions{
+			PriorityLimits: map[PushPriority]int{PushPriorityLow: 1},
+		})
+		p.Enqueue(proxies[0], &model.PushRequest{})
+		p.Enqueue(proxies[1], &model.PushRequest{})
+
+		ExpectDequeue(t, p, proxies[0])
+		// The only low priority slot is in use, but other priorities are not blocked.
+		p.Enqueue(proxies[2], &model.PushRequest{Full: true})
+		ExpectDequeue(t, p, proxies[2])
+
+		// proxies[1] stays queued until the low priority slot is released. This is checked without a
+		// blocking Dequeue, which would take proxies[1] once the slot is released.
+		p.mu.Lock()
+		next, _, _ := p.next(time.Now())
+		p.mu.Unlock()
+		if next != nil {
+			t.Fatalf("Expected no proxy to be ready, got %v", next.ConID)
+		}
+		if p.Pending() != 1 {
+			t.Fatalf("Expected 1 pending proxy, got %v", p.Pending())
+		}
+
+		p.MarkDone(proxies[0])
+		ExpectDequeue(t, p, proxies[1])
+	})
+
+	t.Run("per proxy rate limit", func(t *testing.T) {
+		interval := 100 * time.Millisecond
+		p := NewPushQueueWithOptions(PushQueueOptions{MinPushInterval: interval})
+		p.Enqueue(proxies[0], &model.PushRequest{})
+		ExpectDequeue(t, p, proxies[0])
+		p.MarkDone(proxies[0])
+
+		p.Enqueue(proxies[0], &model.PushRequest{})
+		p.Enqueue(proxies[1], &model.PushRequest{})
+
+		// proxies[0] was just pushed, other proxies are not delayed.
+		p.mu.Lock()
+		now := time.Now()
+		next, _, _ := p.next(now)
+		if next != proxies[1] {
+			p.mu.Unlock()
+			t.Fatalf("Expected %v to be ready, got %v", proxies[1].ConID, next)
+		}
+		next, _, wait := p.next(now)
+		if next != nil || wait <= 0 || wait > interval {
+			p.mu.Unlock()
+			t.Fatalf("Expected %v to be rate limited, got %v after %v", proxies[0].ConID, next, wait)
+		}
+		next, _, _ = p.next(now.Add(interval))
+		p.mu.Unlock()
+		if next != proxies[0] {
+			t.Fatalf("Expected %v to be ready after %v, got %v", proxies[0].ConID, interval, next)
+		}
+	})
+
+	t.Run("rate limited dequeue", func(t *testing.T) {
+		p := NewPushQueueWithOptions(PushQueueOptions{MinPushInterval: 50 * time.Millisecond})
+		p.Enqueue(proxies[0], &model.PushRequest{})
+		ExpectDequeue(t, p, proxies[0])
+		p.MarkDone(proxies[0])
+
+		// Dequeue blocks until the rate limit expires, then returns the merged request.
+		p.Enqueue(proxies[0], &model.PushRequest{})
+		ExpectDequeue(t, p, proxies[0])
+		if len(p.lastPush) != 1 {
+			t.Fatalf("Expected 1 rate limited proxy, got %v", len(p.lastPush))
+		}
+	})
+
 	t.Run("concurrent", func(t *testing.T) {
 		p := NewPushQueue()
 		key := func(p *XdsConnection, eds string) string { return fmt.Sprintf("%s~%s", p.ConID, eds) }
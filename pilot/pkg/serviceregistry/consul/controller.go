@@ -37,7 +37,7 @@ type Controller struct {
 	monitor          Monitor
 	services         map[string]*model.Service //key hostname value service
 	servicesList     []*model.Service
-	serviceInstances map[string][]*model.ServiceInstance //key hostname value serviceInstance array
+	serviceInstances map[string]map[string]*model.ServiceInstance //key hostname value healthy serviceInstances by instanceKey
 	cacheMutex       sync.Mutex
 	initDone         bool
 	clusterID        string
@@ -56,7 +56,7 @@ func NewController(addr string, clusterID string) (*Controller, error) {
 		clusterID: clusterID,
 	}
 
-	//Watch the change events to refresh local caches
+	//Watch the change events to update local caches
 	monitor.AppendServiceHandler(controller.ServiceChanged)
 	monitor.AppendInstanceHandler(controller.InstanceChanged)
 	return &controller, err
@@ -256,7 +256,7 @@ func (c *Controller) initCache() error {
 	}
 
 	c.services = make(map[string]*model.Service)
-	c.serviceInstances = make(map[string][]*model.ServiceInstance)
+	c.serviceInstances = make(map[string]map[string]*model.ServiceInstance)
 
 	// get all services from consul
 	consulServices, err := c.getServices()
@@ -265,27 +265,34 @@ func (c *Controller) initCache() error {
 	}
 
 	for serviceName := range consulServices {
-		// get endpoints of a service from consul
-		endpoints, err := c.getCatalogService(serviceName, nil)
+		// get endpoints of a service, with their health, from consul
+		entries, err := c.getServiceEntries(serviceName, nil)
 		if err != nil {
 			return err
 		}
-		c.services[serviceName] = convertService(endpoints)
+		all, healthy := convertServiceEntries(entries)
+		if len(all) == 0 {
+			continue
+		}
+		c.services[serviceName] = convertService(all)
 
-		instances := make([]*model.ServiceInstance, len(endpoints))
-		for i, endpoint := range endpoints {
-			instances[i] = convertInstance(endpoint)
+		instances := make(map[string]*model.ServiceInstance, len(healthy))
+		for key, endpoint := range healthy {
+			instances[key] = convertInstance(endpoint)
 		}
 		c.serviceInstances[serviceName] = instances
 	}
+	c.updateServicesList()
 
+	c.initDone = true
+	return nil
+}
+
+func (c *Controller) updateServicesList() {
 	c.servicesList = make([]*model.Service, 0, len(c.services))
 	for _, value := range c.services {
 		c.servicesList = append(c.servicesList, value)
 	}
-
-	c.initDone = true
-	return nil
 }
 
 func (c *Controller) getServices() (map[string][]string, error) {
@@ -299,28 +306,64 @@ func (c *Controller) getServices() (map[string][]string, error) {
 }
 
 // nolint: unparam
-func (c *Controller) getCatalogService(name string, q *api.QueryOptions) ([]*api.CatalogService, error) {
-	endpoints, _, err := c.client.Catalog().Service(name, "", q)
+func (c *Controller) getServiceEntries(name string, q *api.QueryOptions) ([]*api.ServiceEntry, error) {
+	entries, _, err := c.client.Health().Service(name, "", false, q)
 	if err != nil {
-		log.Warnf("Could not retrieve service catalog from consul: %v", err)
+		log.Warnf("Could not retrieve service health from consul: %v", err)
 		return nil, err
 	}
 
-	return endpoints, nil
+	return entries, nil
 }
 
-func (c *Controller) refreshCache() {
+// InstanceChanged updates the cached instances of a service with a single changed instance.
+// Changes are ignored until the cache is initialized, as they are then read from Consul.
+func (c *Controller) InstanceChanged(instance *api.CatalogService, event model.Event) error {
 	c.cacheMutex.Lock()
 	defer c.cacheMutex.Unlock()
-	c.initDone = false
-}
+	if !c.initDone {
+		return nil
+	}
 
-func (c *Controller) InstanceChanged(instance *api.CatalogService, event model.Event) error {
-	c.refreshCache()
+	key := instanceKey(instance)
+	instances := c.serviceInstances[instance.ServiceName]
+	switch event {
+	case model.EventDelete:
+		delete(instances, key)
+	default:
+		if instances == nil {
+			instances = make(map[string]*model.ServiceInstance)
+			c.serviceInstances[instance.ServiceName] = instances
+		}
+		instances[key] = convertInstance(instance)
+	}
 	return nil
 }
 
+// ServiceChanged updates the cached service built from the instances of a service.
+// Changes are ignored until the cache is initialized, as they are then read from Consul.
 func (c *Controller) ServiceChanged(instances []*api.CatalogService, event model.Event) error {
-	c.refreshCache()
+	if len(instances) == 0 {
+		return nil
+	}
+
+	c.cacheMutex.Lock()
+	defer c.cacheMutex.Unlock()
+	if !c.initDone {
+		return nil
+	}
+
+	name := instances[0].ServiceName
+	switch event {
+	case model.EventDelete:
+		delete(c.services, name)
+		delete(c.serviceInstances, name)
+	default:
+		c.services[name] = convertService(instances)
+		if _, f := c.serviceInstances[name]; !f {
+			c.serviceInstances[name] = make(map[string]*model.ServiceInstance)
+		}
+	}
+	c.updateServicesList()
 	return nil
 }
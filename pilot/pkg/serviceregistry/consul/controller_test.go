@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -42,10 +43,60 @@ type mockServer struct {
 	productpage []*api.CatalogService
 	reviews     []*api.CatalogService
 	rating      []*api.CatalogService
+	// unhealthy holds the service addresses of the instances failing their health check
+	unhealthy   map[string]bool
 	lock        sync.Mutex
 	consulIndex int
 }
 
+func (m *mockServer) catalogService(name string) []*api.CatalogService {
+	switch name {
+	case "productpage":
+		return m.productpage
+	case "reviews":
+		return m.reviews
+	case "rating":
+		return m.rating
+	}
+	return []*api.CatalogService{}
+}
+
+func (m *mockServer) healthService(name string) []*api.ServiceEntry {
+	entries := make([]*api.ServiceEntry, 0)
+	for _, instance := range m.catalogService(name) {
+		status := api.HealthPassing
+		if m.unhealthy[instance.ServiceAddress] {
+			status = api.HealthCritical
+		}
+		entries = append(entries, &api.ServiceEntry{
+			Node: &api.Node{
+				ID:         instance.ID,
+				Node:       instance.Node,
+				Address:    instance.Address,
+				Datacenter: instance.Datacenter,
+			},
+			Service: &api.AgentService{
+				ID:      instance.ServiceID,
+				Service: instance.ServiceName,
+				Tags:    instance.ServiceTags,
+				Meta:    instance.ServiceMeta,
+				Port:    instance.ServicePort,
+				Address: instance.ServiceAddress,
+			},
+			Checks: api.HealthChecks{
+				{
+					Node:        instance.Node,
+					CheckID:     "service:" + instance.ServiceID,
+					Status:      status,
+					ServiceID:   instance.ServiceID,
+					ServiceName: instance.ServiceName,
+				},
+			},
+		})
+	}
+	return entries
+}
+
 func newServer() *mockServer {
 	m := mockServer{
 		productpage: []*api.CatalogService{
@@ -110,46 +161,27 @@ func newServer() *mockServer {
 			"reviews":     {"version|v1", "version|v2", "version|v3"},
 			"rating":      {"version|v1"},
 		},
+		unhealthy:   map[string]bool{},
 		consulIndex: 1,
 	}
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "/v1/catalog/services" {
-			m.lock.Lock()
-			data, _ := json.Marshal(&m.services)
-			w.Header().Set("X-Consul-Index", strconv.Itoa(m.consulIndex))
-			m.lock.Unlock()
-			w.Header().Set("Content-Type", "application/json")
-			_, _ = fmt.Fprintln(w, string(data))
-		} else if r.URL.Path == "/v1/catalog/service/reviews" {
-			m.lock.Lock()
-			data, _ := json.Marshal(&m.reviews)
-			w.Header().Set("X-Consul-Index", strconv.Itoa(m.consulIndex))
-			m.lock.Unlock()
-			w.Header().Set("Content-Type", "application/json")
-			_, _ = fmt.Fprintln(w, string(data))
-		} else if r.URL.Path == "/v1/catalog/service/productpage" {
-			m.lock.Lock()
-			data, _ := json.Marshal(&m.productpage)
-			w.Header().Set("X-Consul-Index", strconv.Itoa(m.consulIndex))
-			m.lock.Unlock()
-			w.Header().Set("Content-Type", "application/json")
-			_, _ = fmt.Fprintln(w, string(data))
-		} else if r.URL.Path == "/v1/catalog/service/rating" {
-			m.lock.Lock()
-			data, _ := json.Marshal(&m.rating)
-			w.Header().Set("X-Consul-Index", strconv.Itoa(m.consulIndex))
-			m.lock.Unlock()
-			w.Header().Set("Content-Type", "application/json")
-			_, _ = fmt.Fprintln(w, string(data))
-		} else {
-			m.lock.Lock()
-			data, _ := json.Marshal(&[]*api.CatalogService{})
-			w.Header().Set("X-Consul-Index", strconv.Itoa(m.consulIndex))
-			m.lock.Unlock()
-			w.Header().Set("Content-Type", "application/json")
-			_, _ = fmt.Fprintln(w, string(data))
+		m.lock.Lock()
+		var data []byte
+		switch {
+		case r.URL.Path == "/v1/catalog/services":
+			data, _ = json.Marshal(&m.services)
+		case strings.HasPrefix(r.URL.Path, "/v1/catalog/service/"):
+			data, _ = json.Marshal(m.catalogService(strings.TrimPrefix(r.URL.Path, "/v1/catalog/service/")))
+		case strings.HasPrefix(r.URL.Path, "/v1/health/service/"):
+			data, _ = json.Marshal(m.healthService(strings.TrimPrefix(r.URL.Path, "/v1/health/service/")))
+		default:
+			data, _ = json.Marshal(&[]*api.CatalogService{})
 		}
+		w.Header().Set("X-Consul-Index", strconv.Itoa(m.consulIndex))
+		m.lock.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintln(w, string(data))
 	}))
 
 	m.server = server
@@ -603,3 +635,62 @@ func TestGetInstanceByCacheAfterChanged(t *testing.T) {
 		}
 	}
 }
+
+func TestInstancesExcludeUnhealthy(t *testing.T) {
+	ts := newServer()
+	defer ts.server.Close()
+	ts.unhealthy["172.19.0.8"] = true
+	controller, err := NewController(ts.server.URL, clusterID)
+	if err != nil {
+		t.Errorf("could not create Consul Controller: %v", err)
+	}
+	go controller.Run(make(chan struct{}))
+
+	svc := &model.Service{
+		Hostname: serviceHostname("reviews"),
+		Attributes: model.ServiceAttributes{
+			Name:      "reviews",
+			Namespace: model.IstioDefaultConfigNamespace,
+		},
+	}
+	instances, err := controller.InstancesByPort(svc, 0, labels.Collection{})
+	if err != nil {
+		t.Errorf("client encountered error during Instances(): %v", err)
+	}
+	if len(instances) != 2 {
+		t.Errorf("Instances() returned wrong # of service instances => %q, want 2", len(instances))
+	}
+	for _, inst := range instances {
+		if inst.Endpoint.Address == "172.19.0.8" {
+			t.Errorf("Instances() returned unhealthy instance %v", inst.Endpoint.Address)
+		}
+	}
+
+	// The unhealthy instance is still part of the service definition.
+	service, err := controller.GetService(serviceHostname("reviews"))
+	if err != nil {
+		t.Errorf("client encountered error during GetService(): %v", err)
+	}
+	if len(service.Ports) != 2 {
+		t.Errorf("GetService() returned wrong # of ports => %d, want 2", len(service.Ports))
+	}
+
+	ts.lock.Lock()
+	ts.unhealthy = map[string]bool{"172.19.0.6": true}
+	ts.consulIndex++
+	ts.lock.Unlock()
+
+	time.Sleep(notifyThreshold)
+	instances, err = controller.InstancesByPort(svc, 0, labels.Collection{})
+	if err != nil {
+		t.Errorf("client encountered error during Instances(): %v", err)
+	}
+	addresses := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		addresses = append(addresses, inst.Endpoint.Address)
+	}
+	sort.Strings(addresses)
+	if want := []string{"172.19.0.7", "172.19.0.8"}; !reflect.DeepEqual(addresses, want) {
+		t.Errorf("Instances() returned %v after health change, want %v", addresses, want)
+	}
+}
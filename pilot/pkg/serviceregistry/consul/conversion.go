@@ -16,6 +16,7 @@ package consul
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/consul/api"
@@ -91,6 +92,9 @@ func convertService(endpoints []*api.CatalogService) *model.Service {
 	for _, port := range ports {
 		svcPorts = append(svcPorts, port)
 	}
+	sort.Slice(svcPorts, func(i, j int) bool {
+		return svcPorts[i].Port < svcPorts[j].Port
+	})
 
 	hostname := serviceHostname(name)
 	out := &model.Service{
@@ -156,6 +160,47 @@ func convertInstance(instance *api.CatalogService) *model.ServiceInstance {
 	}
 }
 
+// convertServiceEntries converts the result of a Consul health query into catalog services. It returns
+// all the instances of the service, and the instances which are healthy keyed by instanceKey.
+// Instances with a critical or maintenance aggregated check status are considered unhealthy.
+func convertServiceEntries(entries []*api.ServiceEntry) ([]*api.CatalogService, map[string]*api.CatalogService) {
+	all := make([]*api.CatalogService, 0, len(entries))
+	healthy := make(map[string]*api.CatalogService, len(entries))
+	for _, entry := range entries {
+		if entry.Node == nil || entry.Service == nil {
+			continue
+		}
+		instance := &api.CatalogService{
+			ID:              entry.Node.ID,
+			Node:            entry.Node.Node,
+			Address:         entry.Node.Address,
+			Datacenter:      entry.Node.Datacenter,
+			TaggedAddresses: entry.Node.TaggedAddresses,
+			NodeMeta:        entry.Node.Meta,
+			ServiceID:       entry.Service.ID,
+			ServiceName:     entry.Service.Service,
+			ServiceAddress:  entry.Service.Address,
+			ServiceTags:     entry.Service.Tags,
+			ServiceMeta:     entry.Service.Meta,
+			ServicePort:     entry.Service.Port,
+		}
+		all = append(all, instance)
+
+		switch entry.Checks.AggregatedStatus() {
+		case api.HealthCritical, api.HealthMaint:
+			log.Debugf("Instance %s of service %s is unhealthy", instanceKey(instance), instance.ServiceName)
+		default:
+			healthy[instanceKey(instance)] = instance
+		}
+	}
+	return all, healthy
+}
+
+// instanceKey uniquely identifies a service instance in Consul.
+func instanceKey(instance *api.CatalogService) string {
+	return fmt.Sprintf("%s/%s/%s:%d", instance.Node, instance.ServiceID, instance.ServiceAddress, instance.ServicePort)
+}
+
 // serviceHostname produces FQDN for a consul service
 func serviceHostname(name string) host.Name {
 	// TODO include datacenter in Hostname?
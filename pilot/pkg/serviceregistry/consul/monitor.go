@@ -15,6 +15,9 @@
 package consul
 
 import (
+	"context"
+	"reflect"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/consul/api"
@@ -33,19 +36,38 @@ type Monitor interface {
 // InstanceHandler processes service instance change events
 type InstanceHandler func(instance *api.CatalogService, event model.Event) error
 
-// ServiceHandler processes service change events
+// ServiceHandler processes service change events. The instances passed to the handler
+// are all the instances of the service, regardless of their health.
 type ServiceHandler func(instances []*api.CatalogService, event model.Event) error
 
 type consulMonitor struct {
 	discovery        *api.Client
 	instanceHandlers []InstanceHandler
 	serviceHandlers  []ServiceHandler
+
+	// handlerMutex serializes the handler calls made by the service watches, and protects
+	// the instances of each serviceWatch.
+	handlerMutex sync.Mutex
+}
+
+// serviceWatch holds the state of the blocking query watching a single Consul service.
+type serviceWatch struct {
+	name   string
+	cancel context.CancelFunc
+	// index is the X-Consul-Index of the last health query for the service.
+	index uint64
+	// all holds every instance of the service, regardless of their health.
+	all []*api.CatalogService
+	// healthy holds the instances that are not failing their health checks, keyed by instanceKey.
+	healthy map[string]*api.CatalogService
 }
 
 const (
-	refreshIdleTime    time.Duration = 5 * time.Second
-	periodicCheckTime  time.Duration = 2 * time.Second
+	// blockQueryWaitTime is the maximum time a blocking query waits for a change.
 	blockQueryWaitTime time.Duration = 10 * time.Minute
+	// retryInterval is the time to wait before querying Consul again after an error, or after
+	// a blocking query returned without any change.
+	retryInterval time.Duration = 2 * time.Second
 )
 
 // NewConsulMonitor watches for changes in Consul services and CatalogServices
@@ -58,90 +80,164 @@ func NewConsulMonitor(client *api.Client) Monitor {
 }
 
 func (m *consulMonitor) Start(stop <-chan struct{}) {
-	change := make(chan struct{})
-	go m.watchConsul(change, stop)
-	go m.updateRecord(change, stop)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stop
+		cancel()
+	}()
+	go m.watchServices(ctx)
 }
 
-func (m *consulMonitor) watchConsul(change chan struct{}, stop <-chan struct{}) {
+// watchServices runs a blocking query on the Consul catalog services, and starts or stops a
+// serviceWatch for every service added to or removed from the catalog.
+func (m *consulMonitor) watchServices(ctx context.Context) {
+	watches := make(map[string]*serviceWatch)
 	var consulWaitIndex uint64
 
 	for {
-		select {
-		case <-stop:
+		queryOptions := &api.QueryOptions{
+			WaitIndex: consulWaitIndex,
+			WaitTime:  blockQueryWaitTime,
+		}
+		// This Consul REST API will block until services are added or removed, or timeout
+		services, queryMeta, err := m.discovery.Catalog().Services(queryOptions.WithContext(ctx))
+		if ctx.Err() != nil {
 			return
-		default:
-			queryOptions := api.QueryOptions{
-				WaitIndex: consulWaitIndex,
-				WaitTime:  blockQueryWaitTime,
+		}
+		if err != nil {
+			log.Warnf("Could not fetch services: %v", err)
+			if !sleep(ctx, retryInterval) {
+				return
 			}
-			// This Consul REST API will block until service changes or timeout
-			_, queryMeta, err := m.discovery.Catalog().Services(&queryOptions)
-			if err != nil {
-				log.Warnf("Could not fetch services: %v", err)
-			} else if consulWaitIndex != queryMeta.LastIndex {
-				consulWaitIndex = queryMeta.LastIndex
-				change <- struct{}{}
+			continue
+		}
+		if queryMeta.LastIndex == consulWaitIndex {
+			if !sleep(ctx, retryInterval) {
+				return
+			}
+			continue
+		}
+		consulWaitIndex = nextWaitIndex(consulWaitIndex, queryMeta.LastIndex)
+
+		for name := range services {
+			if _, f := watches[name]; !f {
+				watchCtx, cancel := context.WithCancel(ctx)
+				w := &serviceWatch{name: name, cancel: cancel}
+				watches[name] = w
+				go m.watchService(watchCtx, w)
+			}
+		}
+		for name, w := range watches {
+			if _, f := services[name]; !f {
+				delete(watches, name)
+				m.removeService(w)
 			}
-			time.Sleep(periodicCheckTime)
 		}
 	}
 }
 
-func (m *consulMonitor) updateRecord(change <-chan struct{}, stop <-chan struct{}) {
-	lastChange := int64(0)
-	ticker := time.NewTicker(periodicCheckTime)
-
+// watchService runs a blocking query on the health of the instances of a single service,
+// until the context is cancelled.
+func (m *consulMonitor) watchService(ctx context.Context, w *serviceWatch) {
 	for {
-		select {
-		case <-change:
-			lastChange = time.Now().Unix()
-		case <-ticker.C:
-			currentTime := time.Now().Unix()
-			if lastChange > 0 && currentTime-lastChange > int64(refreshIdleTime.Seconds()) {
-				log.Infof("Consul service changed")
-				m.updateServiceRecord()
-				m.updateInstanceRecord()
-				lastChange = int64(0)
-			}
-		case <-stop:
-			ticker.Stop()
+		queryOptions := &api.QueryOptions{
+			WaitIndex: w.index,
+			WaitTime:  blockQueryWaitTime,
+		}
+		// This Consul REST API will block until the service instances or their health change, or timeout
+		entries, queryMeta, err := m.discovery.Health().Service(w.name, "", false, queryOptions.WithContext(ctx))
+		if ctx.Err() != nil {
 			return
 		}
+		if err != nil {
+			log.Warnf("Could not fetch instances of service %s: %v", w.name, err)
+			if !sleep(ctx, retryInterval) {
+				return
+			}
+			continue
+		}
+		if queryMeta.LastIndex == w.index {
+			if !sleep(ctx, retryInterval) {
+				return
+			}
+			continue
+		}
+		w.index = nextWaitIndex(w.index, queryMeta.LastIndex)
+
+		all, healthy := convertServiceEntries(entries)
+		m.updateService(ctx, w, all, healthy)
 	}
 }
 
-func (m *consulMonitor) updateServiceRecord() {
-	// This is only a work-around solution currently
-	// Since Handler functions generally act as a refresher
-	// regardless of the input, thus passing in meaningless
-	// input should make functionalities work
-	//TODO
-	var obj []*api.CatalogService
-	var event model.Event
+// updateService calls the handlers for the changes between the previous and the current
+// instances of a watched service.
+func (m *consulMonitor) updateService(ctx context.Context, w *serviceWatch, all []*api.CatalogService,
+	healthy map[string]*api.CatalogService) {
+	m.handlerMutex.Lock()
+	defer m.handlerMutex.Unlock()
+
+	// The service may have been removed while the query was in flight.
+	if ctx.Err() != nil {
+		return
+	}
+
+	previousAll, previousHealthy := w.all, w.healthy
+	w.all, w.healthy = all, healthy
+
+	// A service is only known to Istio while it has instances, since the service is built from them.
+	switch {
+	case len(previousAll) == 0 && len(all) > 0:
+		m.notifyService(all, model.EventAdd)
+	case len(previousAll) > 0 && len(all) > 0 && serviceChanged(previousAll, all):
+		m.notifyService(all, model.EventUpdate)
+	}
+
+	for key, instance := range healthy {
+		if previous, f := previousHealthy[key]; !f {
+			m.notifyInstance(instance, model.EventAdd)
+		} else if !reflect.DeepEqual(previous, instance) {
+			m.notifyInstance(instance, model.EventUpdate)
+		}
+	}
+	for key, instance := range previousHealthy {
+		if _, f := healthy[key]; !f {
+			m.notifyInstance(instance, model.EventDelete)
+		}
+	}
+
+	if len(previousAll) > 0 && len(all) == 0 {
+		m.notifyService(previousAll, model.EventDelete)
+	}
+}
+
+// removeService stops the watch of a service removed from the catalog, and deletes its instances.
+func (m *consulMonitor) removeService(w *serviceWatch) {
+	w.cancel()
+
+	m.handlerMutex.Lock()
+	defer m.handlerMutex.Unlock()
+
+	for _, instance := range w.healthy {
+		m.notifyInstance(instance, model.EventDelete)
+	}
+	if len(w.all) > 0 {
+		m.notifyService(w.all, model.EventDelete)
+	}
+}
+
+func (m *consulMonitor) notifyService(instances []*api.CatalogService, event model.Event) {
 	for _, f := range m.serviceHandlers {
-		go func(handler ServiceHandler) {
-			if err := handler(obj, event); err != nil {
-				log.Warnf("Error executing service handler function: %v", err)
-			}
-		}(f)
+		if err := f(instances, event); err != nil {
+			log.Warnf("Error executing service handler function: %v", err)
+		}
 	}
 }
 
-func (m *consulMonitor) updateInstanceRecord() {
-	// This is only a work-around solution currently
-	// Since Handler functions generally act as a refresher
-	// regardless of the input, thus passing in meaningless
-	// input should make functionalities work
-	// TODO
-	obj := &api.CatalogService{}
-	var event model.Event
+func (m *consulMonitor) notifyInstance(instance *api.CatalogService, event model.Event) {
 	for _, f := range m.instanceHandlers {
-		go func(handler InstanceHandler) {
-			if err := handler(obj, event); err != nil {
-				log.Warnf("Error executing instance handler function: %v", err)
-			}
-		}(f)
+		if err := f(instance, event); err != nil {
+			log.Warnf("Error executing instance handler function: %v", err)
+		}
 	}
 }
 
@@ -152,3 +248,32 @@ func (m *consulMonitor) AppendServiceHandler(h ServiceHandler) {
 func (m *consulMonitor) AppendInstanceHandler(h InstanceHandler) {
 	m.instanceHandlers = append(m.instanceHandlers, h)
 }
+
+// serviceChanged returns true if the Istio service built from the instances changed.
+func serviceChanged(previous, current []*api.CatalogService) bool {
+	prev, curr := convertService(previous), convertService(current)
+	return prev.MeshExternal != curr.MeshExternal ||
+		prev.Resolution != curr.Resolution ||
+		!reflect.DeepEqual(prev.Ports, curr.Ports)
+}
+
+// nextWaitIndex returns the index to use for the next blocking query. As recommended by Consul,
+// the index is reset if it goes backwards, which happens when the Consul state is restored.
+func nextWaitIndex(previous, last uint64) uint64 {
+	if last < previous {
+		return 0
+	}
+	return last
+}
+
+// sleep waits for the duration, and returns false if the context is cancelled first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -25,6 +25,11 @@ import (
 
 const notifyThreshold = 10 * time.Second
 
+type monitorEvent struct {
+	key   string
+	event model.Event
+}
+
 func TestController(t *testing.T) {
 	ts := newServer()
 	defer ts.server.Close()
@@ -36,16 +41,17 @@ func TestController(t *testing.T) {
 		t.Errorf("could not create Consul Controller: %v", err)
 	}
 
-	updateChannel := make(chan struct{}, 10)
+	instanceEvents := make(chan monitorEvent, 100)
+	serviceEvents := make(chan monitorEvent, 100)
 
 	ctl := NewConsulMonitor(cl)
 	ctl.AppendInstanceHandler(func(instance *api.CatalogService, event model.Event) error {
-		updateChannel <- struct{}{}
+		instanceEvents <- monitorEvent{key: instanceKey(instance), event: event}
 		return nil
 	})
 
 	ctl.AppendServiceHandler(func(instances []*api.CatalogService, event model.Event) error {
-		updateChannel <- struct{}{}
+		serviceEvents <- monitorEvent{key: instances[0].ServiceName, event: event}
 		return nil
 	})
 
@@ -53,27 +59,85 @@ func TestController(t *testing.T) {
 	go ctl.Start(stop)
 	defer close(stop)
 
-	expectNotify := func(t *testing.T, times int) {
+	expectNotify := func(t *testing.T, events chan monitorEvent, want ...monitorEvent) {
 		t.Helper()
-		for i := 0; i < times; i++ {
+		got := map[monitorEvent]bool{}
+		for i := 0; i < len(want); i++ {
 			select {
-			case <-updateChannel:
-				continue
+			case e := <-events:
+				got[e] = true
 			case <-time.After(notifyThreshold):
-				t.Fatalf("got %d notifications from controller, want %d", i, times)
+				t.Fatalf("got %d notifications from controller, want %d", i, len(want))
 			}
 		}
+		for _, e := range want {
+			if !got[e] {
+				t.Fatalf("missing notification %v, got %v", e, got)
+			}
+		}
+	}
+	expectNoNotify := func(t *testing.T, events chan monitorEvent) {
+		t.Helper()
+		select {
+		case e := <-events:
+			t.Fatalf("got unexpected notification %v", e)
+		case <-time.After(2 * retryInterval):
+		}
 	}
 
-	//The first query from monitor to Consul always doesn't block because the index is 0
-	expectNotify(t, 2)
+	//The first query of each service doesn't block because the index is 0, so every service and instance is added
+	expectNotify(t, serviceEvents,
+		monitorEvent{"productpage", model.EventAdd},
+		monitorEvent{"reviews", model.EventAdd},
+		monitorEvent{"rating", model.EventAdd})
+	expectNotify(t, instanceEvents,
+		monitorEvent{"istio-node/productpage/172.19.0.11:9080", model.EventAdd},
+		monitorEvent{"istio-node/reviews-id/172.19.0.6:9081", model.EventAdd},
+		monitorEvent{"istio-node/reviews-id/172.19.0.7:9081", model.EventAdd},
+		monitorEvent{"istio-node/reviews-id/172.19.0.8:9080", model.EventAdd},
+		monitorEvent{"istio-node/rating-id/172.19.0.12:9080", model.EventAdd})
 
-	//There won't be any notifications if X-Consul-Index doesn't change
-	expectNotify(t, 0)
+	//There won't be any notifications if X-Consul-Index changes without any change to the instances
+	ts.lock.Lock()
+	ts.consulIndex++
+	ts.lock.Unlock()
+	expectNoNotify(t, instanceEvents)
+	expectNoNotify(t, serviceEvents)
+
+	//Only the instance failing its health check is removed
+	ts.lock.Lock()
+	ts.unhealthy["172.19.0.7"] = true
+	ts.consulIndex++
+	ts.lock.Unlock()
+	expectNotify(t, instanceEvents, monitorEvent{"istio-node/reviews-id/172.19.0.7:9081", model.EventDelete})
+	expectNoNotify(t, serviceEvents)
+
+	//Instance changes are sent as updates
+	ts.lock.Lock()
+	ts.unhealthy = map[string]bool{}
+	ts.rating = []*api.CatalogService{
+		{
+			Node:           "istio-node",
+			Address:        "172.19.0.6",
+			ID:             "istio-node-id",
+			ServiceID:      "rating-id",
+			ServiceName:    "rating",
+			ServiceTags:    []string{"version|v2"},
+			ServiceAddress: "172.19.0.12",
+			ServicePort:    9080,
+		},
+	}
+	ts.consulIndex++
+	ts.lock.Unlock()
+	expectNotify(t, instanceEvents,
+		monitorEvent{"istio-node/reviews-id/172.19.0.7:9081", model.EventAdd},
+		monitorEvent{"istio-node/rating-id/172.19.0.12:9080", model.EventUpdate})
 
-	//X-Consul-Index change means that the Consul Catalog changes, so there will be notifications
+	//Removing a service from the catalog deletes the service and its instances
 	ts.lock.Lock()
+	delete(ts.services, "productpage")
 	ts.consulIndex++
 	ts.lock.Unlock()
-	expectNotify(t, 2)
+	expectNotify(t, instanceEvents, monitorEvent{"istio-node/productpage/172.19.0.11:9080", model.EventDelete})
+	expectNotify(t, serviceEvents, monitorEvent{"productpage", model.EventDelete})
 }
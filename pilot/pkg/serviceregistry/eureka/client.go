@@ -0,0 +1,162 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eureka
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client for Eureka
+type Client interface {
+	// Applications registered on the Eureka server
+	Applications() ([]*application, error)
+}
+
+// Minimal client for Eureka server's REST APIs.
+// TODO: caching
+// TODO: Eureka v3 support
+type client struct {
+	client http.Client
+	url    string
+}
+
+const (
+	statusUp        = "UP"
+	appsPath        = "/apps"
+	clientTimeout   = 30 * time.Second
+	acceptHeader    = "Accept"
+	mediaTypeJSON   = "application/json"
+	metadataClass   = "@class"
+	requestFailedFn = "eureka %s request failed: %v"
+)
+
+type getApplications struct {
+	Applications applications `json:"applications"`
+}
+
+type applications struct {
+	Applications []*application `json:"application"`
+}
+
+type application struct {
+	Name      string      `json:"name"`
+	Instances []*instance `json:"instance"`
+}
+
+type instance struct { // nolint: maligned
+	InstanceID string   `json:"instanceId,omitempty"`
+	Hostname   string   `json:"hostName"`
+	App        string   `json:"app"`
+	IPAddress  string   `json:"ipAddr"`
+	Status     string   `json:"status"`
+	VIPAddress string   `json:"vipAddress,omitempty"`
+	Port       port     `json:"port,omitempty"`
+	SecurePort port     `json:"securePort,omitempty"`
+	Metadata   metadata `json:"metadata,omitempty"`
+}
+
+type port struct {
+	Port    int  `json:"$,string"`
+	Enabled bool `json:"@enabled,string"`
+}
+
+// UnmarshalJSON accepts ports where the values are either JSON strings or numbers and booleans, since
+// both forms are produced by the different Eureka JSON codecs.
+func (p *port) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Port    json.RawMessage `json:"$"`
+		Enabled json.RawMessage `json:"@enabled"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if len(raw.Port) > 0 {
+		n, err := strconv.Atoi(strings.Trim(string(raw.Port), `"`))
+		if err != nil {
+			return fmt.Errorf("invalid port %s: %v", raw.Port, err)
+		}
+		p.Port = n
+	}
+	if len(raw.Enabled) > 0 {
+		enabled, err := strconv.ParseBool(strings.Trim(string(raw.Enabled), `"`))
+		if err != nil {
+			return fmt.Errorf("invalid port enabled flag %s: %v", raw.Enabled, err)
+		}
+		p.Enabled = enabled
+	}
+	return nil
+}
+
+type metadata map[string]string
+
+// UnmarshalJSON drops the non string values of the metadata, such as the Java class hint
+// added by the Eureka JSON codec.
+func (m *metadata) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	out := make(metadata, len(raw))
+	for k, v := range raw {
+		if s, ok := v.(string); ok && k != metadataClass {
+			out[k] = s
+		}
+	}
+	*m = out
+	return nil
+}
+
+// NewClient instantiates a new Eureka client
+func NewClient(url string) Client {
+	return &client{
+		client: http.Client{Timeout: clientTimeout},
+		url:    strings.TrimSuffix(url, "/"),
+	}
+}
+
+func (c *client) Applications() ([]*application, error) {
+	req, err := http.NewRequest("GET", c.url+appsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(acceptHeader, mediaTypeJSON)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(requestFailedFn, appsPath, err)
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf(requestFailedFn, appsPath, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(requestFailedFn, appsPath, fmt.Sprintf("%s: %s", resp.Status, data))
+	}
+
+	var apps getApplications
+	if err = json.Unmarshal(data, &apps); err != nil {
+		return nil, fmt.Errorf(requestFailedFn, appsPath, err)
+	}
+
+	return apps.Applications.Applications, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eureka
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const appsResponse = `{
+  "applications": {
+    "versions__delta": "1",
+    "apps__hashcode": "UP_2_",
+    "application": [
+      {
+        "name": "REVIEWS",
+        "instance": [
+          {
+            "instanceId": "reviews-1",
+            "hostName": "reviews-1.example.com",
+            "app": "REVIEWS",
+            "ipAddr": "10.0.0.1",
+            "status": "UP",
+            "vipAddress": "reviews",
+            "port": {"$": "9080", "@enabled": "true"},
+            "securePort": {"$": 443, "@enabled": false},
+            "metadata": {"@class": "java.util.Collections$EmptyMap", "version": "v1"}
+          },
+          {
+            "instanceId": "reviews-2",
+            "hostName": "reviews-2.example.com",
+            "app": "REVIEWS",
+            "ipAddr": "10.0.0.2",
+            "status": "DOWN",
+            "vipAddress": "reviews",
+            "port": {"$": 9080, "@enabled": true},
+            "securePort": {"$": "443", "@enabled": "false"}
+          }
+        ]
+      }
+    ]
+  }
+}`
+
+func TestClientApplications(t *testing.T) {
+	var accept string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eureka/apps" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		accept = r.Header.Get("Accept")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, appsResponse)
+	}))
+	defer ts.Close()
+
+	apps, err := NewClient(ts.URL + "/eureka/").Applications()
+	if err != nil {
+		t.Fatalf("Applications() failed: %v", err)
+	}
+	if accept != "application/json" {
+		t.Errorf("Applications() requested %q, want application/json", accept)
+	}
+
+	want := []*application{
+		{
+			Name: "REVIEWS",
+			Instances: []*instance{
+				{
+					InstanceID: "reviews-1",
+					Hostname:   "reviews-1.example.com",
+					App:        "REVIEWS",
+					IPAddress:  "10.0.0.1",
+					Status:     "UP",
+					VIPAddress: "reviews",
+					Port:       port{Port: 9080, Enabled: true},
+					SecurePort: port{Port: 443},
+					Metadata:   metadata{"version": "v1"},
+				},
+				{
+					InstanceID: "reviews-2",
+					Hostname:   "reviews-2.example.com",
+					App:        "REVIEWS",
+					IPAddress:  "10.0.0.2",
+					Status:     "DOWN",
+					VIPAddress: "reviews",
+					Port:       port{Port: 9080, Enabled: true},
+					SecurePort: port{Port: 443},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(apps, want) {
+		t.Errorf("Applications() => %#v, want %#v", apps, want)
+	}
+}
+
+func TestClientApplicationsError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	if _, err := NewClient(ts.URL).Applications(); err == nil {
+		t.Error("Applications() should fail when the server returns an error")
+	}
+
+	ts.Close()
+	if _, err := NewClient(ts.URL).Applications(); err == nil {
+		t.Error("Applications() should fail when the server is not reachable")
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eureka
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"istio.io/pkg/log"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/serviceregistry"
+	"istio.io/istio/pkg/config/host"
+	"istio.io/istio/pkg/config/labels"
+	"istio.io/istio/pkg/spiffe"
+)
+
+var _ serviceregistry.Instance = &Controller{}
+
+const pollInterval = 2 * time.Second
+
+// Controller communicates with Eureka and monitors for changes
+type Controller struct {
+	client       Client
+	clusterID    string
+	pollInterval time.Duration
+
+	cacheMutex sync.RWMutex
+	initDone   bool
+	services   map[host.Name]*model.Service
+	// instances holds the instances which are UP, by service hostname and instanceKey
+	instances map[host.Name]map[string]*model.ServiceInstance
+
+	serviceHandlers  []func(*model.Service, model.Event)
+	instanceHandlers []func(*model.ServiceInstance, model.Event)
+}
+
+// NewController creates a new Eureka controller
+func NewController(client Client, clusterID string) *Controller {
+	return &Controller{
+		client:       client,
+		clusterID:    clusterID,
+		pollInterval: pollInterval,
+	}
+}
+
+func (c *Controller) Provider() serviceregistry.ProviderID {
+	return serviceregistry.Eureka
+}
+
+func (c *Controller) Cluster() string {
+	return c.clusterID
+}
+
+// Services list declarations of all services in the system
+func (c *Controller) Services() ([]*model.Service, error) {
+	if err := c.initCache(); err != nil {
+		return nil, err
+	}
+	c.cacheMutex.RLock()
+	defer c.cacheMutex.RUnlock()
+
+	out := make([]*model.Service, 0, len(c.services))
+	for _, service := range c.services {
+		out = append(out, service)
+	}
+	return out, nil
+}
+
+// GetService retrieves a service by host name if it exists
+func (c *Controller) GetService(hostname host.Name) (*model.Service, error) {
+	if err := c.initCache(); err != nil {
+		return nil, err
+	}
+	c.cacheMutex.RLock()
+	defer c.cacheMutex.RUnlock()
+
+	return c.services[hostname], nil
+}
+
+// ManagementPorts retrieves set of health check ports by instance IP.
+// This does not apply to Eureka service registry, as Eureka does not
+// manage the service instances.
+func (c *Controller) ManagementPorts(addr string) model.PortList {
+	return nil
+}
+
+// WorkloadHealthCheckInfo retrieves set of health check info by instance IP.
+// This does not apply to Eureka service registry, as Eureka does not
+// manage the service instances.
+func (c *Controller) WorkloadHealthCheckInfo(addr string) model.ProbeList {
+	return nil
+}
+
+// InstancesByPort retrieves instances for a service that match
+// any of the supplied labels. All instances match an empty tag list.
+func (c *Controller) InstancesByPort(svc *model.Service, port int,
+	labels labels.Collection) ([]*model.ServiceInstance, error) {
+	if err := c.initCache(); err != nil {
+		return nil, err
+	}
+	c.cacheMutex.RLock()
+	defer c.cacheMutex.RUnlock()
+
+	if _, exists := c.services[svc.Hostname]; !exists {
+		return nil, fmt.Errorf("could not find instance of service: %s", svc.Hostname)
+	}
+
+	var out []*model.ServiceInstance
+	for _, instance := range c.instances[svc.Hostname] {
+		if labels.HasSubsetOf(instance.Endpoint.Labels) && (port == 0 || port == instance.ServicePort.Port) {
+			out = append(out, instance)
+		}
+	}
+	return out, nil
+}
+
+// GetProxyServiceInstances lists service instances co-located with a given proxy
+func (c *Controller) GetProxyServiceInstances(node *model.Proxy) ([]*model.ServiceInstance, error) {
+	if err := c.initCache(); err != nil {
+		return nil, err
+	}
+	c.cacheMutex.RLock()
+	defer c.cacheMutex.RUnlock()
+
+	out := make([]*model.ServiceInstance, 0)
+	for _, instances := range c.instances {
+		for _, instance := range instances {
+			if proxyHasAddress(node, instance.Endpoint.Address) {
+				out = append(out, instance)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (c *Controller) GetProxyWorkloadLabels(proxy *model.Proxy) (labels.Collection, error) {
+	instances, err := c.GetProxyServiceInstances(proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(labels.Collection, 0)
+	seen := make(map[string]bool)
+	for _, instance := range instances {
+		// An instance with several ports has one service instance per port, sharing the same labels.
+		key := string(instance.Service.Hostname) + "/" + instance.Endpoint.Address
+		if !seen[key] {
+			seen[key] = true
+			out = append(out, instance.Endpoint.Labels)
+		}
+	}
+	return out, nil
+}
+
+func proxyHasAddress(node *model.Proxy, addr string) bool {
+	for _, ipAddress := range node.IPAddresses {
+		if ipAddress == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// GetIstioServiceAccounts implements model.ServiceAccounts operation.
+// Eureka does not have service accounts, so all services are assumed
+// to run with the default service account, as for Consul.
+func (c *Controller) GetIstioServiceAccounts(svc *model.Service, ports []int) []string {
+	return []string{
+		spiffe.MustGenSpiffeURI("default", "default"),
+	}
+}
+
+// AppendServiceHandler implements a service catalog operation
+func (c *Controller) AppendServiceHandler(f func(*model.Service, model.Event)) error {
+	c.serviceHandlers = append(c.serviceHandlers, f)
+	return nil
+}
+
+// AppendInstanceHandler implements a service catalog operation
+func (c *Controller) AppendInstanceHandler(f func(*model.ServiceInstance, model.Event)) error {
+	c.instanceHandlers = append(c.instanceHandlers, f)
+	return nil
+}
+
+// Run polls the Eureka applications until a signal is received
+func (c *Controller) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := c.refresh(); err != nil {
+				log.Warnf("Could not refresh Eureka applications: %v", err)
+			}
+		}
+	}
+}
+
+// initCache reads the Eureka applications if they were never read.
+func (c *Controller) initCache() error {
+	c.cacheMutex.RLock()
+	initDone := c.initDone
+	c.cacheMutex.RUnlock()
+	if initDone {
+		return nil
+	}
+	return c.refresh()
+}
+
+// refresh reads the Eureka applications, updates the cache and notifies the handlers of
+// the services and instances which changed.
+func (c *Controller) refresh() error {
+	apps, err := c.client.Applications()
+	if err != nil {
+		return err
+	}
+	services := convertServices(apps)
+	instances := make(map[host.Name]map[string]*model.ServiceInstance, len(services))
+	for _, instance := range convertServiceInstances(services, apps) {
+		hostname := instance.Service.Hostname
+		if instances[hostname] == nil {
+			instances[hostname] = make(map[string]*model.ServiceInstance)
+		}
+		instances[hostname][instanceKey(instance)] = instance
+	}
+
+	c.cacheMutex.Lock()
+	previousServices, previousInstances, initDone := c.services, c.instances, c.initDone
+	c.services, c.instances, c.initDone = services, instances, true
+	c.cacheMutex.Unlock()
+
+	// The first read only fills the cache, other registries and the push context are
+	// initialized from the cache directly.
+	if !initDone {
+		return nil
+	}
+
+	for hostname, service := range services {
+		if previous, f := previousServices[hostname]; !f {
+			c.notifyService(service, model.EventAdd)
+		} else if !reflect.DeepEqual(previous, service) {
+			c.notifyService(service, model.EventUpdate)
+		}
+	}
+	for hostname, current := range instances {
+		previous := previousInstances[hostname]
+		for key, instance := range current {
+			if old, f := previous[key]; !f {
+				c.notifyInstance(instance, model.EventAdd)
+			} else if !reflect.DeepEqual(old, instance) {
+				c.notifyInstance(instance, model.EventUpdate)
+			}
+		}
+	}
+	for hostname, previous := range previousInstances {
+		current := instances[hostname]
+		for key, instance := range previous {
+			if _, f := current[key]; !f {
+				c.notifyInstance(instance, model.EventDelete)
+			}
+		}
+	}
+	for hostname, service := range previousServices {
+		if _, f := services[hostname]; !f {
+			c.notifyService(service, model.EventDelete)
+		}
+	}
+	return nil
+}
+
+func (c *Controller) notifyService(service *model.Service, event model.Event) {
+	for _, f := range c.serviceHandlers {
+		f(service, event)
+	}
+}
+
+func (c *Controller) notifyInstance(instance *model.ServiceInstance, event model.Event) {
+	for _, f := range c.instanceHandlers {
+		f(instance, event)
+	}
+}
+
+// instanceKey uniquely identifies a service instance of a service.
+func instanceKey(instance *model.ServiceInstance) string {
+	return fmt.Sprintf("%s:%d", instance.Endpoint.Address, instance.Endpoint.EndpointPort)
+}
@@ -0,0 +1,216 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eureka
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config/labels"
+)
+
+type fakeClient struct {
+	mutex sync.Mutex
+	apps  []*application
+	err   error
+}
+
+func (f *fakeClient) Applications() ([]*application, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.apps, f.err
+}
+
+func (f *fakeClient) setApps(apps []*application) {
+	f.mutex.Lock()
+	f.apps = apps
+	f.mutex.Unlock()
+}
+
+func newTestApps() []*application {
+	return []*application{
+		{
+			Name: "REVIEWS",
+			Instances: []*instance{
+				makeInstance("REVIEWS", "10.0.0.1", 9080, 0, metadata{"version": "v1"}),
+				makeInstance("REVIEWS", "10.0.0.2", 9080, 0, metadata{"version": "v2"}),
+			},
+		},
+		{
+			Name: "RATINGS",
+			Instances: []*instance{
+				makeInstance("RATINGS", "10.0.0.3", 7000, 0, metadata{"version": "v1"}),
+			},
+		},
+	}
+}
+
+func TestServices(t *testing.T) {
+	controller := NewController(&fakeClient{apps: newTestApps()}, "")
+
+	services, err := controller.Services()
+	if err != nil {
+		t.Fatalf("Services() failed: %v", err)
+	}
+	var names []string
+	for _, svc := range services {
+		names = append(names, string(svc.Hostname))
+	}
+	sort.Strings(names)
+	if !reflect.DeepEqual(names, []string{"ratings", "reviews"}) {
+		t.Errorf("Services() => %v, want [ratings reviews]", names)
+	}
+
+	svc, err := controller.GetService("reviews")
+	if err != nil || svc == nil {
+		t.Fatalf("GetService(reviews) => %v, %v", svc, err)
+	}
+	if svc, _ := controller.GetService("details"); svc != nil {
+		t.Errorf("GetService(details) => %v, want nil", svc)
+	}
+}
+
+func TestServicesError(t *testing.T) {
+	controller := NewController(&fakeClient{err: errors.New("unavailable")}, "")
+	if _, err := controller.Services(); err == nil {
+		t.Error("Services() should fail when Eureka is not available")
+	}
+}
+
+func TestInstancesByPort(t *testing.T) {
+	controller := NewController(&fakeClient{apps: newTestApps()}, "")
+	svc, _ := controller.GetService("reviews")
+
+	instances, err := controller.InstancesByPort(svc, 9080, labels.Collection{{"version": "v2"}})
+	if err != nil {
+		t.Fatalf("InstancesByPort() failed: %v", err)
+	}
+	if len(instances) != 1 || instances[0].Endpoint.Address != "10.0.0.2" {
+		t.Errorf("InstancesByPort() => %v, want the instance 10.0.0.2", instances)
+	}
+
+	instances, _ = controller.InstancesByPort(svc, 0, nil)
+	if len(instances) != 2 {
+		t.Errorf("InstancesByPort() returned %d instances, want 2", len(instances))
+	}
+
+	if _, err := controller.InstancesByPort(&model.Service{Hostname: "details"}, 0, nil); err == nil {
+		t.Error("InstancesByPort() should fail for an unknown service")
+	}
+}
+
+func TestGetProxyServiceInstances(t *testing.T) {
+	controller := NewController(&fakeClient{apps: newTestApps()}, "")
+	proxy := &model.Proxy{IPAddresses: []string{"10.0.0.3"}}
+
+	instances, err := controller.GetProxyServiceInstances(proxy)
+	if err != nil {
+		t.Fatalf("GetProxyServiceInstances() failed: %v", err)
+	}
+	if len(instances) != 1 || instances[0].Service.Hostname != "ratings" {
+		t.Errorf("GetProxyServiceInstances() => %v, want the ratings instance", instances)
+	}
+
+	workloadLabels, _ := controller.GetProxyWorkloadLabels(proxy)
+	if !reflect.DeepEqual(workloadLabels, labels.Collection{{"version": "v1"}}) {
+		t.Errorf("GetProxyWorkloadLabels() => %v, want version=v1", workloadLabels)
+	}
+}
+
+type controllerEvent struct {
+	key   string
+	event model.Event
+}
+
+func TestRefreshEvents(t *testing.T) {
+	client := &fakeClient{apps: newTestApps()}
+	controller := NewController(client, "")
+
+	var events []controllerEvent
+	_ = controller.AppendServiceHandler(func(svc *model.Service, event model.Event) {
+		events = append(events, controllerEvent{string(svc.Hostname), event})
+	})
+	_ = controller.AppendInstanceHandler(func(inst *model.ServiceInstance, event model.Event) {
+		events = append(events, controllerEvent{instanceKey(inst), event})
+	})
+
+	// The first read fills the cache without events
+	if err := controller.refresh(); err != nil {
+		t.Fatalf("refresh() failed: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("refresh() sent events on the first read: %v", events)
+	}
+
+	apps := newTestApps()
+	// reviews v2 goes down, its v1 instance gets a new label
+	apps[0].Instances[1].Status = "DOWN"
+	apps[0].Instances[0].Metadata["canary"] = "true"
+	// ratings is removed and details is added
+	apps[1] = &application{
+		Name:      "DETAILS",
+		Instances: []*instance{makeInstance("DETAILS", "10.0.0.4", 9080, 0, nil)},
+	}
+	client.setApps(apps)
+	if err := controller.refresh(); err != nil {
+		t.Fatalf("refresh() failed: %v", err)
+	}
+
+	want := []controllerEvent{
+		{"details", model.EventAdd},
+		{"10.0.0.4:9080", model.EventAdd},
+		{"10.0.0.1:9080", model.EventUpdate},
+		{"10.0.0.2:9080", model.EventDelete},
+		{"10.0.0.3:7000", model.EventDelete},
+		{"ratings", model.EventDelete},
+	}
+	if !sameEvents(events, want) {
+		t.Errorf("refresh() sent events %v, want %v", events, want)
+	}
+
+	// A refresh without changes sends no event
+	events = nil
+	if err := controller.refresh(); err != nil {
+		t.Fatalf("refresh() failed: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("refresh() sent events without changes: %v", events)
+	}
+}
+
+// sameEvents checks that both lists hold the same events, in any order as instance events are sent
+// in map order, and that the service addition comes first and the service deletion last.
+func sameEvents(got, want []controllerEvent) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	seen := make(map[controllerEvent]bool)
+	for _, e := range got {
+		seen[e] = true
+	}
+	for _, e := range want {
+		if !seen[e] {
+			return false
+		}
+	}
+	if got[0] != (controllerEvent{"details", model.EventAdd}) {
+		return false
+	}
+	return got[len(got)-1] == controllerEvent{"ratings", model.EventDelete}
+}
@@ -0,0 +1,184 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eureka
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"istio.io/pkg/log"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/serviceregistry"
+	"istio.io/istio/pkg/config/host"
+	"istio.io/istio/pkg/config/labels"
+	"istio.io/istio/pkg/config/protocol"
+)
+
+const (
+	// protocolMetadata is the instance metadata key holding the protocol of the (non secure) port
+	protocolMetadata = "istio.protocol"
+	// externalMetadata is the instance metadata key marking an instance as external to the mesh
+	externalMetadata = "istio.external"
+)
+
+// convertServices builds the Istio services from the Eureka applications. Every instance
+// contributes its ports to the service, regardless of its status.
+func convertServices(apps []*application) map[host.Name]*model.Service {
+	services := make(map[host.Name]*model.Service)
+	for _, app := range apps {
+		for _, inst := range app.Instances {
+			hostname := serviceHostname(app, inst)
+			if hostname == "" {
+				continue
+			}
+
+			service, exists := services[hostname]
+			if !exists {
+				service = &model.Service{
+					Hostname:   hostname,
+					Address:    "0.0.0.0",
+					Resolution: model.ClientSideLB,
+					Attributes: model.ServiceAttributes{
+						ServiceRegistry: string(serviceregistry.Eureka),
+						Name:            string(hostname),
+						Namespace:       model.IstioDefaultConfigNamespace,
+					},
+				}
+				services[hostname] = service
+			}
+
+			for _, port := range convertPorts(inst) {
+				if svcPort, exists := service.Ports.GetByPort(port.Port); exists {
+					if svcPort.Protocol != port.Protocol {
+						log.Warnf("Service %v has two instances on same port %v but different protocols (%v, %v)",
+							hostname, port.Port, svcPort.Protocol, port.Protocol)
+					}
+					continue
+				}
+				service.Ports = append(service.Ports, port)
+			}
+
+			// TODO This will not work if service is a mix of external and local services
+			if inst.Metadata[externalMetadata] != "" {
+				service.MeshExternal = true
+			}
+		}
+	}
+
+	for _, service := range services {
+		sort.Slice(service.Ports, func(i, j int) bool {
+			return service.Ports[i].Port < service.Ports[j].Port
+		})
+	}
+	return services
+}
+
+// convertServiceInstances builds the service instances of the Eureka instances which are UP.
+func convertServiceInstances(services map[host.Name]*model.Service, apps []*application) []*model.ServiceInstance {
+	out := make([]*model.ServiceInstance, 0)
+	for _, app := range apps {
+		for _, inst := range app.Instances {
+			if inst.Status != statusUp {
+				continue
+			}
+			service := services[serviceHostname(app, inst)]
+			if service == nil {
+				continue
+			}
+
+			instLabels := convertLabels(inst.Metadata)
+			tlsMode := model.GetTLSModeFromEndpointLabels(instLabels)
+			for _, port := range convertPorts(inst) {
+				out = append(out, &model.ServiceInstance{
+					Endpoint: &model.IstioEndpoint{
+						Address:         inst.IPAddress,
+						EndpointPort:    uint32(port.Port),
+						ServicePortName: port.Name,
+						Labels:          instLabels,
+						TLSMode:         tlsMode,
+						Attributes: model.ServiceAttributes{
+							Name:      service.Attributes.Name,
+							Namespace: service.Attributes.Namespace,
+						},
+					},
+					ServicePort: port,
+					Service:     service,
+				})
+			}
+		}
+	}
+	return out
+}
+
+// serviceHostname returns the hostname of the Istio service of an instance. The Eureka VIP address is
+// used when set, as this is the name Eureka clients use to look up the instances, or else the
+// application name.
+func serviceHostname(app *application, inst *instance) host.Name {
+	name := inst.VIPAddress
+	if name == "" {
+		name = app.Name
+	}
+	// Eureka allows multiple comma separated VIP addresses
+	name = strings.TrimSpace(strings.Split(name, ",")[0])
+	return host.Name(strings.ToLower(name))
+}
+
+func convertPorts(inst *instance) model.PortList {
+	out := make(model.PortList, 0, 2)
+	if inst.Port.Enabled {
+		protocol := convertProtocol(inst.Metadata[protocolMetadata])
+		out = append(out, &model.Port{
+			Name:     fmt.Sprintf("%s-%d", strings.ToLower(string(protocol)), inst.Port.Port),
+			Port:     inst.Port.Port,
+			Protocol: protocol,
+		})
+	}
+	if inst.SecurePort.Enabled {
+		out = append(out, &model.Port{
+			Name:     fmt.Sprintf("https-%d", inst.SecurePort.Port),
+			Port:     inst.SecurePort.Port,
+			Protocol: protocol.HTTPS,
+		})
+	}
+	return out
+}
+
+// convertLabels uses the instance metadata as labels, except for the keys used by Istio itself.
+func convertLabels(md metadata) labels.Instance {
+	out := make(labels.Instance, len(md))
+	for k, v := range md {
+		switch k {
+		case protocolMetadata, externalMetadata:
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func convertProtocol(name string) protocol.Instance {
+	if name == "" {
+		// Applications registered in Eureka are mostly REST services
+		return protocol.HTTP
+	}
+	p := protocol.Parse(name)
+	if p == protocol.Unsupported {
+		log.Warnf("unsupported protocol value: %s", name)
+		return protocol.TCP
+	}
+	return p
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eureka
+
+import (
+	"reflect"
+	"testing"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/serviceregistry"
+	"istio.io/istio/pkg/config/host"
+	"istio.io/istio/pkg/config/labels"
+	"istio.io/istio/pkg/config/protocol"
+)
+
+func makeInstance(app, ip string, portNum, securePort int, md metadata) *instance {
+	inst := &instance{
+		Hostname:  ip + ".example.com",
+		App:       app,
+		IPAddress: ip,
+		Status:    statusUp,
+		Metadata:  md,
+	}
+	if portNum > 0 {
+		inst.Port = port{Port: portNum, Enabled: true}
+	}
+	if securePort > 0 {
+		inst.SecurePort = port{Port: securePort, Enabled: true}
+	}
+	return inst
+}
+
+func TestConvertServices(t *testing.T) {
+	apps := []*application{
+		{
+			Name: "REVIEWS",
+			Instances: []*instance{
+				makeInstance("REVIEWS", "10.0.0.1", 9080, 0, metadata{"version": "v1"}),
+				makeInstance("REVIEWS", "10.0.0.2", 9080, 9443, metadata{"version": "v2"}),
+			},
+		},
+		{
+			Name: "RATINGS",
+			Instances: []*instance{
+				makeInstance("RATINGS", "10.0.0.3", 7000, 0, metadata{protocolMetadata: "grpc"}),
+			},
+		},
+	}
+	apps[1].Instances[0].VIPAddress = "ratings.default.svc.cluster.local,ratings"
+
+	services := convertServices(apps)
+	want := map[host.Name]*model.Service{
+		"reviews": {
+			Hostname:   "reviews",
+			Address:    "0.0.0.0",
+			Resolution: model.ClientSideLB,
+			Ports: model.PortList{
+				{Name: "http-9080", Port: 9080, Protocol: protocol.HTTP},
+				{Name: "https-9443", Port: 9443, Protocol: protocol.HTTPS},
+			},
+			Attributes: model.ServiceAttributes{
+				ServiceRegistry: string(serviceregistry.Eureka),
+				Name:            "reviews",
+				Namespace:       model.IstioDefaultConfigNamespace,
+			},
+		},
+		"ratings.default.svc.cluster.local": {
+			Hostname:   "ratings.default.svc.cluster.local",
+			Address:    "0.0.0.0",
+			Resolution: model.ClientSideLB,
+			Ports: model.PortList{
+				{Name: "grpc-7000", Port: 7000, Protocol: protocol.GRPC},
+			},
+			Attributes: model.ServiceAttributes{
+				ServiceRegistry: string(serviceregistry.Eureka),
+				Name:            "ratings.default.svc.cluster.local",
+				Namespace:       model.IstioDefaultConfigNamespace,
+			},
+		},
+	}
+	if !reflect.DeepEqual(services, want) {
+		t.Errorf("convertServices() => %#v, want %#v", services, want)
+	}
+}
+
+func TestConvertServiceInstances(t *testing.T) {
+	apps := []*application{
+		{
+			Name: "REVIEWS",
+			Instances: []*instance{
+				makeInstance("REVIEWS", "10.0.0.1", 9080, 9443, metadata{"version": "v1", externalMetadata: ""}),
+				makeInstance("REVIEWS", "10.0.0.2", 9080, 0, metadata{"version": "v2"}),
+			},
+		},
+	}
+	apps[0].Instances[1].Status = "OUT_OF_SERVICE"
+
+	services := convertServices(apps)
+	instances := convertServiceInstances(services, apps)
+	if len(instances) != 2 {
+		t.Fatalf("convertServiceInstances() returned %d instances, want 2", len(instances))
+	}
+	for i, wantPort := range []int{9080, 9443} {
+		inst := instances[i]
+		if inst.Endpoint.Address != "10.0.0.1" || int(inst.Endpoint.EndpointPort) != wantPort {
+			t.Errorf("convertServiceInstances() returned endpoint %s:%d, want 10.0.0.1:%d",
+				inst.Endpoint.Address, inst.Endpoint.EndpointPort, wantPort)
+		}
+		if inst.ServicePort.Port != wantPort || inst.Endpoint.ServicePortName != inst.ServicePort.Name {
+			t.Errorf("convertServiceInstances() returned service port %v for endpoint port %d", inst.ServicePort, wantPort)
+		}
+		if inst.Service != services["reviews"] {
+			t.Errorf("convertServiceInstances() returned service %v, want reviews", inst.Service.Hostname)
+		}
+		if !reflect.DeepEqual(inst.Endpoint.Labels, labels.Instance{"version": "v1"}) {
+			t.Errorf("convertServiceInstances() returned labels %v, want version=v1", inst.Endpoint.Labels)
+		}
+	}
+}
+
+func TestConvertProtocol(t *testing.T) {
+	cases := map[string]protocol.Instance{
+		"":      protocol.HTTP,
+		"http2": protocol.HTTP2,
+		"tcp":   protocol.TCP,
+		"bogus": protocol.TCP,
+	}
+	for name, want := range cases {
+		if got := convertProtocol(name); got != want {
+			t.Errorf("convertProtocol(%q) => %v, want %v", name, got, want)
+		}
+	}
+}
@@ -72,6 +72,8 @@ func TestConvertProtocol(t *testing.T) {
 		{8888, "redis-test", coreV1.ProtocolTCP, protocol.Redis},
 		{8888, "mysql", coreV1.ProtocolTCP, protocol.MySQL},
 		{8888, "mysql-test", coreV1.ProtocolTCP, protocol.MySQL},
+		{8888, "kafka", coreV1.ProtocolTCP, protocol.Kafka},
+		{8888, "kafka-broker", coreV1.ProtocolTCP, protocol.Kafka},
 	}
 
 	// Create the list of cases for all of the names in both upper and lowercase.
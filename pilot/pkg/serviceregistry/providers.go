@@ -24,6 +24,8 @@ const (
 	Kubernetes ProviderID = "Kubernetes"
 	// Consul is a service registry backed by Consul
 	Consul ProviderID = "Consul"
+	// Eureka is a service registry backed by Netflix Eureka
+	Eureka ProviderID = "Eureka"
 	// MCP is a service registry backed by MCP ServiceEntries
 	MCP ProviderID = "MCP"
 	// External is a service registry for externally provided ServiceEntries
@@ -31,6 +31,7 @@ import (
 	"github.com/envoyproxy/go-control-plane/pkg/conversion"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
 	pstruct "github.com/golang/protobuf/ptypes/struct"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -55,6 +56,9 @@ type Config struct {
 	// IP is currently the primary key used to locate inbound configs. It is sent by client,
 	// must match a known endpoint IP. Tests can use a ServiceEntry to register fake IPs.
 	IP string
+
+	// Delta uses the incremental (delta) variant of ADS instead of state of the world.
+	Delta bool
 }
 
 // ADSC implements a basic client for ADS, for use in stress tests and tools
@@ -64,6 +68,19 @@ type ADSC struct {
 	// Set after Dial is called.
 	stream ads.AggregatedDiscoveryService_StreamAggregatedResourcesClient
 
+	// deltaStream is used instead of stream when the delta protocol is enabled.
+	deltaStream ads.AggregatedDiscoveryService_DeltaAggregatedResourcesClient
+
+	// delta is set if the connection should use the delta protocol.
+	delta bool
+
+	// deltaResources holds the resources received on a delta stream, keyed by type and name.
+	// Responses only carry changes, the handlers are called with the merged state.
+	deltaResources map[string]map[string]*any.Any
+
+	// deltaWatches holds the resource names subscribed on a delta stream, keyed by type.
+	deltaWatches map[string]map[string]struct{}
+
 	conn *grpc.ClientConn
 
 	// NodeID is the node identity sent to Pilot.
@@ -132,6 +149,7 @@ func Dial(url string, certDir string, opts *Config) (*ADSC, error) {
 		VersionInfo: map[string]string{},
 		certDir:     certDir,
 		url:         url,
+		delta:       opts.Delta,
 	}
 	if opts.Namespace == "" {
 		opts.Namespace = "default"
@@ -235,6 +253,17 @@ func (a *ADSC) Run() error {
 	}
 
 	xds := ads.NewAggregatedDiscoveryServiceClient(a.conn)
+	if a.delta {
+		deltastr, err := xds.DeltaAggregatedResources(context.Background())
+		if err != nil {
+			return err
+		}
+		a.deltaStream = deltastr
+		a.deltaResources = map[string]map[string]*any.Any{}
+		a.deltaWatches = map[string]map[string]struct{}{}
+		go a.handleDeltaRecv()
+		return nil
+	}
 	edsstr, err := xds.StreamAggregatedResources(context.Background())
 	if err != nil {
 		return err
@@ -248,58 +277,97 @@ func (a *ADSC) handleRecv() {
 	for {
 		msg, err := a.stream.Recv()
 		if err != nil {
-			adscLog.Infof("Connection closed for node %v with err: %v", a.nodeID, err)
-			a.Close()
-			a.WaitClear()
-			a.Updates <- "close"
+			a.closed(err)
 			return
 		}
 
-		listeners := []*xdsapi.Listener{}
-		clusters := []*xdsapi.Cluster{}
-		routes := []*xdsapi.RouteConfiguration{}
-		eds := []*xdsapi.ClusterLoadAssignment{}
-		for _, rsc := range msg.Resources { // Any
-			a.VersionInfo[rsc.TypeUrl] = msg.VersionInfo
-			valBytes := rsc.Value
-			if rsc.TypeUrl == listenerType {
-				ll := &xdsapi.Listener{}
-				_ = proto.Unmarshal(valBytes, ll)
-				listeners = append(listeners, ll)
-			} else if rsc.TypeUrl == clusterType {
-				ll := &xdsapi.Cluster{}
-				_ = proto.Unmarshal(valBytes, ll)
-				clusters = append(clusters, ll)
-			} else if rsc.TypeUrl == endpointType {
-				ll := &xdsapi.ClusterLoadAssignment{}
-				_ = proto.Unmarshal(valBytes, ll)
-				eds = append(eds, ll)
-			} else if rsc.TypeUrl == routeType {
-				ll := &xdsapi.RouteConfiguration{}
-				_ = proto.Unmarshal(valBytes, ll)
-				routes = append(routes, ll)
-			}
-		}
-
 		// TODO: add hook to inject nacks
 		a.mutex.Lock()
 		a.ack(msg)
 		a.mutex.Unlock()
 
-		if len(listeners) > 0 {
-			a.handleLDS(listeners)
+		a.handleResources(msg.VersionInfo, msg.Resources)
+	}
+}
+
+func (a *ADSC) handleDeltaRecv() {
+	for {
+		msg, err := a.deltaStream.Recv()
+		if err != nil {
+			a.closed(err)
+			return
+		}
+
+		a.mutex.Lock()
+		known := a.deltaResources[msg.TypeUrl]
+		if known == nil {
+			known = map[string]*any.Any{}
+			a.deltaResources[msg.TypeUrl] = known
 		}
-		if len(clusters) > 0 {
-			a.handleCDS(clusters)
+		for _, r := range msg.Resources {
+			known[r.Name] = r.Resource
 		}
-		if len(eds) > 0 {
-			a.handleEDS(eds)
+		for _, name := range msg.RemovedResources {
+			delete(known, name)
 		}
-		if len(routes) > 0 {
-			a.handleRDS(routes)
+		resources := make([]*any.Any, 0, len(known))
+		for _, r := range known {
+			resources = append(resources, r)
+		}
+		a.ackDelta(msg)
+		a.mutex.Unlock()
+
+		a.handleResources(msg.SystemVersionInfo, resources)
+	}
+}
+
+func (a *ADSC) closed(err error) {
+	adscLog.Infof("Connection closed for node %v with err: %v", a.nodeID, err)
+	a.Close()
+	a.WaitClear()
+	a.Updates <- "close"
+}
+
+// handleResources dispatches the current set of resources of a response to the handler of their type.
+func (a *ADSC) handleResources(versionInfo string, resources []*any.Any) {
+	listeners := []*xdsapi.Listener{}
+	clusters := []*xdsapi.Cluster{}
+	routes := []*xdsapi.RouteConfiguration{}
+	eds := []*xdsapi.ClusterLoadAssignment{}
+	for _, rsc := range resources { // Any
+		a.VersionInfo[rsc.TypeUrl] = versionInfo
+		valBytes := rsc.Value
+		if rsc.TypeUrl == listenerType {
+			ll := &xdsapi.Listener{}
+			_ = proto.Unmarshal(valBytes, ll)
+			listeners = append(listeners, ll)
+		} else if rsc.TypeUrl == clusterType {
+			ll := &xdsapi.Cluster{}
+			_ = proto.Unmarshal(valBytes, ll)
+			clusters = append(clusters, ll)
+		} else if rsc.TypeUrl == endpointType {
+			ll := &xdsapi.ClusterLoadAssignment{}
+			_ = proto.Unmarshal(valBytes, ll)
+			eds = append(eds, ll)
+		} else if rsc.TypeUrl == routeType {
+			ll := &xdsapi.RouteConfiguration{}
+			_ = proto.Unmarshal(valBytes, ll)
+			routes = append(routes, ll)
 		}
 	}
 
+	if len(listeners) > 0 {
+		a.handleLDS(listeners)
+	}
+	if len(clusters) > 0 {
+		a.handleCDS(clusters)
+	}
+	if len(eds) > 0 {
+		a.handleEDS(eds)
+	}
+	if len(routes) > 0 {
+		a.handleRDS(routes)
+	}
 }
 
 // nolint: staticcheck
@@ -528,11 +596,7 @@ func (a *ADSC) handleEDS(eds []*xdsapi.ClusterLoadAssignment) {
 	}
 	if a.InitialLoad == 0 {
 		// first load - Envoy loads listeners after endpoints
-		_ = a.stream.Send(&xdsapi.DiscoveryRequest{
-			ResponseNonce: time.Now().String(),
-			Node:          a.node(),
-			TypeUrl:       listenerType,
-		})
+		a.watch(listenerType)
 	}
 
 	a.mutex.Lock()
@@ -633,14 +697,30 @@ func (a *ADSC) EndpointsJSON() string {
 // it will start watching RDS and CDS.
 func (a *ADSC) Watch() {
 	a.watchTime = time.Now()
+	a.watch(clusterType)
+}
+
+// watch sends the initial request for a type that is watched without resource names (CDS, LDS).
+func (a *ADSC) watch(typeurl string) {
+	if a.delta {
+		_ = a.deltaStream.Send(&xdsapi.DeltaDiscoveryRequest{
+			Node:    a.node(),
+			TypeUrl: typeurl,
+		})
+		return
+	}
 	_ = a.stream.Send(&xdsapi.DiscoveryRequest{
 		ResponseNonce: time.Now().String(),
 		Node:          a.node(),
-		TypeUrl:       clusterType,
+		TypeUrl:       typeurl,
 	})
 }
 
 func (a *ADSC) sendRsc(typeurl string, rsc []string) {
+	if a.delta {
+		a.sendDeltaRsc(typeurl, rsc)
+		return
+	}
 	_ = a.stream.Send(&xdsapi.DiscoveryRequest{
 		ResponseNonce: "",
 		Node:          a.node(),
@@ -658,6 +738,45 @@ func (a *ADSC) ack(msg *xdsapi.DiscoveryResponse) {
 	})
 }
 
+// sendDeltaRsc updates the delta subscriptions of a type to the given resource names.
+func (a *ADSC) sendDeltaRsc(typeurl string, rsc []string) {
+	watched := a.deltaWatches[typeurl]
+	if watched == nil {
+		watched = map[string]struct{}{}
+		a.deltaWatches[typeurl] = watched
+	}
+	want := make(map[string]struct{}, len(rsc))
+	req := &xdsapi.DeltaDiscoveryRequest{
+		Node:    a.node(),
+		TypeUrl: typeurl,
+	}
+	for _, name := range rsc {
+		want[name] = struct{}{}
+		if _, f := watched[name]; !f {
+			req.ResourceNamesSubscribe = append(req.ResourceNamesSubscribe, name)
+			watched[name] = struct{}{}
+		}
+	}
+	for name := range watched {
+		if _, f := want[name]; !f {
+			req.ResourceNamesUnsubscribe = append(req.ResourceNamesUnsubscribe, name)
+			delete(watched, name)
+		}
+	}
+	if len(req.ResourceNamesSubscribe) == 0 && len(req.ResourceNamesUnsubscribe) == 0 {
+		return
+	}
+	_ = a.deltaStream.Send(req)
+}
+
+func (a *ADSC) ackDelta(msg *xdsapi.DeltaDiscoveryResponse) {
+	_ = a.deltaStream.Send(&xdsapi.DeltaDiscoveryRequest{
+		ResponseNonce: msg.Nonce,
+		TypeUrl:       msg.TypeUrl,
+		Node:          a.node(),
+	})
+}
+
 // GetHTTPListeners returns all the http listeners.
 func (a *ADSC) GetHTTPListeners() map[string]*xdsapi.Listener {
 	a.mutex.Lock()
@@ -96,4 +96,15 @@ const (
 	// PodInfoAnnotationsPath is the filepath that pod annotations will be stored
 	// This is typically set by the downward API
 	PodInfoAnnotationsPath = "./etc/istio/pod/annotations"
+
+	// FailoverNetworkPrefix prefixes the locality lb failover targets selecting the endpoints
+	// of a network, e.g. "network:network2". The To field of a failover setting otherwise holds
+	// a region. Unlike regions, network targets also order the endpoints in the proxy region.
+	FailoverNetworkPrefix = "network:"
+
+	// FailoverClusterPrefix prefixes the locality lb failover targets selecting the endpoints
+	// of a cluster, e.g. "cluster:cluster2". The endpoints of a cluster are found through the
+	// networks which read their endpoints from the registry of this cluster in the mesh networks,
+	// and are then ordered like the ones of a network target.
+	FailoverClusterPrefix = "cluster:"
 )
@@ -58,6 +58,9 @@ func TestParse(t *testing.T) {
 		{"mysql", protocol.MySQL},
 		{"MYSQL", protocol.MySQL},
 		{"MySQL", protocol.MySQL},
+		{"Kafka", protocol.Kafka},
+		{"kafka", protocol.Kafka},
+		{"KAFKA", protocol.Kafka},
 		{"", protocol.Unsupported},
 		{"SMTP", protocol.Unsupported},
 	}
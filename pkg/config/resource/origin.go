@@ -14,9 +14,44 @@
 
 package resource
 
+import (
+	"fmt"
+)
+
 // Origin of a resource. This is source-implementation dependent.
 type Origin interface {
 	FriendlyName() string
 
 	Namespace() Namespace
+
+	// Reference returns the reference to the source of the resource, e.g. its position in a file,
+	// or nil if it is not known.
+	Reference() Reference
+
+	// FieldMap returns the line of the fields of the resource in its source, by field path
+	// (e.g. "{.spec.hosts[0]}"), or nil if it is not known.
+	FieldMap() map[string]int
+}
+
+// Reference provides more information about the source of an Origin. This is also
+// source-implementation dependent.
+type Reference interface {
+	String() string
+}
+
+// Position is a Reference to a line of a file.
+type Position struct {
+	Filename string
+	// Line is 1-based.
+	Line int
+}
+
+var _ Reference = &Position{}
+
+// String implements Reference
+func (p *Position) String() string {
+	if p.Line <= 0 {
+		return p.Filename
+	}
+	return fmt.Sprintf("%s:%d", p.Filename, p.Line)
 }
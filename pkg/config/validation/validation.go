@@ -2799,11 +2799,35 @@ func validateLocalityLbSetting(lb *networking.LocalityLoadBalancerSetting) error
 		if strings.Contains(failover.To, "*") {
 			return fmt.Errorf("locality lb failover region should not contain '*' wildcard")
 		}
+		if err := validateFailoverTarget(failover.To); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// validateFailoverTarget validates the network ("network:<name>") and cluster ("cluster:<name>")
+// locality lb failover targets, which the To field of a failover setting accepts besides a region.
+func validateFailoverTarget(to string) error {
+	var name string
+	switch {
+	case strings.HasPrefix(to, constants.FailoverNetworkPrefix):
+		name = strings.TrimPrefix(to, constants.FailoverNetworkPrefix)
+	case strings.HasPrefix(to, constants.FailoverClusterPrefix):
+		name = strings.TrimPrefix(to, constants.FailoverClusterPrefix)
+	default:
+		return nil
+	}
+	if name == "" {
+		return fmt.Errorf("locality lb failover target %q must specify a name", to)
+	}
+	if strings.Contains(name, "/") {
+		return fmt.Errorf("locality lb failover target %q should not contain '/'", to)
+	}
+	return nil
+}
+
 func validateLocalities(localities []string) error {
 	regionZoneSubZoneMap := map[string]map[string]map[string]bool{}
 
@@ -1319,7 +1319,7 @@ func TestValidateServerPort(t *testing.T) {
 			""},
 		{"invalid protocol",
 			&networking.Port{
-				Protocol: "kafka",
+				Protocol: "pop3",
 				Number:   1,
 				Name:     "Henry",
 			},
@@ -5615,6 +5615,49 @@ func TestValidateLocalityLbSetting(t *testing.T) {
 			},
 			valid: false,
 		},
+
+		{
+			name: "valid failover to network and cluster",
+			in: &networking.LocalityLoadBalancerSetting{
+				Failover: []*networking.LocalityLoadBalancerSetting_Failover{
+					{
+						From: "region1",
+						To:   "network:network2",
+					},
+					{
+						From: "region1",
+						To:   "cluster:cluster2",
+					},
+				},
+			},
+			valid: true,
+		},
+
+		{
+			name: "invalid failover to network without name",
+			in: &networking.LocalityLoadBalancerSetting{
+				Failover: []*networking.LocalityLoadBalancerSetting_Failover{
+					{
+						From: "region1",
+						To:   "network:",
+					},
+				},
+			},
+			valid: false,
+		},
+
+		{
+			name: "invalid failover to cluster with locality",
+			in: &networking.LocalityLoadBalancerSetting{
+				Failover: []*networking.LocalityLoadBalancerSetting_Failover{
+					{
+						From: "region1",
+						To:   "cluster:cluster2/zone1",
+					},
+				},
+			},
+			valid: false,
+		},
 	}
 
 	for _, c := range cases {
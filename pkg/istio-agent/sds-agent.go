@@ -34,6 +34,7 @@ import (
 	"istio.io/istio/security/pkg/nodeagent/cache"
 	"istio.io/istio/security/pkg/nodeagent/sds"
 	"istio.io/istio/security/pkg/nodeagent/secretfetcher"
+	pkiutil "istio.io/istio/security/pkg/pki/util"
 	"istio.io/pkg/env"
 	"istio.io/pkg/log"
 )
@@ -75,6 +76,8 @@ var (
 		"The ticker to detect and close stale connections").Get()
 	initialBackoffInMilliSecEnv = env.RegisterIntVar(InitialBackoffInMilliSec, 0, "").Get()
 	pkcs8KeysEnv                = env.RegisterBoolVar(pkcs8Key, false, "Whether to generate PKCS#8 private keys").Get()
+	keyAlgorithmEnv             = env.RegisterStringVar(keyAlgorithm, string(pkiutil.RSA),
+		"The algorithm of the generated private keys: RSA, ECDSA-P256 or ECDSA-P384").Get()
 
 	// Location of K8S CA root.
 	k8sCAPath = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
@@ -124,6 +127,9 @@ const (
 	InitialBackoffInMilliSec = "INITIAL_BACKOFF_MSEC"
 
 	pkcs8Key = "PKCS8_KEY"
+
+	// The environmental variable name for the algorithm of the generated private keys.
+	keyAlgorithm = "KEY_ALGORITHM"
 )
 
 var (
@@ -394,6 +400,10 @@ func newSecretCache(serverOptions sds.Options) (workloadSecretCache *cache.Secre
 
 	workloadSdsCacheOptions.TrustDomain = serverOptions.TrustDomain
 	workloadSdsCacheOptions.Pkcs8Keys = serverOptions.Pkcs8Keys
+	if workloadSdsCacheOptions.KeyAlgorithm, err = pkiutil.ParseKeyAlgorithm(serverOptions.KeyAlgorithm); err != nil {
+		log.Errorf("invalid key algorithm for workload proxy: %v", err)
+		os.Exit(1)
+	}
 	workloadSdsCacheOptions.Plugins = sds.NewPlugins(serverOptions.PluginNames)
 	workloadSecretCache = cache.NewSecretCache(ret, sds.NotifyProxy, workloadSdsCacheOptions)
 	return
@@ -431,6 +441,7 @@ func applyEnvVars() {
 	serverOptions.CAEndpoint = caEndpointEnv
 	serverOptions.TrustDomain = trustDomainEnv
 	serverOptions.Pkcs8Keys = pkcs8KeysEnv
+	serverOptions.KeyAlgorithm = keyAlgorithmEnv
 	serverOptions.RecycleInterval = staledConnectionRecycleIntervalEnv
 	workloadSdsCacheOptions.SecretTTL = secretTTLEnv
 	workloadSdsCacheOptions.SecretRefreshGraceDuration = secretRefreshGraceDurationEnv
@@ -0,0 +1,109 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	mcp "istio.io/api/mcp/v1alpha1"
+)
+
+// persisted is the file format of a persisted snapshot. Each collection is stored as a serialized
+// mcp.Resources message.
+type persisted struct {
+	SavedAt     time.Time `json:"savedAt"`
+	Collections [][]byte  `json:"collections"`
+}
+
+// Save persists the snapshot to the file at path. The file is replaced atomically, so that a crash
+// while saving does not leave a partial snapshot behind.
+func Save(path string, s Snapshot, savedAt time.Time) error {
+	p := persisted{SavedAt: savedAt}
+	for _, col := range s.Collections() {
+		b, err := proto.Marshal(&mcp.Resources{
+			Collection:        col,
+			SystemVersionInfo: s.Version(col),
+			Resources:         derefResources(s.Resources(col)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to serialize collection %s: %v", col, err)
+		}
+		p.Collections = append(p.Collections, b)
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err = tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load reads a snapshot persisted with Save from the file at path, and returns it along with the time
+// it was saved at.
+func Load(path string) (*InMemory, time.Time, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var p persisted
+	if err = json.Unmarshal(b, &p); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse snapshot file %s: %v", path, err)
+	}
+
+	builder := NewInMemoryBuilder()
+	for _, c := range p.Collections {
+		var resources mcp.Resources
+		if err = proto.Unmarshal(c, &resources); err != nil {
+			return nil, time.Time{}, fmt.Errorf("failed to parse collection in snapshot file %s: %v", path, err)
+		}
+		entries := make([]*mcp.Resource, 0, len(resources.Resources))
+		for i := range resources.Resources {
+			entries = append(entries, &resources.Resources[i])
+		}
+		builder.Set(resources.Collection, resources.SystemVersionInfo, entries)
+	}
+
+	return builder.Build(), p.SavedAt, nil
+}
+
+func derefResources(resources []*mcp.Resource) []mcp.Resource {
+	result := make([]mcp.Resource, 0, len(resources))
+	for _, r := range resources {
+		result = append(result, *r)
+	}
+	return result
+}
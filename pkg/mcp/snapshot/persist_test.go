@@ -0,0 +1,107 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	"istio.io/istio/pkg/mcp/internal/test"
+)
+
+func TestSaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "default.snapshot")
+	snapshot := makeSnapshot("v1")
+	snapshot.versions[test.FakeType1Collection] = "v2"
+	savedAt := time.Unix(1580000000, 0).UTC()
+
+	if err := Save(path, snapshot, savedAt); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	got, gotSavedAt, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !gotSavedAt.Equal(savedAt) {
+		t.Errorf("Load() saved at %v, want %v", gotSavedAt, savedAt)
+	}
+
+	gotCollections := got.Collections()
+	wantCollections := snapshot.Collections()
+	sort.Strings(gotCollections)
+	sort.Strings(wantCollections)
+	if len(gotCollections) != len(wantCollections) {
+		t.Fatalf("Load() collections = %v, want %v", gotCollections, wantCollections)
+	}
+	for i, col := range wantCollections {
+		if gotCollections[i] != col {
+			t.Fatalf("Load() collections = %v, want %v", gotCollections, wantCollections)
+		}
+		if got.Version(col) != snapshot.Version(col) {
+			t.Errorf("Load() version of %s = %q, want %q", col, got.Version(col), snapshot.Version(col))
+		}
+		gotResources, wantResources := got.Resources(col), snapshot.Resources(col)
+		if len(gotResources) != len(wantResources) {
+			t.Fatalf("Load() resources of %s = %v, want %v", col, gotResources, wantResources)
+		}
+		for j := range wantResources {
+			if !proto.Equal(gotResources[j], wantResources[j]) {
+				t.Errorf("Load() resource %d of %s = %v, want %v", j, col, gotResources[j], wantResources[j])
+			}
+		}
+	}
+
+	// The temporary file is not left behind.
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("Save() left %d files, want 1", len(files))
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	if _, _, err := Load(filepath.Join(dir, "missing")); !os.IsNotExist(err) {
+		t.Errorf("Load() of a missing file returned %v, want not exist error", err)
+	}
+
+	path := filepath.Join(dir, "corrupt")
+	if err := ioutil.WriteFile(path, []byte(`{"collections": ["AAEC"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Load(path); err == nil {
+		t.Errorf("Load() of a corrupt file succeeded")
+	}
+}
@@ -251,6 +251,40 @@ func (c *Cache) SetSnapshot(group string, snapshot Snapshot) {
 	}
 }
 
+// StaleVersionSuffix is appended to the collection versions of a stale snapshot, so that they never
+// match the versions of the live snapshots.
+const StaleVersionSuffix = "-stale"
+
+// staleSnapshot is a snapshot known to be outdated, e.g. one restored from disk when starting up.
+type staleSnapshot struct {
+	Snapshot
+}
+
+// Version implements Snapshot
+func (s *staleSnapshot) Version(collection string) string {
+	v := s.Snapshot.Version(collection)
+	if v == "" {
+		return v
+	}
+	return v + StaleVersionSuffix
+}
+
+// SetStaleSnapshot sets a snapshot known to be outdated for a group, e.g. one restored from disk when
+// starting up. It is served, with its versions suffixed with StaleVersionSuffix, until SetSnapshot
+// replaces it with a live one.
+func (c *Cache) SetStaleSnapshot(group string, snapshot Snapshot) {
+	c.SetSnapshot(group, &staleSnapshot{Snapshot: snapshot})
+}
+
+// IsStale returns whether the snapshot of a group was set with SetStaleSnapshot and not replaced yet.
+func (c *Cache) IsStale(group string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, ok := c.snapshots[group].(*staleSnapshot)
+	return ok
+}
+
 // ClearSnapshot clears snapshot for a group. This does not cancel any open
 // watches already created (see ClearStatus).
 func (c *Cache) ClearSnapshot(group string) {
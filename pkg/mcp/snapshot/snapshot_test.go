@@ -328,3 +328,35 @@ func TestClearStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestStaleSnapshot(t *testing.T) {
+	c := New(groups.DefaultIndexFn)
+	c.SetStaleSnapshot(groups.Default, makeSnapshot("1"))
+	if !c.IsStale(groups.Default) {
+		t.Fatal("IsStale() = false after SetStaleSnapshot()")
+	}
+
+	// The stale snapshot is served with its versions marked as stale.
+	responseC := make(chan *source.WatchResponse, 1)
+	gotResponse, _, err := createTestWatch(c, test.FakeType0Collection, "1", responseC, true, false)
+	if err != nil {
+		t.Fatalf("CreateWatch() failed: %v", err)
+	}
+	if gotResponse.Version != "1"+StaleVersionSuffix {
+		t.Fatalf("got version %q, want %q", gotResponse.Version, "1"+StaleVersionSuffix)
+	}
+
+	// A watch on the stale version is answered by the live snapshot, even if its version is the same as the
+	// one the stale snapshot was saved with.
+	_, _, err = createTestWatch(c, test.FakeType0Collection, gotResponse.Version, responseC, false, true)
+	if err != nil {
+		t.Fatalf("CreateWatch() failed: %v", err)
+	}
+	c.SetSnapshot(groups.Default, makeSnapshot("1"))
+	if c.IsStale(groups.Default) {
+		t.Fatal("IsStale() = true after SetSnapshot()")
+	}
+	if gotResponse, _ := getAsyncResponse(responseC); gotResponse == nil || gotResponse.Version != "1" {
+		t.Fatalf("got response %v, want version %q", gotResponse, "1")
+	}
+}
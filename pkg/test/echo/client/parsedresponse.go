@@ -35,6 +35,8 @@ var (
 	hostFieldRegex           = regexp.MustCompile(string(response.HostField) + "=(.*)")
 	hostnameFieldRegex       = regexp.MustCompile(string(response.HostnameField) + "=(.*)")
 	URLFieldRegex            = regexp.MustCompile(string(response.URLField) + "=(.*)")
+	protocolFieldRegex       = regexp.MustCompile(string(response.ProtocolField) + "=(.*)")
+	sniFieldRegex            = regexp.MustCompile(string(response.SNIField) + "=(.*)")
 )
 
 // ParsedResponse represents a response to a single echo request.
@@ -55,6 +57,10 @@ type ParsedResponse struct {
 	Host string
 	// Hostname is the host that responded to the request
 	Hostname string
+	// Protocol is the protocol of the request received by the server, e.g. HTTP/1.1, TCP, TLS or UDP
+	Protocol string
+	// SNI is the server name received by a TLS endpoint
+	SNI string
 	// RawResponse gives a map of all values returned in the response (headers, etc)
 	RawResponse map[string]string
 }
@@ -150,6 +156,23 @@ func (r ParsedResponses) CheckPortOrFail(t test.Failer, expected int) ParsedResp
 	return r
 }
 
+func (r ParsedResponses) CheckSNI(expected string) error {
+	return r.Check(func(i int, response *ParsedResponse) error {
+		if response.SNI != expected {
+			return fmt.Errorf("response[%d] SNI: expected %s, received %s", i, expected, response.SNI)
+		}
+		return nil
+	})
+}
+
+func (r ParsedResponses) CheckSNIOrFail(t test.Failer, expected string) ParsedResponses {
+	t.Helper()
+	if err := r.CheckSNI(expected); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
 // Count occurrences of the given text within the bodies of all responses.
 func (r ParsedResponses) Count(text string) int {
 	count := 0
@@ -207,6 +230,16 @@ func parseResponse(output string) *ParsedResponse {
 		out.URL = match[1]
 	}
 
+	match = protocolFieldRegex.FindStringSubmatch(output)
+	if match != nil {
+		out.Protocol = match[1]
+	}
+
+	match = sniFieldRegex.FindStringSubmatch(output)
+	if match != nil {
+		out.SNI = match[1]
+	}
+
 	out.RawResponse = map[string]string{}
 	for _, l := range strings.Split(output, "\n") {
 		prefixSplit := strings.Split(l, "body] ")
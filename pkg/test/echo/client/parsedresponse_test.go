@@ -0,0 +1,47 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"istio.io/istio/pkg/test/echo/proto"
+)
+
+func TestCheckSNI(t *testing.T) {
+	responses := parseForwardedResponse(&proto.ForwardEchoResponse{
+		Output: []string{
+			"[0] Url=tls://10.0.0.1:443\n[0 body] StatusCode=200\n[0 body] SNI=server.example.com\n",
+			"[1] Url=tls://10.0.0.1:443\n[1 body] StatusCode=200\n[1 body] SNI=server.example.com\n",
+		},
+	})
+	if err := responses.CheckSNI("server.example.com"); err != nil {
+		t.Errorf("CheckSNI() failed: %v", err)
+	}
+	if err := responses.CheckSNI("other.example.com"); err == nil {
+		t.Error("expected CheckSNI() to fail for another server name")
+	}
+
+	// A TCP endpoint doesn't report the server name.
+	responses = parseForwardedResponse(&proto.ForwardEchoResponse{
+		Output: []string{"[0] Url=tcp://10.0.0.1:80\n[0 body] StatusCode=200\n"},
+	})
+	if err := responses.CheckSNI(""); err != nil {
+		t.Errorf("CheckSNI() failed: %v", err)
+	}
+	if err := responses.CheckSNI("server.example.com"); err == nil {
+		t.Error("expected CheckSNI() to fail without server name")
+	}
+}
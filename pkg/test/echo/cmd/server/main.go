@@ -32,6 +32,9 @@ import (
 var (
 	httpPorts []int
 	grpcPorts []int
+	tcpPorts  []int
+	tlsPorts  []int
+	udpPorts  []int
 	uds       string
 	version   string
 	crt       string
@@ -46,24 +49,12 @@ var (
 		Long:              `Echo application for testing Istio E2E`,
 		PersistentPreRunE: configureLogging,
 		Run: func(cmd *cobra.Command, args []string) {
-			ports := make(model.PortList, len(httpPorts)+len(grpcPorts))
-			portIndex := 0
-			for i, p := range httpPorts {
-				ports[portIndex] = &model.Port{
-					Name:     "http-" + strconv.Itoa(i),
-					Protocol: protocol.HTTP,
-					Port:     p,
-				}
-				portIndex++
-			}
-			for i, p := range grpcPorts {
-				ports[portIndex] = &model.Port{
-					Name:     "grpc-" + strconv.Itoa(i),
-					Protocol: protocol.GRPC,
-					Port:     p,
-				}
-				portIndex++
-			}
+			ports := make(model.PortList, 0, len(httpPorts)+len(grpcPorts)+len(tcpPorts)+len(tlsPorts)+len(udpPorts))
+			ports = appendPorts(ports, "http", protocol.HTTP, httpPorts)
+			ports = appendPorts(ports, "grpc", protocol.GRPC, grpcPorts)
+			ports = appendPorts(ports, "tcp", protocol.TCP, tcpPorts)
+			ports = appendPorts(ports, "tls", protocol.TLS, tlsPorts)
+			ports = appendPorts(ports, "udp", protocol.UDP, udpPorts)
 
 			s := server.New(server.Config{
 				Ports:     ports,
@@ -89,6 +80,17 @@ var (
 	}
 )
 
+func appendPorts(ports model.PortList, prefix string, p protocol.Instance, portNumbers []int) model.PortList {
+	for i, n := range portNumbers {
+		ports = append(ports, &model.Port{
+			Name:     prefix + "-" + strconv.Itoa(i),
+			Protocol: p,
+			Port:     n,
+		})
+	}
+	return ports
+}
+
 func configureLogging(_ *cobra.Command, _ []string) error {
 	if err := log.Configure(loggingOptions); err != nil {
 		return err
@@ -99,10 +101,13 @@ func configureLogging(_ *cobra.Command, _ []string) error {
 func init() {
 	rootCmd.PersistentFlags().IntSliceVar(&httpPorts, "port", []int{8080}, "HTTP/1.1 ports")
 	rootCmd.PersistentFlags().IntSliceVar(&grpcPorts, "grpc", []int{7070}, "GRPC ports")
+	rootCmd.PersistentFlags().IntSliceVar(&tcpPorts, "tcp", []int{}, "Raw TCP ports")
+	rootCmd.PersistentFlags().IntSliceVar(&tlsPorts, "tls", []int{}, "TLS ports, using the --crt and --key server certificate")
+	rootCmd.PersistentFlags().IntSliceVar(&udpPorts, "udp", []int{}, "UDP ports")
 	rootCmd.PersistentFlags().StringVar(&uds, "uds", "", "HTTP server on unix domain socket")
 	rootCmd.PersistentFlags().StringVar(&version, "version", "", "Version string")
-	rootCmd.PersistentFlags().StringVar(&crt, "crt", "", "gRPC TLS server-side certificate")
-	rootCmd.PersistentFlags().StringVar(&key, "key", "", "gRPC TLS server-side key")
+	rootCmd.PersistentFlags().StringVar(&crt, "crt", "", "gRPC and TLS server-side certificate")
+	rootCmd.PersistentFlags().StringVar(&key, "key", "", "gRPC and TLS server-side key")
 
 	loggingOptions.AttachCobraFlags(rootCmd)
 
@@ -37,4 +37,8 @@ const (
 	URLField            Field = "URL"
 	HostField           Field = "Host"
 	HostnameField       Field = "Hostname"
+	ProtocolField       Field = "Proto"
+	RemoteAddrField     Field = "RemoteAddr"
+	SNIField            Field = "SNI"
+	EchoField           Field = "Echo"
 )
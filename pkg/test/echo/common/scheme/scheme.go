@@ -24,4 +24,7 @@ const (
 	GRPCS      Instance = "grpcs"
 	WebSocket  Instance = "ws"
 	WebSocketS Instance = "wss"
+	TCP        Instance = "tcp"
+	TLS        Instance = "tls"
+	UDP        Instance = "udp"
 )
@@ -263,8 +263,8 @@ func (h *httpHandler) addResponsePayload(r *http.Request, body *bytes.Buffer) {
 
 	writeField(body, response.Field("Method"), r.Method)
 	writeField(body, response.Field("URL"), r.URL.String())
-	writeField(body, response.Field("Proto"), r.Proto)
-	writeField(body, response.Field("RemoteAddr"), r.RemoteAddr)
+	writeField(body, response.ProtocolField, r.Proto)
+	writeField(body, response.RemoteAddrField, r.RemoteAddr)
 	writeField(body, response.Field("Method"), r.Method)
 
 	for name, values := range r.Header {
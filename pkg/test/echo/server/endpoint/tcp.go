@@ -0,0 +1,206 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"istio.io/istio/pkg/test/echo/common"
+	"istio.io/istio/pkg/test/echo/common/response"
+	"istio.io/istio/pkg/test/echo/common/scheme"
+	"istio.io/istio/pkg/test/echo/proto"
+	"istio.io/istio/pkg/test/echo/server/forwarder"
+	"istio.io/istio/pkg/test/util/retry"
+	"istio.io/pkg/log"
+)
+
+var _ Instance = &tcpInstance{}
+
+// tcpInstance serves raw TCP, or TLS terminated by the endpoint. A client sends a message and closes
+// its side of the connection, and the endpoint echoes the message with the response fields.
+type tcpInstance struct {
+	Config
+	tls      bool
+	listener net.Listener
+}
+
+func newTCP(config Config, useTLS bool) Instance {
+	return &tcpInstance{
+		Config: config,
+		tls:    useTLS,
+	}
+}
+
+func (s *tcpInstance) protocol() string {
+	if s.tls {
+		return "TLS"
+	}
+	return "TCP"
+}
+
+func (s *tcpInstance) Start(onReady OnReadyFunc) error {
+	// Listen on the given port and update the port if it changed from what was passed in.
+	listener, p, err := listenOnPort(s.Port.Port)
+	if err != nil {
+		return err
+	}
+	// Store the actual listening port back to the argument.
+	s.Port.Port = p
+
+	if s.tls {
+		cert, err := tls.LoadX509KeyPair(s.TLSCert, s.TLSKey)
+		if err != nil {
+			_ = listener.Close()
+			return fmt.Errorf("could not load TLS keys: %v", err)
+		}
+		listener = tls.NewListener(listener, &tls.Config{Certificates: []tls.Certificate{cert}})
+	}
+	s.listener = listener
+	fmt.Printf("Listening %s on %v\n", s.protocol(), p)
+
+	// Start serving TCP traffic.
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				// The listener is closed.
+				return
+			}
+			go s.echo(conn)
+		}
+	}()
+
+	// Notify the WaitGroup once the port has transitioned to ready.
+	go s.awaitReady(onReady, listener.Addr().String())
+
+	return nil
+}
+
+func (s *tcpInstance) echo(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// Don't hold the connection forever if the client doesn't close its side.
+	_ = conn.SetDeadline(time.Now().Add(common.ConnectionTimeout))
+
+	body := bytes.Buffer{}
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		if err := tlsConn.Handshake(); err != nil {
+			log.Warnf("%s handshake with %s failed: %v", s.protocol(), conn.RemoteAddr(), err)
+			return
+		}
+		writeField(&body, response.SNIField, tlsConn.ConnectionState().ServerName)
+	}
+
+	// Read the message until the client closes its side of the connection.
+	message, err := ioutil.ReadAll(conn)
+	if err != nil {
+		log.Warnf("%s read from %s failed: %v", s.protocol(), conn.RemoteAddr(), err)
+		return
+	}
+	log.Infof("%s Request:\n  RemoteAddr: %s\n  Message: %s", s.protocol(), conn.RemoteAddr(), message)
+
+	if s.IsServerReady() {
+		writeField(&body, response.StatusCodeField, response.StatusCodeOK)
+	} else {
+		// Handle readiness probe failure.
+		log.Infof("%s service not ready, returning 503", s.protocol())
+		writeField(&body, response.StatusCodeField, response.StatusCodeUnavailable)
+	}
+	writeRawResponseFields(&body, s.Config, s.protocol(), conn.RemoteAddr().String(), message)
+
+	if _, err := conn.Write(body.Bytes()); err != nil {
+		log.Warnf("%s write to %s failed: %v", s.protocol(), conn.RemoteAddr(), err)
+	}
+}
+
+func (s *tcpInstance) awaitReady(onReady OnReadyFunc, address string) {
+	defer onReady()
+
+	sch := scheme.TCP
+	if s.tls {
+		sch = scheme.TLS
+	}
+	err := retry.UntilSuccess(func() error {
+		return forwardReadinessRequest(string(sch) + "://" + address)
+	}, retry.Timeout(readyTimeout), retry.Delay(readyInterval))
+	if err != nil {
+		log.Errorf("readiness failed for %s endpoint %s: %v", s.protocol(), address, err)
+	} else {
+		log.Infof("ready for %s endpoint %s", s.protocol(), address)
+	}
+}
+
+func (s *tcpInstance) Close() error {
+	if s.listener != nil {
+		return s.listener.Close()
+	}
+	return nil
+}
+
+// forwardReadinessRequest sends a request to a raw TCP, TLS or UDP endpoint. Any response means that
+// the endpoint is serving: it answers 503 until the server is ready.
+func forwardReadinessRequest(url string) error {
+	f, err := forwarder.New(forwarder.Config{
+		Request: &proto.ForwardEchoRequest{
+			Url:           url,
+			Message:       "hello",
+			TimeoutMicros: common.DurationToMicros(readyInterval),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	resp, err := f.Run(context.Background())
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(resp.Output[0], string(response.StatusCodeField)+"=") {
+		return fmt.Errorf("unexpected response %q", resp.Output[0])
+	}
+	return nil
+}
+
+// writeRawResponseFields writes the response fields of the raw TCP, TLS and UDP endpoints, and the
+// lines of the echoed message.
+// nolint: interfacer
+func writeRawResponseFields(body *bytes.Buffer, cfg Config, protocol, remoteAddr string, message []byte) {
+	writeField(body, response.ServiceVersionField, cfg.Version)
+	writeField(body, response.ServicePortField, strconv.Itoa(cfg.Port.Port))
+	writeField(body, response.ProtocolField, protocol)
+	writeField(body, response.RemoteAddrField, remoteAddr)
+	if hostname, err := os.Hostname(); err == nil {
+		writeField(body, response.HostnameField, hostname)
+	}
+	for _, line := range strings.Split(string(message), "\n") {
+		if line != "" {
+			writeField(body, response.EchoField, line)
+		}
+	}
+}
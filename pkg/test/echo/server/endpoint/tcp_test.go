@@ -0,0 +1,151 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config/protocol"
+	"istio.io/istio/pkg/test/echo/common/response"
+	"istio.io/istio/pkg/test/echo/proto"
+	"istio.io/istio/pkg/test/echo/server/forwarder"
+)
+
+const (
+	certFile = "../../../../../tests/testdata/certs/pilot/cert-chain.pem"
+	keyFile  = "../../../../../tests/testdata/certs/pilot/key.pem"
+)
+
+func TestTCP(t *testing.T) {
+	cases := []struct {
+		name     string
+		protocol protocol.Instance
+		scheme   string
+		wantSNI  bool
+	}{
+		{"tcp", protocol.TCP, "tcp", false},
+		{"tls", protocol.TLS, "tls", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ready := int32(1)
+			port := &model.Port{Name: c.name, Protocol: c.protocol}
+			ep := startEndpoint(t, Config{
+				IsServerReady: func() bool { return atomic.LoadInt32(&ready) == 1 },
+				Version:       "v1",
+				TLSCert:       certFile,
+				TLSKey:        keyFile,
+				Port:          port,
+			})
+			defer func() {
+				_ = ep.Close()
+			}()
+
+			url := fmt.Sprintf("%s://127.0.0.1:%d", c.scheme, port.Port)
+			out := forward(t, url, "hello\nworld", "server.example.com")
+			expectFields(t, out,
+				response.StatusCodeField, response.StatusCodeOK,
+				response.ServiceVersionField, "v1",
+				response.ServicePortField, fmt.Sprint(port.Port),
+				response.ProtocolField, strings.ToUpper(c.scheme),
+				response.EchoField, "hello",
+				response.EchoField, "world")
+			if sni := string(response.SNIField) + "=server.example.com"; strings.Contains(out, sni) != c.wantSNI {
+				t.Errorf("expected SNI %v in the response:\n%s", c.wantSNI, out)
+			}
+
+			atomic.StoreInt32(&ready, 0)
+			out = forward(t, url, "hello", "")
+			expectFields(t, out, response.StatusCodeField, response.StatusCodeUnavailable)
+		})
+	}
+}
+
+func TestTCPInvalidTLSKeys(t *testing.T) {
+	ep, err := New(Config{
+		IsServerReady: func() bool { return true },
+		TLSCert:       "missing-cert.pem",
+		TLSKey:        "missing-key.pem",
+		Port:          &model.Port{Name: "tls", Protocol: protocol.TLS},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ep.Start(func() {}); err == nil {
+		_ = ep.Close()
+		t.Fatal("expected Start to fail without TLS keys")
+	}
+}
+
+// startEndpoint starts an endpoint and waits until it is ready.
+func startEndpoint(t *testing.T, cfg Config) Instance {
+	t.Helper()
+	ep, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ready := make(chan struct{})
+	if err := ep.Start(func() { close(ready) }); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ready:
+	case <-time.After(readyTimeout):
+		_ = ep.Close()
+		t.Fatal("timed out waiting for the endpoint to be ready")
+	}
+	return ep
+}
+
+// forward sends a message to the URL, with the given Host header if not empty, and returns the output.
+func forward(t *testing.T, url, message, host string) string {
+	t.Helper()
+	req := &proto.ForwardEchoRequest{
+		Url:     url,
+		Message: message,
+	}
+	if host != "" {
+		req.Headers = []*proto.Header{{Key: "Host", Value: host}}
+	}
+	f, err := forwarder.New(forwarder.Config{Request: req})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	resp, err := f.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Output[0]
+}
+
+// expectFields checks that the response body in the output holds the fields, given as field and value pairs.
+func expectFields(t *testing.T, out string, fieldValues ...interface{}) {
+	t.Helper()
+	for i := 0; i < len(fieldValues); i += 2 {
+		line := fmt.Sprintf("[0 body] %s=%s\n", fieldValues[i], fieldValues[i+1])
+		if !strings.Contains(out, line) {
+			t.Errorf("expected %q in the response:\n%s", line, out)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+
+	"istio.io/istio/pkg/test/echo/common/response"
+	"istio.io/istio/pkg/test/echo/common/scheme"
+	"istio.io/istio/pkg/test/util/retry"
+	"istio.io/pkg/log"
+)
+
+// maxUDPPayload is the maximum size of a UDP datagram.
+const maxUDPPayload = 65535
+
+var _ Instance = &udpInstance{}
+
+// udpInstance serves UDP. Each datagram received is echoed to its sender with the response fields.
+type udpInstance struct {
+	Config
+	conn net.PacketConn
+}
+
+func newUDP(config Config) Instance {
+	return &udpInstance{
+		Config: config,
+	}
+}
+
+func (s *udpInstance) Start(onReady OnReadyFunc) error {
+	// Listen on the given port and update the port if it changed from what was passed in.
+	conn, err := net.ListenPacket("udp", fmt.Sprintf(":%d", s.Port.Port))
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	// Store the actual listening port back to the argument.
+	s.Port.Port = conn.LocalAddr().(*net.UDPAddr).Port
+	fmt.Printf("Listening UDP on %v\n", s.Port.Port)
+
+	// Start serving UDP traffic.
+	go func() {
+		buf := make([]byte, maxUDPPayload)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				// The connection is closed.
+				return
+			}
+			s.echo(addr, buf[:n])
+		}
+	}()
+
+	// Notify the WaitGroup once the port has transitioned to ready.
+	go s.awaitReady(onReady, fmt.Sprintf("127.0.0.1:%d", s.Port.Port))
+
+	return nil
+}
+
+func (s *udpInstance) echo(addr net.Addr, message []byte) {
+	log.Infof("UDP Request:\n  RemoteAddr: %s\n  Message: %s", addr, message)
+
+	body := bytes.Buffer{}
+	if s.IsServerReady() {
+		writeField(&body, response.StatusCodeField, response.StatusCodeOK)
+	} else {
+		// Handle readiness probe failure.
+		log.Infof("UDP service not ready, returning 503")
+		writeField(&body, response.StatusCodeField, response.StatusCodeUnavailable)
+	}
+	writeRawResponseFields(&body, s.Config, "UDP", addr.String(), message)
+
+	if _, err := s.conn.WriteTo(body.Bytes(), addr); err != nil {
+		log.Warnf("UDP write to %s failed: %v", addr, err)
+	}
+}
+
+func (s *udpInstance) awaitReady(onReady OnReadyFunc, address string) {
+	defer onReady()
+
+	err := retry.UntilSuccess(func() error {
+		return forwardReadinessRequest(string(scheme.UDP) + "://" + address)
+	}, retry.Timeout(readyTimeout), retry.Delay(readyInterval))
+	if err != nil {
+		log.Errorf("readiness failed for UDP endpoint %s: %v", address, err)
+	} else {
+		log.Infof("ready for UDP endpoint %s", address)
+	}
+}
+
+func (s *udpInstance) Close() error {
+	if s.conn != nil {
+		return s.conn.Close()
+	}
+	return nil
+}
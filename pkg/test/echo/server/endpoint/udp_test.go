@@ -0,0 +1,52 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config/protocol"
+	"istio.io/istio/pkg/test/echo/common/response"
+)
+
+func TestUDP(t *testing.T) {
+	ready := int32(1)
+	port := &model.Port{Name: "udp", Protocol: protocol.UDP}
+	ep := startEndpoint(t, Config{
+		IsServerReady: func() bool { return atomic.LoadInt32(&ready) == 1 },
+		Version:       "v1",
+		Port:          port,
+	})
+	defer func() {
+		_ = ep.Close()
+	}()
+
+	url := fmt.Sprintf("udp://127.0.0.1:%d", port.Port)
+	out := forward(t, url, "hello\nworld", "")
+	expectFields(t, out,
+		response.StatusCodeField, response.StatusCodeOK,
+		response.ServiceVersionField, "v1",
+		response.ServicePortField, fmt.Sprint(port.Port),
+		response.ProtocolField, "UDP",
+		response.EchoField, "hello",
+		response.EchoField, "world")
+
+	atomic.StoreInt32(&ready, 0)
+	out = forward(t, url, "hello", "")
+	expectFields(t, out, response.StatusCodeField, response.StatusCodeUnavailable)
+}
@@ -128,6 +128,22 @@ func newProtocol(cfg Config) (protocol, error) {
 		return &websocketProtocol{
 			dialer: dialer,
 		}, nil
+	case scheme.TCP, scheme.TLS:
+		p := &tcpProtocol{
+			address: u.Host,
+			dial:    cfg.Dialer.TCP,
+			uds:     cfg.UDS,
+		}
+		if scheme.Instance(u.Scheme) == scheme.TLS {
+			p.tlsConfig = &tls.Config{
+				InsecureSkipVerify: true,
+			}
+		}
+		return p, nil
+	case scheme.UDP:
+		return &udpProtocol{
+			address: u.Host,
+		}, nil
 	}
 
 	return nil, fmt.Errorf("unrecognized protocol %q", u.String())
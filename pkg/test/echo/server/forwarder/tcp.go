@@ -0,0 +1,136 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwarder
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+
+	"istio.io/istio/pkg/test/echo/common"
+)
+
+var _ protocol = &tcpProtocol{}
+
+// tcpProtocol sends the message over raw TCP, or TLS if tlsConfig is set, and reads the response
+// until the endpoint closes the connection.
+type tcpProtocol struct {
+	// address of the endpoint.
+	address   string
+	tlsConfig *tls.Config
+	dial      common.TCPDialFunc
+	uds       string
+}
+
+func (c *tcpProtocol) makeRequest(ctx context.Context, req *request) (string, error) {
+	var outBuffer bytes.Buffer
+	outBuffer.WriteString(fmt.Sprintf("[%d] Url=%s\n", req.RequestID, req.URL))
+	host := ""
+	writeHeaders(req.RequestID, req.Header, outBuffer, func(key string, value string) {
+		if key == hostHeader {
+			host = value
+		}
+	})
+	if req.Message != "" {
+		outBuffer.WriteString(fmt.Sprintf("[%d] Echo=%s\n", req.RequestID, req.Message))
+	}
+
+	// Apply per-request timeout to calculate deadline for reads/writes.
+	ctx, cancel := context.WithTimeout(ctx, req.Timeout)
+	defer cancel()
+
+	var conn net.Conn
+	var err error
+	if c.uds != "" {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "unix", c.uds)
+	} else {
+		conn, err = c.dial(ctx, net.Dialer{}, c.address)
+	}
+	if err != nil {
+		return outBuffer.String(), err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// Apply the deadline to the connection.
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return outBuffer.String(), err
+	}
+
+	if c.tlsConfig != nil {
+		cfg := c.tlsConfig.Clone()
+		// Set SNI value to be same as the request Host, as for HTTPS. For use with SNI routing tests.
+		cfg.ServerName = hostName(host, c.address)
+		tlsConn := tls.Client(conn, cfg)
+		if err := tlsConn.Handshake(); err != nil {
+			return outBuffer.String(), err
+		}
+		conn = tlsConn
+	}
+
+	if _, err := conn.Write([]byte(req.Message)); err != nil {
+		return outBuffer.String(), err
+	}
+	// Signal the end of the message to the endpoint.
+	if err := closeWrite(conn); err != nil {
+		return outBuffer.String(), err
+	}
+
+	resp, err := ioutil.ReadAll(conn)
+	if err != nil {
+		return outBuffer.String(), err
+	}
+	writeBody(req.RequestID, resp, &outBuffer)
+
+	return outBuffer.String(), nil
+}
+
+func (c *tcpProtocol) Close() error {
+	return nil
+}
+
+// closeWrite shuts down the writing side of a TCP or TLS connection.
+func closeWrite(conn net.Conn) error {
+	if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+		return cw.CloseWrite()
+	}
+	return fmt.Errorf("connection to %s can't be half-closed", conn.RemoteAddr())
+}
+
+// hostName returns the host of the Host header if set, otherwise the host of the address.
+func hostName(hostHeader, address string) string {
+	if hostHeader == "" {
+		hostHeader = address
+	}
+	if host, _, err := net.SplitHostPort(hostHeader); err == nil {
+		return host
+	}
+	return hostHeader
+}
+
+// writeBody writes the lines of a response body to the output.
+func writeBody(requestID int, body []byte, outBuffer *bytes.Buffer) {
+	for _, line := range strings.Split(string(body), "\n") {
+		if line != "" {
+			outBuffer.WriteString(fmt.Sprintf("[%d body] %s\n", requestID, line))
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwarder
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"istio.io/istio/pkg/test/echo/common"
+	"istio.io/istio/pkg/test/echo/proto"
+)
+
+const (
+	certFile = "../../../../../tests/testdata/certs/pilot/cert-chain.pem"
+	keyFile  = "../../../../../tests/testdata/certs/pilot/key.pem"
+)
+
+// startTCPServer starts a TCP server, or TLS if tlsConfig is set, which reads the message until the client closes
+// its side of the connection and answers with the message and the server name of the TLS connection.
+func startTCPServer(t *testing.T, tlsConfig *tls.Config) net.Listener {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig != nil {
+		l = tls.NewListener(l, tlsConfig)
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() {
+					_ = conn.Close()
+				}()
+				message, err := ioutil.ReadAll(conn)
+				if err != nil {
+					return
+				}
+				sni := ""
+				if tlsConn, ok := conn.(*tls.Conn); ok {
+					sni = tlsConn.ConnectionState().ServerName
+				}
+				_, _ = fmt.Fprintf(conn, "SNI=%s\nEcho=%s\n", sni, message)
+			}()
+		}
+	}()
+	return l
+}
+
+func TestTCPProtocol(t *testing.T) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name      string
+		serverTLS *tls.Config
+		clientTLS *tls.Config
+		host      string
+		wantSNI   string
+	}{
+		{"tcp", nil, nil, "", ""},
+		{"tls", &tls.Config{Certificates: []tls.Certificate{cert}}, &tls.Config{InsecureSkipVerify: true}, "", ""},
+		{"tls with host", &tls.Config{Certificates: []tls.Certificate{cert}}, &tls.Config{InsecureSkipVerify: true},
+			"server.example.com:443", "server.example.com"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			l := startTCPServer(t, c.serverTLS)
+			defer func() {
+				_ = l.Close()
+			}()
+
+			p := &tcpProtocol{
+				address:   l.Addr().String(),
+				tlsConfig: c.clientTLS,
+				dial:      common.DefaultTCPDialFunc,
+			}
+			header := http.Header{}
+			if c.host != "" {
+				header.Set(hostHeader, c.host)
+			}
+			out, err := p.makeRequest(context.Background(), &request{
+				URL:       "tcp://" + l.Addr().String(),
+				Header:    header,
+				RequestID: 1,
+				Message:   "hello",
+				Timeout:   common.ConnectionTimeout,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{
+				"[1] Url=tcp://" + l.Addr().String() + "\n",
+				"[1] Echo=hello\n",
+				"[1 body] SNI=" + c.wantSNI + "\n",
+				"[1 body] Echo=hello\n",
+			} {
+				if !strings.Contains(out, want) {
+					t.Errorf("expected %q in the output:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestTCPProtocolUnreachable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := l.Addr().String()
+	_ = l.Close()
+
+	p := &tcpProtocol{address: address, dial: common.DefaultTCPDialFunc}
+	out, err := p.makeRequest(context.Background(), &request{
+		URL:     "tcp://" + address,
+		Message: "hello",
+		Timeout: time.Second,
+	})
+	if err == nil {
+		t.Fatalf("expected the request to fail, got:\n%s", out)
+	}
+}
+
+func TestHostName(t *testing.T) {
+	cases := []struct {
+		host    string
+		address string
+		want    string
+	}{
+		{"", "10.0.0.1:80", "10.0.0.1"},
+		{"server.example.com", "10.0.0.1:80", "server.example.com"},
+		{"server.example.com:443", "10.0.0.1:80", "server.example.com"},
+	}
+	for _, c := range cases {
+		if got := hostName(c.host, c.address); got != c.want {
+			t.Errorf("hostName(%q, %q) = %q, want %q", c.host, c.address, got, c.want)
+		}
+	}
+}
+
+func TestNewTCPAndUDP(t *testing.T) {
+	for _, url := range []string{"tcp://127.0.0.1:80", "tls://127.0.0.1:443", "udp://127.0.0.1:53"} {
+		i, err := New(Config{Request: &proto.ForwardEchoRequest{Url: url}})
+		if err != nil {
+			t.Fatalf("New(%s) failed: %v", url, err)
+		}
+		switch p := i.p.(type) {
+		case *tcpProtocol:
+			if strings.HasPrefix(url, "udp") || (p.tlsConfig != nil) != strings.HasPrefix(url, "tls") {
+				t.Errorf("unexpected protocol %+v for %s", p, url)
+			}
+		case *udpProtocol:
+			if !strings.HasPrefix(url, "udp") {
+				t.Errorf("unexpected protocol %+v for %s", p, url)
+			}
+		default:
+			t.Errorf("unexpected protocol %T for %s", p, url)
+		}
+		_ = i.Close()
+	}
+}
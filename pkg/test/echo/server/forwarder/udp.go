@@ -0,0 +1,77 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwarder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+)
+
+// maxUDPPayload is the maximum size of a UDP datagram.
+const maxUDPPayload = 65535
+
+var _ protocol = &udpProtocol{}
+
+// udpProtocol sends the message in a UDP datagram, and reads the response datagram.
+type udpProtocol struct {
+	// address of the endpoint.
+	address string
+}
+
+func (c *udpProtocol) makeRequest(ctx context.Context, req *request) (string, error) {
+	var outBuffer bytes.Buffer
+	outBuffer.WriteString(fmt.Sprintf("[%d] Url=%s\n", req.RequestID, req.URL))
+	writeHeaders(req.RequestID, req.Header, outBuffer, func(string, string) {})
+	if req.Message != "" {
+		outBuffer.WriteString(fmt.Sprintf("[%d] Echo=%s\n", req.RequestID, req.Message))
+	}
+
+	// Apply per-request timeout to calculate deadline for reads/writes.
+	ctx, cancel := context.WithTimeout(ctx, req.Timeout)
+	defer cancel()
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "udp", c.address)
+	if err != nil {
+		return outBuffer.String(), err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// Apply the deadline to the connection.
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return outBuffer.String(), err
+	}
+
+	if _, err := conn.Write([]byte(req.Message)); err != nil {
+		return outBuffer.String(), err
+	}
+
+	resp := make([]byte, maxUDPPayload)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return outBuffer.String(), err
+	}
+	writeBody(req.RequestID, resp[:n], &outBuffer)
+
+	return outBuffer.String(), nil
+}
+
+func (c *udpProtocol) Close() error {
+	return nil
+}
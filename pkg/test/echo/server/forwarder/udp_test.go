@@ -0,0 +1,87 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwarder
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"istio.io/istio/pkg/test/echo/common"
+)
+
+func TestUDPProtocol(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	go func() {
+		buf := make([]byte, maxUDPPayload)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = conn.WriteTo([]byte(fmt.Sprintf("Echo=%s\n", buf[:n])), addr)
+		}
+	}()
+
+	p := &udpProtocol{address: conn.LocalAddr().String()}
+	out, err := p.makeRequest(context.Background(), &request{
+		URL:       "udp://" + conn.LocalAddr().String(),
+		RequestID: 2,
+		Message:   "hello",
+		Timeout:   common.ConnectionTimeout,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"[2] Url=udp://" + conn.LocalAddr().String() + "\n",
+		"[2] Echo=hello\n",
+		"[2 body] Echo=hello\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the output:\n%s", want, out)
+		}
+	}
+}
+
+func TestUDPProtocolTimeout(t *testing.T) {
+	// The server never answers.
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	p := &udpProtocol{address: conn.LocalAddr().String()}
+	out, err := p.makeRequest(context.Background(), &request{
+		URL:     "udp://" + conn.LocalAddr().String(),
+		Message: "hello",
+		Timeout: 100 * time.Millisecond,
+	})
+	if err == nil {
+		t.Fatalf("expected the request to time out, got:\n%s", out)
+	}
+}
@@ -149,6 +149,11 @@ func (s *Instance) validate() error {
 		case protocol.HTTPS:
 		case protocol.HTTP2:
 		case protocol.GRPC:
+		case protocol.TLS:
+			if s.TLSCert == "" || s.TLSKey == "" {
+				return fmt.Errorf("port %d: protocol %v requires a TLS certificate and key", port.Port, port.Protocol)
+			}
+		case protocol.UDP:
 		default:
 			return fmt.Errorf("protocol %v not currently supported", port.Protocol)
 		}
@@ -159,8 +159,12 @@ func schemeForPort(port *echo.Port) (scheme.Instance, error) {
 		return scheme.GRPC, nil
 	case protocol.HTTP, protocol.TCP:
 		return scheme.HTTP, nil
-	case protocol.HTTPS, protocol.TLS:
+	case protocol.HTTPS:
 		return scheme.HTTPS, nil
+	case protocol.TLS:
+		return scheme.TLS, nil
+	case protocol.UDP:
+		return scheme.UDP, nil
 	default:
 		return "", fmt.Errorf("failed creating call for port %s: unsupported protocol %s",
 			port.Name, port.Protocol)
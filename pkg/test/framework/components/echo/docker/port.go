@@ -71,16 +71,27 @@ func newPortMap(portMgr reserveport.PortManager, cfg echo.Config) (*portMap, err
 	return m, nil
 }
 
-func (m *portMap) toEchoArgs() []string {
+// toEchoArgs returns the port arguments of the echo server. TLS endpoints use the given certificate and key files.
+func (m *portMap) toEchoArgs(tlsCertFile, tlsKeyFile string) []string {
 	echoArgs := make([]string, 0)
+	hasTLS := false
 	for _, port := range m.ports {
 		portNumber := port.containerPort.ServicePort
-		if port.containerPort.Protocol.IsGRPC() {
+		switch {
+		case port.containerPort.Protocol.IsGRPC():
 			echoArgs = append(echoArgs, "--grpc", strconv.Itoa(portNumber))
-		} else {
+		case port.containerPort.Protocol == protocol.TLS:
+			echoArgs = append(echoArgs, "--tls", strconv.Itoa(portNumber))
+			hasTLS = true
+		case port.containerPort.Protocol == protocol.UDP:
+			echoArgs = append(echoArgs, "--udp", strconv.Itoa(portNumber))
+		default:
 			echoArgs = append(echoArgs, "--port", strconv.Itoa(portNumber))
 		}
 	}
+	if hasTLS {
+		echoArgs = append(echoArgs, "--crt", tlsCertFile, "--key", tlsKeyFile)
+	}
 	return echoArgs
 }
 
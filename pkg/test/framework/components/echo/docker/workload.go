@@ -104,9 +104,14 @@ func newWorkload(e *native.Environment, cfg echo.Config, dumpDir string) (out *w
 		return nil, err
 	}
 
+	// TLS endpoints use the certificate of the echo image.
+	tlsCertFile, tlsKeyFile := "/cert.crt", "/cert.key"
+	if cfg.Annotations.GetBool(echo.SidecarInject) {
+		tlsCertFile, tlsKeyFile = "/var/lib/istio/cert.crt", "/var/lib/istio/cert.key"
+	}
 	echoArgs := append([]string{
 		"--version", cfg.Version,
-	}, w.portMap.toEchoArgs()...)
+	}, w.portMap.toEchoArgs(tlsCertFile, tlsKeyFile)...)
 
 	var image string
 	var cmd []string
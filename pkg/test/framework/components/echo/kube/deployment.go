@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"text/template"
 
+	"istio.io/istio/pkg/config/protocol"
 	"istio.io/istio/pkg/test/framework/components/echo"
 	"istio.io/istio/pkg/test/framework/core/image"
 	"istio.io/istio/pkg/test/scopes"
@@ -54,6 +55,9 @@ spec:
   - name: {{ $p.Name }}
     port: {{ $p.ServicePort }}
     targetPort: {{ $p.InstancePort }}
+{{- if eq $p.Protocol "UDP" }}
+    protocol: UDP
+{{- end }}
 {{- end }}
   selector:
     app: {{ .Service }}
@@ -103,6 +107,10 @@ spec:
 {{- range $i, $p := .ContainerPorts }}
 {{- if eq .Protocol "GRPC" }}
           - --grpc
+{{- else if eq .Protocol "TLS" }}
+          - --tls
+{{- else if eq .Protocol "UDP" }}
+          - --udp
 {{- else }}
           - --port
 {{- end }}
@@ -114,9 +122,18 @@ spec:
 {{- end }}
           - --version
           - "{{ .Version }}"
+{{- if .HasTLSPorts }}
+          - --crt
+          - /cert.crt
+          - --key
+          - /cert.key
+{{- end }}
         ports:
 {{- range $i, $p := .ContainerPorts }}
         - containerPort: {{ $p.Port }} 
+{{- if eq .Protocol "UDP" }}
+          protocol: UDP
+{{- end }}
 {{- if eq .Port 3333 }}
           name: tcp-health-port
 {{- end }}
@@ -188,6 +205,14 @@ func generateYAML(cfg echo.Config) (string, error) {
 		}
 	}
 
+	// TLS endpoints use the certificate of the echo image.
+	hasTLSPorts := false
+	for _, p := range cfg.Ports {
+		if p.Protocol == protocol.TLS {
+			hasTLSPorts = true
+		}
+	}
+
 	params := map[string]interface{}{
 		"Hub":                 settings.Hub,
 		"Tag":                 settings.Tag,
@@ -203,6 +228,7 @@ func generateYAML(cfg echo.Config) (string, error) {
 		"ServiceAnnotations":  serviceAnnotations,
 		"WorkloadAnnotations": workloadAnnotations,
 		"IncludeInboundPorts": cfg.IncludeInboundPorts,
+		"HasTLSPorts":         hasTLSPorts,
 	}
 
 	// Generate the YAML content.
@@ -36,6 +36,8 @@ import (
 	"istio.io/istio/security/pkg/cmd"
 	"istio.io/istio/security/pkg/k8s/controller"
 	"istio.io/istio/security/pkg/pki/ca"
+	"istio.io/istio/security/pkg/pki/ca/vault"
+	"istio.io/istio/security/pkg/pki/util"
 	probecontroller "istio.io/istio/security/pkg/probe"
 	"istio.io/istio/security/pkg/registry"
 	"istio.io/istio/security/pkg/registry/kube"
@@ -55,6 +57,17 @@ const (
 	selfSignedRootCertGracePeriodPercentile = "CITADEL_SELF_SIGNED_ROOT_CERT_GRACE_PERIOD_PERCENTILE"
 	workloadCertMinGracePeriod              = "CITADEL_WORKLOAD_CERT_MIN_GRACE_PERIOD"
 	enableJitterForRootCertRotator          = "CITADEL_ENABLE_JITTER_FOR_ROOT_CERT_ROTATOR"
+	selfSignedCAKeyAlgorithm                = "CITADEL_SELF_SIGNED_CA_KEY_ALGORITHM"
+	pluggedCertCheckInterval                = "CITADEL_PLUGGED_CERT_CHECK_INTERVAL"
+	pluggedRootCertOverlap                  = "CITADEL_PLUGGED_ROOT_CERT_OVERLAP"
+	vaultAddr                               = "CITADEL_VAULT_ADDR"
+	vaultTLSRootCertFile                    = "CITADEL_VAULT_TLS_ROOT_CERT_FILE"
+	vaultLoginPath                          = "CITADEL_VAULT_LOGIN_PATH"
+	vaultLoginRole                          = "CITADEL_VAULT_LOGIN_ROLE"
+	vaultSignPath                           = "CITADEL_VAULT_SIGN_PATH"
+	vaultCAChainPath                        = "CITADEL_VAULT_CA_CHAIN_PATH"
+
+	serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
 )
 
 type cliOptions struct { // nolint: maligned
@@ -75,6 +88,13 @@ type cliOptions struct { // nolint: maligned
 	selfSignedRootCertCheckInterval         time.Duration
 	selfSignedRootCertGracePeriodPercentile int
 	enableJitterForRootCertRotator          bool
+	selfSignedCAKeyAlgorithm                string
+
+	pluggedCertCheckInterval time.Duration
+	pluggedRootCertOverlap   time.Duration
+
+	// The Vault signer is used if the Vault address is set, even with selfSignedCA.
+	vaultConfig vault.Config
 
 	workloadCertTTL    time.Duration
 	maxWorkloadCertTTL time.Duration
@@ -155,6 +175,35 @@ var (
 			"If true, set up a jitter to start root cert rotator. "+
 				"Jitter selects a backoff time in seconds to start root cert rotator, "+
 				"and the back off time is below root cert check interval.").Get(),
+		selfSignedCAKeyAlgorithm: env.RegisterStringVar(selfSignedCAKeyAlgorithm,
+			string(util.RSA),
+			"The algorithm of the self-signed CA private key: RSA, ECDSA-P256 or ECDSA-P384.").Get(),
+		pluggedCertCheckInterval: env.RegisterDurationVar(pluggedCertCheckInterval,
+			cmd.DefaultPluggedCertCheckInterval,
+			"The interval that a CA using plugged certificates checks whether its key and "+
+				"certificate files changed, and reloads them. Setting this interval to zero "+
+				"or a negative value disables the reloading.").Get(),
+		pluggedRootCertOverlap: env.RegisterDurationVar(pluggedRootCertOverlap,
+			cmd.DefaultPluggedRootCertOverlap,
+			"The duration the previous root certificate stays in the trust bundle after "+
+				"the plugged root certificate changed.").Get(),
+		vaultConfig: vault.Config{
+			Addr: env.RegisterStringVar(vaultAddr, "",
+				"The address of a Vault server signing the certificates with its PKI secrets engine, "+
+					"instead of the CA key. The Vault token is read from VAULT_TOKEN, or obtained with "+
+					"the Kubernetes auth method if it is not set.").Get(),
+			TLSRootCertFile: env.RegisterStringVar(vaultTLSRootCertFile, "",
+				"The root cert file verifying the Vault server.").Get(),
+			LoginPath: env.RegisterStringVar(vaultLoginPath, "auth/kubernetes/login",
+				"The login path of the Vault Kubernetes auth method.").Get(),
+			LoginRole: env.RegisterStringVar(vaultLoginRole, "istio-ca",
+				"The role of the Vault Kubernetes auth method.").Get(),
+			JWTPath: serviceAccountTokenPath,
+			SignPath: env.RegisterStringVar(vaultSignPath, "pki/sign/istio",
+				"The path of the Vault PKI endpoint signing the CSRs.").Get(),
+			CAChainPath: env.RegisterStringVar(vaultCAChainPath, "pki/cert/ca_chain",
+				"The path of the Vault PKI CA chain.").Get(),
+		},
 	}
 
 	rootCmd = &cobra.Command{
@@ -224,7 +273,8 @@ func initCLI() {
 	// Configuration if Citadel acts as a self signed CA.
 	flags.BoolVar(&opts.selfSignedCA, "self-signed-ca", false,
 		"Indicates whether to use auto-generated self-signed CA certificate. "+
-			"When set to true, the '--signing-cert' and '--signing-key' options are ignored.")
+			"When set to true, the '--signing-cert' and '--signing-key' options are ignored. "+
+			"Ignored when the Vault address is set.")
 	flags.StringVar(&opts.trustDomain, "trust-domain", "",
 		"The domain serves to identify the system with SPIFFE.")
 	// Upstream CA configuration if Citadel interacts with upstream CA.
@@ -357,7 +407,7 @@ func runCA() {
 		sc, err := controller.NewSecretController(ca, opts.enableNamespacesByDefault,
 			opts.workloadCertTTL, opts.workloadCertGracePeriodRatio, opts.workloadCertMinGracePeriod,
 			opts.dualUse, cs.CoreV1(), opts.signCACerts, opts.pkcs8Keys, listenedNamespaces, webhooks,
-			opts.istioCaStorageNamespace, opts.rootCertFile, opts.selfSignedCA)
+			opts.istioCaStorageNamespace, opts.rootCertFile, opts.selfSignedCA && opts.vaultConfig.Addr == "")
 		if err != nil {
 			fatalf("Failed to create secret controller: %v", err)
 		}
@@ -464,11 +514,22 @@ func createCA(client corev1.CoreV1Interface, stopCh chan struct{}) *ca.IstioCA {
 
 	spiffe.SetTrustDomain(spiffe.DetermineTrustDomain(opts.trustDomain, true))
 
-	if opts.selfSignedCA {
+	if opts.vaultConfig.Addr != "" {
+		log.Infof("Use Vault at %s to sign the certificates", opts.vaultConfig.Addr)
+		caOpts, err = vault.NewIstioCAOptions(&opts.vaultConfig, opts.rootCertFile, opts.workloadCertTTL,
+			opts.maxWorkloadCertTTL, opts.istioCaStorageNamespace, client)
+		if err != nil {
+			fatalf("Failed to create a Citadel signing with Vault (error: %v)", err)
+		}
+	} else if opts.selfSignedCA {
 		log.Info("Use self-signed certificate as the CA certificate")
 		// Abort after 20 minutes.
 		ctx, cancel := context.WithTimeout(context.Background(), time.Minute*20)
 		defer cancel()
+		keyAlgorithm, err := util.ParseKeyAlgorithm(opts.selfSignedCAKeyAlgorithm)
+		if err != nil {
+			fatalf("Invalid self-signed CA key algorithm (error: %v)", err)
+		}
 		var checkInterval time.Duration
 		if opts.readSigningCertOnly {
 			checkInterval = cmd.ReadSigningCertRetryInterval
@@ -480,7 +541,7 @@ func createCA(client corev1.CoreV1Interface, stopCh chan struct{}) *ca.IstioCA {
 			opts.selfSignedRootCertCheckInterval, opts.workloadCertTTL,
 			opts.maxWorkloadCertTTL, spiffe.GetTrustDomain(), opts.dualUse,
 			opts.istioCaStorageNamespace, checkInterval, client, opts.rootCertFile,
-			opts.enableJitterForRootCertRotator)
+			opts.enableJitterForRootCertRotator, keyAlgorithm)
 		if err != nil {
 			fatalf("Failed to create a self-signed Citadel (error: %v)", err)
 		}
@@ -491,6 +552,8 @@ func createCA(client corev1.CoreV1Interface, stopCh chan struct{}) *ca.IstioCA {
 		if err != nil {
 			fatalf("Failed to create an Citadel (error: %v)", err)
 		}
+		caOpts.PluggedCertRotatorConfig.CheckInterval = opts.pluggedCertCheckInterval
+		caOpts.PluggedCertRotatorConfig.RootCertOverlap = opts.pluggedRootCertOverlap
 	}
 
 	caOpts.LivenessProbeOptions = opts.LivenessProbeOptions
@@ -511,14 +574,14 @@ func createCA(client corev1.CoreV1Interface, stopCh chan struct{}) *ca.IstioCA {
 			livenessProbeChecker.Run()
 		}
 	}
-	// Start root cert rotator in a separate goroutine.
+	// Start root cert rotator or plugged cert rotator in a separate goroutine.
 	istioCA.Run(stopCh)
 
 	return istioCA
 }
 
 func verifyCommandLineOptions() {
-	if opts.selfSignedCA {
+	if opts.selfSignedCA || opts.vaultConfig.Addr != "" {
 		return
 	}
 
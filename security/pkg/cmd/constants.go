@@ -28,6 +28,14 @@ const (
 	// rotation grace period, configured as the ratio of the certificate TTL.
 	DefaultRootCertGracePeriodPercentile = 20
 
+	// DefaultPluggedCertCheckInterval is the default interval a plugged cert CA checks
+	// whether its key and certificate files changed.
+	DefaultPluggedCertCheckInterval = 1 * time.Minute
+
+	// DefaultPluggedRootCertOverlap is the default duration the previous root certificate is
+	// still trusted after the plugged root certificate changed.
+	DefaultPluggedRootCertOverlap = 24 * time.Hour
+
 	// ReadSigningCertRetryInterval specifies the time to wait between retries on reading the signing key and cert.
 	ReadSigningCertRetryInterval = time.Second * 5
 
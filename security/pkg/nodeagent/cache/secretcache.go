@@ -129,6 +129,9 @@ type Options struct {
 
 	// Whether to generate PKCS#8 private keys.
 	Pkcs8Keys bool
+
+	// The algorithm of the generated private keys, RSA if empty.
+	KeyAlgorithm util.KeyAlgorithm
 }
 
 // SecretManager defines secrets management interface which is used by SDS.
@@ -733,9 +736,10 @@ func (sc *SecretCache) generateSecret(ctx context.Context, token string, connKey
 		csrHostName = connKey.ResourceName
 	}
 	options := util.CertOptions{
-		Host:       csrHostName,
-		RSAKeySize: keySize,
-		PKCS8Key:   sc.configOptions.Pkcs8Keys,
+		Host:         csrHostName,
+		RSAKeySize:   keySize,
+		KeyAlgorithm: sc.configOptions.KeyAlgorithm,
+		PKCS8Key:     sc.configOptions.Pkcs8Keys,
 	}
 
 	// Generate the cert/key, send CSR to CA.
@@ -106,6 +106,9 @@ type Options struct {
 	// Whether to generate PKCS#8 private keys.
 	Pkcs8Keys bool
 
+	// The algorithm of the generated private keys: RSA, ECDSA-P256 or ECDSA-P384.
+	KeyAlgorithm string
+
 	// PilotCertProvider is the provider of the Pilot certificate.
 	PilotCertProvider string
 
@@ -16,11 +16,8 @@ package ca
 
 import (
 	"context"
-	"crypto/x509"
 	"encoding/base64"
-	"encoding/pem"
 	"fmt"
-	"io/ioutil"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -70,6 +67,8 @@ const (
 	selfSignedCA caTypes = iota
 	// pluggedCertCA means the Istio CA uses a operator-specified key/cert.
 	pluggedCertCA
+	// externalSignerCA means the Istio CA delegates the signing to an external authority.
+	externalSignerCA
 )
 
 // IstioCAOptions holds the configurations for creating an Istio CA.
@@ -87,6 +86,13 @@ type IstioCAOptions struct {
 
 	// Config for creating self-signed root cert rotator.
 	RotatorConfig *SelfSignedCARootCertRotatorConfig
+
+	// Config for creating the rotator reloading the plugged cert files.
+	PluggedCertRotatorConfig *PluggedCertRotatorConfig
+
+	// Signer signs the certificates. If it is nil, the certificates are signed with the
+	// key of KeyCertBundle.
+	Signer Signer
 }
 
 // NewSelfSignedIstioCAOptions returns a new IstioCAOptions instance using self-signed certificate.
@@ -94,7 +100,7 @@ func NewSelfSignedIstioCAOptions(ctx context.Context,
 	rootCertGracePeriodPercentile int, caCertTTL, rootCertCheckInverval, certTTL,
 	maxCertTTL time.Duration, org string, dualUse bool, namespace string,
 	readCertRetryInterval time.Duration, client corev1.CoreV1Interface,
-	rootCertFile string, enableJitter bool) (caOpts *IstioCAOptions, err error) {
+	rootCertFile string, enableJitter bool, keyAlgorithm util.KeyAlgorithm) (caOpts *IstioCAOptions, err error) {
 	// For the first time the CA is up, if readSigningCertOnly is unset,
 	// it generates a self-signed key/cert pair and write it to CASecret.
 	// For subsequent restart, CA will reads key/cert from CASecret.
@@ -141,6 +147,7 @@ func NewSelfSignedIstioCAOptions(ctx context.Context,
 			IsCA:         true,
 			IsSelfSigned: true,
 			RSAKeySize:   caKeySize,
+			KeyAlgorithm: keyAlgorithm,
 			IsDualUse:    dualUse,
 		}
 		pemCert, pemKey, ckErr := util.GenCertKeyFromOptions(options)
@@ -192,6 +199,14 @@ func NewPluggedCertIstioCAOptions(certChainFile, signingCertFile, signingKeyFile
 		CAType:     pluggedCertCA,
 		CertTTL:    certTTL,
 		MaxCertTTL: maxCertTTL,
+		PluggedCertRotatorConfig: &PluggedCertRotatorConfig{
+			certChainFile:      certChainFile,
+			signingCertFile:    signingCertFile,
+			signingKeyFile:     signingKeyFile,
+			rootCertFile:       rootCertFile,
+			caStorageNamespace: namespace,
+			client:             client,
+		},
 	}
 	if caOpts.KeyCertBundle, err = util.NewVerifiedKeyCertBundleFromFile(
 		signingCertFile, signingKeyFile, certChainFile, rootCertFile); err != nil {
@@ -199,23 +214,10 @@ func NewPluggedCertIstioCAOptions(certChainFile, signingCertFile, signingKeyFile
 	}
 
 	// Validate that the passed in signing cert can be used as CA.
-	// The check can't be done inside `KeyCertBundle`, since bundle could also be used to
-	// validate workload certificates (i.e., where the leaf certificate is not a CA).
-	b, err := ioutil.ReadFile(signingCertFile)
-	if err != nil {
+	signingCert, _, _, _ := caOpts.KeyCertBundle.GetAllPem()
+	if err = verifySigningCert(signingCert); err != nil {
 		return nil, err
 	}
-	block, _ := pem.Decode(b)
-	if block == nil {
-		return nil, fmt.Errorf("invalid PEM encoded certificate")
-	}
-	cert, err := x509.ParseCertificate(block.Bytes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse X.509 certificate")
-	}
-	if !cert.IsCA {
-		return nil, fmt.Errorf("certificate is not authorized to sign other certificates")
-	}
 
 	crt := caOpts.KeyCertBundle.GetCertChainPem()
 	if len(crt) == 0 {
@@ -227,18 +229,49 @@ func NewPluggedCertIstioCAOptions(certChainFile, signingCertFile, signingKeyFile
 	return caOpts, nil
 }
 
+// NewExternalSignerIstioCAOptions returns a new IstioCAOptions instance delegating the signing to an
+// external signer. certChain is the PEM-encoded chain of the signer certificate, which may be empty if
+// the signer certificate is the root, and rootCert is the PEM-encoded root certificate.
+func NewExternalSignerIstioCAOptions(signer Signer, certChain, rootCert []byte, certTTL, maxCertTTL time.Duration,
+	namespace string, client corev1.CoreV1Interface) (caOpts *IstioCAOptions, err error) {
+	caOpts = &IstioCAOptions{
+		CAType:     externalSignerCA,
+		CertTTL:    certTTL,
+		MaxCertTTL: maxCertTTL,
+		Signer:     signer,
+	}
+	if caOpts.KeyCertBundle, err = util.NewKeyCertBundleWithCertChainFromPem(certChain, rootCert); err != nil {
+		return nil, fmt.Errorf("failed to create CA KeyCertBundle (%v)", err)
+	}
+
+	crt := certChain
+	if len(crt) == 0 {
+		crt = rootCert
+	}
+	if client != nil {
+		if err = updateCertInConfigmap(namespace, client, crt); err != nil {
+			pkiCaLog.Errorf("Failed to write Citadel cert to configmap (%v). Node agents will not be able to connect.", err)
+		}
+	}
+	return caOpts, nil
+}
+
 // IstioCA generates keys and certificates for Istio identities.
 type IstioCA struct {
 	certTTL    time.Duration
 	maxCertTTL time.Duration
 
 	keyCertBundle util.KeyCertBundle
+	signer        Signer
 
 	livenessProbe *probe.Probe
 
 	// rootCertRotator periodically rotates self-signed root cert for CA. It is nil
 	// if CA is not self-signed CA.
 	rootCertRotator *SelfSignedCARootCertRotator
+	// pluggedCertRotator periodically reloads the plugged cert files. It is nil
+	// if CA is not plugged cert CA, or if the reloading is disabled.
+	pluggedCertRotator *PluggedCertRotator
 }
 
 // NewIstioCA returns a new IstioCA instance.
@@ -247,12 +280,20 @@ func NewIstioCA(opts *IstioCAOptions) (*IstioCA, error) {
 		certTTL:       opts.CertTTL,
 		maxCertTTL:    opts.MaxCertTTL,
 		keyCertBundle: opts.KeyCertBundle,
+		signer:        opts.Signer,
 		livenessProbe: probe.NewProbe(),
 	}
+	if ca.signer == nil {
+		ca.signer = &keyCertBundleSigner{keyCertBundle: opts.KeyCertBundle}
+	}
 
 	if opts.CAType == selfSignedCA && opts.RotatorConfig.CheckInterval > time.Duration(0) {
 		ca.rootCertRotator = NewSelfSignedCARootCertRotator(opts.RotatorConfig, ca)
 	}
+	if opts.CAType == pluggedCertCA && opts.PluggedCertRotatorConfig != nil &&
+		opts.PluggedCertRotatorConfig.CheckInterval > time.Duration(0) {
+		ca.pluggedCertRotator = NewPluggedCertRotator(opts.PluggedCertRotatorConfig, ca)
+	}
 	return ca, nil
 }
 
@@ -261,17 +302,16 @@ func (ca *IstioCA) Run(stopChan chan struct{}) {
 		// Start root cert rotator in a separate goroutine.
 		go ca.rootCertRotator.Run(stopChan)
 	}
+	if ca.pluggedCertRotator != nil {
+		// Start plugged cert rotator in a separate goroutine.
+		go ca.pluggedCertRotator.Run(stopChan)
+	}
 }
 
 // Sign takes a PEM-encoded CSR, subject IDs and lifetime, and returns a signed certificate. If forCA is true,
 // the signed certificate is a CA certificate, otherwise, it is a workload certificate.
 // TODO(myidpt): Add error code to identify the Sign error types.
 func (ca *IstioCA) Sign(csrPEM []byte, subjectIDs []string, requestedLifetime time.Duration, forCA bool) ([]byte, error) {
-	signingCert, signingKey, _, _ := ca.keyCertBundle.GetAll()
-	if signingCert == nil {
-		return nil, caerror.NewError(caerror.CANotReady, fmt.Errorf("Istio CA is not ready")) // nolint
-	}
-
 	csr, err := util.ParsePemEncodedCSR(csrPEM)
 	if err != nil {
 		return nil, caerror.NewError(caerror.CSRError, err)
@@ -288,17 +328,13 @@ func (ca *IstioCA) Sign(csrPEM []byte, subjectIDs []string, requestedLifetime ti
 			"requested TTL %s is greater than the max allowed TTL %s", requestedLifetime, ca.maxCertTTL))
 	}
 
-	certBytes, err := util.GenCertFromCSR(csr, signingCert, csr.PublicKey, *signingKey, subjectIDs, lifetime, forCA)
+	cert, err := ca.signer.Sign(csr, csrPEM, subjectIDs, lifetime, forCA)
 	if err != nil {
+		if _, ok := err.(*caerror.Error); ok {
+			return nil, err
+		}
 		return nil, caerror.NewError(caerror.CertGenError, err)
 	}
-
-	block := &pem.Block{
-		Type:  "CERTIFICATE",
-		Bytes: certBytes,
-	}
-	cert := pem.EncodeToMemory(block)
-
 	return cert, nil
 }
 
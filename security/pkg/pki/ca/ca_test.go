@@ -101,7 +101,7 @@ func TestCreateSelfSignedIstioCAWithoutSecret(t *testing.T) {
 	caopts, err := NewSelfSignedIstioCAOptions(context.Background(),
 		0, caCertTTL, rootCertCheckInverval, defaultCertTTL,
 		maxCertTTL, org, false, caNamespace, -1, client.CoreV1(),
-		rootCertFile, false)
+		rootCertFile, false, util.RSA)
 	if err != nil {
 		t.Fatalf("Failed to create a self-signed CA Options: %v", err)
 	}
@@ -167,6 +167,54 @@ func TestCreateSelfSignedIstioCAWithoutSecret(t *testing.T) {
 	}
 }
 
+func TestCreateSelfSignedIstioCAWithECDSAKey(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	caopts, err := NewSelfSignedIstioCAOptions(context.Background(),
+		0, time.Hour, time.Hour, 30*time.Minute,
+		time.Hour, "test.ca.Org", false, "default", -1, client.CoreV1(),
+		"", false, util.ECDSAP256)
+	if err != nil {
+		t.Fatalf("Failed to create a self-signed CA Options: %v", err)
+	}
+	ca, err := NewIstioCA(caopts)
+	if err != nil {
+		t.Fatalf("Got error while creating self-signed CA: %v", err)
+	}
+
+	signingCert, signingKey, _, rootCertBytes := ca.GetCAKeyCertBundle().GetAll()
+	if signingCert.PublicKeyAlgorithm != x509.ECDSA {
+		t.Errorf("Unexpected CA public key algorithm %v", signingCert.PublicKeyAlgorithm)
+	}
+	if alg, _, err := util.GetKeyAlgorithm(*signingKey); err != nil || alg != util.ECDSAP256 {
+		t.Errorf("Unexpected CA key algorithm %v (%v)", alg, err)
+	}
+
+	// The CA signs both RSA and ECDSA workload keys.
+	subjectID := "spiffe://example.com/ns/foo/sa/bar"
+	for _, alg := range []util.KeyAlgorithm{util.RSA, util.ECDSAP256, util.ECDSAP384} {
+		csrPEM, keyPEM, err := util.GenCSR(util.CertOptions{
+			Host:         subjectID,
+			RSAKeySize:   2048,
+			KeyAlgorithm: alg,
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", alg, err)
+		}
+		certPEM, err := ca.Sign(csrPEM, []string{subjectID}, 30*time.Minute, false)
+		if err != nil {
+			t.Fatalf("%s: failed to sign the CSR: %v", alg, err)
+		}
+		fields := &util.VerifyFields{
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+			KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			Host:        subjectID,
+		}
+		if err = util.VerifyCertificate(keyPEM, certPEM, rootCertBytes, fields); err != nil {
+			t.Errorf("%s: %v", alg, err)
+		}
+	}
+}
+
 func TestCreateSelfSignedIstioCAWithSecret(t *testing.T) {
 	rootCertPem := cert1Pem
 	// Use the same signing cert and root cert for self-signed CA.
@@ -192,7 +240,7 @@ func TestCreateSelfSignedIstioCAWithSecret(t *testing.T) {
 	caopts, err := NewSelfSignedIstioCAOptions(context.Background(),
 		0, caCertTTL, rootCertCheckInverval, certTTL, maxCertTTL,
 		org, false, caNamespace, -1, client.CoreV1(),
-		rootCertFile, false)
+		rootCertFile, false, util.RSA)
 	if err != nil {
 		t.Fatalf("Failed to create a self-signed CA Options: %v", err)
 	}
@@ -262,7 +310,7 @@ func TestCreateSelfSignedIstioCAReadSigningCertOnly(t *testing.T) {
 	defer cancel0()
 	_, err := NewSelfSignedIstioCAOptions(ctx0, 0,
 		caCertTTL, certTTL, rootCertCheckInverval, maxCertTTL, org, false,
-		caNamespace, time.Millisecond*10, client.CoreV1(), rootCertFile, false)
+		caNamespace, time.Millisecond*10, client.CoreV1(), rootCertFile, false, util.RSA)
 	if err == nil {
 		t.Errorf("Expected error, but succeeded.")
 	} else if err.Error() != expectedErr {
@@ -281,7 +329,7 @@ func TestCreateSelfSignedIstioCAReadSigningCertOnly(t *testing.T) {
 	defer cancel1()
 	caopts, err := NewSelfSignedIstioCAOptions(ctx1, 0,
 		caCertTTL, certTTL, rootCertCheckInverval, maxCertTTL, org, false,
-		caNamespace, time.Millisecond*10, client.CoreV1(), rootCertFile, false)
+		caNamespace, time.Millisecond*10, client.CoreV1(), rootCertFile, false, util.RSA)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
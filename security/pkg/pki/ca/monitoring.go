@@ -0,0 +1,62 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ca
+
+import (
+	"istio.io/pkg/monitoring"
+)
+
+const (
+	errorlabel = "error"
+
+	readFileError = "read_file"
+	verifyError   = "verify"
+	updateError   = "update"
+)
+
+var (
+	errorTag = monitoring.MustCreateLabel(errorlabel)
+
+	signingCertRotationCounts = monitoring.NewSum(
+		"citadel_signing_cert_rotation_count",
+		"The number of times the plugged CA signing certificate has been reloaded.",
+	)
+
+	signingCertRotationErrorCounts = monitoring.NewSum(
+		"citadel_signing_cert_rotation_err_count",
+		"The number of errors occurred when reloading the plugged CA signing certificate.",
+		monitoring.WithLabels(errorTag),
+	)
+
+	signingCertExpiryTimestamp = monitoring.NewGauge(
+		"citadel_signing_cert_expiry_timestamp",
+		"The unix timestamp, in seconds, when the CA signing certificate will expire.",
+	)
+
+	trustBundleRootCount = monitoring.NewGauge(
+		"citadel_trust_bundle_root_cert_count",
+		"The number of root certificates in the trust bundle, including the previous roots "+
+			"kept after a rotation.",
+	)
+)
+
+func init() {
+	monitoring.MustRegister(
+		signingCertRotationCounts,
+		signingCertRotationErrorCounts,
+		signingCertExpiryTimestamp,
+		trustBundleRootCount,
+	)
+}
@@ -0,0 +1,264 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ca
+
+import (
+	"bytes"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"istio.io/istio/security/pkg/pki/util"
+	"istio.io/pkg/log"
+)
+
+var pluggedCertRotatorLog = log.RegisterScope("pluggedcertrotator", "Plugged CA signing cert rotator log", 0)
+
+// PluggedCertRotatorConfig is the configuration of the rotator reloading the plugged CA key and certificates.
+type PluggedCertRotatorConfig struct {
+	certChainFile      string
+	signingCertFile    string
+	signingKeyFile     string
+	rootCertFile       string
+	caStorageNamespace string
+	client             corev1.CoreV1Interface
+
+	// CheckInterval is the interval at which the plugged cert files are checked for changes.
+	// The rotation is disabled if it is not positive.
+	CheckInterval time.Duration
+	// RootCertOverlap is how long the previous root certificates stay in the trust bundle after
+	// a rotation, so the workload certificates they signed remain valid until they are renewed.
+	RootCertOverlap time.Duration
+}
+
+// previousRoot is a root certificate replaced by a rotation, kept in the trust bundle until expiry.
+type previousRoot struct {
+	pem    []byte
+	expiry time.Time
+}
+
+// PluggedCertRotator watches the files of a plugged CA key and certificates, e.g. mounted from the
+// cacerts secret, and reloads them into the CA when they change.
+type PluggedCertRotator struct {
+	config *PluggedCertRotatorConfig
+	ca     *IstioCA
+
+	// files holds the content of the files which is loaded in the CA.
+	files pluggedCertFiles
+	// previousRoots are the root certificates still published in the trust bundle.
+	previousRoots []previousRoot
+	now           func() time.Time
+}
+
+// pluggedCertFiles holds the content of the plugged cert files.
+type pluggedCertFiles struct {
+	certChain   []byte
+	signingCert []byte
+	signingKey  []byte
+	rootCert    []byte
+}
+
+func (f pluggedCertFiles) equal(o pluggedCertFiles) bool {
+	return bytes.Equal(f.certChain, o.certChain) && bytes.Equal(f.signingCert, o.signingCert) &&
+		bytes.Equal(f.signingKey, o.signingKey) && bytes.Equal(f.rootCert, o.rootCert)
+}
+
+// NewPluggedCertRotator returns a new rotator of the plugged CA key and certificates.
+func NewPluggedCertRotator(config *PluggedCertRotatorConfig, ca *IstioCA) *PluggedCertRotator {
+	rotator := &PluggedCertRotator{
+		config: config,
+		ca:     ca,
+		now:    time.Now,
+	}
+	signingCert, signingKey, certChain, rootCert := ca.GetCAKeyCertBundle().GetAllPem()
+	rotator.files = pluggedCertFiles{
+		certChain:   certChain,
+		signingCert: signingCert,
+		signingKey:  signingKey,
+		rootCert:    rootCert,
+	}
+	updateSigningCertMetrics(ca.GetCAKeyCertBundle())
+	return rotator
+}
+
+// Run checks the plugged cert files periodically until a signal is received.
+func (rotator *PluggedCertRotator) Run(stopCh chan struct{}) {
+	ticker := time.NewTicker(rotator.config.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			rotator.checkAndReload()
+		case <-stopCh:
+			pluggedCertRotatorLog.Info("Received stop signal, so stop the plugged cert rotator.")
+			return
+		}
+	}
+}
+
+// checkAndReload reloads the plugged cert files into the CA if they changed, and removes the expired
+// previous root certificates from the trust bundle.
+func (rotator *PluggedCertRotator) checkAndReload() {
+	files, err := rotator.readFiles()
+	if err != nil {
+		pluggedCertRotatorLog.Errorf("Failed to read the plugged cert files: %v", err)
+		signingCertRotationErrorCounts.With(errorTag.Value(readFileError)).Increment()
+		return
+	}
+
+	if files.equal(rotator.files) {
+		if rotator.expirePreviousRoots() {
+			if err := rotator.setBundle(files); err != nil {
+				pluggedCertRotatorLog.Errorf("Failed to remove the expired root certificates: %v", err)
+			}
+		}
+		return
+	}
+
+	pluggedCertRotatorLog.Info("Plugged cert files changed, reloading the CA signing certificate.")
+	if err := verifySigningCert(files.signingCert); err != nil {
+		pluggedCertRotatorLog.Errorf("Invalid plugged signing certificate, keeping the current one: %v", err)
+		signingCertRotationErrorCounts.With(errorTag.Value(verifyError)).Increment()
+		return
+	}
+	// Files are often updated one by one, e.g. by a kubelet updating a secret volume. Check the new
+	// files before replacing the previous root, so a partial update is retried at the next check.
+	if _, err := util.NewVerifiedKeyCertBundleFromPem(
+		files.signingCert, files.signingKey, files.certChain, files.rootCert); err != nil {
+		pluggedCertRotatorLog.Errorf("Invalid plugged cert files, keeping the current ones: %v", err)
+		signingCertRotationErrorCounts.With(errorTag.Value(verifyError)).Increment()
+		return
+	}
+
+	rotator.expirePreviousRoots()
+	if !bytes.Equal(files.rootCert, rotator.files.rootCert) && rotator.config.RootCertOverlap > 0 {
+		rotator.previousRoots = append(rotator.previousRoots, previousRoot{
+			pem:    rotator.files.rootCert,
+			expiry: rotator.now().Add(rotator.config.RootCertOverlap),
+		})
+	}
+	if err := rotator.setBundle(files); err != nil {
+		pluggedCertRotatorLog.Errorf("Failed to reload the plugged cert files: %v", err)
+		signingCertRotationErrorCounts.With(errorTag.Value(updateError)).Increment()
+		return
+	}
+	rotator.files = files
+	signingCertRotationCounts.Increment()
+	pluggedCertRotatorLog.Info("CA signing certificate is reloaded successfully.")
+}
+
+// setBundle sets the files into the CA key cert bundle, with the previous root certificates appended
+// to the root certificates, and publishes the trust bundle.
+func (rotator *PluggedCertRotator) setBundle(files pluggedCertFiles) error {
+	rootCerts := files.rootCert
+	// As in NewPluggedCertIstioCAOptions, the cert chain is published if there is one.
+	published := files.certChain
+	if len(published) == 0 {
+		published = files.rootCert
+	}
+	for _, root := range rotator.previousRoots {
+		rootCerts = appendPem(rootCerts, root.pem)
+		published = appendPem(published, root.pem)
+	}
+	bundle := rotator.ca.GetCAKeyCertBundle()
+	if err := bundle.VerifyAndSetAll(files.signingCert, files.signingKey, files.certChain, rootCerts); err != nil {
+		return err
+	}
+	updateSigningCertMetrics(bundle)
+
+	if rotator.config.client != nil {
+		if err := updateCertInConfigmap(rotator.config.caStorageNamespace, rotator.config.client, published); err != nil {
+			return fmt.Errorf("failed to write the trust bundle into configmap: %v", err)
+		}
+	}
+	return nil
+}
+
+// expirePreviousRoots removes the previous root certificates which are past their overlap window,
+// and returns true if some were removed.
+func (rotator *PluggedCertRotator) expirePreviousRoots() bool {
+	now := rotator.now()
+	kept := rotator.previousRoots[:0]
+	for _, root := range rotator.previousRoots {
+		if now.Before(root.expiry) {
+			kept = append(kept, root)
+		}
+	}
+	expired := len(kept) != len(rotator.previousRoots)
+	rotator.previousRoots = kept
+	return expired
+}
+
+func (rotator *PluggedCertRotator) readFiles() (files pluggedCertFiles, err error) {
+	if files.signingCert, err = ioutil.ReadFile(rotator.config.signingCertFile); err != nil {
+		return
+	}
+	if files.signingKey, err = ioutil.ReadFile(rotator.config.signingKeyFile); err != nil {
+		return
+	}
+	if files.rootCert, err = ioutil.ReadFile(rotator.config.rootCertFile); err != nil {
+		return
+	}
+	files.certChain = []byte{}
+	if rotator.config.certChainFile != "" {
+		files.certChain, err = ioutil.ReadFile(rotator.config.certChainFile)
+	}
+	return
+}
+
+// verifySigningCert checks that a PEM encoded certificate can be used as CA.
+// The check can't be done inside `KeyCertBundle`, since bundle could also be used to
+// validate workload certificates (i.e., where the leaf certificate is not a CA).
+func verifySigningCert(certPem []byte) error {
+	cert, err := util.ParsePemEncodedCertificate(certPem)
+	if err != nil {
+		return err
+	}
+	if !cert.IsCA {
+		return fmt.Errorf("certificate is not authorized to sign other certificates")
+	}
+	return nil
+}
+
+// appendPem appends PEM encoded data, making sure that the blocks are separated by a newline.
+func appendPem(pemBytes, other []byte) []byte {
+	out := make([]byte, 0, len(pemBytes)+len(other)+1)
+	out = append(out, pemBytes...)
+	if len(out) > 0 && out[len(out)-1] != '\n' {
+		out = append(out, '\n')
+	}
+	return append(out, other...)
+}
+
+// updateSigningCertMetrics records the expiry of the signing certificate and the number of root
+// certificates in the trust bundle.
+func updateSigningCertMetrics(bundle util.KeyCertBundle) {
+	signingCert, _, _, rootCerts := bundle.GetAll()
+	if signingCert != nil {
+		signingCertExpiryTimestamp.Record(float64(signingCert.NotAfter.Unix()))
+	}
+	roots := 0
+	for rest := rootCerts; ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		roots++
+	}
+	trustBundleRootCount.Record(float64(roots))
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ca
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	"istio.io/istio/security/pkg/k8s/configmap"
+	"istio.io/istio/security/pkg/pki/util"
+)
+
+type pluggedCertPaths struct {
+	certChainFile   string
+	signingCertFile string
+	signingKeyFile  string
+	rootCertFile    string
+}
+
+// writePluggedCerts generates a root CA and an intermediate CA signed by it, and writes them as
+// plugged cert files. It returns the root cert and the intermediate cert.
+func writePluggedCerts(t *testing.T, paths pluggedCertPaths, intermediateIsCA bool) ([]byte, []byte) {
+	rootCertBytes, rootKeyBytes, err := util.GenCertKeyFromOptions(util.CertOptions{
+		IsCA:         true,
+		IsSelfSigned: true,
+		TTL:          time.Hour,
+		Org:          "Root CA",
+		RSAKeySize:   2048,
+	})
+	if err != nil {
+		t.Fatalf("failed to generate root CA: %v", err)
+	}
+	rootCert, _ := util.ParsePemEncodedCertificate(rootCertBytes)
+	rootKey, _ := util.ParsePemEncodedKey(rootKeyBytes)
+	intermediateCert, intermediateKey, err := util.GenCertKeyFromOptions(util.CertOptions{
+		Host:       "intermediate",
+		IsCA:       intermediateIsCA,
+		TTL:        time.Hour,
+		Org:        "Intermediate CA",
+		RSAKeySize: 2048,
+		SignerCert: rootCert,
+		SignerPriv: rootKey,
+	})
+	if err != nil {
+		t.Fatalf("failed to generate intermediate CA: %v", err)
+	}
+
+	for file, content := range map[string][]byte{
+		paths.certChainFile:   intermediateCert,
+		paths.signingCertFile: intermediateCert,
+		paths.signingKeyFile:  intermediateKey,
+		paths.rootCertFile:    rootCertBytes,
+	} {
+		if err := ioutil.WriteFile(file, content, 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", file, err)
+		}
+	}
+	return rootCertBytes, intermediateCert
+}
+
+func getConfigMapCert(t *testing.T, rotator *PluggedCertRotator) []byte {
+	cmc := configmap.NewController(rotator.config.caStorageNamespace, rotator.config.client)
+	encoded, err := cmc.GetCATLSRootCert()
+	if err != nil {
+		t.Fatalf("failed to read the configmap: %v", err)
+	}
+	cert, _ := base64.StdEncoding.DecodeString(encoded)
+	return cert
+}
+
+func TestPluggedCertRotator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugged-certs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	paths := pluggedCertPaths{
+		certChainFile:   filepath.Join(dir, "cert-chain.pem"),
+		signingCertFile: filepath.Join(dir, "ca-cert.pem"),
+		signingKeyFile:  filepath.Join(dir, "ca-key.pem"),
+		rootCertFile:    filepath.Join(dir, "root-cert.pem"),
+	}
+	oldRoot, _ := writePluggedCerts(t, paths, true)
+
+	client := fake.NewSimpleClientset()
+	caOpts, err := NewPluggedCertIstioCAOptions(paths.certChainFile, paths.signingCertFile, paths.signingKeyFile,
+		paths.rootCertFile, time.Hour, 2*time.Hour, caNamespace, client.CoreV1())
+	if err != nil {
+		t.Fatalf("failed to create plugged cert CA options: %v", err)
+	}
+	caOpts.PluggedCertRotatorConfig.CheckInterval = time.Minute
+	caOpts.PluggedCertRotatorConfig.RootCertOverlap = time.Hour
+	ca, err := NewIstioCA(caOpts)
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	rotator := ca.pluggedCertRotator
+	if rotator == nil {
+		t.Fatal("plugged cert rotator should be created")
+	}
+	now := time.Now()
+	rotator.now = func() time.Time { return now }
+
+	// Files are not changed, so the bundle is kept.
+	rotator.checkAndReload()
+	if !bytes.Equal(ca.GetCAKeyCertBundle().GetRootCertPem(), oldRoot) {
+		t.Error("root cert should not change when the files are not changed")
+	}
+
+	// Files are replaced with a new root, so both the new and the old roots are trusted.
+	newRoot, newSigningCert := writePluggedCerts(t, paths, true)
+	rotator.checkAndReload()
+	signingCert, _, _, rootCerts := ca.GetCAKeyCertBundle().GetAllPem()
+	if !bytes.Equal(signingCert, newSigningCert) {
+		t.Error("signing cert should be reloaded")
+	}
+	if want := appendPem(newRoot, oldRoot); !bytes.Equal(rootCerts, want) {
+		t.Errorf("root certs should hold the new and the old roots, got %s", rootCerts)
+	}
+	if want := appendPem(newSigningCert, oldRoot); !bytes.Equal(getConfigMapCert(t, rotator), want) {
+		t.Error("configmap should hold the cert chain and the old root")
+	}
+	subjectID := "spiffe://example.com/ns/foo/sa/bar"
+	csrPEM, _, err := util.GenCSR(util.CertOptions{Host: subjectID, RSAKeySize: 2048})
+	if err != nil {
+		t.Fatalf("failed to generate CSR: %v", err)
+	}
+	if _, err := ca.Sign(csrPEM, []string{subjectID}, time.Hour, false); err != nil {
+		t.Errorf("failed to sign a CSR with the reloaded signing cert: %v", err)
+	}
+
+	// The overlap window is over, so the old root is removed.
+	now = now.Add(2 * time.Hour)
+	rotator.checkAndReload()
+	if rootCerts := ca.GetCAKeyCertBundle().GetRootCertPem(); !bytes.Equal(rootCerts, newRoot) {
+		t.Errorf("root certs should only hold the new root, got %s", rootCerts)
+	}
+	if !bytes.Equal(getConfigMapCert(t, rotator), newSigningCert) {
+		t.Error("configmap should only hold the cert chain")
+	}
+
+	// The new signing cert can't sign certificates, so it is not loaded.
+	writePluggedCerts(t, paths, false)
+	rotator.checkAndReload()
+	if signingCert, _, _, _ := ca.GetCAKeyCertBundle().GetAllPem(); !bytes.Equal(signingCert, newSigningCert) {
+		t.Error("signing cert which is not a CA should not be loaded")
+	}
+}
+
+func TestPluggedCertRotatorDisabled(t *testing.T) {
+	caOpts, err := NewPluggedCertIstioCAOptions("../testdata/multilevelpki/int2-cert-chain.pem",
+		"../testdata/multilevelpki/int2-cert.pem", "../testdata/multilevelpki/int2-key.pem",
+		"../testdata/multilevelpki/root-cert.pem", time.Hour, time.Hour, caNamespace, fake.NewSimpleClientset().CoreV1())
+	if err != nil {
+		t.Fatalf("failed to create plugged cert CA options: %v", err)
+	}
+	ca, err := NewIstioCA(caOpts)
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	if ca.pluggedCertRotator != nil {
+		t.Error("plugged cert rotator should not be created without check interval")
+	}
+}
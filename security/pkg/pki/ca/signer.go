@@ -0,0 +1,59 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ca
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	caerror "istio.io/istio/security/pkg/pki/error"
+	"istio.io/istio/security/pkg/pki/util"
+)
+
+// Signer signs the certificates issued by an IstioCA. The IstioCA validates the CSR and the
+// lifetime, and the signer issues the certificate, either with the CA key or by delegating to
+// an external signing authority.
+type Signer interface {
+	// Sign signs a certificate for the given CSR, subject IDs and lifetime, and returns the
+	// PEM-encoded certificate. If forCA is true, the signed certificate is a CA certificate.
+	Sign(csr *x509.CertificateRequest, csrPEM []byte, subjectIDs []string, lifetime time.Duration,
+		forCA bool) ([]byte, error)
+}
+
+// keyCertBundleSigner signs the certificates with the key and certificate of a KeyCertBundle.
+type keyCertBundleSigner struct {
+	keyCertBundle util.KeyCertBundle
+}
+
+func (s *keyCertBundleSigner) Sign(csr *x509.CertificateRequest, _ []byte, subjectIDs []string,
+	lifetime time.Duration, forCA bool) ([]byte, error) {
+	signingCert, signingKey, _, _ := s.keyCertBundle.GetAll()
+	if signingCert == nil {
+		return nil, caerror.NewError(caerror.CANotReady, fmt.Errorf("Istio CA is not ready")) // nolint
+	}
+
+	certBytes, err := util.GenCertFromCSR(csr, signingCert, csr.PublicKey, *signingKey, subjectIDs, lifetime, forCA)
+	if err != nil {
+		return nil, caerror.NewError(caerror.CertGenError, err)
+	}
+
+	block := &pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: certBytes,
+	}
+	return pem.EncodeToMemory(block), nil
+}
@@ -0,0 +1,287 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vault implements a signer for the Istio CA which signs the certificates with the PKI
+// secrets engine of a Vault server.
+package vault
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"istio.io/istio/security/pkg/pki/ca"
+	"istio.io/pkg/log"
+)
+
+var vaultSignerLog = log.RegisterScope("vaultsigner", "Vault signer debugging", 0)
+
+// Config holds the configuration of a Vault signer.
+type Config struct {
+	// Addr is the address of the Vault server, e.g. "https://vault:8200".
+	Addr string
+	// TLSRootCertFile is the file of the root cert verifying the Vault server. The system roots
+	// are used if it is empty.
+	TLSRootCertFile string
+
+	// Token is the Vault token. If it is empty and the VAULT_TOKEN environment variable is not set,
+	// the signer logs in with the Kubernetes auth method.
+	Token string
+	// LoginPath is the path of the Kubernetes auth method login, e.g. "auth/kubernetes/login".
+	LoginPath string
+	// LoginRole is the role of the Kubernetes auth method.
+	LoginRole string
+	// JWTPath is the file of the service account token used to log in.
+	JWTPath string
+
+	// SignPath is the path of the PKI sign endpoint, e.g. "pki/sign/istio". The role must allow
+	// the URI SANs of the Istio identities, and must not require a common name.
+	SignPath string
+	// CAChainPath is the path of the PKI CA chain, e.g. "pki/cert/ca_chain".
+	CAChainPath string
+}
+
+// Signer signs the certificates of the Istio CA with the PKI secrets engine of a Vault server.
+type Signer struct {
+	config *Config
+	client *api.Client
+	// login is true if the signer logs in with the Kubernetes auth method.
+	login bool
+
+	// mutex protects the token state.
+	mutex    sync.Mutex
+	loggedIn bool
+	// tokenExpiry is when to log in again, unless it is zero.
+	tokenExpiry time.Time
+	now         func() time.Time
+}
+
+// NewSigner returns a new Vault signer.
+func NewSigner(config *Config) (*Signer, error) {
+	vaultConfig := api.DefaultConfig()
+	if vaultConfig.Error != nil {
+		return nil, fmt.Errorf("failed to create Vault client config: %v", vaultConfig.Error)
+	}
+	vaultConfig.Address = config.Addr
+	if config.TLSRootCertFile != "" {
+		if err := vaultConfig.ConfigureTLS(&api.TLSConfig{CACert: config.TLSRootCertFile}); err != nil {
+			return nil, fmt.Errorf("failed to configure Vault client TLS: %v", err)
+		}
+	}
+	client, err := api.NewClient(vaultConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Vault client: %v", err)
+	}
+	if config.Token != "" {
+		client.SetToken(config.Token)
+	}
+	s := &Signer{
+		config: config,
+		client: client,
+		login:  client.Token() == "",
+		now:    time.Now,
+	}
+	if s.login && (config.LoginPath == "" || config.JWTPath == "") {
+		return nil, fmt.Errorf("no Vault token, and no Kubernetes auth method login path or JWT path")
+	}
+	vaultSignerLog.Infof("created Vault signer for Vault address: %s, sign path: %s", config.Addr, config.SignPath)
+	return s, nil
+}
+
+// NewIstioCAOptions returns the options of an Istio CA signing with a Vault signer. The root cert is read
+// from rootCertFile if it is not empty, otherwise it must be the last certificate of the Vault CA chain.
+func NewIstioCAOptions(config *Config, rootCertFile string, certTTL, maxCertTTL time.Duration,
+	namespace string, client corev1.CoreV1Interface) (*ca.IstioCAOptions, error) {
+	signer, err := NewSigner(config)
+	if err != nil {
+		return nil, err
+	}
+	certChain, rootCert, err := signer.CAChain()
+	if err != nil {
+		return nil, err
+	}
+	if rootCertFile != "" {
+		if rootCert != nil {
+			certChain = append(certChain, rootCert...)
+		}
+		if rootCert, err = ioutil.ReadFile(rootCertFile); err != nil {
+			return nil, fmt.Errorf("failed to read root cert: %v", err)
+		}
+	} else if rootCert == nil {
+		return nil, fmt.Errorf("the Vault CA chain does not end with a root cert, and no root cert file is set")
+	}
+	return ca.NewExternalSignerIstioCAOptions(signer, certChain, rootCert, certTTL, maxCertTTL, namespace, client)
+}
+
+// Sign signs a workload certificate for the CSR with the Vault PKI sign endpoint. The subject IDs are
+// set as SANs of the certificate, by type. CA certificates are not supported.
+func (s *Signer) Sign(_ *x509.CertificateRequest, csrPEM []byte, subjectIDs []string, lifetime time.Duration,
+	forCA bool) ([]byte, error) {
+	if forCA {
+		return nil, fmt.Errorf("the Vault signer does not sign CA certificates")
+	}
+
+	var uriSANs, ipSANs, dnsSANs []string
+	for _, id := range subjectIDs {
+		switch {
+		case net.ParseIP(id) != nil:
+			ipSANs = append(ipSANs, id)
+		case strings.Contains(id, "://"):
+			uriSANs = append(uriSANs, id)
+		default:
+			dnsSANs = append(dnsSANs, id)
+		}
+	}
+	data := map[string]interface{}{
+		"format":               "pem",
+		"csr":                  string(csrPEM),
+		"ttl":                  strconv.FormatInt(int64(lifetime.Seconds()), 10) + "s",
+		"exclude_cn_from_sans": true,
+	}
+	if len(uriSANs) > 0 {
+		data["uri_sans"] = strings.Join(uriSANs, ",")
+	}
+	if len(ipSANs) > 0 {
+		data["ip_sans"] = strings.Join(ipSANs, ",")
+	}
+	if len(dnsSANs) > 0 {
+		data["alt_names"] = strings.Join(dnsSANs, ",")
+	}
+
+	resp, err := s.write(s.config.SignPath, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign CSR with Vault at %s: %v", s.config.SignPath, err)
+	}
+	cert, err := stringData(resp, "certificate")
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimSpace(cert) + "\n"), nil
+}
+
+// CAChain returns the CA chain of the Vault PKI secrets engine. If the chain ends with a self-signed
+// certificate, it is returned as the root cert and removed from the chain, otherwise the root cert
+// is nil.
+func (s *Signer) CAChain() (certChain, rootCert []byte, err error) {
+	if err := s.ensureToken(); err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.client.Logical().Read(s.config.CAChainPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the CA chain from Vault at %s: %v", s.config.CAChainPath, err)
+	}
+	chain, err := stringData(resp, "certificate")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var blocks [][]byte
+	var last *pem.Block
+	for rest := []byte(chain); ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		blocks = append(blocks, pem.EncodeToMemory(block))
+		last = block
+	}
+	if last == nil {
+		return nil, nil, fmt.Errorf("no certificate in the CA chain from Vault")
+	}
+	lastCert, err := x509.ParseCertificate(last.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the CA chain from Vault: %v", err)
+	}
+	if lastCert.CheckSignatureFrom(lastCert) == nil {
+		return bytes.Join(blocks[:len(blocks)-1], nil), blocks[len(blocks)-1], nil
+	}
+	return bytes.Join(blocks, nil), nil, nil
+}
+
+// write writes data to Vault, logging in again if the token is rejected.
+func (s *Signer) write(path string, data map[string]interface{}) (*api.Secret, error) {
+	if err := s.ensureToken(); err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Logical().Write(path, data)
+	if respErr, ok := err.(*api.ResponseError); ok && respErr.StatusCode == http.StatusForbidden && s.login {
+		vaultSignerLog.Info("Vault token is rejected, logging in again")
+		s.mutex.Lock()
+		s.loggedIn = false
+		s.mutex.Unlock()
+		if err := s.ensureToken(); err != nil {
+			return nil, err
+		}
+		resp, err = s.client.Logical().Write(path, data)
+	}
+	return resp, err
+}
+
+// ensureToken logs in with the Kubernetes auth method if the signer has no valid token.
+func (s *Signer) ensureToken() error {
+	if !s.login {
+		return nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.loggedIn && (s.tokenExpiry.IsZero() || s.now().Before(s.tokenExpiry)) {
+		return nil
+	}
+
+	jwt, err := ioutil.ReadFile(s.config.JWTPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the service account token: %v", err)
+	}
+	resp, err := s.client.Logical().Write(s.config.LoginPath, map[string]interface{}{
+		"jwt":  strings.TrimSpace(string(jwt)),
+		"role": s.config.LoginRole,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to login Vault at %s: %v", s.config.Addr, err)
+	}
+	if resp == nil || resp.Auth == nil {
+		return fmt.Errorf("login response from Vault has no auth field")
+	}
+	s.client.SetToken(resp.Auth.ClientToken)
+	s.loggedIn = true
+	// Log in again a bit before the token expires. A token without lease duration does not expire.
+	s.tokenExpiry = time.Time{}
+	if lease := time.Duration(resp.Auth.LeaseDuration) * time.Second; lease > 0 {
+		s.tokenExpiry = s.now().Add(lease * 9 / 10)
+	}
+	return nil
+}
+
+// stringData returns a string field of the data of a Vault response.
+func stringData(resp *api.Secret, field string) (string, error) {
+	if resp == nil || resp.Data == nil {
+		return "", fmt.Errorf("response from Vault has no data")
+	}
+	value, ok := resp.Data[field].(string)
+	if !ok || value == "" {
+		return "", fmt.Errorf("response from Vault has no %s", field)
+	}
+	return value, nil
+}
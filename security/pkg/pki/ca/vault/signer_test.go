@@ -0,0 +1,290 @@
+// Copyright 2020 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"istio.io/istio/security/pkg/pki/ca"
+	"istio.io/istio/security/pkg/pki/util"
+)
+
+const (
+	testJWT   = "service-account-token"
+	testRole  = "istio-ca"
+	testToken = "vault-token"
+)
+
+// mockVault mimics the Kubernetes auth method and the PKI secrets engine of a Vault server.
+type mockVault struct {
+	t          *testing.T
+	rootCert   []byte
+	signerCert *x509.Certificate
+	signerKey  crypto.PrivateKey
+	certChain  string
+
+	mutex        sync.Mutex
+	logins       int
+	rejectTokens bool
+	signRequest  map[string]interface{}
+}
+
+func newMockVault(t *testing.T) *mockVault {
+	rootCert, rootKey, err := util.GenCertKeyFromOptions(util.CertOptions{
+		IsCA:         true,
+		IsSelfSigned: true,
+		TTL:          time.Hour,
+		Org:          "Root CA",
+		RSAKeySize:   2048,
+	})
+	if err != nil {
+		t.Fatalf("failed to generate root CA: %v", err)
+	}
+	parsedRootCert, _ := util.ParsePemEncodedCertificate(rootCert)
+	parsedRootKey, _ := util.ParsePemEncodedKey(rootKey)
+	intermediateCert, intermediateKey, err := util.GenCertKeyFromOptions(util.CertOptions{
+		IsCA:       true,
+		TTL:        time.Hour,
+		Org:        "Vault Intermediate CA",
+		RSAKeySize: 2048,
+		SignerCert: parsedRootCert,
+		SignerPriv: parsedRootKey,
+	})
+	if err != nil {
+		t.Fatalf("failed to generate intermediate CA: %v", err)
+	}
+	signerCert, _ := util.ParsePemEncodedCertificate(intermediateCert)
+	signerKey, _ := util.ParsePemEncodedKey(intermediateKey)
+	return &mockVault{
+		t:          t,
+		rootCert:   rootCert,
+		signerCert: signerCert,
+		signerKey:  signerKey,
+		certChain:  string(intermediateCert) + string(rootCert),
+	}
+}
+
+func (m *mockVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var body map[string]interface{}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+	switch r.URL.Path {
+	case "/v1/auth/kubernetes/login":
+		if body["jwt"] != testJWT || body["role"] != testRole {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		m.logins++
+		m.rejectTokens = false
+		writeJSON(w, map[string]interface{}{
+			"auth": map[string]interface{}{"client_token": testToken, "lease_duration": 3600},
+		})
+		return
+	}
+
+	if r.Header.Get("X-Vault-Token") != testToken || m.rejectTokens {
+		w.WriteHeader(http.StatusForbidden)
+		writeJSON(w, map[string]interface{}{"errors": []string{"permission denied"}})
+		return
+	}
+	switch r.URL.Path {
+	case "/v1/pki/cert/ca_chain":
+		writeJSON(w, map[string]interface{}{"data": map[string]interface{}{"certificate": m.certChain}})
+	case "/v1/pki/sign/istio":
+		m.signRequest = body
+		csr, err := util.ParsePemEncodedCSR([]byte(body["csr"].(string)))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		ttl, _ := time.ParseDuration(body["ttl"].(string))
+		uriSANs, _ := body["uri_sans"].(string)
+		der, err := util.GenCertFromCSR(csr, m.signerCert, csr.PublicKey, m.signerKey,
+			strings.Split(uriSANs, ","), ttl, false)
+		if err != nil {
+			m.t.Errorf("failed to sign CSR: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		cert := strings.TrimSpace(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+		writeJSON(w, map[string]interface{}{"data": map[string]interface{}{"certificate": cert}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// newTestSigner returns a signer logging in with a service account token written in dir.
+func newTestSigner(t *testing.T, addr, dir string) *Signer {
+	jwtPath := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(jwtPath, []byte(testJWT), 0600); err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewSigner(&Config{
+		Addr:        addr,
+		LoginPath:   "auth/kubernetes/login",
+		LoginRole:   testRole,
+		JWTPath:     jwtPath,
+		SignPath:    "pki/sign/istio",
+		CAChainPath: "pki/cert/ca_chain",
+	})
+	if err != nil {
+		t.Fatalf("failed to create Vault signer: %v", err)
+	}
+	return signer
+}
+
+func TestIstioCAWithVaultSigner(t *testing.T) {
+	os.Unsetenv("VAULT_TOKEN")
+	vault := newMockVault(t)
+	server := httptest.NewServer(vault)
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "vault-signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	signer := newTestSigner(t, server.URL, dir)
+
+	certChain, rootCert, err := signer.CAChain()
+	if err != nil {
+		t.Fatalf("CAChain() failed: %v", err)
+	}
+	if !bytes.Equal(rootCert, vault.rootCert) {
+		t.Errorf("CAChain() returned root cert %s, want the Vault root cert", rootCert)
+	}
+	if signerCert, _ := util.ParsePemEncodedCertificate(certChain); !signerCert.Equal(vault.signerCert) {
+		t.Error("CAChain() should return the Vault signing cert in the chain")
+	}
+
+	caOpts, err := ca.NewExternalSignerIstioCAOptions(signer, certChain, rootCert, time.Hour, 2*time.Hour, "", nil)
+	if err != nil {
+		t.Fatalf("failed to create CA options: %v", err)
+	}
+	istioCA, err := ca.NewIstioCA(caOpts)
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+
+	subjectID := "spiffe://cluster.local/ns/foo/sa/bar"
+	csrPEM, _, err := util.GenCSR(util.CertOptions{Host: subjectID, RSAKeySize: 2048})
+	if err != nil {
+		t.Fatalf("failed to generate CSR: %v", err)
+	}
+	certPEM, err := istioCA.SignWithCertChain(csrPEM, []string{subjectID}, time.Hour, false)
+	if err != nil {
+		t.Fatalf("SignWithCertChain() failed: %v", err)
+	}
+	if vault.signRequest["uri_sans"] != subjectID || vault.signRequest["ttl"] != "3600s" {
+		t.Errorf("unexpected sign request %v", vault.signRequest)
+	}
+
+	// The certificate is verified by the root cert of the CA bundle through the chain.
+	cert, err := util.ParsePemEncodedCertificate(certPEM)
+	if err != nil {
+		t.Fatalf("failed to parse the signed cert: %v", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(istioCA.GetCAKeyCertBundle().GetRootCertPem())
+	intermediates := x509.NewCertPool()
+	intermediates.AppendCertsFromPEM(certPEM)
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		t.Errorf("the signed cert is not verified by the root cert: %v", err)
+	}
+	ids, err := util.ExtractIDs(cert.Extensions)
+	if err != nil || len(ids) != 1 || ids[0] != subjectID {
+		t.Errorf("the signed cert has IDs %v (%v), want %s", ids, err, subjectID)
+	}
+
+	// The CA checks the TTL before delegating to Vault.
+	if _, err := istioCA.Sign(csrPEM, []string{subjectID}, 3*time.Hour, false); err == nil {
+		t.Error("Sign() should fail for a TTL above the max TTL")
+	}
+	// Vault does not sign CA certificates.
+	if _, err := istioCA.Sign(csrPEM, []string{subjectID}, time.Hour, true); err == nil {
+		t.Error("Sign() should fail for a CA certificate")
+	}
+}
+
+func TestVaultSignerLogin(t *testing.T) {
+	os.Unsetenv("VAULT_TOKEN")
+	vault := newMockVault(t)
+	server := httptest.NewServer(vault)
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "vault-signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	signer := newTestSigner(t, server.URL, dir)
+
+	csrPEM, _, err := util.GenCSR(util.CertOptions{Host: "spiffe://cluster.local/ns/foo/sa/bar", RSAKeySize: 2048})
+	if err != nil {
+		t.Fatalf("failed to generate CSR: %v", err)
+	}
+	sign := func() {
+		if _, err := signer.Sign(nil, csrPEM, []string{"spiffe://cluster.local/ns/foo/sa/bar"}, time.Hour, false); err != nil {
+			t.Fatalf("Sign() failed: %v", err)
+		}
+	}
+
+	sign()
+	sign()
+	if vault.logins != 1 {
+		t.Errorf("the signer logged in %d times, want 1", vault.logins)
+	}
+
+	// The token expires.
+	now := time.Now().Add(time.Hour)
+	signer.now = func() time.Time { return now }
+	sign()
+	if vault.logins != 2 {
+		t.Errorf("the signer logged in %d times, want 2 after the token expired", vault.logins)
+	}
+
+	// The token is revoked.
+	vault.mutex.Lock()
+	vault.rejectTokens = true
+	vault.mutex.Unlock()
+	sign()
+	if vault.logins != 3 {
+		t.Errorf("the signer logged in %d times, want 3 after the token was rejected", vault.logins)
+	}
+}
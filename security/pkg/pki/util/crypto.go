@@ -16,6 +16,8 @@ package util
 
 import (
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -90,6 +92,24 @@ func ParsePemEncodedKey(keyBytes []byte) (crypto.PrivateKey, error) {
 	}
 }
 
+// GetKeyAlgorithm returns the algorithm of a private key, and its size if it is a RSA key.
+func GetKeyAlgorithm(privKey crypto.PrivateKey) (KeyAlgorithm, int, error) {
+	switch k := privKey.(type) {
+	case *rsa.PrivateKey:
+		return RSA, k.N.BitLen(), nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return ECDSAP256, 0, nil
+		case elliptic.P384():
+			return ECDSAP384, 0, nil
+		}
+		return "", 0, fmt.Errorf("unsupported ECDSA curve: %v", k.Curve.Params().Name)
+	default:
+		return "", 0, fmt.Errorf("unsupported key type: %T", privKey)
+	}
+}
+
 // GetRSAKeySize returns the size if it is RSA key, otherwise it returns an error.
 func GetRSAKeySize(privKey crypto.PrivateKey) (int, error) {
 	if t := reflect.TypeOf(privKey); t != reflect.TypeOf(&rsa.PrivateKey{}) {
@@ -273,6 +273,80 @@ func TestParsePemEncodedKey(t *testing.T) {
 		}
 	}
 }
+func TestGetKeyAlgorithm(t *testing.T) {
+	testCases := map[string]struct {
+		pem    string
+		alg    KeyAlgorithm
+		size   int
+		errMsg string
+	}{
+		"RSA key": {
+			pem:  keyRSA,
+			alg:  RSA,
+			size: 2048,
+		},
+		"PKCS8RSA key": {
+			pem:  keyPKCS8RSA,
+			alg:  RSA,
+			size: 2048,
+		},
+		"Failure with ECDSA P-224 key": {
+			pem:    keyECDSA,
+			errMsg: "unsupported ECDSA curve: P-224",
+		},
+	}
+
+	for id, c := range testCases {
+		key, err := ParsePemEncodedKey([]byte(c.pem))
+		if err != nil {
+			t.Errorf("%s: failed to parse the Pem key.", id)
+		}
+		alg, size, err := GetKeyAlgorithm(key)
+		if c.errMsg != "" {
+			if err == nil || err.Error() != c.errMsg {
+				t.Errorf("%s: expected error %q, got %v", id, c.errMsg, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: unexpected error: %v", id, err)
+		}
+		if alg != c.alg || size != c.size {
+			t.Errorf("%s: got %v (%d), expected %v (%d)", id, alg, size, c.alg, c.size)
+		}
+	}
+
+	for _, alg := range []KeyAlgorithm{ECDSAP256, ECDSAP384} {
+		key, err := genPrivateKey(CertOptions{KeyAlgorithm: alg})
+		if err != nil {
+			t.Fatalf("%s: failed to generate key: %v", alg, err)
+		}
+		if got, _, err := GetKeyAlgorithm(key); err != nil || got != alg {
+			t.Errorf("%s: got %v, %v", alg, got, err)
+		}
+	}
+}
+
+func TestParseKeyAlgorithm(t *testing.T) {
+	testCases := map[string]struct {
+		alg    KeyAlgorithm
+		hasErr bool
+	}{
+		"":           {alg: RSA},
+		"RSA":        {alg: RSA},
+		"ecdsa-p256": {alg: ECDSAP256},
+		"ECDSA-P384": {alg: ECDSAP384},
+		"ECDSA":      {hasErr: true},
+	}
+	for name, c := range testCases {
+		alg, err := ParseKeyAlgorithm(name)
+		if c.hasErr != (err != nil) {
+			t.Errorf("%q: unexpected error %v", name, err)
+		}
+		if alg != c.alg {
+			t.Errorf("%q: got %v, expected %v", name, alg, c.alg)
+		}
+	}
+}
+
 func TestGetRSAKeySize(t *testing.T) {
 	testCases := map[string]struct {
 		pem    string
@@ -22,6 +22,7 @@ import (
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -36,6 +37,32 @@ import (
 	"istio.io/pkg/log"
 )
 
+// KeyAlgorithm is the algorithm of the generated private keys.
+type KeyAlgorithm string
+
+const (
+	// RSA keys, of the size set in the CertOptions. This is the default.
+	RSA KeyAlgorithm = "RSA"
+	// ECDSAP256 keys on the NIST P-256 curve.
+	ECDSAP256 KeyAlgorithm = "ECDSA-P256"
+	// ECDSAP384 keys on the NIST P-384 curve.
+	ECDSAP384 KeyAlgorithm = "ECDSA-P384"
+)
+
+// ParseKeyAlgorithm returns the key algorithm with the given name, case insensitive.
+// An empty name is the default RSA algorithm.
+func ParseKeyAlgorithm(name string) (KeyAlgorithm, error) {
+	if name == "" {
+		return RSA, nil
+	}
+	for _, alg := range []KeyAlgorithm{RSA, ECDSAP256, ECDSAP384} {
+		if strings.EqualFold(name, string(alg)) {
+			return alg, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported key algorithm %q, must be one of %s, %s or %s", name, RSA, ECDSAP256, ECDSAP384)
+}
+
 // CertOptions contains options for generating a new certificate.
 type CertOptions struct {
 	// Comma-separated hostnames and IPs to generate a certificate for.
@@ -64,6 +91,9 @@ type CertOptions struct {
 	// The size of RSA private key to be generated.
 	RSAKeySize int
 
+	// The algorithm of the private key to be generated, RSA if empty.
+	KeyAlgorithm KeyAlgorithm
+
 	// Whether this certificate is used as signing cert for CA.
 	IsCA bool
 
@@ -85,14 +115,14 @@ type CertOptions struct {
 
 // GenCertKeyFromOptions generates a X.509 certificate and a private key with the given options.
 func GenCertKeyFromOptions(options CertOptions) (pemCert []byte, pemKey []byte, err error) {
-	// Generate a RSA private&public key pair.
+	// Generate a private&public key pair.
 	// The public key will be bound to the certificate generated below. The
 	// private key will be used to sign this certificate in the self-signed
 	// case, otherwise the certificate is signed by the signer private key
 	// as specified in the CertOptions.
-	priv, err := rsa.GenerateKey(rand.Reader, options.RSAKeySize)
+	priv, err := genPrivateKey(options)
 	if err != nil {
-		return nil, nil, fmt.Errorf("cert generation fails at RSA key generation (%v)", err)
+		return nil, nil, fmt.Errorf("cert generation fails at %v key generation (%v)", keyAlgorithm(options), err)
 	}
 	template, err := genCertTemplateFromOptions(options)
 	if err != nil {
@@ -102,7 +132,7 @@ func GenCertKeyFromOptions(options CertOptions) (pemCert []byte, pemKey []byte,
 	if !options.IsSelfSigned {
 		signerCert, signerKey = options.SignerCert, options.SignerPriv
 	}
-	certBytes, err := x509.CreateCertificate(rand.Reader, template, signerCert, &priv.PublicKey, signerKey)
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, signerCert, priv.Public(), signerKey)
 	if err != nil {
 		return nil, nil, fmt.Errorf("cert generation fails at X509 cert creation (%v)", err)
 	}
@@ -114,6 +144,28 @@ func GenCertKeyFromOptions(options CertOptions) (pemCert []byte, pemKey []byte,
 	return
 }
 
+// keyAlgorithm returns the algorithm of the private key to generate for the options.
+func keyAlgorithm(options CertOptions) KeyAlgorithm {
+	if options.KeyAlgorithm == "" {
+		return RSA
+	}
+	return options.KeyAlgorithm
+}
+
+// genPrivateKey generates a private key with the algorithm of the options.
+func genPrivateKey(options CertOptions) (crypto.Signer, error) {
+	switch alg := keyAlgorithm(options); alg {
+	case RSA:
+		return rsa.GenerateKey(rand.Reader, options.RSAKeySize)
+	case ECDSAP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case ECDSAP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %q", alg)
+	}
+}
+
 func publicKey(priv interface{}) interface{} {
 	switch k := priv.(type) {
 	case *rsa.PrivateKey:
@@ -270,8 +322,7 @@ func genCertTemplateFromCSR(csr *x509.CertificateRequest, subjectIDs []string, t
 		ExtKeyUsage:           extKeyUsages,
 		IsCA:                  isCA,
 		BasicConstraintsValid: true,
-		ExtraExtensions:       exts,
-		SignatureAlgorithm:    csr.SignatureAlgorithm}, nil
+		ExtraExtensions:       exts}, nil
 }
 
 // genCertTemplateFromoptions generates a certificate template with the given options.
@@ -346,7 +397,7 @@ func genSerialNum() (*big.Int, error) {
 	return serialNum, nil
 }
 
-func encodePem(isCSR bool, csrOrCert []byte, priv *rsa.PrivateKey, pkcs8 bool) (
+func encodePem(isCSR bool, csrOrCert []byte, priv crypto.Signer, pkcs8 bool) (
 	csrOrCertPem []byte, privPem []byte, err error) {
 	encodeMsg := "CERTIFICATE"
 	if isCSR {
@@ -361,8 +412,18 @@ func encodePem(isCSR bool, csrOrCert []byte, priv *rsa.PrivateKey, pkcs8 bool) (
 		}
 		privPem = pem.EncodeToMemory(&pem.Block{Type: blockTypePKCS8PrivateKey, Bytes: encodedKey})
 	} else {
-		encodedKey = x509.MarshalPKCS1PrivateKey(priv)
-		privPem = pem.EncodeToMemory(&pem.Block{Type: blockTypeRSAPrivateKey, Bytes: encodedKey})
+		switch k := priv.(type) {
+		case *rsa.PrivateKey:
+			encodedKey = x509.MarshalPKCS1PrivateKey(k)
+			privPem = pem.EncodeToMemory(&pem.Block{Type: blockTypeRSAPrivateKey, Bytes: encodedKey})
+		case *ecdsa.PrivateKey:
+			if encodedKey, err = x509.MarshalECPrivateKey(k); err != nil {
+				return nil, nil, err
+			}
+			privPem = pem.EncodeToMemory(&pem.Block{Type: blockTypeECPrivateKey, Bytes: encodedKey})
+		default:
+			return nil, nil, fmt.Errorf("unsupported private key type %T", priv)
+		}
 	}
 	err = nil
 	return
@@ -334,6 +334,57 @@ func TestGenCertKeyFromOptions(t *testing.T) {
 	}
 }
 
+func TestGenCertKeyFromOptionsECDSA(t *testing.T) {
+	caCertPem, caPrivPem, err := GenCertKeyFromOptions(CertOptions{
+		Host:         "test_ca.com",
+		TTL:          time.Hour,
+		Org:          "MyOrg",
+		IsCA:         true,
+		IsSelfSigned: true,
+		KeyAlgorithm: ECDSAP384,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, err := ParsePemEncodedCertificate(caCertPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caCert.SignatureAlgorithm != x509.ECDSAWithSHA384 {
+		t.Errorf("unexpected CA signature algorithm %v", caCert.SignatureAlgorithm)
+	}
+	caPriv, err := ParsePemEncodedKey(caPrivPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Both a RSA and an ECDSA key can be signed by the ECDSA CA.
+	for _, alg := range []KeyAlgorithm{RSA, ECDSAP256} {
+		certPem, privPem, err := GenCertKeyFromOptions(CertOptions{
+			Host:         "test_server.com",
+			TTL:          time.Hour,
+			SignerCert:   caCert,
+			SignerPriv:   caPriv,
+			Org:          "MyOrg",
+			RSAKeySize:   512,
+			KeyAlgorithm: alg,
+			IsServer:     true,
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", alg, err)
+		}
+		fields := &VerifyFields{
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			Org:         "MyOrg",
+			Host:        "test_server.com",
+		}
+		if err := VerifyCertificate(privPem, certPem, caCertPem, fields); err != nil {
+			t.Errorf("%s: cert verification error: %v", alg, err)
+		}
+	}
+}
+
 func TestGenCertFromCSR(t *testing.T) {
 	keyFile := "../testdata/key.pem"
 	certFile := "../testdata/cert.pem"
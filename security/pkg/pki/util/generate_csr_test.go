@@ -15,6 +15,8 @@
 package util
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -102,6 +104,65 @@ func TestGenCSRPKCS8Key(t *testing.T) {
 	}
 }
 
+func TestGenCSRECDSAKey(t *testing.T) {
+	cases := map[KeyAlgorithm]struct {
+		pkcs8     bool
+		curve     elliptic.Curve
+		blockType string
+	}{
+		ECDSAP256: {curve: elliptic.P256(), blockType: blockTypeECPrivateKey},
+		ECDSAP384: {pkcs8: true, curve: elliptic.P384(), blockType: blockTypePKCS8PrivateKey},
+	}
+	for alg, c := range cases {
+		csrPem, keyPem, err := GenCSR(CertOptions{
+			Host:         "test_ca.com",
+			Org:          "MyOrg",
+			KeyAlgorithm: alg,
+			PKCS8Key:     c.pkcs8,
+		})
+		if err != nil {
+			t.Fatalf("%s: failed to gen CSR: %v", alg, err)
+		}
+
+		csr, err := ParsePemEncodedCSR(csrPem)
+		if err != nil {
+			t.Fatalf("%s: failed to parse csr: %v", alg, err)
+		}
+		if err = csr.CheckSignature(); err != nil {
+			t.Errorf("%s: csr signature is invalid", alg)
+		}
+		if csr.PublicKeyAlgorithm != x509.ECDSA {
+			t.Errorf("%s: unexpected csr public key algorithm %v", alg, csr.PublicKeyAlgorithm)
+		}
+
+		keyPemBlock, _ := pem.Decode(keyPem)
+		if keyPemBlock == nil || keyPemBlock.Type != c.blockType {
+			t.Fatalf("%s: unexpected private key PEM block %v", alg, keyPemBlock)
+		}
+		key, err := ParsePemEncodedKey(keyPem)
+		if err != nil {
+			t.Fatalf("%s: failed to parse private key: %v", alg, err)
+		}
+		ecKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok || ecKey.Curve != c.curve {
+			t.Errorf("%s: unexpected private key %T", alg, key)
+		}
+		if !reflect.DeepEqual(&ecKey.PublicKey, csr.PublicKey) {
+			t.Errorf("%s: the private key does not match the csr", alg)
+		}
+	}
+}
+
+func TestGenCSRWithInvalidKeyAlgorithm(t *testing.T) {
+	csr, priv, err := GenCSR(CertOptions{
+		Host:         "test_ca.com",
+		KeyAlgorithm: "DSA",
+	})
+	if err == nil || csr != nil || priv != nil {
+		t.Errorf("Should have failed")
+	}
+}
+
 func TestGenCSRWithInvalidOption(t *testing.T) {
 	// Options with invalid Key size.
 	csrOptions := CertOptions{
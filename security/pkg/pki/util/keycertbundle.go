@@ -117,6 +117,36 @@ func NewKeyCertBundleWithRootCertFromFile(rootCertFile string) (*KeyCertBundleIm
 	}, nil
 }
 
+// NewKeyCertBundleWithCertChainFromPem returns a new KeyCertBundle with the cert chain and the root cert,
+// but without key, for a CA whose key is held by an external signer. The first cert of the chain, if any,
+// must be verified by the root cert through the rest of the chain.
+func NewKeyCertBundleWithCertChainFromPem(certChainBytes, rootCertBytes []byte) (*KeyCertBundleImpl, error) {
+	if _, err := ParsePemEncodedCertificate(rootCertBytes); err != nil {
+		return nil, fmt.Errorf("failed to parse root cert PEM: %v", err)
+	}
+	if len(certChainBytes) != 0 {
+		cert, err := ParsePemEncodedCertificate(certChainBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cert chain PEM: %v", err)
+		}
+		rcp := x509.NewCertPool()
+		rcp.AppendCertsFromPEM(rootCertBytes)
+		icp := x509.NewCertPool()
+		icp.AppendCertsFromPEM(certChainBytes)
+		if _, err := cert.Verify(x509.VerifyOptions{Intermediates: icp, Roots: rcp}); err != nil {
+			return nil, fmt.Errorf("cannot verify the cert chain with the provided root cert: %v", err)
+		}
+	}
+	return &KeyCertBundleImpl{
+		certBytes:      []byte{},
+		cert:           nil,
+		privKeyBytes:   []byte{},
+		privKey:        nil,
+		certChainBytes: copyBytes(certChainBytes),
+		rootCertBytes:  copyBytes(rootCertBytes),
+	}, nil
+}
+
 // GetAllPem returns all key/cert PEMs in KeyCertBundle together. Getting all values together avoids inconsistency.
 func (b *KeyCertBundleImpl) GetAllPem() (certBytes, privKeyBytes, certChainBytes, rootCertBytes []byte) {
 	b.mutex.RLock()
@@ -186,17 +216,18 @@ func (b *KeyCertBundleImpl) CertOptions() (*CertOptions, error) {
 	if len(ids) != 1 {
 		return nil, fmt.Errorf("expect single id from the cert, found %v", ids)
 	}
-	size, err := GetRSAKeySize(*b.privKey)
+	alg, size, err := GetKeyAlgorithm(*b.privKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get RSA key size: %v", err)
+		return nil, fmt.Errorf("failed to get key algorithm: %v", err)
 	}
 	return &CertOptions{
-		Host:       ids[0],
-		Org:        b.cert.Issuer.Organization[0],
-		IsCA:       b.cert.IsCA,
-		TTL:        b.cert.NotAfter.Sub(b.cert.NotBefore),
-		RSAKeySize: size,
-		IsDualUse:  ids[0] == b.cert.Subject.CommonName,
+		Host:         ids[0],
+		Org:          b.cert.Issuer.Organization[0],
+		IsCA:         b.cert.IsCA,
+		TTL:          b.cert.NotAfter.Sub(b.cert.NotBefore),
+		RSAKeySize:   size,
+		KeyAlgorithm: alg,
+		IsDualUse:    ids[0] == b.cert.Subject.CommonName,
 	}, nil
 }
 
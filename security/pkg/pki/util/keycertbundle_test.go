@@ -15,6 +15,7 @@
 package util
 
 import (
+	"io/ioutil"
 	"strings"
 	"testing"
 	"time"
@@ -108,6 +109,75 @@ func TestKeyCertBundleWithRootCertFromFile(t *testing.T) {
 	}
 }
 
+func TestKeyCertBundleWithCertChainFromPem(t *testing.T) {
+	rootCert, err := ioutil.ReadFile(rootCertFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certChain, err := ioutil.ReadFile(int2CertChainFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	badCert, err := ioutil.ReadFile(badCertFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherRoot, _, err := GenCertKeyFromOptions(CertOptions{
+		IsCA:         true,
+		IsSelfSigned: true,
+		TTL:          time.Hour,
+		Org:          "Other Root CA",
+		RSAKeySize:   2048,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		certChain   []byte
+		rootCert    []byte
+		expectedErr bool
+	}{
+		"With cert chain": {
+			certChain: certChain,
+			rootCert:  rootCert,
+		},
+		"Without cert chain": {
+			rootCert: rootCert,
+		},
+		"Bad root cert": {
+			certChain:   certChain,
+			rootCert:    badCert,
+			expectedErr: true,
+		},
+		"Cert chain not verified by root cert": {
+			certChain:   certChain,
+			rootCert:    otherRoot,
+			expectedErr: true,
+		},
+	}
+	for id, tc := range testCases {
+		bundle, err := NewKeyCertBundleWithCertChainFromPem(tc.certChain, tc.rootCert)
+		if tc.expectedErr {
+			if err == nil {
+				t.Errorf("%s: Expected error but succeeded", id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: Unexpected error: %v", id, err)
+			continue
+		}
+		cert, key, chain, root := bundle.GetAll()
+		if cert != nil || key != nil {
+			t.Errorf("%s: cert and private key should be nil", id)
+		}
+		if string(chain) != string(tc.certChain) || string(root) != string(tc.rootCert) {
+			t.Errorf("%s: unexpected cert chain or root cert", id)
+		}
+	}
+}
+
 // The test of CertOptions
 func TestCertOptionsAndRetrieveID(t *testing.T) {
 	testCases := map[string]struct {